@@ -0,0 +1,325 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: comment_grpc.pb.go
+//
+// Generated by this command:
+//
+//	mockgen -package=mockcomment -source=comment_grpc.pb.go -destination=mocks/mock_comment_grpc.pb.go CommentServiceClient
+//
+
+// Package mockcomment is a generated GoMock package.
+package mockcomment
+
+import (
+	context "context"
+	reflect "reflect"
+
+	commentv1 "github.com/liupch66/basic-go/webook/api/proto/gen/comment/v1"
+	gomock "go.uber.org/mock/gomock"
+	grpc "google.golang.org/grpc"
+)
+
+// MockCommentServiceClient is a mock of CommentServiceClient interface.
+type MockCommentServiceClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentServiceClientMockRecorder
+	isgomock struct{}
+}
+
+// MockCommentServiceClientMockRecorder is the mock recorder for MockCommentServiceClient.
+type MockCommentServiceClientMockRecorder struct {
+	mock *MockCommentServiceClient
+}
+
+// NewMockCommentServiceClient creates a new mock instance.
+func NewMockCommentServiceClient(ctrl *gomock.Controller) *MockCommentServiceClient {
+	mock := &MockCommentServiceClient{ctrl: ctrl}
+	mock.recorder = &MockCommentServiceClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentServiceClient) EXPECT() *MockCommentServiceClientMockRecorder {
+	return m.recorder
+}
+
+// CreateComment mocks base method.
+func (m *MockCommentServiceClient) CreateComment(ctx context.Context, in *commentv1.CreateCommentRequest, opts ...grpc.CallOption) (*commentv1.CreateCommentResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateComment", varargs...)
+	ret0, _ := ret[0].(*commentv1.CreateCommentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateComment indicates an expected call of CreateComment.
+func (mr *MockCommentServiceClientMockRecorder) CreateComment(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockCommentServiceClient)(nil).CreateComment), varargs...)
+}
+
+// DeleteByUid mocks base method.
+func (m *MockCommentServiceClient) DeleteByUid(ctx context.Context, in *commentv1.DeleteByUidRequest, opts ...grpc.CallOption) (*commentv1.DeleteByUidResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteByUid", varargs...)
+	ret0, _ := ret[0].(*commentv1.DeleteByUidResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByUid indicates an expected call of DeleteByUid.
+func (mr *MockCommentServiceClientMockRecorder) DeleteByUid(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUid", reflect.TypeOf((*MockCommentServiceClient)(nil).DeleteByUid), varargs...)
+}
+
+// DeleteComment mocks base method.
+func (m *MockCommentServiceClient) DeleteComment(ctx context.Context, in *commentv1.DeleteCommentRequest, opts ...grpc.CallOption) (*commentv1.DeleteCommentResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteComment", varargs...)
+	ret0, _ := ret[0].(*commentv1.DeleteCommentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockCommentServiceClientMockRecorder) DeleteComment(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockCommentServiceClient)(nil).DeleteComment), varargs...)
+}
+
+// GetCommentList mocks base method.
+func (m *MockCommentServiceClient) GetCommentList(ctx context.Context, in *commentv1.GetCommentListRequest, opts ...grpc.CallOption) (*commentv1.GetCommentListResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetCommentList", varargs...)
+	ret0, _ := ret[0].(*commentv1.GetCommentListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommentList indicates an expected call of GetCommentList.
+func (mr *MockCommentServiceClientMockRecorder) GetCommentList(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentList", reflect.TypeOf((*MockCommentServiceClient)(nil).GetCommentList), varargs...)
+}
+
+// GetCount mocks base method.
+func (m *MockCommentServiceClient) GetCount(ctx context.Context, in *commentv1.GetCountRequest, opts ...grpc.CallOption) (*commentv1.GetCountResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetCount", varargs...)
+	ret0, _ := ret[0].(*commentv1.GetCountResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockCommentServiceClientMockRecorder) GetCount(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockCommentServiceClient)(nil).GetCount), varargs...)
+}
+
+// GetMoreReplies mocks base method.
+func (m *MockCommentServiceClient) GetMoreReplies(ctx context.Context, in *commentv1.GetMoreRepliesRequest, opts ...grpc.CallOption) (*commentv1.GetMoreRepliesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetMoreReplies", varargs...)
+	ret0, _ := ret[0].(*commentv1.GetMoreRepliesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMoreReplies indicates an expected call of GetMoreReplies.
+func (mr *MockCommentServiceClientMockRecorder) GetMoreReplies(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMoreReplies", reflect.TypeOf((*MockCommentServiceClient)(nil).GetMoreReplies), varargs...)
+}
+
+// MockCommentServiceServer is a mock of CommentServiceServer interface.
+type MockCommentServiceServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentServiceServerMockRecorder
+	isgomock struct{}
+}
+
+// MockCommentServiceServerMockRecorder is the mock recorder for MockCommentServiceServer.
+type MockCommentServiceServerMockRecorder struct {
+	mock *MockCommentServiceServer
+}
+
+// NewMockCommentServiceServer creates a new mock instance.
+func NewMockCommentServiceServer(ctrl *gomock.Controller) *MockCommentServiceServer {
+	mock := &MockCommentServiceServer{ctrl: ctrl}
+	mock.recorder = &MockCommentServiceServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentServiceServer) EXPECT() *MockCommentServiceServerMockRecorder {
+	return m.recorder
+}
+
+// CreateComment mocks base method.
+func (m *MockCommentServiceServer) CreateComment(arg0 context.Context, arg1 *commentv1.CreateCommentRequest) (*commentv1.CreateCommentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateComment", arg0, arg1)
+	ret0, _ := ret[0].(*commentv1.CreateCommentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateComment indicates an expected call of CreateComment.
+func (mr *MockCommentServiceServerMockRecorder) CreateComment(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockCommentServiceServer)(nil).CreateComment), arg0, arg1)
+}
+
+// DeleteByUid mocks base method.
+func (m *MockCommentServiceServer) DeleteByUid(arg0 context.Context, arg1 *commentv1.DeleteByUidRequest) (*commentv1.DeleteByUidResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUid", arg0, arg1)
+	ret0, _ := ret[0].(*commentv1.DeleteByUidResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByUid indicates an expected call of DeleteByUid.
+func (mr *MockCommentServiceServerMockRecorder) DeleteByUid(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUid", reflect.TypeOf((*MockCommentServiceServer)(nil).DeleteByUid), arg0, arg1)
+}
+
+// DeleteComment mocks base method.
+func (m *MockCommentServiceServer) DeleteComment(arg0 context.Context, arg1 *commentv1.DeleteCommentRequest) (*commentv1.DeleteCommentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", arg0, arg1)
+	ret0, _ := ret[0].(*commentv1.DeleteCommentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockCommentServiceServerMockRecorder) DeleteComment(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockCommentServiceServer)(nil).DeleteComment), arg0, arg1)
+}
+
+// GetCommentList mocks base method.
+func (m *MockCommentServiceServer) GetCommentList(arg0 context.Context, arg1 *commentv1.GetCommentListRequest) (*commentv1.GetCommentListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommentList", arg0, arg1)
+	ret0, _ := ret[0].(*commentv1.GetCommentListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommentList indicates an expected call of GetCommentList.
+func (mr *MockCommentServiceServerMockRecorder) GetCommentList(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentList", reflect.TypeOf((*MockCommentServiceServer)(nil).GetCommentList), arg0, arg1)
+}
+
+// GetCount mocks base method.
+func (m *MockCommentServiceServer) GetCount(arg0 context.Context, arg1 *commentv1.GetCountRequest) (*commentv1.GetCountResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", arg0, arg1)
+	ret0, _ := ret[0].(*commentv1.GetCountResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockCommentServiceServerMockRecorder) GetCount(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockCommentServiceServer)(nil).GetCount), arg0, arg1)
+}
+
+// GetMoreReplies mocks base method.
+func (m *MockCommentServiceServer) GetMoreReplies(arg0 context.Context, arg1 *commentv1.GetMoreRepliesRequest) (*commentv1.GetMoreRepliesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMoreReplies", arg0, arg1)
+	ret0, _ := ret[0].(*commentv1.GetMoreRepliesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMoreReplies indicates an expected call of GetMoreReplies.
+func (mr *MockCommentServiceServerMockRecorder) GetMoreReplies(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMoreReplies", reflect.TypeOf((*MockCommentServiceServer)(nil).GetMoreReplies), arg0, arg1)
+}
+
+// mustEmbedUnimplementedCommentServiceServer mocks base method.
+func (m *MockCommentServiceServer) mustEmbedUnimplementedCommentServiceServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedCommentServiceServer")
+}
+
+// mustEmbedUnimplementedCommentServiceServer indicates an expected call of mustEmbedUnimplementedCommentServiceServer.
+func (mr *MockCommentServiceServerMockRecorder) mustEmbedUnimplementedCommentServiceServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedCommentServiceServer", reflect.TypeOf((*MockCommentServiceServer)(nil).mustEmbedUnimplementedCommentServiceServer))
+}
+
+// MockUnsafeCommentServiceServer is a mock of UnsafeCommentServiceServer interface.
+type MockUnsafeCommentServiceServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockUnsafeCommentServiceServerMockRecorder
+	isgomock struct{}
+}
+
+// MockUnsafeCommentServiceServerMockRecorder is the mock recorder for MockUnsafeCommentServiceServer.
+type MockUnsafeCommentServiceServerMockRecorder struct {
+	mock *MockUnsafeCommentServiceServer
+}
+
+// NewMockUnsafeCommentServiceServer creates a new mock instance.
+func NewMockUnsafeCommentServiceServer(ctrl *gomock.Controller) *MockUnsafeCommentServiceServer {
+	mock := &MockUnsafeCommentServiceServer{ctrl: ctrl}
+	mock.recorder = &MockUnsafeCommentServiceServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUnsafeCommentServiceServer) EXPECT() *MockUnsafeCommentServiceServerMockRecorder {
+	return m.recorder
+}
+
+// mustEmbedUnimplementedCommentServiceServer mocks base method.
+func (m *MockUnsafeCommentServiceServer) mustEmbedUnimplementedCommentServiceServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedCommentServiceServer")
+}
+
+// mustEmbedUnimplementedCommentServiceServer indicates an expected call of mustEmbedUnimplementedCommentServiceServer.
+func (mr *MockUnsafeCommentServiceServerMockRecorder) mustEmbedUnimplementedCommentServiceServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedCommentServiceServer", reflect.TypeOf((*MockUnsafeCommentServiceServer)(nil).mustEmbedUnimplementedCommentServiceServer))
+}
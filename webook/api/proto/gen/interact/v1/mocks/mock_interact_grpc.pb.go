@@ -42,6 +42,26 @@ func (m *MockInteractServiceClient) EXPECT() *MockInteractServiceClientMockRecor
 	return m.recorder
 }
 
+// CancelCollect mocks base method.
+func (m *MockInteractServiceClient) CancelCollect(ctx context.Context, in *interactv1.CancelCollectRequest, opts ...grpc.CallOption) (*interactv1.CancelCollectResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CancelCollect", varargs...)
+	ret0, _ := ret[0].(*interactv1.CancelCollectResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelCollect indicates an expected call of CancelCollect.
+func (mr *MockInteractServiceClientMockRecorder) CancelCollect(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelCollect", reflect.TypeOf((*MockInteractServiceClient)(nil).CancelCollect), varargs...)
+}
+
 // CancelLike mocks base method.
 func (m *MockInteractServiceClient) CancelLike(ctx context.Context, in *interactv1.CancelLikeRequest, opts ...grpc.CallOption) (*interactv1.CancelLikeResponse, error) {
 	m.ctrl.T.Helper()
@@ -82,6 +102,46 @@ func (mr *MockInteractServiceClientMockRecorder) Collect(ctx, in any, opts ...an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Collect", reflect.TypeOf((*MockInteractServiceClient)(nil).Collect), varargs...)
 }
 
+// CreateCollection mocks base method.
+func (m *MockInteractServiceClient) CreateCollection(ctx context.Context, in *interactv1.CreateCollectionRequest, opts ...grpc.CallOption) (*interactv1.CreateCollectionResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateCollection", varargs...)
+	ret0, _ := ret[0].(*interactv1.CreateCollectionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCollection indicates an expected call of CreateCollection.
+func (mr *MockInteractServiceClientMockRecorder) CreateCollection(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCollection", reflect.TypeOf((*MockInteractServiceClient)(nil).CreateCollection), varargs...)
+}
+
+// DeleteCollection mocks base method.
+func (m *MockInteractServiceClient) DeleteCollection(ctx context.Context, in *interactv1.DeleteCollectionRequest, opts ...grpc.CallOption) (*interactv1.DeleteCollectionResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteCollection", varargs...)
+	ret0, _ := ret[0].(*interactv1.DeleteCollectionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCollection indicates an expected call of DeleteCollection.
+func (mr *MockInteractServiceClientMockRecorder) DeleteCollection(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollection", reflect.TypeOf((*MockInteractServiceClient)(nil).DeleteCollection), varargs...)
+}
+
 // Get mocks base method.
 func (m *MockInteractServiceClient) Get(ctx context.Context, in *interactv1.GetRequest, opts ...grpc.CallOption) (*interactv1.GetResponse, error) {
 	m.ctrl.T.Helper()
@@ -162,6 +222,126 @@ func (mr *MockInteractServiceClientMockRecorder) Like(ctx, in any, opts ...any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Like", reflect.TypeOf((*MockInteractServiceClient)(nil).Like), varargs...)
 }
 
+// ListCollectionItems mocks base method.
+func (m *MockInteractServiceClient) ListCollectionItems(ctx context.Context, in *interactv1.ListCollectionItemsRequest, opts ...grpc.CallOption) (*interactv1.ListCollectionItemsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListCollectionItems", varargs...)
+	ret0, _ := ret[0].(*interactv1.ListCollectionItemsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCollectionItems indicates an expected call of ListCollectionItems.
+func (mr *MockInteractServiceClientMockRecorder) ListCollectionItems(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCollectionItems", reflect.TypeOf((*MockInteractServiceClient)(nil).ListCollectionItems), varargs...)
+}
+
+// ListCollections mocks base method.
+func (m *MockInteractServiceClient) ListCollections(ctx context.Context, in *interactv1.ListCollectionsRequest, opts ...grpc.CallOption) (*interactv1.ListCollectionsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListCollections", varargs...)
+	ret0, _ := ret[0].(*interactv1.ListCollectionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCollections indicates an expected call of ListCollections.
+func (mr *MockInteractServiceClientMockRecorder) ListCollections(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCollections", reflect.TypeOf((*MockInteractServiceClient)(nil).ListCollections), varargs...)
+}
+
+// ListLikes mocks base method.
+func (m *MockInteractServiceClient) ListLikes(ctx context.Context, in *interactv1.ListLikesRequest, opts ...grpc.CallOption) (*interactv1.ListLikesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListLikes", varargs...)
+	ret0, _ := ret[0].(*interactv1.ListLikesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLikes indicates an expected call of ListLikes.
+func (mr *MockInteractServiceClientMockRecorder) ListLikes(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLikes", reflect.TypeOf((*MockInteractServiceClient)(nil).ListLikes), varargs...)
+}
+
+// MergeUser mocks base method.
+func (m *MockInteractServiceClient) MergeUser(ctx context.Context, in *interactv1.MergeUserRequest, opts ...grpc.CallOption) (*interactv1.MergeUserResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "MergeUser", varargs...)
+	ret0, _ := ret[0].(*interactv1.MergeUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeUser indicates an expected call of MergeUser.
+func (mr *MockInteractServiceClientMockRecorder) MergeUser(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeUser", reflect.TypeOf((*MockInteractServiceClient)(nil).MergeUser), varargs...)
+}
+
+// MoveCollectionItem mocks base method.
+func (m *MockInteractServiceClient) MoveCollectionItem(ctx context.Context, in *interactv1.MoveCollectionItemRequest, opts ...grpc.CallOption) (*interactv1.MoveCollectionItemResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "MoveCollectionItem", varargs...)
+	ret0, _ := ret[0].(*interactv1.MoveCollectionItemResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveCollectionItem indicates an expected call of MoveCollectionItem.
+func (mr *MockInteractServiceClientMockRecorder) MoveCollectionItem(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveCollectionItem", reflect.TypeOf((*MockInteractServiceClient)(nil).MoveCollectionItem), varargs...)
+}
+
+// RenameCollection mocks base method.
+func (m *MockInteractServiceClient) RenameCollection(ctx context.Context, in *interactv1.RenameCollectionRequest, opts ...grpc.CallOption) (*interactv1.RenameCollectionResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RenameCollection", varargs...)
+	ret0, _ := ret[0].(*interactv1.RenameCollectionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameCollection indicates an expected call of RenameCollection.
+func (mr *MockInteractServiceClientMockRecorder) RenameCollection(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameCollection", reflect.TypeOf((*MockInteractServiceClient)(nil).RenameCollection), varargs...)
+}
+
 // MockInteractServiceServer is a mock of InteractServiceServer interface.
 type MockInteractServiceServer struct {
 	ctrl     *gomock.Controller
@@ -186,6 +366,21 @@ func (m *MockInteractServiceServer) EXPECT() *MockInteractServiceServerMockRecor
 	return m.recorder
 }
 
+// CancelCollect mocks base method.
+func (m *MockInteractServiceServer) CancelCollect(arg0 context.Context, arg1 *interactv1.CancelCollectRequest) (*interactv1.CancelCollectResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelCollect", arg0, arg1)
+	ret0, _ := ret[0].(*interactv1.CancelCollectResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelCollect indicates an expected call of CancelCollect.
+func (mr *MockInteractServiceServerMockRecorder) CancelCollect(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelCollect", reflect.TypeOf((*MockInteractServiceServer)(nil).CancelCollect), arg0, arg1)
+}
+
 // CancelLike mocks base method.
 func (m *MockInteractServiceServer) CancelLike(arg0 context.Context, arg1 *interactv1.CancelLikeRequest) (*interactv1.CancelLikeResponse, error) {
 	m.ctrl.T.Helper()
@@ -216,6 +411,36 @@ func (mr *MockInteractServiceServerMockRecorder) Collect(arg0, arg1 any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Collect", reflect.TypeOf((*MockInteractServiceServer)(nil).Collect), arg0, arg1)
 }
 
+// CreateCollection mocks base method.
+func (m *MockInteractServiceServer) CreateCollection(arg0 context.Context, arg1 *interactv1.CreateCollectionRequest) (*interactv1.CreateCollectionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCollection", arg0, arg1)
+	ret0, _ := ret[0].(*interactv1.CreateCollectionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCollection indicates an expected call of CreateCollection.
+func (mr *MockInteractServiceServerMockRecorder) CreateCollection(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCollection", reflect.TypeOf((*MockInteractServiceServer)(nil).CreateCollection), arg0, arg1)
+}
+
+// DeleteCollection mocks base method.
+func (m *MockInteractServiceServer) DeleteCollection(arg0 context.Context, arg1 *interactv1.DeleteCollectionRequest) (*interactv1.DeleteCollectionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollection", arg0, arg1)
+	ret0, _ := ret[0].(*interactv1.DeleteCollectionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCollection indicates an expected call of DeleteCollection.
+func (mr *MockInteractServiceServerMockRecorder) DeleteCollection(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollection", reflect.TypeOf((*MockInteractServiceServer)(nil).DeleteCollection), arg0, arg1)
+}
+
 // Get mocks base method.
 func (m *MockInteractServiceServer) Get(arg0 context.Context, arg1 *interactv1.GetRequest) (*interactv1.GetResponse, error) {
 	m.ctrl.T.Helper()
@@ -276,6 +501,96 @@ func (mr *MockInteractServiceServerMockRecorder) Like(arg0, arg1 any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Like", reflect.TypeOf((*MockInteractServiceServer)(nil).Like), arg0, arg1)
 }
 
+// ListCollectionItems mocks base method.
+func (m *MockInteractServiceServer) ListCollectionItems(arg0 context.Context, arg1 *interactv1.ListCollectionItemsRequest) (*interactv1.ListCollectionItemsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCollectionItems", arg0, arg1)
+	ret0, _ := ret[0].(*interactv1.ListCollectionItemsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCollectionItems indicates an expected call of ListCollectionItems.
+func (mr *MockInteractServiceServerMockRecorder) ListCollectionItems(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCollectionItems", reflect.TypeOf((*MockInteractServiceServer)(nil).ListCollectionItems), arg0, arg1)
+}
+
+// ListCollections mocks base method.
+func (m *MockInteractServiceServer) ListCollections(arg0 context.Context, arg1 *interactv1.ListCollectionsRequest) (*interactv1.ListCollectionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCollections", arg0, arg1)
+	ret0, _ := ret[0].(*interactv1.ListCollectionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCollections indicates an expected call of ListCollections.
+func (mr *MockInteractServiceServerMockRecorder) ListCollections(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCollections", reflect.TypeOf((*MockInteractServiceServer)(nil).ListCollections), arg0, arg1)
+}
+
+// ListLikes mocks base method.
+func (m *MockInteractServiceServer) ListLikes(arg0 context.Context, arg1 *interactv1.ListLikesRequest) (*interactv1.ListLikesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLikes", arg0, arg1)
+	ret0, _ := ret[0].(*interactv1.ListLikesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLikes indicates an expected call of ListLikes.
+func (mr *MockInteractServiceServerMockRecorder) ListLikes(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLikes", reflect.TypeOf((*MockInteractServiceServer)(nil).ListLikes), arg0, arg1)
+}
+
+// MergeUser mocks base method.
+func (m *MockInteractServiceServer) MergeUser(arg0 context.Context, arg1 *interactv1.MergeUserRequest) (*interactv1.MergeUserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeUser", arg0, arg1)
+	ret0, _ := ret[0].(*interactv1.MergeUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeUser indicates an expected call of MergeUser.
+func (mr *MockInteractServiceServerMockRecorder) MergeUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeUser", reflect.TypeOf((*MockInteractServiceServer)(nil).MergeUser), arg0, arg1)
+}
+
+// MoveCollectionItem mocks base method.
+func (m *MockInteractServiceServer) MoveCollectionItem(arg0 context.Context, arg1 *interactv1.MoveCollectionItemRequest) (*interactv1.MoveCollectionItemResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveCollectionItem", arg0, arg1)
+	ret0, _ := ret[0].(*interactv1.MoveCollectionItemResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveCollectionItem indicates an expected call of MoveCollectionItem.
+func (mr *MockInteractServiceServerMockRecorder) MoveCollectionItem(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveCollectionItem", reflect.TypeOf((*MockInteractServiceServer)(nil).MoveCollectionItem), arg0, arg1)
+}
+
+// RenameCollection mocks base method.
+func (m *MockInteractServiceServer) RenameCollection(arg0 context.Context, arg1 *interactv1.RenameCollectionRequest) (*interactv1.RenameCollectionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameCollection", arg0, arg1)
+	ret0, _ := ret[0].(*interactv1.RenameCollectionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameCollection indicates an expected call of RenameCollection.
+func (mr *MockInteractServiceServerMockRecorder) RenameCollection(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameCollection", reflect.TypeOf((*MockInteractServiceServer)(nil).RenameCollection), arg0, arg1)
+}
+
 // mustEmbedUnimplementedInteractServiceServer mocks base method.
 func (m *MockInteractServiceServer) mustEmbedUnimplementedInteractServiceServer() {
 	m.ctrl.T.Helper()
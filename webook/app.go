@@ -5,10 +5,13 @@ import (
 	"github.com/robfig/cron/v3"
 
 	"github.com/liupch66/basic-go/webook/internal/events"
+	"github.com/liupch66/basic-go/webook/internal/job"
 )
 
 type App struct {
 	web       *gin.Engine
 	consumers []events.Consumer
 	cron      *cron.Cron
+	// scheduler 抢占数据库里面的任务来执行，多个实例部署的时候同一个任务只有一个实例在跑
+	scheduler *job.Scheduler
 }
@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"time"
+)
+
+type Comment struct {
+	Id int64 `json:"id"`
+	// Uid 评论者
+	Uid   int64  `json:"uid"`
+	Biz   string `json:"biz"`
+	BizId int64  `json:"biz_id"`
+	// Content 评论内容
+	Content string `json:"content"`
+	// RootId 根评论的 id，根评论自己是 0
+	RootId int64 `json:"root_id"`
+	// ParentId 直接回复的评论，根评论是 0
+	ParentId int64     `json:"parent_id"`
+	Ctime    time.Time `json:"ctime"`
+	Utime    time.Time `json:"utime"`
+}
+
+// IsRoot 是否是根评论
+func (c Comment) IsRoot() bool {
+	return c.RootId == 0
+}
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: producer.go
+//
+// Generated by this command:
+//
+//	mockgen -package=mockevt -source=producer.go -destination=mocks/mock_producer.go Producer
+//
+
+// Package mockevt is a generated GoMock package.
+package mockevt
+
+import (
+	reflect "reflect"
+
+	events "github.com/liupch66/basic-go/webook/comment/events"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockProducer is a mock of Producer interface.
+type MockProducer struct {
+	ctrl     *gomock.Controller
+	recorder *MockProducerMockRecorder
+	isgomock struct{}
+}
+
+// MockProducerMockRecorder is the mock recorder for MockProducer.
+type MockProducerMockRecorder struct {
+	mock *MockProducer
+}
+
+// NewMockProducer creates a new mock instance.
+func NewMockProducer(ctrl *gomock.Controller) *MockProducer {
+	mock := &MockProducer{ctrl: ctrl}
+	mock.recorder = &MockProducerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProducer) EXPECT() *MockProducerMockRecorder {
+	return m.recorder
+}
+
+// ProduceCommentEvent mocks base method.
+func (m *MockProducer) ProduceCommentEvent(evt events.CommentEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProduceCommentEvent", evt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProduceCommentEvent indicates an expected call of ProduceCommentEvent.
+func (mr *MockProducerMockRecorder) ProduceCommentEvent(evt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProduceCommentEvent", reflect.TypeOf((*MockProducer)(nil).ProduceCommentEvent), evt)
+}
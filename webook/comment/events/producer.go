@@ -0,0 +1,50 @@
+package events
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/IBM/sarama"
+)
+
+// TopicCommentEvent 下游消费评论事件也用这个 topic
+const TopicCommentEvent = "comment_event"
+
+// CommentEvent 评论写成功之后发出来，下游的通知之类的用
+type CommentEvent struct {
+	Id    int64
+	Biz   string
+	BizId int64
+	Uid   int64
+	// ParentUid 回复的那条评论的作者，不是回复就是 0
+	ParentUid int64
+	// Ctime 毫秒
+	Ctime int64
+}
+
+//go:generate mockgen -package=mockevt -source=producer.go -destination=mocks/mock_producer.go Producer
+type Producer interface {
+	ProduceCommentEvent(evt CommentEvent) error
+}
+
+type SaramaSyncProducer struct {
+	producer sarama.SyncProducer
+}
+
+func NewSaramaSyncProducer(producer sarama.SyncProducer) Producer {
+	return &SaramaSyncProducer{producer: producer}
+}
+
+func (s *SaramaSyncProducer) ProduceCommentEvent(evt CommentEvent) error {
+	data, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+	_, _, err = s.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: TopicCommentEvent,
+		// 同一个资源的评论落到同一个分区
+		Key:   sarama.StringEncoder(evt.Biz + ":" + strconv.FormatInt(evt.BizId, 10)),
+		Value: sarama.ByteEncoder(data),
+	})
+	return err
+}
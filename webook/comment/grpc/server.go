@@ -0,0 +1,105 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ecodeclub/ekit/slice"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	commentv1 "github.com/liupch66/basic-go/webook/api/proto/gen/comment/v1"
+	"github.com/liupch66/basic-go/webook/comment/domain"
+	"github.com/liupch66/basic-go/webook/comment/service"
+)
+
+// CommentServiceServer 同样只是把 service 包装成 gRPC
+type CommentServiceServer struct {
+	commentv1.UnimplementedCommentServiceServer
+	svc service.CommentService
+}
+
+func NewCommentServiceServer(svc service.CommentService) *CommentServiceServer {
+	return &CommentServiceServer{svc: svc}
+}
+
+func (c *CommentServiceServer) Register(server *grpc.Server) {
+	commentv1.RegisterCommentServiceServer(server, c)
+}
+
+func (c *CommentServiceServer) CreateComment(ctx context.Context, request *commentv1.CreateCommentRequest) (*commentv1.CreateCommentResponse, error) {
+	cmt := request.GetComment()
+	if cmt.GetUid() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "uid 非法")
+	}
+	if cmt.GetContent() == "" {
+		return nil, status.Error(codes.InvalidArgument, "评论内容不能为空")
+	}
+	id, err := c.svc.Create(ctx, domain.Comment{
+		Uid:      cmt.GetUid(),
+		Biz:      cmt.GetBiz(),
+		BizId:    cmt.GetBizId(),
+		Content:  cmt.GetContent(),
+		ParentId: cmt.GetParentId(),
+	})
+	return &commentv1.CreateCommentResponse{Id: id}, err
+}
+
+func (c *CommentServiceServer) DeleteComment(ctx context.Context, request *commentv1.DeleteCommentRequest) (*commentv1.DeleteCommentResponse, error) {
+	err := c.svc.Delete(ctx, request.GetId(), request.GetBiz(), request.GetBizId(),
+		request.GetUid(), request.GetBizOwnerUid())
+	if errors.Is(err, service.ErrNoPermission) {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	return &commentv1.DeleteCommentResponse{}, err
+}
+
+func (c *CommentServiceServer) GetCommentList(ctx context.Context, request *commentv1.GetCommentListRequest) (*commentv1.GetCommentListResponse, error) {
+	cs, err := c.svc.GetCommentList(ctx, request.GetBiz(), request.GetBizId(), request.GetMinId(), int(request.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	return &commentv1.GetCommentListResponse{Comments: c.toDTOs(cs)}, nil
+}
+
+func (c *CommentServiceServer) GetMoreReplies(ctx context.Context, request *commentv1.GetMoreRepliesRequest) (*commentv1.GetMoreRepliesResponse, error) {
+	cs, err := c.svc.GetMoreReplies(ctx, request.GetRootId(), request.GetMaxId(), int(request.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	return &commentv1.GetMoreRepliesResponse{Replies: c.toDTOs(cs)}, nil
+}
+
+func (c *CommentServiceServer) GetCount(ctx context.Context, request *commentv1.GetCountRequest) (*commentv1.GetCountResponse, error) {
+	cnt, err := c.svc.GetCount(ctx, request.GetBiz(), request.GetBizId())
+	return &commentv1.GetCountResponse{Cnt: cnt}, err
+}
+
+func (c *CommentServiceServer) DeleteByUid(ctx context.Context, request *commentv1.DeleteByUidRequest) (*commentv1.DeleteByUidResponse, error) {
+	if request.GetUid() <= 0 || request.GetLimit() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "uid 或者 limit 非法")
+	}
+	cnt, err := c.svc.DeleteByUid(ctx, request.GetUid(), int(request.GetLimit()))
+	return &commentv1.DeleteByUidResponse{Cnt: cnt}, err
+}
+
+func (c *CommentServiceServer) toDTOs(cs []domain.Comment) []*commentv1.Comment {
+	return slice.Map(cs, func(idx int, src domain.Comment) *commentv1.Comment {
+		return c.toDTO(src)
+	})
+}
+
+func (c *CommentServiceServer) toDTO(cmt domain.Comment) *commentv1.Comment {
+	return &commentv1.Comment{
+		Id:       cmt.Id,
+		Uid:      cmt.Uid,
+		Biz:      cmt.Biz,
+		BizId:    cmt.BizId,
+		Content:  cmt.Content,
+		RootId:   cmt.RootId,
+		ParentId: cmt.ParentId,
+		Ctime:    cmt.Ctime.UnixMilli(),
+		Utime:    cmt.Utime.UnixMilli(),
+	}
+}
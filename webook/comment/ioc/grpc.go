@@ -0,0 +1,32 @@
+package ioc
+
+import (
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+
+	cgrpc "github.com/liupch66/basic-go/webook/comment/grpc"
+	"github.com/liupch66/basic-go/webook/pkg/grpcx"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
+)
+
+func InitGRPCxServer(commentSrv *cgrpc.CommentServiceServer, l logger.LoggerV1) *grpcx.Server {
+	type Config struct {
+		Port     int    `yaml:"port"`
+		EtcdAddr string `yaml:"etcdAddr"`
+	}
+	var cfg Config
+	if err := viper.UnmarshalKey("grpc.server", &cfg); err != nil {
+		panic(err)
+	}
+
+	server := grpc.NewServer()
+	commentSrv.Register(server)
+
+	return &grpcx.Server{
+		Server:   server,
+		Port:     cfg.Port,
+		EtcdAddr: cfg.EtcdAddr,
+		Name:     "comment",
+		L:        l,
+	}
+}
@@ -0,0 +1,24 @@
+package ioc
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/spf13/viper"
+)
+
+func InitRedis() redis.Cmdable {
+	type Config struct {
+		Addr string `yaml:"addr"`
+	}
+	var cfg Config
+	if err := viper.UnmarshalKey("redis", &cfg); err != nil {
+		panic(err)
+	}
+
+	rdb := redis.NewClient(&redis.Options{Addr: cfg.Addr})
+	if err := rdb.Ping(context.Background()).Err(); err != nil {
+		panic(err)
+	}
+	return rdb
+}
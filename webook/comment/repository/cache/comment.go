@@ -0,0 +1,44 @@
+package cache
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+//go:embed lua/incr_cnt.lua
+var luaIncrCnt string
+
+//go:generate mockgen -package=mockcache -source=comment.go -destination=mocks/mock_comment.go CommentCache
+type CommentCache interface {
+	IncrCntIfPresent(ctx context.Context, biz string, bizId int64, delta int64) error
+	GetCnt(ctx context.Context, biz string, bizId int64) (int64, error)
+	SetCnt(ctx context.Context, biz string, bizId int64, cnt int64) error
+}
+
+type RedisCommentCache struct {
+	cmd redis.Cmdable
+}
+
+func NewRedisCommentCache(cmd redis.Cmdable) CommentCache {
+	return &RedisCommentCache{cmd: cmd}
+}
+
+func (cache *RedisCommentCache) cntKey(biz string, bizId int64) string {
+	return fmt.Sprintf("comment:cnt:%s:%d", biz, bizId)
+}
+
+func (cache *RedisCommentCache) IncrCntIfPresent(ctx context.Context, biz string, bizId int64, delta int64) error {
+	return cache.cmd.Eval(ctx, luaIncrCnt, []string{cache.cntKey(biz, bizId)}, delta).Err()
+}
+
+func (cache *RedisCommentCache) GetCnt(ctx context.Context, biz string, bizId int64) (int64, error) {
+	return cache.cmd.Get(ctx, cache.cntKey(biz, bizId)).Int64()
+}
+
+func (cache *RedisCommentCache) SetCnt(ctx context.Context, biz string, bizId int64, cnt int64) error {
+	return cache.cmd.Set(ctx, cache.cntKey(biz, bizId), cnt, 15*time.Minute).Err()
+}
@@ -0,0 +1,11 @@
+--Eval(ctx, luaIncrCnt, []string{"comment:cnt:$biz:$bizId"}, 1)
+-- 有缓存才更新，没有缓存就等下一次查询回写
+local key = KEYS[1]
+local delta = tonumber(ARGV[1])
+local exists = redis.call("EXISTS", key)
+if exists == 1 then
+    redis.call("INCRBY", key, delta)
+    return 1
+else
+    return 0
+end
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+
+	"github.com/liupch66/basic-go/webook/comment/domain"
+	"github.com/liupch66/basic-go/webook/comment/repository/cache"
+	"github.com/liupch66/basic-go/webook/comment/repository/dao"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
+)
+
+var ErrCommentNotFound = dao.ErrDataNotFound
+
+//go:generate mockgen -package=mockrepo -source=comment.go -destination=mocks/mock_comment.go CommentRepository
+type CommentRepository interface {
+	CreateComment(ctx context.Context, c domain.Comment) (int64, error)
+	DeleteComment(ctx context.Context, c domain.Comment) error
+	FindById(ctx context.Context, id int64) (domain.Comment, error)
+	FindRoots(ctx context.Context, biz string, bizId int64, minId int64, limit int) ([]domain.Comment, error)
+	FindReplies(ctx context.Context, rootId int64, maxId int64, limit int) ([]domain.Comment, error)
+	GetCount(ctx context.Context, biz string, bizId int64) (int64, error)
+	FindByUid(ctx context.Context, uid int64, limit int) ([]domain.Comment, error)
+}
+
+type CachedCommentRepository struct {
+	dao   dao.CommentDAO
+	cache cache.CommentCache
+	l     logger.LoggerV1
+}
+
+func NewCachedCommentRepository(dao dao.CommentDAO, cache cache.CommentCache, l logger.LoggerV1) CommentRepository {
+	return &CachedCommentRepository{dao: dao, cache: cache, l: l}
+}
+
+func (repo *CachedCommentRepository) CreateComment(ctx context.Context, c domain.Comment) (int64, error) {
+	id, err := repo.dao.Insert(ctx, repo.toEntity(c))
+	if err != nil {
+		return 0, err
+	}
+	if er := repo.cache.IncrCntIfPresent(ctx, c.Biz, c.BizId, 1); er != nil {
+		// 计数缓存 15 分钟就过期，不准也可以容忍
+		repo.l.Error("更新评论数缓存失败", logger.String("biz", c.Biz),
+			logger.Int64("biz_id", c.BizId), logger.Error(er))
+	}
+	return id, nil
+}
+
+func (repo *CachedCommentRepository) DeleteComment(ctx context.Context, c domain.Comment) error {
+	deleted, err := repo.dao.Delete(ctx, repo.toEntity(c))
+	if err != nil || deleted == 0 {
+		return err
+	}
+	if er := repo.cache.IncrCntIfPresent(ctx, c.Biz, c.BizId, -deleted); er != nil {
+		repo.l.Error("更新评论数缓存失败", logger.String("biz", c.Biz),
+			logger.Int64("biz_id", c.BizId), logger.Error(er))
+	}
+	return nil
+}
+
+func (repo *CachedCommentRepository) FindByUid(ctx context.Context, uid int64, limit int) ([]domain.Comment, error) {
+	cs, err := repo.dao.FindByUid(ctx, uid, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(cs, func(idx int, src dao.Comment) domain.Comment {
+		return repo.toDomain(src)
+	}), nil
+}
+
+func (repo *CachedCommentRepository) FindById(ctx context.Context, id int64) (domain.Comment, error) {
+	c, err := repo.dao.FindById(ctx, id)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	return repo.toDomain(c), nil
+}
+
+func (repo *CachedCommentRepository) FindRoots(ctx context.Context, biz string, bizId int64, minId int64, limit int) ([]domain.Comment, error) {
+	cs, err := repo.dao.FindRoots(ctx, biz, bizId, minId, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(cs, func(idx int, src dao.Comment) domain.Comment {
+		return repo.toDomain(src)
+	}), nil
+}
+
+func (repo *CachedCommentRepository) FindReplies(ctx context.Context, rootId int64, maxId int64, limit int) ([]domain.Comment, error) {
+	cs, err := repo.dao.FindReplies(ctx, rootId, maxId, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(cs, func(idx int, src dao.Comment) domain.Comment {
+		return repo.toDomain(src)
+	}), nil
+}
+
+func (repo *CachedCommentRepository) GetCount(ctx context.Context, biz string, bizId int64) (int64, error) {
+	cnt, err := repo.cache.GetCnt(ctx, biz, bizId)
+	if err == nil {
+		return cnt, nil
+	}
+	res, err := repo.dao.GetCount(ctx, biz, bizId)
+	switch {
+	case err == nil:
+		cnt = res.Cnt
+	case errors.Is(err, dao.ErrDataNotFound):
+		// 还没有人评论过
+		cnt = 0
+	default:
+		return 0, err
+	}
+	if er := repo.cache.SetCnt(ctx, biz, bizId, cnt); er != nil {
+		repo.l.Error("回写评论数缓存失败", logger.String("biz", biz),
+			logger.Int64("biz_id", bizId), logger.Error(er))
+	}
+	return cnt, nil
+}
+
+func (repo *CachedCommentRepository) toEntity(c domain.Comment) dao.Comment {
+	return dao.Comment{
+		Id:       c.Id,
+		Uid:      c.Uid,
+		Biz:      c.Biz,
+		BizId:    c.BizId,
+		RootId:   c.RootId,
+		Content:  c.Content,
+		ParentId: c.ParentId,
+	}
+}
+
+func (repo *CachedCommentRepository) toDomain(c dao.Comment) domain.Comment {
+	return domain.Comment{
+		Id:       c.Id,
+		Uid:      c.Uid,
+		Biz:      c.Biz,
+		BizId:    c.BizId,
+		Content:  c.Content,
+		RootId:   c.RootId,
+		ParentId: c.ParentId,
+		Ctime:    time.UnixMilli(c.Ctime),
+		Utime:    time.UnixMilli(c.Utime),
+	}
+}
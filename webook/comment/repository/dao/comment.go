@@ -0,0 +1,140 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Comment struct {
+	Id  int64 `gorm:"primaryKey,autoIncrement"`
+	Uid int64 `gorm:"index"`
+	// 查询根评论：WHERE biz = ? AND biz_id = ? AND root_id = 0 AND id < ? ORDER BY id DESC
+	Biz     string `gorm:"type:varchar(128);index:biz_type_id_root"`
+	BizId   int64  `gorm:"index:biz_type_id_root"`
+	RootId  int64  `gorm:"index:biz_type_id_root;index"`
+	Content string `gorm:"type:text"`
+	// 回复的评论被删除了之后 parent_id 不变，前端展示成"评论已删除"就可以
+	ParentId int64 `gorm:"index"`
+	Ctime    int64
+	Utime    int64
+}
+
+// CommentCount 每个 biz 的评论数，包括回复
+type CommentCount struct {
+	Id    int64  `gorm:"primaryKey,autoIncrement"`
+	BizId int64  `gorm:"uniqueIndex:biz_type_id"`
+	Biz   string `gorm:"type:varchar(128);uniqueIndex:biz_type_id"`
+	Cnt   int64
+	Ctime int64
+	Utime int64
+}
+
+//go:generate mockgen -package=mockdao -source=comment.go -destination=mocks/mock_comment.go CommentDAO
+type CommentDAO interface {
+	// Insert 插入评论并且评论数加一
+	Insert(ctx context.Context, c Comment) (int64, error)
+	// Delete 删除评论，根评论会连带删除它下面的回复，返回删除的条数
+	Delete(ctx context.Context, c Comment) (int64, error)
+	FindById(ctx context.Context, id int64) (Comment, error)
+	FindRoots(ctx context.Context, biz string, bizId int64, minId int64, limit int) ([]Comment, error)
+	FindReplies(ctx context.Context, rootId int64, maxId int64, limit int) ([]Comment, error)
+	GetCount(ctx context.Context, biz string, bizId int64) (CommentCount, error)
+	// FindByUid 按 id 正序查询用户发的评论，注销账号的时候用
+	FindByUid(ctx context.Context, uid int64, limit int) ([]Comment, error)
+}
+
+type GORMCommentDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMCommentDAO(db *gorm.DB) CommentDAO {
+	return &GORMCommentDAO{db: db}
+}
+
+func (dao *GORMCommentDAO) Insert(ctx context.Context, c Comment) (int64, error) {
+	now := time.Now().UnixMilli()
+	c.Ctime = now
+	c.Utime = now
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&c).Error; err != nil {
+			return err
+		}
+		return tx.Clauses(clause.OnConflict{
+			DoUpdates: clause.Assignments(map[string]any{
+				"cnt":   gorm.Expr("cnt+1"),
+				"utime": now,
+			}),
+		}).Create(&CommentCount{
+			BizId: c.BizId,
+			Biz:   c.Biz,
+			Cnt:   1,
+			Ctime: now,
+			Utime: now,
+		}).Error
+	})
+	return c.Id, err
+}
+
+func (dao *GORMCommentDAO) Delete(ctx context.Context, c Comment) (int64, error) {
+	var deleted int64
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var res *gorm.DB
+		if c.RootId == 0 {
+			// 根评论，回复一起删掉
+			res = tx.Where("id = ? OR root_id = ?", c.Id, c.Id).Delete(&Comment{})
+		} else {
+			res = tx.Where("id = ?", c.Id).Delete(&Comment{})
+		}
+		if res.Error != nil {
+			return res.Error
+		}
+		deleted = res.RowsAffected
+		if deleted == 0 {
+			return nil
+		}
+		return tx.Model(&CommentCount{}).Where("biz = ? AND biz_id = ?", c.Biz, c.BizId).
+			Updates(map[string]any{
+				"cnt":   gorm.Expr("cnt-?", deleted),
+				"utime": time.Now().UnixMilli(),
+			}).Error
+	})
+	return deleted, err
+}
+
+func (dao *GORMCommentDAO) FindByUid(ctx context.Context, uid int64, limit int) ([]Comment, error) {
+	var res []Comment
+	err := dao.db.WithContext(ctx).Where("uid = ?", uid).Order("id ASC").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMCommentDAO) FindById(ctx context.Context, id int64) (Comment, error) {
+	var res Comment
+	err := dao.db.WithContext(ctx).Where("id = ?", id).First(&res).Error
+	return res, err
+}
+
+func (dao *GORMCommentDAO) FindRoots(ctx context.Context, biz string, bizId int64, minId int64, limit int) ([]Comment, error) {
+	var res []Comment
+	db := dao.db.WithContext(ctx).Where("biz = ? AND biz_id = ? AND root_id = 0", biz, bizId)
+	if minId > 0 {
+		db = db.Where("id < ?", minId)
+	}
+	err := db.Order("id DESC").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMCommentDAO) FindReplies(ctx context.Context, rootId int64, maxId int64, limit int) ([]Comment, error) {
+	var res []Comment
+	err := dao.db.WithContext(ctx).Where("root_id = ? AND id > ?", rootId, maxId).
+		Order("id ASC").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMCommentDAO) GetCount(ctx context.Context, biz string, bizId int64) (CommentCount, error) {
+	var res CommentCount
+	err := dao.db.WithContext(ctx).Where("biz = ? AND biz_id = ?", biz, bizId).First(&res).Error
+	return res, err
+}
@@ -0,0 +1,7 @@
+package dao
+
+import (
+	"gorm.io/gorm"
+)
+
+var ErrDataNotFound = gorm.ErrRecordNotFound
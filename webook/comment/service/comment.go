@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/liupch66/basic-go/webook/comment/domain"
+	"github.com/liupch66/basic-go/webook/comment/events"
+	"github.com/liupch66/basic-go/webook/comment/repository"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
+)
+
+var (
+	ErrNoPermission    = errors.New("没有权限删除该评论")
+	ErrParentNotFound  = errors.New("回复的评论不存在")
+	ErrCommentNotFound = repository.ErrCommentNotFound
+)
+
+//go:generate mockgen -package=mocksvc -source=comment.go -destination=mocks/mock_comment.go CommentService
+type CommentService interface {
+	// Create 创建评论，ParentId 不为 0 的时候就是回复
+	Create(ctx context.Context, c domain.Comment) (int64, error)
+	// Delete 评论作者或者资源（比如文章）的作者都可以删除评论，删除根评论会把它下面的回复一并删除
+	Delete(ctx context.Context, id int64, biz string, bizId int64, uid int64, bizOwnerUid int64) error
+	// GetCommentList 按照 id 倒序分页查询根评论，minId 是上一页最后一条的 id，第一页传 0
+	GetCommentList(ctx context.Context, biz string, bizId int64, minId int64, limit int) ([]domain.Comment, error)
+	// GetMoreReplies 按照 id 正序懒加载根评论下面的回复，maxId 是上一页最后一条的 id，第一页传 0
+	GetMoreReplies(ctx context.Context, rootId int64, maxId int64, limit int) ([]domain.Comment, error)
+	GetCount(ctx context.Context, biz string, bizId int64) (int64, error)
+	// DeleteByUid 删除用户的一批评论，返回这一批删了几条，比 limit 少说明删完了
+	DeleteByUid(ctx context.Context, uid int64, limit int) (int64, error)
+}
+
+type commentService struct {
+	repo     repository.CommentRepository
+	producer events.Producer
+	l        logger.LoggerV1
+}
+
+func NewCommentService(repo repository.CommentRepository, producer events.Producer, l logger.LoggerV1) CommentService {
+	return &commentService{repo: repo, producer: producer, l: l}
+}
+
+func (svc *commentService) Create(ctx context.Context, c domain.Comment) (int64, error) {
+	var parentUid int64
+	if c.ParentId > 0 {
+		parent, err := svc.repo.FindById(ctx, c.ParentId)
+		if errors.Is(err, repository.ErrCommentNotFound) {
+			return 0, ErrParentNotFound
+		}
+		if err != nil {
+			return 0, err
+		}
+		if parent.Biz != c.Biz || parent.BizId != c.BizId {
+			return 0, ErrParentNotFound
+		}
+		// 回复都挂在根评论下面，这样才能按照根评论懒加载
+		c.RootId = parent.RootId
+		if parent.IsRoot() {
+			c.RootId = parent.Id
+		}
+		parentUid = parent.Uid
+	} else {
+		c.RootId = 0
+	}
+	id, err := svc.repo.CreateComment(ctx, c)
+	if err != nil {
+		return 0, err
+	}
+	// 和互动服务一样，写成功之后同步发事件，发送失败只记日志，评论本身已经成功了
+	er := svc.producer.ProduceCommentEvent(events.CommentEvent{
+		Id:        id,
+		Biz:       c.Biz,
+		BizId:     c.BizId,
+		Uid:       c.Uid,
+		ParentUid: parentUid,
+		Ctime:     time.Now().UnixMilli(),
+	})
+	if er != nil {
+		svc.l.Error("发送评论事件失败", logger.Int64("id", id), logger.String("biz", c.Biz),
+			logger.Int64("bizId", c.BizId), logger.Error(er))
+	}
+	return id, nil
+}
+
+func (svc *commentService) Delete(ctx context.Context, id int64, biz string, bizId int64, uid int64, bizOwnerUid int64) error {
+	c, err := svc.repo.FindById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if c.Biz != biz || c.BizId != bizId {
+		return ErrNoPermission
+	}
+	if c.Uid != uid && bizOwnerUid != uid {
+		return ErrNoPermission
+	}
+	return svc.repo.DeleteComment(ctx, c)
+}
+
+func (svc *commentService) GetCommentList(ctx context.Context, biz string, bizId int64, minId int64, limit int) ([]domain.Comment, error) {
+	return svc.repo.FindRoots(ctx, biz, bizId, minId, limit)
+}
+
+func (svc *commentService) GetMoreReplies(ctx context.Context, rootId int64, maxId int64, limit int) ([]domain.Comment, error) {
+	return svc.repo.FindReplies(ctx, rootId, maxId, limit)
+}
+
+func (svc *commentService) GetCount(ctx context.Context, biz string, bizId int64) (int64, error) {
+	return svc.repo.GetCount(ctx, biz, bizId)
+}
+
+func (svc *commentService) DeleteByUid(ctx context.Context, uid int64, limit int) (int64, error) {
+	cs, err := svc.repo.FindByUid(ctx, uid, limit)
+	if err != nil {
+		return 0, err
+	}
+	// 一条一条删，评论数和缓存都走原来删除的逻辑。删掉的评论下一批就查不到了，所以不用翻页
+	for _, c := range cs {
+		if err = svc.repo.DeleteComment(ctx, c); err != nil {
+			return 0, err
+		}
+	}
+	return int64(len(cs)), nil
+}
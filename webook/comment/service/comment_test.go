@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/liupch66/basic-go/webook/comment/domain"
+	"github.com/liupch66/basic-go/webook/comment/events"
+	mockevt "github.com/liupch66/basic-go/webook/comment/events/mocks"
+	"github.com/liupch66/basic-go/webook/comment/repository"
+	mockrepo "github.com/liupch66/basic-go/webook/comment/repository/mocks"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
+)
+
+func Test_commentService_Create(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.CommentRepository, events.Producer)
+		c    domain.Comment
+
+		expectedId  int64
+		expectedErr error
+	}{
+		{
+			name: "创建根评论",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, events.Producer) {
+				repo := mockrepo.NewMockCommentRepository(ctrl)
+				repo.EXPECT().CreateComment(gomock.Any(), domain.Comment{Uid: 123, Biz: "article", BizId: 1,
+					Content: "评论"}).Return(int64(10), nil)
+				producer := mockevt.NewMockProducer(ctrl)
+				producer.EXPECT().ProduceCommentEvent(gomock.Any()).DoAndReturn(func(evt events.CommentEvent) error {
+					assert.Equal(t, int64(0), evt.ParentUid)
+					return nil
+				})
+				return repo, producer
+			},
+			c:          domain.Comment{Uid: 123, Biz: "article", BizId: 1, Content: "评论", RootId: 9},
+			expectedId: 10,
+		},
+		{
+			name: "回复根评论",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, events.Producer) {
+				repo := mockrepo.NewMockCommentRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(10)).
+					Return(domain.Comment{Id: 10, Uid: 456, Biz: "article", BizId: 1}, nil)
+				repo.EXPECT().CreateComment(gomock.Any(), domain.Comment{Uid: 123, Biz: "article", BizId: 1,
+					Content: "回复", RootId: 10, ParentId: 10}).Return(int64(11), nil)
+				producer := mockevt.NewMockProducer(ctrl)
+				producer.EXPECT().ProduceCommentEvent(gomock.Any()).DoAndReturn(func(evt events.CommentEvent) error {
+					assert.Equal(t, int64(456), evt.ParentUid)
+					return nil
+				})
+				return repo, producer
+			},
+			c:          domain.Comment{Uid: 123, Biz: "article", BizId: 1, Content: "回复", ParentId: 10},
+			expectedId: 11,
+		},
+		{
+			name: "回复别人的回复",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, events.Producer) {
+				repo := mockrepo.NewMockCommentRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(11)).
+					Return(domain.Comment{Id: 11, Uid: 789, Biz: "article", BizId: 1, RootId: 10, ParentId: 10}, nil)
+				repo.EXPECT().CreateComment(gomock.Any(), domain.Comment{Uid: 123, Biz: "article", BizId: 1,
+					Content: "回复", RootId: 10, ParentId: 11}).Return(int64(12), nil)
+				producer := mockevt.NewMockProducer(ctrl)
+				producer.EXPECT().ProduceCommentEvent(gomock.Any()).DoAndReturn(func(evt events.CommentEvent) error {
+					assert.Equal(t, int64(789), evt.ParentUid)
+					return nil
+				})
+				return repo, producer
+			},
+			c:          domain.Comment{Uid: 123, Biz: "article", BizId: 1, Content: "回复", ParentId: 11},
+			expectedId: 12,
+		},
+		{
+			name: "回复的评论不存在",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, events.Producer) {
+				repo := mockrepo.NewMockCommentRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(10)).
+					Return(domain.Comment{}, repository.ErrCommentNotFound)
+				return repo, mockevt.NewMockProducer(ctrl)
+			},
+			c:           domain.Comment{Uid: 123, Biz: "article", BizId: 1, Content: "回复", ParentId: 10},
+			expectedErr: ErrParentNotFound,
+		},
+		{
+			name: "回复的评论不属于同一个资源",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, events.Producer) {
+				repo := mockrepo.NewMockCommentRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(10)).
+					Return(domain.Comment{Id: 10, Biz: "article", BizId: 2}, nil)
+				return repo, mockevt.NewMockProducer(ctrl)
+			},
+			c:           domain.Comment{Uid: 123, Biz: "article", BizId: 1, Content: "回复", ParentId: 10},
+			expectedErr: ErrParentNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, producer := tc.mock(ctrl)
+			svc := NewCommentService(repo, producer, logger.NewNopLogger())
+			id, err := svc.Create(context.Background(), tc.c)
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedId, id)
+		})
+	}
+}
+
+func Test_commentService_Delete(t *testing.T) {
+	c := domain.Comment{Id: 10, Uid: 123, Biz: "article", BizId: 1}
+	testCases := []struct {
+		name        string
+		mock        func(ctrl *gomock.Controller) repository.CommentRepository
+		biz         string
+		bizId       int64
+		uid         int64
+		bizOwnerUid int64
+
+		expectedErr error
+	}{
+		{
+			name: "作者删除自己的评论",
+			mock: func(ctrl *gomock.Controller) repository.CommentRepository {
+				repo := mockrepo.NewMockCommentRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(10)).Return(c, nil)
+				repo.EXPECT().DeleteComment(gomock.Any(), c).Return(nil)
+				return repo
+			},
+			biz: "article", bizId: 1, uid: 123, bizOwnerUid: 456,
+		},
+		{
+			name: "文章作者删除评论",
+			mock: func(ctrl *gomock.Controller) repository.CommentRepository {
+				repo := mockrepo.NewMockCommentRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(10)).Return(c, nil)
+				repo.EXPECT().DeleteComment(gomock.Any(), c).Return(nil)
+				return repo
+			},
+			biz: "article", bizId: 1, uid: 456, bizOwnerUid: 456,
+		},
+		{
+			name: "其他人不能删除",
+			mock: func(ctrl *gomock.Controller) repository.CommentRepository {
+				repo := mockrepo.NewMockCommentRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(10)).Return(c, nil)
+				return repo
+			},
+			biz: "article", bizId: 1, uid: 789, bizOwnerUid: 456,
+			expectedErr: ErrNoPermission,
+		},
+		{
+			name: "评论不属于这篇文章",
+			mock: func(ctrl *gomock.Controller) repository.CommentRepository {
+				repo := mockrepo.NewMockCommentRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(10)).Return(c, nil)
+				return repo
+			},
+			biz: "article", bizId: 2, uid: 456, bizOwnerUid: 456,
+			expectedErr: ErrNoPermission,
+		},
+		{
+			name: "查询评论失败",
+			mock: func(ctrl *gomock.Controller) repository.CommentRepository {
+				repo := mockrepo.NewMockCommentRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(10)).Return(domain.Comment{}, errors.New("mock db error"))
+				return repo
+			},
+			biz: "article", bizId: 1, uid: 123, bizOwnerUid: 456,
+			expectedErr: errors.New("mock db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewCommentService(tc.mock(ctrl), nil, logger.NewNopLogger())
+			err := svc.Delete(context.Background(), 10, tc.biz, tc.bizId, tc.uid, tc.bizOwnerUid)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func Test_commentService_DeleteByUid(t *testing.T) {
+	root := domain.Comment{Id: 10, Uid: 123, Biz: "article", BizId: 1}
+	reply := domain.Comment{Id: 11, Uid: 123, Biz: "article", BizId: 2, RootId: 5, ParentId: 5}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) repository.CommentRepository
+
+		expectedCnt int64
+		expectedErr error
+	}{
+		{
+			name: "删除一批",
+			mock: func(ctrl *gomock.Controller) repository.CommentRepository {
+				repo := mockrepo.NewMockCommentRepository(ctrl)
+				repo.EXPECT().FindByUid(gomock.Any(), int64(123), 10).Return([]domain.Comment{root, reply}, nil)
+				repo.EXPECT().DeleteComment(gomock.Any(), root).Return(nil)
+				repo.EXPECT().DeleteComment(gomock.Any(), reply).Return(nil)
+				return repo
+			},
+			expectedCnt: 2,
+		},
+		{
+			name: "删除失败",
+			mock: func(ctrl *gomock.Controller) repository.CommentRepository {
+				repo := mockrepo.NewMockCommentRepository(ctrl)
+				repo.EXPECT().FindByUid(gomock.Any(), int64(123), 10).Return([]domain.Comment{root, reply}, nil)
+				repo.EXPECT().DeleteComment(gomock.Any(), root).Return(errors.New("mock db error"))
+				return repo
+			},
+			expectedErr: errors.New("mock db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewCommentService(tc.mock(ctrl), nil, logger.NewNopLogger())
+			cnt, err := svc.DeleteByUid(context.Background(), 123, 10)
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedCnt, cnt)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+//go:build wireinject
+// +build wireinject
+
+package main
+
+import (
+	"github.com/google/wire"
+
+	"github.com/liupch66/basic-go/webook/comment/events"
+	"github.com/liupch66/basic-go/webook/comment/grpc"
+	"github.com/liupch66/basic-go/webook/comment/ioc"
+	"github.com/liupch66/basic-go/webook/comment/repository"
+	"github.com/liupch66/basic-go/webook/comment/repository/cache"
+	"github.com/liupch66/basic-go/webook/comment/repository/dao"
+	"github.com/liupch66/basic-go/webook/comment/service"
+)
+
+var thirdPartyProvider = wire.NewSet(
+	ioc.InitDB,
+	ioc.InitRedis,
+	ioc.InitLogger,
+	ioc.InitKafka, ioc.InitSyncProducer,
+	events.NewSaramaSyncProducer,
+)
+
+var commentServiceProvider = wire.NewSet(
+	dao.NewGORMCommentDAO, cache.NewRedisCommentCache,
+	repository.NewCachedCommentRepository,
+	service.NewCommentService,
+)
+
+func InitApp() *app {
+	wire.Build(
+		thirdPartyProvider,
+		commentServiceProvider,
+
+		grpc.NewCommentServiceServer,
+		ioc.InitGRPCxServer,
+
+		wire.Struct(new(app), "*"),
+	)
+	return new(app)
+}
@@ -0,0 +1,45 @@
+// Code generated by Wire. DO NOT EDIT.
+
+//go:generate go run -mod=mod github.com/google/wire/cmd/wire
+//go:build !wireinject
+// +build !wireinject
+
+package main
+
+import (
+	"github.com/google/wire"
+	"github.com/liupch66/basic-go/webook/comment/events"
+	"github.com/liupch66/basic-go/webook/comment/grpc"
+	"github.com/liupch66/basic-go/webook/comment/ioc"
+	"github.com/liupch66/basic-go/webook/comment/repository"
+	"github.com/liupch66/basic-go/webook/comment/repository/cache"
+	"github.com/liupch66/basic-go/webook/comment/repository/dao"
+	"github.com/liupch66/basic-go/webook/comment/service"
+)
+
+// Injectors from wire.go:
+
+func InitApp() *app {
+	db := ioc.InitDB()
+	commentDAO := dao.NewGORMCommentDAO(db)
+	cmdable := ioc.InitRedis()
+	commentCache := cache.NewRedisCommentCache(cmdable)
+	loggerV1 := ioc.InitLogger()
+	commentRepository := repository.NewCachedCommentRepository(commentDAO, commentCache, loggerV1)
+	client := ioc.InitKafka()
+	syncProducer := ioc.InitSyncProducer(client)
+	producer := events.NewSaramaSyncProducer(syncProducer)
+	commentService := service.NewCommentService(commentRepository, producer, loggerV1)
+	commentServiceServer := grpc.NewCommentServiceServer(commentService)
+	server := ioc.InitGRPCxServer(commentServiceServer, loggerV1)
+	mainApp := &app{
+		server: server,
+	}
+	return mainApp
+}
+
+// wire.go:
+
+var thirdPartyProvider = wire.NewSet(ioc.InitDB, ioc.InitRedis, ioc.InitLogger, ioc.InitKafka, ioc.InitSyncProducer, events.NewSaramaSyncProducer)
+
+var commentServiceProvider = wire.NewSet(dao.NewGORMCommentDAO, cache.NewRedisCommentCache, repository.NewCachedCommentRepository, service.NewCommentService)
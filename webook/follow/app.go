@@ -0,0 +1,9 @@
+package main
+
+import (
+	"github.com/liupch66/basic-go/webook/pkg/grpcx"
+)
+
+type app struct {
+	server *grpcx.Server
+}
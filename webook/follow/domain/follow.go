@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"time"
+)
+
+// FollowRelation Follower 关注了 Followee
+type FollowRelation struct {
+	Id       int64     `json:"id"`
+	Follower int64     `json:"follower"`
+	Followee int64     `json:"followee"`
+	Ctime    time.Time `json:"ctime"`
+}
+
+type FollowStatic struct {
+	// Followers 粉丝数
+	Followers int64 `json:"followers"`
+	// Followees 关注数
+	Followees int64 `json:"followees"`
+}
@@ -0,0 +1,94 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ecodeclub/ekit/slice"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	followv1 "github.com/liupch66/basic-go/webook/api/proto/gen/follow/v1"
+	"github.com/liupch66/basic-go/webook/follow/domain"
+	"github.com/liupch66/basic-go/webook/follow/service"
+)
+
+type FollowServiceServer struct {
+	followv1.UnimplementedFollowServiceServer
+	svc service.FollowService
+}
+
+func NewFollowServiceServer(svc service.FollowService) *FollowServiceServer {
+	return &FollowServiceServer{svc: svc}
+}
+
+func (f *FollowServiceServer) Register(server *grpc.Server) {
+	followv1.RegisterFollowServiceServer(server, f)
+}
+
+func (f *FollowServiceServer) Follow(ctx context.Context, request *followv1.FollowRequest) (*followv1.FollowResponse, error) {
+	if request.GetFollower() <= 0 || request.GetFollowee() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "uid 非法")
+	}
+	err := f.svc.Follow(ctx, request.GetFollower(), request.GetFollowee())
+	if errors.Is(err, service.ErrFollowSelf) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &followv1.FollowResponse{}, err
+}
+
+func (f *FollowServiceServer) CancelFollow(ctx context.Context, request *followv1.CancelFollowRequest) (*followv1.CancelFollowResponse, error) {
+	err := f.svc.CancelFollow(ctx, request.GetFollower(), request.GetFollowee())
+	return &followv1.CancelFollowResponse{}, err
+}
+
+func (f *FollowServiceServer) GetFollowee(ctx context.Context, request *followv1.GetFolloweeRequest) (*followv1.GetFolloweeResponse, error) {
+	rs, err := f.svc.GetFollowee(ctx, request.GetFollower(), request.GetMinId(), int(request.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	return &followv1.GetFolloweeResponse{FollowRelations: f.toDTOs(rs)}, nil
+}
+
+func (f *FollowServiceServer) GetFollower(ctx context.Context, request *followv1.GetFollowerRequest) (*followv1.GetFollowerResponse, error) {
+	rs, err := f.svc.GetFollower(ctx, request.GetFollowee(), request.GetMinId(), int(request.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	return &followv1.GetFollowerResponse{FollowRelations: f.toDTOs(rs)}, nil
+}
+
+func (f *FollowServiceServer) GetFollowStatic(ctx context.Context, request *followv1.GetFollowStaticRequest) (*followv1.GetFollowStaticResponse, error) {
+	static, err := f.svc.GetFollowStatic(ctx, request.GetUid())
+	if err != nil {
+		return nil, err
+	}
+	return &followv1.GetFollowStaticResponse{FollowStatic: &followv1.FollowStatic{
+		Followers: static.Followers,
+		Followees: static.Followees,
+	}}, nil
+}
+
+func (f *FollowServiceServer) BatchCheckFollow(ctx context.Context, request *followv1.BatchCheckFollowRequest) (*followv1.BatchCheckFollowResponse, error) {
+	res, err := f.svc.BatchCheckFollow(ctx, request.GetFollower(), request.GetFollowees())
+	if err != nil {
+		return nil, err
+	}
+	return &followv1.BatchCheckFollowResponse{Followed: res}, nil
+}
+
+func (f *FollowServiceServer) toDTOs(rs []domain.FollowRelation) []*followv1.FollowRelation {
+	return slice.Map(rs, func(idx int, src domain.FollowRelation) *followv1.FollowRelation {
+		return f.toDTO(src)
+	})
+}
+
+func (f *FollowServiceServer) toDTO(r domain.FollowRelation) *followv1.FollowRelation {
+	return &followv1.FollowRelation{
+		Id:       r.Id,
+		Follower: r.Follower,
+		Followee: r.Followee,
+		Ctime:    r.Ctime.UnixMilli(),
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+func initViper() {
+	valPtr := pflag.String("config", "config/config.yaml", "指定配置文件")
+	pflag.Parse()
+
+	viper.SetConfigFile(*valPtr)
+	err := viper.ReadInConfig()
+	if err != nil {
+		panic(err)
+	}
+
+	viper.WatchConfig()
+	viper.OnConfigChange(func(in fsnotify.Event) {
+		fmt.Printf("配置文件：%s，变更：%d\n", in.Name, in.Op)
+	})
+}
+
+func main() {
+	initViper()
+	app := InitApp()
+	err := app.server.Serve()
+	if err != nil {
+		panic(err)
+	}
+}
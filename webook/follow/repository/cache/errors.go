@@ -0,0 +1,7 @@
+package cache
+
+import (
+	"github.com/redis/go-redis/v9"
+)
+
+var ErrKeyNotExist = redis.Nil
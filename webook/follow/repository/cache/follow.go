@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/liupch66/basic-go/webook/follow/domain"
+)
+
+//go:embed lua/incr_static.lua
+var luaIncrStatic string
+
+const (
+	fieldFollowers = "followers"
+	fieldFollowees = "followees"
+)
+
+//go:generate mockgen -package=mockcache -source=follow.go -destination=mocks/mock_follow.go FollowCache
+type FollowCache interface {
+	// Follow 和 CancelFollow 只在有缓存的时候更新关注数和粉丝数
+	Follow(ctx context.Context, follower, followee int64) error
+	CancelFollow(ctx context.Context, follower, followee int64) error
+	GetStatic(ctx context.Context, uid int64) (domain.FollowStatic, error)
+	SetStatic(ctx context.Context, uid int64, static domain.FollowStatic) error
+}
+
+type RedisFollowCache struct {
+	cmd redis.Cmdable
+}
+
+func NewRedisFollowCache(cmd redis.Cmdable) FollowCache {
+	return &RedisFollowCache{cmd: cmd}
+}
+
+func (cache *RedisFollowCache) staticKey(uid int64) string {
+	return fmt.Sprintf("follow:static:%d", uid)
+}
+
+func (cache *RedisFollowCache) Follow(ctx context.Context, follower, followee int64) error {
+	return cache.cmd.Eval(ctx, luaIncrStatic, []string{cache.staticKey(follower), cache.staticKey(followee)}, 1).Err()
+}
+
+func (cache *RedisFollowCache) CancelFollow(ctx context.Context, follower, followee int64) error {
+	return cache.cmd.Eval(ctx, luaIncrStatic, []string{cache.staticKey(follower), cache.staticKey(followee)}, -1).Err()
+}
+
+func (cache *RedisFollowCache) GetStatic(ctx context.Context, uid int64) (domain.FollowStatic, error) {
+	data, err := cache.cmd.HGetAll(ctx, cache.staticKey(uid)).Result()
+	if err != nil {
+		return domain.FollowStatic{}, err
+	}
+	if len(data) == 0 {
+		return domain.FollowStatic{}, ErrKeyNotExist
+	}
+	followers, _ := strconv.ParseInt(data[fieldFollowers], 10, 64)
+	followees, _ := strconv.ParseInt(data[fieldFollowees], 10, 64)
+	return domain.FollowStatic{Followers: followers, Followees: followees}, nil
+}
+
+func (cache *RedisFollowCache) SetStatic(ctx context.Context, uid int64, static domain.FollowStatic) error {
+	key := cache.staticKey(uid)
+	err := cache.cmd.HSet(ctx, key, fieldFollowers, static.Followers, fieldFollowees, static.Followees).Err()
+	if err != nil {
+		return err
+	}
+	return cache.cmd.Expire(ctx, key, 15*time.Minute).Err()
+}
@@ -0,0 +1,12 @@
+--Eval(ctx, luaIncrStatic, []string{"follow:static:$follower", "follow:static:$followee"}, 1)
+-- follower 的关注数和 followee 的粉丝数一起变，有缓存才更新
+local followerKey = KEYS[1]
+local followeeKey = KEYS[2]
+local delta = tonumber(ARGV[1])
+if redis.call("EXISTS", followerKey) == 1 then
+    redis.call("HINCRBY", followerKey, "followees", delta)
+end
+if redis.call("EXISTS", followeeKey) == 1 then
+    redis.call("HINCRBY", followeeKey, "followers", delta)
+end
+return 1
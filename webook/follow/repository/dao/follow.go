@@ -0,0 +1,178 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// 取消关注不删数据，只是改状态，方便以后做"曾经关注过"之类的功能
+	FollowRelationStatusUnknown uint8 = iota
+	FollowRelationStatusActive
+	FollowRelationStatusInactive
+)
+
+type FollowRelation struct {
+	Id int64 `gorm:"primaryKey,autoIncrement"`
+	// 查询关注列表：WHERE follower = ? AND status = ? AND id < ? ORDER BY id DESC
+	Follower int64 `gorm:"uniqueIndex:follower_followee"`
+	// 查询粉丝列表走 followee 上的索引
+	Followee int64 `gorm:"uniqueIndex:follower_followee;index"`
+	Status   uint8
+	Ctime    int64
+	Utime    int64
+}
+
+// FollowStatic 关注数和粉丝数，关注关系变化的时候在同一个事务里面更新
+type FollowStatic struct {
+	Id        int64 `gorm:"primaryKey,autoIncrement"`
+	Uid       int64 `gorm:"uniqueIndex"`
+	Followers int64
+	Followees int64
+	Ctime     int64
+	Utime     int64
+}
+
+//go:generate mockgen -package=mockdao -source=follow.go -destination=mocks/mock_follow.go FollowDAO
+type FollowDAO interface {
+	// Follow 和 CancelFollow 返回关注关系有没有真的发生变化，重复关注和重复取消都是 false
+	Follow(ctx context.Context, follower, followee int64) (bool, error)
+	CancelFollow(ctx context.Context, follower, followee int64) (bool, error)
+	FindFollowees(ctx context.Context, follower int64, minId int64, limit int) ([]FollowRelation, error)
+	FindFollowers(ctx context.Context, followee int64, minId int64, limit int) ([]FollowRelation, error)
+	// FindRelations 查询 follower 关注了 followees 里面的哪些人
+	FindRelations(ctx context.Context, follower int64, followees []int64) ([]FollowRelation, error)
+	GetStatic(ctx context.Context, uid int64) (FollowStatic, error)
+}
+
+type GORMFollowDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMFollowDAO(db *gorm.DB) FollowDAO {
+	return &GORMFollowDAO{db: db}
+}
+
+func (dao *GORMFollowDAO) Follow(ctx context.Context, follower, followee int64) (bool, error) {
+	var changed bool
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now().UnixMilli()
+		// 先看看是不是取消过又重新关注
+		res := tx.Model(&FollowRelation{}).
+			Where("follower = ? AND followee = ? AND status = ?", follower, followee, FollowRelationStatusInactive).
+			Updates(map[string]any{
+				"status": FollowRelationStatusActive,
+				"utime":  now,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			// 已经关注了的话这里就是冲突，什么都不做
+			res = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&FollowRelation{
+				Follower: follower,
+				Followee: followee,
+				Status:   FollowRelationStatusActive,
+				Ctime:    now,
+				Utime:    now,
+			})
+			if res.Error != nil {
+				return res.Error
+			}
+		}
+		changed = res.RowsAffected > 0
+		if !changed {
+			return nil
+		}
+		return dao.updateStatic(tx, follower, followee, 1, now)
+	})
+	return changed, err
+}
+
+func (dao *GORMFollowDAO) CancelFollow(ctx context.Context, follower, followee int64) (bool, error) {
+	var changed bool
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now().UnixMilli()
+		res := tx.Model(&FollowRelation{}).
+			Where("follower = ? AND followee = ? AND status = ?", follower, followee, FollowRelationStatusActive).
+			Updates(map[string]any{
+				"status": FollowRelationStatusInactive,
+				"utime":  now,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		changed = res.RowsAffected > 0
+		if !changed {
+			return nil
+		}
+		return dao.updateStatic(tx, follower, followee, -1, now)
+	})
+	return changed, err
+}
+
+// updateStatic follower 的关注数和 followee 的粉丝数一起变化
+func (dao *GORMFollowDAO) updateStatic(tx *gorm.DB, follower, followee int64, delta int64, now int64) error {
+	err := tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"followees": gorm.Expr("followees+?", delta),
+			"utime":     now,
+		}),
+	}).Create(&FollowStatic{
+		Uid:       follower,
+		Followees: delta,
+		Ctime:     now,
+		Utime:     now,
+	}).Error
+	if err != nil {
+		return err
+	}
+	return tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"followers": gorm.Expr("followers+?", delta),
+			"utime":     now,
+		}),
+	}).Create(&FollowStatic{
+		Uid:       followee,
+		Followers: delta,
+		Ctime:     now,
+		Utime:     now,
+	}).Error
+}
+
+func (dao *GORMFollowDAO) FindFollowees(ctx context.Context, follower int64, minId int64, limit int) ([]FollowRelation, error) {
+	var res []FollowRelation
+	db := dao.db.WithContext(ctx).Where("follower = ? AND status = ?", follower, FollowRelationStatusActive)
+	if minId > 0 {
+		db = db.Where("id < ?", minId)
+	}
+	err := db.Order("id DESC").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMFollowDAO) FindFollowers(ctx context.Context, followee int64, minId int64, limit int) ([]FollowRelation, error) {
+	var res []FollowRelation
+	db := dao.db.WithContext(ctx).Where("followee = ? AND status = ?", followee, FollowRelationStatusActive)
+	if minId > 0 {
+		db = db.Where("id < ?", minId)
+	}
+	err := db.Order("id DESC").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMFollowDAO) FindRelations(ctx context.Context, follower int64, followees []int64) ([]FollowRelation, error) {
+	var res []FollowRelation
+	err := dao.db.WithContext(ctx).
+		Where("follower = ? AND followee IN ? AND status = ?", follower, followees, FollowRelationStatusActive).
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMFollowDAO) GetStatic(ctx context.Context, uid int64) (FollowStatic, error) {
+	var res FollowStatic
+	err := dao.db.WithContext(ctx).Where("uid = ?", uid).First(&res).Error
+	return res, err
+}
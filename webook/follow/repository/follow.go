@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+
+	"github.com/liupch66/basic-go/webook/follow/domain"
+	"github.com/liupch66/basic-go/webook/follow/repository/cache"
+	"github.com/liupch66/basic-go/webook/follow/repository/dao"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
+)
+
+//go:generate mockgen -package=mockrepo -source=follow.go -destination=mocks/mock_follow.go FollowRepository
+type FollowRepository interface {
+	AddFollowRelation(ctx context.Context, follower, followee int64) error
+	InactiveFollowRelation(ctx context.Context, follower, followee int64) error
+	GetFollowee(ctx context.Context, follower int64, minId int64, limit int) ([]domain.FollowRelation, error)
+	GetFollower(ctx context.Context, followee int64, minId int64, limit int) ([]domain.FollowRelation, error)
+	GetFollowRelations(ctx context.Context, follower int64, followees []int64) ([]domain.FollowRelation, error)
+	GetFollowStatic(ctx context.Context, uid int64) (domain.FollowStatic, error)
+}
+
+type CachedFollowRepository struct {
+	dao   dao.FollowDAO
+	cache cache.FollowCache
+	l     logger.LoggerV1
+}
+
+func NewCachedFollowRepository(dao dao.FollowDAO, cache cache.FollowCache, l logger.LoggerV1) FollowRepository {
+	return &CachedFollowRepository{dao: dao, cache: cache, l: l}
+}
+
+func (repo *CachedFollowRepository) AddFollowRelation(ctx context.Context, follower, followee int64) error {
+	changed, err := repo.dao.Follow(ctx, follower, followee)
+	if err != nil || !changed {
+		return err
+	}
+	if er := repo.cache.Follow(ctx, follower, followee); er != nil {
+		repo.l.Error("更新关注数缓存失败", logger.Int64("follower", follower),
+			logger.Int64("followee", followee), logger.Error(er))
+	}
+	return nil
+}
+
+func (repo *CachedFollowRepository) InactiveFollowRelation(ctx context.Context, follower, followee int64) error {
+	changed, err := repo.dao.CancelFollow(ctx, follower, followee)
+	if err != nil || !changed {
+		return err
+	}
+	if er := repo.cache.CancelFollow(ctx, follower, followee); er != nil {
+		repo.l.Error("更新关注数缓存失败", logger.Int64("follower", follower),
+			logger.Int64("followee", followee), logger.Error(er))
+	}
+	return nil
+}
+
+func (repo *CachedFollowRepository) GetFollowee(ctx context.Context, follower int64, minId int64, limit int) ([]domain.FollowRelation, error) {
+	rs, err := repo.dao.FindFollowees(ctx, follower, minId, limit)
+	if err != nil {
+		return nil, err
+	}
+	return repo.toDomains(rs), nil
+}
+
+func (repo *CachedFollowRepository) GetFollower(ctx context.Context, followee int64, minId int64, limit int) ([]domain.FollowRelation, error) {
+	rs, err := repo.dao.FindFollowers(ctx, followee, minId, limit)
+	if err != nil {
+		return nil, err
+	}
+	return repo.toDomains(rs), nil
+}
+
+func (repo *CachedFollowRepository) GetFollowRelations(ctx context.Context, follower int64, followees []int64) ([]domain.FollowRelation, error) {
+	rs, err := repo.dao.FindRelations(ctx, follower, followees)
+	if err != nil {
+		return nil, err
+	}
+	return repo.toDomains(rs), nil
+}
+
+func (repo *CachedFollowRepository) GetFollowStatic(ctx context.Context, uid int64) (domain.FollowStatic, error) {
+	static, err := repo.cache.GetStatic(ctx, uid)
+	if err == nil {
+		return static, nil
+	}
+	res, err := repo.dao.GetStatic(ctx, uid)
+	switch {
+	case err == nil:
+		static = domain.FollowStatic{Followers: res.Followers, Followees: res.Followees}
+	case errors.Is(err, dao.ErrDataNotFound):
+		// 没关注过别人也没有粉丝
+		static = domain.FollowStatic{}
+	default:
+		return domain.FollowStatic{}, err
+	}
+	if er := repo.cache.SetStatic(ctx, uid, static); er != nil {
+		repo.l.Error("回写关注数缓存失败", logger.Int64("uid", uid), logger.Error(er))
+	}
+	return static, nil
+}
+
+func (repo *CachedFollowRepository) toDomains(rs []dao.FollowRelation) []domain.FollowRelation {
+	return slice.Map(rs, func(idx int, src dao.FollowRelation) domain.FollowRelation {
+		return repo.toDomain(src)
+	})
+}
+
+func (repo *CachedFollowRepository) toDomain(r dao.FollowRelation) domain.FollowRelation {
+	return domain.FollowRelation{
+		Id:       r.Id,
+		Follower: r.Follower,
+		Followee: r.Followee,
+		Ctime:    time.UnixMilli(r.Ctime),
+	}
+}
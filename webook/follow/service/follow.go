@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/liupch66/basic-go/webook/follow/domain"
+	"github.com/liupch66/basic-go/webook/follow/repository"
+)
+
+var ErrFollowSelf = errors.New("不能关注自己")
+
+//go:generate mockgen -package=mocksvc -source=follow.go -destination=mocks/mock_follow.go FollowService
+type FollowService interface {
+	Follow(ctx context.Context, follower, followee int64) error
+	CancelFollow(ctx context.Context, follower, followee int64) error
+	// GetFollowee 按照关注时间倒序分页，minId 是上一页最后一条的 id，第一页传 0
+	GetFollowee(ctx context.Context, follower int64, minId int64, limit int) ([]domain.FollowRelation, error)
+	GetFollower(ctx context.Context, followee int64, minId int64, limit int) ([]domain.FollowRelation, error)
+	GetFollowStatic(ctx context.Context, uid int64) (domain.FollowStatic, error)
+	// BatchCheckFollow followees 里面每个人都会有结果，没关注的是 false
+	BatchCheckFollow(ctx context.Context, follower int64, followees []int64) (map[int64]bool, error)
+}
+
+type followService struct {
+	repo repository.FollowRepository
+}
+
+func NewFollowService(repo repository.FollowRepository) FollowService {
+	return &followService{repo: repo}
+}
+
+func (svc *followService) Follow(ctx context.Context, follower, followee int64) error {
+	if follower == followee {
+		return ErrFollowSelf
+	}
+	return svc.repo.AddFollowRelation(ctx, follower, followee)
+}
+
+func (svc *followService) CancelFollow(ctx context.Context, follower, followee int64) error {
+	return svc.repo.InactiveFollowRelation(ctx, follower, followee)
+}
+
+func (svc *followService) GetFollowee(ctx context.Context, follower int64, minId int64, limit int) ([]domain.FollowRelation, error) {
+	return svc.repo.GetFollowee(ctx, follower, minId, limit)
+}
+
+func (svc *followService) GetFollower(ctx context.Context, followee int64, minId int64, limit int) ([]domain.FollowRelation, error) {
+	return svc.repo.GetFollower(ctx, followee, minId, limit)
+}
+
+func (svc *followService) GetFollowStatic(ctx context.Context, uid int64) (domain.FollowStatic, error) {
+	return svc.repo.GetFollowStatic(ctx, uid)
+}
+
+func (svc *followService) BatchCheckFollow(ctx context.Context, follower int64, followees []int64) (map[int64]bool, error) {
+	res := make(map[int64]bool, len(followees))
+	if len(followees) == 0 {
+		return res, nil
+	}
+	rs, err := svc.repo.GetFollowRelations(ctx, follower, followees)
+	if err != nil {
+		return nil, err
+	}
+	for _, followee := range followees {
+		res[followee] = false
+	}
+	for _, r := range rs {
+		res[r.Followee] = true
+	}
+	return res, nil
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/liupch66/basic-go/webook/follow/domain"
+	"github.com/liupch66/basic-go/webook/follow/repository"
+	mockrepo "github.com/liupch66/basic-go/webook/follow/repository/mocks"
+)
+
+func Test_followService_Follow(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mock     func(ctrl *gomock.Controller) repository.FollowRepository
+		follower int64
+		followee int64
+
+		expectedErr error
+	}{
+		{
+			name: "关注成功",
+			mock: func(ctrl *gomock.Controller) repository.FollowRepository {
+				repo := mockrepo.NewMockFollowRepository(ctrl)
+				repo.EXPECT().AddFollowRelation(gomock.Any(), int64(1), int64(2)).Return(nil)
+				return repo
+			},
+			follower: 1,
+			followee: 2,
+		},
+		{
+			name: "不能关注自己",
+			mock: func(ctrl *gomock.Controller) repository.FollowRepository {
+				return mockrepo.NewMockFollowRepository(ctrl)
+			},
+			follower:    1,
+			followee:    1,
+			expectedErr: ErrFollowSelf,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewFollowService(tc.mock(ctrl))
+			err := svc.Follow(context.Background(), tc.follower, tc.followee)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func Test_followService_BatchCheckFollow(t *testing.T) {
+	testCases := []struct {
+		name      string
+		mock      func(ctrl *gomock.Controller) repository.FollowRepository
+		followees []int64
+
+		expectedRes map[int64]bool
+		expectedErr error
+	}{
+		{
+			name: "部分关注",
+			mock: func(ctrl *gomock.Controller) repository.FollowRepository {
+				repo := mockrepo.NewMockFollowRepository(ctrl)
+				repo.EXPECT().GetFollowRelations(gomock.Any(), int64(1), []int64{2, 3, 4}).
+					Return([]domain.FollowRelation{{Follower: 1, Followee: 2}, {Follower: 1, Followee: 4}}, nil)
+				return repo
+			},
+			followees:   []int64{2, 3, 4},
+			expectedRes: map[int64]bool{2: true, 3: false, 4: true},
+		},
+		{
+			name: "没有传 followees",
+			mock: func(ctrl *gomock.Controller) repository.FollowRepository {
+				return mockrepo.NewMockFollowRepository(ctrl)
+			},
+			expectedRes: map[int64]bool{},
+		},
+		{
+			name: "查询失败",
+			mock: func(ctrl *gomock.Controller) repository.FollowRepository {
+				repo := mockrepo.NewMockFollowRepository(ctrl)
+				repo.EXPECT().GetFollowRelations(gomock.Any(), int64(1), []int64{2}).
+					Return(nil, errors.New("mock db error"))
+				return repo
+			},
+			followees:   []int64{2},
+			expectedErr: errors.New("mock db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewFollowService(tc.mock(ctrl))
+			res, err := svc.BatchCheckFollow(context.Background(), 1, tc.followees)
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedRes, res)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+//go:build wireinject
+// +build wireinject
+
+package main
+
+import (
+	"github.com/google/wire"
+
+	"github.com/liupch66/basic-go/webook/follow/grpc"
+	"github.com/liupch66/basic-go/webook/follow/ioc"
+	"github.com/liupch66/basic-go/webook/follow/repository"
+	"github.com/liupch66/basic-go/webook/follow/repository/cache"
+	"github.com/liupch66/basic-go/webook/follow/repository/dao"
+	"github.com/liupch66/basic-go/webook/follow/service"
+)
+
+var thirdPartyProvider = wire.NewSet(
+	ioc.InitDB,
+	ioc.InitRedis,
+	ioc.InitLogger,
+)
+
+var followServiceProvider = wire.NewSet(
+	dao.NewGORMFollowDAO, cache.NewRedisFollowCache,
+	repository.NewCachedFollowRepository,
+	service.NewFollowService,
+)
+
+func InitApp() *app {
+	wire.Build(
+		thirdPartyProvider,
+		followServiceProvider,
+
+		grpc.NewFollowServiceServer,
+		ioc.InitGRPCxServer,
+
+		wire.Struct(new(app), "*"),
+	)
+	return new(app)
+}
@@ -0,0 +1,41 @@
+// Code generated by Wire. DO NOT EDIT.
+
+//go:generate go run -mod=mod github.com/google/wire/cmd/wire
+//go:build !wireinject
+// +build !wireinject
+
+package main
+
+import (
+	"github.com/google/wire"
+	"github.com/liupch66/basic-go/webook/follow/grpc"
+	"github.com/liupch66/basic-go/webook/follow/ioc"
+	"github.com/liupch66/basic-go/webook/follow/repository"
+	"github.com/liupch66/basic-go/webook/follow/repository/cache"
+	"github.com/liupch66/basic-go/webook/follow/repository/dao"
+	"github.com/liupch66/basic-go/webook/follow/service"
+)
+
+// Injectors from wire.go:
+
+func InitApp() *app {
+	db := ioc.InitDB()
+	followDAO := dao.NewGORMFollowDAO(db)
+	cmdable := ioc.InitRedis()
+	followCache := cache.NewRedisFollowCache(cmdable)
+	loggerV1 := ioc.InitLogger()
+	followRepository := repository.NewCachedFollowRepository(followDAO, followCache, loggerV1)
+	followService := service.NewFollowService(followRepository)
+	followServiceServer := grpc.NewFollowServiceServer(followService)
+	server := ioc.InitGRPCxServer(followServiceServer, loggerV1)
+	mainApp := &app{
+		server: server,
+	}
+	return mainApp
+}
+
+// wire.go:
+
+var thirdPartyProvider = wire.NewSet(ioc.InitDB, ioc.InitRedis, ioc.InitLogger)
+
+var followServiceProvider = wire.NewSet(dao.NewGORMFollowDAO, cache.NewRedisFollowCache, repository.NewCachedFollowRepository, service.NewFollowService)
@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"time"
+)
+
+// Collection 收藏夹
+type Collection struct {
+	Id    int64     `json:"id"`
+	Name  string    `json:"name"`
+	Uid   int64     `json:"uid"`
+	Ctime time.Time `json:"ctime"`
+	Utime time.Time `json:"utime"`
+}
+
+// CollectionItem 收藏夹里面收藏的东西
+type CollectionItem struct {
+	Cid   int64     `json:"cid"`
+	Biz   string    `json:"biz"`
+	BizId int64     `json:"biz_id"`
+	Uid   int64     `json:"uid"`
+	Ctime time.Time `json:"ctime"`
+}
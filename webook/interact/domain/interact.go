@@ -1,5 +1,18 @@
 package domain
 
+import "time"
+
+// InteractAction 互动事件的动作，取消类的动作下游要反过来扣分
+type InteractAction string
+
+const (
+	InteractActionRead          InteractAction = "read"
+	InteractActionLike          InteractAction = "like"
+	InteractActionCancelLike    InteractAction = "cancel_like"
+	InteractActionCollect       InteractAction = "collect"
+	InteractActionCancelCollect InteractAction = "cancel_collect"
+)
+
 type Interact struct {
 	Biz        string `json:"biz"`
 	BizId      int64  `json:"biz_id"`
@@ -9,3 +22,10 @@ type Interact struct {
 	Liked      bool   `json:"liked"`
 	Collected  bool   `json:"collected"`
 }
+
+// UserLike 用户的一条点赞记录
+type UserLike struct {
+	Biz   string    `json:"biz"`
+	BizId int64     `json:"biz_id"`
+	Ctime time.Time `json:"ctime"`
+}
@@ -6,19 +6,22 @@ import (
 
 	"github.com/IBM/sarama"
 
+	"github.com/liupch66/basic-go/webook/interact/domain"
 	"github.com/liupch66/basic-go/webook/interact/repository"
 	"github.com/liupch66/basic-go/webook/pkg/logger"
 	"github.com/liupch66/basic-go/webook/pkg/saramax"
 )
 
 type InteractReadEventBatchConsumer struct {
-	client sarama.Client
-	repo   repository.InteractRepository
-	l      logger.LoggerV1
+	client   sarama.Client
+	repo     repository.InteractRepository
+	producer Producer
+	l        logger.LoggerV1
 }
 
-func NewInteractReadEventBatchConsumer(client sarama.Client, repo repository.InteractRepository, l logger.LoggerV1) *InteractReadEventBatchConsumer {
-	return &InteractReadEventBatchConsumer{client: client, repo: repo, l: l}
+func NewInteractReadEventBatchConsumer(client sarama.Client, repo repository.InteractRepository,
+	producer Producer, l logger.LoggerV1) *InteractReadEventBatchConsumer {
+	return &InteractReadEventBatchConsumer{client: client, repo: repo, producer: producer, l: l}
 }
 
 func (i *InteractReadEventBatchConsumer) Consume(msgs []*sarama.ConsumerMessage, ts []ReadEvent) error {
@@ -28,7 +31,24 @@ func (i *InteractReadEventBatchConsumer) Consume(msgs []*sarama.ConsumerMessage,
 	for _, t := range ts {
 		bizIds = append(bizIds, t.Aid)
 	}
-	return i.repo.BatchIncrReadCnt(ctx, "article", bizIds)
+	err := i.repo.BatchIncrReadCnt(ctx, "article", bizIds)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UnixMilli()
+	for _, t := range ts {
+		er := i.producer.ProduceInteractEvent(InteractEvent{
+			Biz:    "article",
+			BizId:  t.Aid,
+			Uid:    t.Uid,
+			Action: domain.InteractActionRead,
+			Ctime:  now,
+		})
+		if er != nil {
+			i.l.Error("发送互动事件失败", logger.Int64("aid", t.Aid), logger.Error(er))
+		}
+	}
+	return nil
 }
 
 func (i *InteractReadEventBatchConsumer) Start() error {
@@ -6,25 +6,43 @@ import (
 
 	"github.com/IBM/sarama"
 
+	"github.com/liupch66/basic-go/webook/interact/domain"
 	"github.com/liupch66/basic-go/webook/interact/repository"
 	"github.com/liupch66/basic-go/webook/pkg/logger"
 	"github.com/liupch66/basic-go/webook/pkg/saramax"
 )
 
 type InteractReadEventConsumer struct {
-	client sarama.Client
-	repo   repository.InteractRepository
-	l      logger.LoggerV1
+	client   sarama.Client
+	repo     repository.InteractRepository
+	producer Producer
+	l        logger.LoggerV1
 }
 
-func NewInteractReadEventConsumer(client sarama.Client, repo repository.InteractRepository, l logger.LoggerV1) *InteractReadEventConsumer {
-	return &InteractReadEventConsumer{client: client, repo: repo, l: l}
+func NewInteractReadEventConsumer(client sarama.Client, repo repository.InteractRepository,
+	producer Producer, l logger.LoggerV1) *InteractReadEventConsumer {
+	return &InteractReadEventConsumer{client: client, repo: repo, producer: producer, l: l}
 }
 
 func (i *InteractReadEventConsumer) Consume(msg *sarama.ConsumerMessage, t ReadEvent) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
-	return i.repo.IncrReadCnt(ctx, "article", t.Aid)
+	err := i.repo.IncrReadCnt(ctx, "article", t.Aid)
+	if err != nil {
+		return err
+	}
+	// 阅读数已经加上了，互动事件发失败也不要重试，不然阅读数会重复加
+	er := i.producer.ProduceInteractEvent(InteractEvent{
+		Biz:    "article",
+		BizId:  t.Aid,
+		Uid:    t.Uid,
+		Action: domain.InteractActionRead,
+		Ctime:  time.Now().UnixMilli(),
+	})
+	if er != nil {
+		i.l.Error("发送互动事件失败", logger.Int64("aid", t.Aid), logger.Error(er))
+	}
+	return nil
 }
 
 func (i *InteractReadEventConsumer) Start() error {
@@ -0,0 +1,50 @@
+package events
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/IBM/sarama"
+
+	"github.com/liupch66/basic-go/webook/interact/domain"
+)
+
+// TopicInteractEvent 下游消费互动事件也用这个 topic
+const TopicInteractEvent = "interact_event"
+
+// InteractEvent 阅读、点赞、收藏写成功之后发出来，下游的实时热榜之类的用
+type InteractEvent struct {
+	Biz    string
+	BizId  int64
+	Uid    int64
+	Action domain.InteractAction
+	// Ctime 毫秒
+	Ctime int64
+}
+
+//go:generate mockgen -package=mockevt -source=producer.go -destination=mocks/mock_producer.go Producer
+type Producer interface {
+	ProduceInteractEvent(evt InteractEvent) error
+}
+
+type SaramaSyncProducer struct {
+	producer sarama.SyncProducer
+}
+
+func NewSaramaSyncProducer(producer sarama.SyncProducer) Producer {
+	return &SaramaSyncProducer{producer: producer}
+}
+
+func (s *SaramaSyncProducer) ProduceInteractEvent(evt InteractEvent) error {
+	data, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+	_, _, err = s.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: TopicInteractEvent,
+		// 同一个资源的事件落到同一个分区
+		Key:   sarama.StringEncoder(evt.Biz + ":" + strconv.FormatInt(evt.BizId, 10)),
+		Value: sarama.ByteEncoder(data),
+	})
+	return err
+}
@@ -2,7 +2,9 @@ package grpc
 
 import (
 	"context"
+	"errors"
 
+	"github.com/ecodeclub/ekit/slice"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -78,6 +80,118 @@ func (i *InteractServiceServer) GetByIds(ctx context.Context, request *interactv
 	return &interactv1.GetByIdsResponse{Interacts: res}, nil
 }
 
+func (i *InteractServiceServer) CancelCollect(ctx context.Context, request *interactv1.CancelCollectRequest) (*interactv1.CancelCollectResponse, error) {
+	err := i.svc.CancelCollect(ctx, request.GetBiz(), request.GetBizId(), request.GetUid())
+	return &interactv1.CancelCollectResponse{}, err
+}
+
+func (i *InteractServiceServer) CreateCollection(ctx context.Context, request *interactv1.CreateCollectionRequest) (*interactv1.CreateCollectionResponse, error) {
+	if request.GetUid() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "uid 非法")
+	}
+	cid, err := i.svc.CreateCollection(ctx, request.GetUid(), request.GetName())
+	if err != nil {
+		return nil, i.toStatus(err)
+	}
+	return &interactv1.CreateCollectionResponse{Cid: cid}, nil
+}
+
+func (i *InteractServiceServer) RenameCollection(ctx context.Context, request *interactv1.RenameCollectionRequest) (*interactv1.RenameCollectionResponse, error) {
+	err := i.svc.RenameCollection(ctx, request.GetCid(), request.GetUid(), request.GetName())
+	if err != nil {
+		return nil, i.toStatus(err)
+	}
+	return &interactv1.RenameCollectionResponse{}, nil
+}
+
+func (i *InteractServiceServer) DeleteCollection(ctx context.Context, request *interactv1.DeleteCollectionRequest) (*interactv1.DeleteCollectionResponse, error) {
+	err := i.svc.DeleteCollection(ctx, request.GetCid(), request.GetUid())
+	if err != nil {
+		return nil, i.toStatus(err)
+	}
+	return &interactv1.DeleteCollectionResponse{}, nil
+}
+
+func (i *InteractServiceServer) ListCollections(ctx context.Context, request *interactv1.ListCollectionsRequest) (*interactv1.ListCollectionsResponse, error) {
+	cs, err := i.svc.ListCollections(ctx, request.GetUid(), int(request.GetOffset()), int(request.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	return &interactv1.ListCollectionsResponse{
+		Collections: slice.Map(cs, func(idx int, src domain.Collection) *interactv1.Collection {
+			return &interactv1.Collection{
+				Id:    src.Id,
+				Name:  src.Name,
+				Uid:   src.Uid,
+				Ctime: src.Ctime.UnixMilli(),
+				Utime: src.Utime.UnixMilli(),
+			}
+		}),
+	}, nil
+}
+
+func (i *InteractServiceServer) ListCollectionItems(ctx context.Context, request *interactv1.ListCollectionItemsRequest) (*interactv1.ListCollectionItemsResponse, error) {
+	items, err := i.svc.ListCollectionItems(ctx, request.GetCid(), request.GetUid(),
+		int(request.GetOffset()), int(request.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	return &interactv1.ListCollectionItemsResponse{
+		Items: slice.Map(items, func(idx int, src domain.CollectionItem) *interactv1.CollectionItem {
+			return &interactv1.CollectionItem{
+				Cid:   src.Cid,
+				Biz:   src.Biz,
+				BizId: src.BizId,
+				Uid:   src.Uid,
+				Ctime: src.Ctime.UnixMilli(),
+			}
+		}),
+	}, nil
+}
+
+func (i *InteractServiceServer) MoveCollectionItem(ctx context.Context, request *interactv1.MoveCollectionItemRequest) (*interactv1.MoveCollectionItemResponse, error) {
+	err := i.svc.MoveCollectItem(ctx, request.GetBiz(), request.GetBizId(), request.GetUid(), request.GetCid())
+	if err != nil {
+		return nil, i.toStatus(err)
+	}
+	return &interactv1.MoveCollectionItemResponse{}, nil
+}
+
+func (i *InteractServiceServer) MergeUser(ctx context.Context, request *interactv1.MergeUserRequest) (*interactv1.MergeUserResponse, error) {
+	err := i.svc.MergeUser(ctx, request.GetFromUid(), request.GetToUid())
+	if err != nil {
+		return nil, i.toStatus(err)
+	}
+	return &interactv1.MergeUserResponse{}, nil
+}
+func (i *InteractServiceServer) ListLikes(ctx context.Context, request *interactv1.ListLikesRequest) (*interactv1.ListLikesResponse, error) {
+	likes, err := i.svc.ListLikes(ctx, request.GetUid(), int(request.GetOffset()), int(request.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	return &interactv1.ListLikesResponse{
+		Likes: slice.Map(likes, func(idx int, src domain.UserLike) *interactv1.UserLike {
+			return &interactv1.UserLike{
+				Biz:   src.Biz,
+				BizId: src.BizId,
+				Ctime: src.Ctime.UnixMilli(),
+			}
+		}),
+	}, nil
+}
+
+// toStatus 业务错误转成 gRPC 的错误码，客户端才能区分
+func (i *InteractServiceServer) toStatus(err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidCollectionName), errors.Is(err, service.ErrInvalidMergeUid):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrCollectionNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		return err
+	}
+}
+
 // DTO: Data Transfer Object
 func (i *InteractServiceServer) toDTO(inter domain.Interact) *interactv1.Interact {
 	return &interactv1.Interact{
@@ -5,6 +5,7 @@ package startup
 import (
 	"github.com/google/wire"
 
+	"github.com/liupch66/basic-go/webook/interact/events"
 	"github.com/liupch66/basic-go/webook/interact/grpc"
 	"github.com/liupch66/basic-go/webook/interact/repository"
 	"github.com/liupch66/basic-go/webook/interact/repository/cache"
@@ -12,7 +13,8 @@ import (
 	"github.com/liupch66/basic-go/webook/interact/service"
 )
 
-var thirdPS = wire.NewSet(InitTestDB, InitRedis, InitLog)
+var thirdPS = wire.NewSet(InitTestDB, InitRedis, InitLog,
+	InitKafka, InitSyncProducer, events.NewSaramaSyncProducer)
 
 var interactSvcPS = wire.NewSet(
 	dao.NewGORMInteractDAO, cache.NewRedisInteractCache,
@@ -22,7 +24,7 @@ var interactSvcPS = wire.NewSet(
 
 func InitInteractService() service.InteractService {
 	wire.Build(thirdPS, interactSvcPS)
-	return service.NewInteractService(nil, nil)
+	return service.NewInteractService(nil, nil, nil)
 }
 
 func InitGRPCServer() *grpc.InteractServiceServer {
@@ -8,6 +8,7 @@ package startup
 
 import (
 	"github.com/google/wire"
+	"github.com/liupch66/basic-go/webook/interact/events"
 	"github.com/liupch66/basic-go/webook/interact/grpc"
 	"github.com/liupch66/basic-go/webook/interact/repository"
 	"github.com/liupch66/basic-go/webook/interact/repository/cache"
@@ -24,7 +25,10 @@ func InitInteractService() service.InteractService {
 	interactCache := cache.NewRedisInteractCache(cmdable)
 	loggerV1 := InitLog()
 	interactRepository := repository.NewCachedInteractRepository(interactDAO, interactCache, loggerV1)
-	interactService := service.NewInteractService(interactRepository, loggerV1)
+	client := InitKafka()
+	syncProducer := InitSyncProducer(client)
+	producer := events.NewSaramaSyncProducer(syncProducer)
+	interactService := service.NewInteractService(interactRepository, producer, loggerV1)
 	return interactService
 }
 
@@ -35,13 +39,17 @@ func InitGRPCServer() *grpc.InteractServiceServer {
 	interactCache := cache.NewRedisInteractCache(cmdable)
 	loggerV1 := InitLog()
 	interactRepository := repository.NewCachedInteractRepository(interactDAO, interactCache, loggerV1)
-	interactService := service.NewInteractService(interactRepository, loggerV1)
+	client := InitKafka()
+	syncProducer := InitSyncProducer(client)
+	producer := events.NewSaramaSyncProducer(syncProducer)
+	interactService := service.NewInteractService(interactRepository, producer, loggerV1)
 	interactServiceServer := grpc.NewInteractServiceServer(interactService)
 	return interactServiceServer
 }
 
 // wire.go:
 
-var thirdPS = wire.NewSet(InitTestDB, InitRedis, InitLog)
+var thirdPS = wire.NewSet(InitTestDB, InitRedis, InitLog,
+	InitKafka, InitSyncProducer, events.NewSaramaSyncProducer)
 
 var interactSvcPS = wire.NewSet(dao.NewGORMInteractDAO, cache.NewRedisInteractCache, repository.NewCachedInteractRepository, service.NewInteractService)
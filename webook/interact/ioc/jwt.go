@@ -0,0 +1,22 @@
+package ioc
+
+import (
+	"github.com/redis/go-redis/v9"
+	"github.com/spf13/viper"
+
+	ijwt "github.com/liupch66/basic-go/webook/internal/web/jwt"
+)
+
+// InitJwtHandler 迁移的管理接口只需要校验 webook 签发的 access_token，
+// 所以只配置 access 的密钥，redis 要和 webook 用同一个，退出登录的会话才能识别出来
+func InitJwtHandler(cmd redis.Cmdable) ijwt.Handler {
+	var cfg ijwt.KeyringConfig
+	if err := viper.UnmarshalKey("jwt.access", &cfg); err != nil {
+		panic(err)
+	}
+	access, err := ijwt.NewKeyring(cfg)
+	if err != nil {
+		panic(err)
+	}
+	return ijwt.NewRedisJwtHandler(cmd, &ijwt.Keyrings{Access: access}, nil)
+}
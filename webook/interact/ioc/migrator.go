@@ -8,6 +8,9 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/liupch66/basic-go/webook/interact/repository/dao"
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	ijwt "github.com/liupch66/basic-go/webook/internal/web/jwt"
+	"github.com/liupch66/basic-go/webook/internal/web/middleware"
 	"github.com/liupch66/basic-go/webook/pkg/ginx"
 	"github.com/liupch66/basic-go/webook/pkg/gormx/connpool"
 	"github.com/liupch66/basic-go/webook/pkg/logger"
@@ -19,7 +22,7 @@ import (
 const topic = "migrator_interact"
 
 func InitMigratorWeb(src SrcDB, dst DstDB, l logger.LoggerV1,
-	producer events.Producer, pool *connpool.DoubleWritePool) *ginx.Server {
+	producer events.Producer, pool *connpool.DoubleWritePool, jwtHdl ijwt.Handler) *ginx.Server {
 	ginx.InitCounter(prometheus.CounterOpts{
 		Namespace: "geektime",
 		Subsystem: "webook_interact_admin",
@@ -37,7 +40,10 @@ func InitMigratorWeb(src SrcDB, dst DstDB, l logger.LoggerV1,
 		interSch.UpdatePattern(pattern)
 	})
 	engine := gin.Default()
-	interSch.RegisterRoutes(engine.Group("/migrator"))
+	// 切换读写模式会影响线上数据，只有管理员才能调用
+	interSch.RegisterRoutes(engine.Group("/migrator",
+		middleware.NewLoginJWTMiddlewareBuilder(jwtHdl).Build(),
+		middleware.RequirePermission(domain.PermMigrator)))
 	// 这里可以就用一个了，多个就跟下面例子一样后面再接路由区分，
 	// interSch.RegisterRoutes(engine.Group("/migrator/interact"))
 	addr := viper.GetString("migrator.http.addr")
@@ -27,6 +27,7 @@ type InteractCache interface {
 	IncrLikeCntIfPresent(ctx context.Context, biz string, bizId int64) error
 	DecrLikeCntIfPresent(ctx context.Context, biz string, bizId int64) error
 	IncrCollectCntIfPresent(ctx context.Context, biz string, bizId int64) error
+	DecrCollectCntIfPresent(ctx context.Context, biz string, bizId int64) error
 	Get(ctx context.Context, biz string, bizId int64) (domain.Interact, error)
 	Set(ctx context.Context, biz string, bizId int64, interact domain.Interact) error
 }
@@ -59,6 +60,10 @@ func (cache *RedisInteractCache) IncrCollectCntIfPresent(ctx context.Context, bi
 	return cache.cmd.Eval(ctx, luaIncrCnt, []string{cache.key(biz, bizId)}, argCollectCnt, 1).Err()
 }
 
+func (cache *RedisInteractCache) DecrCollectCntIfPresent(ctx context.Context, biz string, bizId int64) error {
+	return cache.cmd.Eval(ctx, luaIncrCnt, []string{cache.key(biz, bizId)}, argCollectCnt, -1).Err()
+}
+
 func (cache *RedisInteractCache) Get(ctx context.Context, biz string, bizId int64) (domain.Interact, error) {
 	// HMGET key field [field ...]
 	// 返回一个接口{}，以区分空字符串和 nil 值
@@ -115,3 +115,48 @@ func (dao *DoubleWriteDAO) GetByIds(ctx context.Context, biz string, bizIds []in
 	// TODO implement me
 	panic("implement me")
 }
+
+func (dao *DoubleWriteDAO) DeleteCollectionBiz(ctx context.Context, biz string, bizId, uid int64) error {
+	// TODO implement me
+	panic("implement me")
+}
+
+func (dao *DoubleWriteDAO) MoveCollectionBiz(ctx context.Context, biz string, bizId, uid, cid int64) error {
+	// TODO implement me
+	panic("implement me")
+}
+
+func (dao *DoubleWriteDAO) InsertCollection(ctx context.Context, c Collection) (int64, error) {
+	// TODO implement me
+	panic("implement me")
+}
+
+func (dao *DoubleWriteDAO) UpdateCollectionName(ctx context.Context, cid, uid int64, name string) error {
+	// TODO implement me
+	panic("implement me")
+}
+
+func (dao *DoubleWriteDAO) DeleteCollection(ctx context.Context, cid, uid int64) ([]UserCollectionBiz, error) {
+	// TODO implement me
+	panic("implement me")
+}
+
+func (dao *DoubleWriteDAO) GetCollections(ctx context.Context, uid int64, offset, limit int) ([]Collection, error) {
+	// TODO implement me
+	panic("implement me")
+}
+
+func (dao *DoubleWriteDAO) GetCollectionItems(ctx context.Context, cid, uid int64, offset, limit int) ([]UserCollectionBiz, error) {
+	// TODO implement me
+	panic("implement me")
+}
+
+func (dao *DoubleWriteDAO) MergeUser(ctx context.Context, fromUid, toUid int64) (MergeResult, error) {
+	// TODO implement me
+	panic("implement me")
+}
+
+func (dao *DoubleWriteDAO) GetLikes(ctx context.Context, uid int64, offset, limit int) ([]UserLikeBiz, error) {
+	// TODO implement me
+	panic("implement me")
+}
@@ -2,6 +2,7 @@ package dao
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"gorm.io/gorm"
@@ -47,9 +48,10 @@ type UserLikeBiz struct {
 
 // Collection 收藏夹
 type Collection struct {
-	Id    int64  `gorm:"primaryKey,autoIncrement"`
-	Name  string `gorm:"type=varchar(1024)"`
-	Uid   int64
+	Id   int64  `gorm:"primaryKey,autoIncrement"`
+	Name string `gorm:"type=varchar(1024)"`
+	// 查询用户的收藏夹列表：WHERE uid=?
+	Uid   int64 `gorm:"index"`
 	Ctime int64
 	Utime int64
 }
@@ -79,6 +81,28 @@ type InteractDAO interface {
 	GetCollectionInfo(ctx context.Context, biz string, bizId, uid int64) (UserCollectionBiz, error)
 	BatchIncrReadCnt(ctx context.Context, biz string, bizIds []int64) error
 	GetByIds(ctx context.Context, biz string, bizIds []int64) ([]Interact, error)
+	// DeleteCollectionBiz 删除收藏记录，并更新计数，没有收藏过返回 ErrDataNotFound
+	DeleteCollectionBiz(ctx context.Context, biz string, bizId, uid int64) error
+	// MoveCollectionBiz 移动到 uid 自己的另外一个收藏夹，收藏夹不存在或者没有收藏过都返回 ErrDataNotFound
+	MoveCollectionBiz(ctx context.Context, biz string, bizId, uid, cid int64) error
+	InsertCollection(ctx context.Context, c Collection) (int64, error)
+	// UpdateCollectionName 只能改自己的收藏夹，不存在返回 ErrDataNotFound
+	UpdateCollectionName(ctx context.Context, cid, uid int64, name string) error
+	// DeleteCollection 删除收藏夹和里面收藏的东西，并更新计数，返回被删除的收藏记录
+	DeleteCollection(ctx context.Context, cid, uid int64) ([]UserCollectionBiz, error)
+	GetCollections(ctx context.Context, uid int64, offset, limit int) ([]Collection, error)
+	GetCollectionItems(ctx context.Context, cid, uid int64, offset, limit int) ([]UserCollectionBiz, error)
+	// MergeUser fromUid 的点赞、收藏夹和收藏转给 toUid，两个人都点赞（收藏）过的只保留 toUid 的那一份并且计数减一，
+	// 返回计数减了一的点赞和收藏记录
+	MergeUser(ctx context.Context, fromUid, toUid int64) (MergeResult, error)
+	// GetLikes 用户有效的点赞记录，按点赞时间倒序
+	GetLikes(ctx context.Context, uid int64, offset, limit int) ([]UserLikeBiz, error)
+}
+
+// MergeResult 合并账号的时候重复了的记录，用来更新缓存里面的计数
+type MergeResult struct {
+	DupLikes    []UserLikeBiz
+	DupCollects []UserCollectionBiz
 }
 
 type GORMInteractDAO struct {
@@ -252,3 +276,222 @@ func (dao *GORMInteractDAO) GetByIds(ctx context.Context, biz string, bizIds []i
 	err := dao.db.WithContext(ctx).Model(&Interact{}).Where("biz = ? AND biz_id IN ?", biz, bizIds).Find(&res).Error
 	return res, err
 }
+
+func (dao *GORMInteractDAO) DeleteCollectionBiz(ctx context.Context, biz string, bizId, uid int64) error {
+	now := time.Now().UnixMilli()
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("uid=? AND biz_id=? AND biz=?", uid, bizId, biz).Delete(&UserCollectionBiz{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrDataNotFound
+		}
+		return dao.decrCollectCnt(tx, biz, bizId, now)
+	})
+}
+
+func (dao *GORMInteractDAO) decrCollectCnt(tx *gorm.DB, biz string, bizId int64, now int64) error {
+	return tx.Model(&Interact{}).Where("biz=? AND biz_id=?", biz, bizId).
+		Updates(map[string]any{
+			"collect_cnt": gorm.Expr("collect_cnt-1"),
+			"utime":       now,
+		}).Error
+}
+
+func (dao *GORMInteractDAO) MoveCollectionBiz(ctx context.Context, biz string, bizId, uid, cid int64) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 只能移动到自己的收藏夹
+		var c Collection
+		err := tx.Where("id=? AND uid=?", cid, uid).First(&c).Error
+		if err != nil {
+			return err
+		}
+		res := tx.Model(&UserCollectionBiz{}).Where("uid=? AND biz_id=? AND biz=?", uid, bizId, biz).
+			Updates(map[string]any{
+				"cid":   cid,
+				"utime": time.Now().UnixMilli(),
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrDataNotFound
+		}
+		return nil
+	})
+}
+
+func (dao *GORMInteractDAO) InsertCollection(ctx context.Context, c Collection) (int64, error) {
+	now := time.Now().UnixMilli()
+	c.Ctime = now
+	c.Utime = now
+	err := dao.db.WithContext(ctx).Create(&c).Error
+	return c.Id, err
+}
+
+func (dao *GORMInteractDAO) UpdateCollectionName(ctx context.Context, cid, uid int64, name string) error {
+	res := dao.db.WithContext(ctx).Model(&Collection{}).Where("id=? AND uid=?", cid, uid).
+		Updates(map[string]any{
+			"name":  name,
+			"utime": time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrDataNotFound
+	}
+	return nil
+}
+
+func (dao *GORMInteractDAO) DeleteCollection(ctx context.Context, cid, uid int64) ([]UserCollectionBiz, error) {
+	var items []UserCollectionBiz
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("id=? AND uid=?", cid, uid).Delete(&Collection{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrDataNotFound
+		}
+		err := tx.Where("cid=? AND uid=?", cid, uid).Find(&items).Error
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return nil
+		}
+		err = tx.Where("cid=? AND uid=?", cid, uid).Delete(&UserCollectionBiz{}).Error
+		if err != nil {
+			return err
+		}
+		now := time.Now().UnixMilli()
+		for _, item := range items {
+			if err = dao.decrCollectCnt(tx, item.Biz, item.BizId, now); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return items, err
+}
+
+func (dao *GORMInteractDAO) GetCollections(ctx context.Context, uid int64, offset, limit int) ([]Collection, error) {
+	var res []Collection
+	err := dao.db.WithContext(ctx).Where("uid=?", uid).Order("id DESC").
+		Offset(offset).Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMInteractDAO) GetCollectionItems(ctx context.Context, cid, uid int64, offset, limit int) ([]UserCollectionBiz, error) {
+	var res []UserCollectionBiz
+	err := dao.db.WithContext(ctx).Where("cid=? AND uid=?", cid, uid).Order("utime DESC").
+		Offset(offset).Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMInteractDAO) GetLikes(ctx context.Context, uid int64, offset, limit int) ([]UserLikeBiz, error) {
+	var res []UserLikeBiz
+	err := dao.db.WithContext(ctx).Where("uid=? AND status=?", uid, 1).Order("utime DESC").
+		Offset(offset).Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMInteractDAO) MergeUser(ctx context.Context, fromUid, toUid int64) (MergeResult, error) {
+	var res MergeResult
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now().UnixMilli()
+		dupLikes, err := dao.mergeLikes(tx, fromUid, toUid, now)
+		if err != nil {
+			return err
+		}
+		dupCollects, err := dao.mergeCollects(tx, fromUid, toUid, now)
+		if err != nil {
+			return err
+		}
+		// 收藏夹直接转过去，里面的收藏上面已经处理过了
+		err = tx.Model(&Collection{}).Where("uid=?", fromUid).
+			Updates(map[string]any{"uid": toUid, "utime": now}).Error
+		if err != nil {
+			return err
+		}
+		res = MergeResult{DupLikes: dupLikes, DupCollects: dupCollects}
+		return nil
+	})
+	return res, err
+}
+
+func (dao *GORMInteractDAO) mergeLikes(tx *gorm.DB, fromUid, toUid int64, now int64) ([]UserLikeBiz, error) {
+	var likes []UserLikeBiz
+	if err := tx.Where("uid=?", fromUid).Find(&likes).Error; err != nil {
+		return nil, err
+	}
+	var dups []UserLikeBiz
+	for _, like := range likes {
+		var target UserLikeBiz
+		err := tx.Where("uid=? AND biz_id=? AND biz=?", toUid, like.BizId, like.Biz).First(&target).Error
+		switch {
+		case errors.Is(err, ErrDataNotFound):
+			err = tx.Model(&UserLikeBiz{}).Where("id=?", like.Id).
+				Updates(map[string]any{"uid": toUid, "utime": now}).Error
+			if err != nil {
+				return nil, err
+			}
+			continue
+		case err != nil:
+			return nil, err
+		}
+		// 两边都有记录，from 的删掉，有效的点赞只能算一次
+		if err = tx.Delete(&UserLikeBiz{}, like.Id).Error; err != nil {
+			return nil, err
+		}
+		if like.Status != 1 {
+			continue
+		}
+		if target.Status == 1 {
+			err = tx.Model(&Interact{}).Where("biz_id=? AND biz=?", like.BizId, like.Biz).
+				Updates(map[string]any{"like_cnt": gorm.Expr("like_cnt-1"), "utime": now}).Error
+			dups = append(dups, like)
+		} else {
+			err = tx.Model(&UserLikeBiz{}).Where("id=?", target.Id).
+				Updates(map[string]any{"status": 1, "utime": now}).Error
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return dups, nil
+}
+
+func (dao *GORMInteractDAO) mergeCollects(tx *gorm.DB, fromUid, toUid int64, now int64) ([]UserCollectionBiz, error) {
+	var items []UserCollectionBiz
+	if err := tx.Where("uid=?", fromUid).Find(&items).Error; err != nil {
+		return nil, err
+	}
+	var dups []UserCollectionBiz
+	for _, item := range items {
+		var target UserCollectionBiz
+		err := tx.Where("uid=? AND biz_id=? AND biz=?", toUid, item.BizId, item.Biz).First(&target).Error
+		switch {
+		case errors.Is(err, ErrDataNotFound):
+			// 收藏夹 ID 不用变，收藏夹也会一起转过去
+			err = tx.Model(&UserCollectionBiz{}).Where("id=?", item.Id).
+				Updates(map[string]any{"uid": toUid, "utime": now}).Error
+			if err != nil {
+				return nil, err
+			}
+			continue
+		case err != nil:
+			return nil, err
+		}
+		if err = tx.Delete(&UserCollectionBiz{}, item.Id).Error; err != nil {
+			return nil, err
+		}
+		if err = dao.decrCollectCnt(tx, item.Biz, item.BizId, now); err != nil {
+			return nil, err
+		}
+		dups = append(dups, item)
+	}
+	return dups, nil
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/ecodeclub/ekit/slice"
 
@@ -12,6 +13,8 @@ import (
 	"github.com/liupch66/basic-go/webook/pkg/logger"
 )
 
+var ErrDataNotFound = dao.ErrDataNotFound
+
 //go:generate mockgen -package=mockrepo -source=interact.go -destination=mocks/mock_interact.go InteractRepository
 type InteractRepository interface {
 	IncrReadCnt(ctx context.Context, biz string, bizId int64) error
@@ -23,6 +26,17 @@ type InteractRepository interface {
 	Collected(ctx context.Context, biz string, bizId, uid int64) (bool, error)
 	BatchIncrReadCnt(ctx context.Context, biz string, bizIds []int64) error
 	GetByIds(ctx context.Context, biz string, bizIds []int64) ([]domain.Interact, error)
+	// DeleteCollectionItem 没有收藏过返回 ErrDataNotFound
+	DeleteCollectionItem(ctx context.Context, biz string, bizId, uid int64) error
+	MoveCollectionItem(ctx context.Context, biz string, bizId, uid, cid int64) error
+	CreateCollection(ctx context.Context, c domain.Collection) (int64, error)
+	RenameCollection(ctx context.Context, cid, uid int64, name string) error
+	// DeleteCollection 返回收藏夹里面被一起删掉的东西
+	DeleteCollection(ctx context.Context, cid, uid int64) ([]domain.CollectionItem, error)
+	GetCollections(ctx context.Context, uid int64, offset, limit int) ([]domain.Collection, error)
+	GetCollectionItems(ctx context.Context, cid, uid int64, offset, limit int) ([]domain.CollectionItem, error)
+	MergeUser(ctx context.Context, fromUid, toUid int64) error
+	GetLikes(ctx context.Context, uid int64, offset, limit int) ([]domain.UserLike, error)
 }
 
 type CachedInteractRepository struct {
@@ -141,3 +155,113 @@ func (repo *CachedInteractRepository) GetByIds(ctx context.Context, biz string,
 		}
 	}), nil
 }
+
+func (repo *CachedInteractRepository) DeleteCollectionItem(ctx context.Context, biz string, bizId, uid int64) error {
+	// 本来就没有收藏的话是 ErrDataNotFound，计数也不用动
+	err := repo.dao.DeleteCollectionBiz(ctx, biz, bizId, uid)
+	if err != nil {
+		return err
+	}
+	return repo.cache.DecrCollectCntIfPresent(ctx, biz, bizId)
+}
+
+func (repo *CachedInteractRepository) MoveCollectionItem(ctx context.Context, biz string, bizId, uid, cid int64) error {
+	// 换个收藏夹而已，计数不变
+	return repo.dao.MoveCollectionBiz(ctx, biz, bizId, uid, cid)
+}
+
+func (repo *CachedInteractRepository) CreateCollection(ctx context.Context, c domain.Collection) (int64, error) {
+	return repo.dao.InsertCollection(ctx, dao.Collection{Name: c.Name, Uid: c.Uid})
+}
+
+func (repo *CachedInteractRepository) RenameCollection(ctx context.Context, cid, uid int64, name string) error {
+	return repo.dao.UpdateCollectionName(ctx, cid, uid, name)
+}
+
+func (repo *CachedInteractRepository) DeleteCollection(ctx context.Context, cid, uid int64) ([]domain.CollectionItem, error) {
+	items, err := repo.dao.DeleteCollection(ctx, cid, uid)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		// 数据库已经改好了，缓存更新失败了也只是计数不准，等缓存过期就好了
+		if er := repo.cache.DecrCollectCntIfPresent(ctx, item.Biz, item.BizId); er != nil {
+			repo.l.Error("更新收藏数缓存失败", logger.String("biz", item.Biz),
+				logger.Int64("biz_id", item.BizId), logger.Error(er))
+		}
+	}
+	return slice.Map(items, func(idx int, src dao.UserCollectionBiz) domain.CollectionItem {
+		return repo.collectionItemToDomain(src)
+	}), nil
+}
+
+func (repo *CachedInteractRepository) GetCollections(ctx context.Context, uid int64, offset, limit int) ([]domain.Collection, error) {
+	cs, err := repo.dao.GetCollections(ctx, uid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(cs, func(idx int, src dao.Collection) domain.Collection {
+		return domain.Collection{
+			Id:    src.Id,
+			Name:  src.Name,
+			Uid:   src.Uid,
+			Ctime: time.UnixMilli(src.Ctime),
+			Utime: time.UnixMilli(src.Utime),
+		}
+	}), nil
+}
+
+func (repo *CachedInteractRepository) GetCollectionItems(ctx context.Context, cid, uid int64, offset, limit int) ([]domain.CollectionItem, error) {
+	items, err := repo.dao.GetCollectionItems(ctx, cid, uid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(items, func(idx int, src dao.UserCollectionBiz) domain.CollectionItem {
+		return repo.collectionItemToDomain(src)
+	}), nil
+}
+
+func (repo *CachedInteractRepository) collectionItemToDomain(src dao.UserCollectionBiz) domain.CollectionItem {
+	return domain.CollectionItem{
+		Cid:   src.Cid,
+		Biz:   src.Biz,
+		BizId: src.BizId,
+		Uid:   src.Uid,
+		Ctime: time.UnixMilli(src.Ctime),
+	}
+}
+
+func (repo *CachedInteractRepository) MergeUser(ctx context.Context, fromUid, toUid int64) error {
+	res, err := repo.dao.MergeUser(ctx, fromUid, toUid)
+	if err != nil {
+		return err
+	}
+	// 和 DeleteCollection 一样，缓存更新失败只是计数不准
+	for _, like := range res.DupLikes {
+		if er := repo.cache.DecrLikeCntIfPresent(ctx, like.Biz, like.BizId); er != nil {
+			repo.l.Error("更新点赞数缓存失败", logger.String("biz", like.Biz),
+				logger.Int64("biz_id", like.BizId), logger.Error(er))
+		}
+	}
+	for _, item := range res.DupCollects {
+		if er := repo.cache.DecrCollectCntIfPresent(ctx, item.Biz, item.BizId); er != nil {
+			repo.l.Error("更新收藏数缓存失败", logger.String("biz", item.Biz),
+				logger.Int64("biz_id", item.BizId), logger.Error(er))
+		}
+	}
+	return nil
+}
+
+func (repo *CachedInteractRepository) GetLikes(ctx context.Context, uid int64, offset, limit int) ([]domain.UserLike, error) {
+	likes, err := repo.dao.GetLikes(ctx, uid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(likes, func(idx int, src dao.UserLikeBiz) domain.UserLike {
+		return domain.UserLike{
+			Biz:   src.Biz,
+			BizId: src.BizId,
+			Ctime: time.UnixMilli(src.Utime),
+		}
+	}), nil
+}
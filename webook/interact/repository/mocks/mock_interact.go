@@ -0,0 +1,303 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interact.go
+//
+// Generated by this command:
+//
+//	mockgen -package=mockrepo -source=interact.go -destination=mocks/mock_interact.go InteractRepository
+//
+
+// Package mockrepo is a generated GoMock package.
+package mockrepo
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/liupch66/basic-go/webook/interact/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockInteractRepository is a mock of InteractRepository interface.
+type MockInteractRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockInteractRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockInteractRepositoryMockRecorder is the mock recorder for MockInteractRepository.
+type MockInteractRepositoryMockRecorder struct {
+	mock *MockInteractRepository
+}
+
+// NewMockInteractRepository creates a new mock instance.
+func NewMockInteractRepository(ctrl *gomock.Controller) *MockInteractRepository {
+	mock := &MockInteractRepository{ctrl: ctrl}
+	mock.recorder = &MockInteractRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInteractRepository) EXPECT() *MockInteractRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddCollectionItem mocks base method.
+func (m *MockInteractRepository) AddCollectionItem(ctx context.Context, biz string, bizId, cid, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCollectionItem", ctx, biz, bizId, cid, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddCollectionItem indicates an expected call of AddCollectionItem.
+func (mr *MockInteractRepositoryMockRecorder) AddCollectionItem(ctx, biz, bizId, cid, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCollectionItem", reflect.TypeOf((*MockInteractRepository)(nil).AddCollectionItem), ctx, biz, bizId, cid, uid)
+}
+
+// BatchIncrReadCnt mocks base method.
+func (m *MockInteractRepository) BatchIncrReadCnt(ctx context.Context, biz string, bizIds []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchIncrReadCnt", ctx, biz, bizIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchIncrReadCnt indicates an expected call of BatchIncrReadCnt.
+func (mr *MockInteractRepositoryMockRecorder) BatchIncrReadCnt(ctx, biz, bizIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchIncrReadCnt", reflect.TypeOf((*MockInteractRepository)(nil).BatchIncrReadCnt), ctx, biz, bizIds)
+}
+
+// Collected mocks base method.
+func (m *MockInteractRepository) Collected(ctx context.Context, biz string, bizId, uid int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Collected", ctx, biz, bizId, uid)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Collected indicates an expected call of Collected.
+func (mr *MockInteractRepositoryMockRecorder) Collected(ctx, biz, bizId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Collected", reflect.TypeOf((*MockInteractRepository)(nil).Collected), ctx, biz, bizId, uid)
+}
+
+// CreateCollection mocks base method.
+func (m *MockInteractRepository) CreateCollection(ctx context.Context, c domain.Collection) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCollection", ctx, c)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCollection indicates an expected call of CreateCollection.
+func (mr *MockInteractRepositoryMockRecorder) CreateCollection(ctx, c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCollection", reflect.TypeOf((*MockInteractRepository)(nil).CreateCollection), ctx, c)
+}
+
+// DecrLike mocks base method.
+func (m *MockInteractRepository) DecrLike(ctx context.Context, biz string, bizId, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecrLike", ctx, biz, bizId, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DecrLike indicates an expected call of DecrLike.
+func (mr *MockInteractRepositoryMockRecorder) DecrLike(ctx, biz, bizId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrLike", reflect.TypeOf((*MockInteractRepository)(nil).DecrLike), ctx, biz, bizId, uid)
+}
+
+// DeleteCollection mocks base method.
+func (m *MockInteractRepository) DeleteCollection(ctx context.Context, cid, uid int64) ([]domain.CollectionItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollection", ctx, cid, uid)
+	ret0, _ := ret[0].([]domain.CollectionItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCollection indicates an expected call of DeleteCollection.
+func (mr *MockInteractRepositoryMockRecorder) DeleteCollection(ctx, cid, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollection", reflect.TypeOf((*MockInteractRepository)(nil).DeleteCollection), ctx, cid, uid)
+}
+
+// DeleteCollectionItem mocks base method.
+func (m *MockInteractRepository) DeleteCollectionItem(ctx context.Context, biz string, bizId, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollectionItem", ctx, biz, bizId, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCollectionItem indicates an expected call of DeleteCollectionItem.
+func (mr *MockInteractRepositoryMockRecorder) DeleteCollectionItem(ctx, biz, bizId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollectionItem", reflect.TypeOf((*MockInteractRepository)(nil).DeleteCollectionItem), ctx, biz, bizId, uid)
+}
+
+// Get mocks base method.
+func (m *MockInteractRepository) Get(ctx context.Context, biz string, bizId int64) (domain.Interact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, biz, bizId)
+	ret0, _ := ret[0].(domain.Interact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockInteractRepositoryMockRecorder) Get(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockInteractRepository)(nil).Get), ctx, biz, bizId)
+}
+
+// GetByIds mocks base method.
+func (m *MockInteractRepository) GetByIds(ctx context.Context, biz string, bizIds []int64) ([]domain.Interact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIds", ctx, biz, bizIds)
+	ret0, _ := ret[0].([]domain.Interact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIds indicates an expected call of GetByIds.
+func (mr *MockInteractRepositoryMockRecorder) GetByIds(ctx, biz, bizIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIds", reflect.TypeOf((*MockInteractRepository)(nil).GetByIds), ctx, biz, bizIds)
+}
+
+// GetCollectionItems mocks base method.
+func (m *MockInteractRepository) GetCollectionItems(ctx context.Context, cid, uid int64, offset, limit int) ([]domain.CollectionItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollectionItems", ctx, cid, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.CollectionItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollectionItems indicates an expected call of GetCollectionItems.
+func (mr *MockInteractRepositoryMockRecorder) GetCollectionItems(ctx, cid, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollectionItems", reflect.TypeOf((*MockInteractRepository)(nil).GetCollectionItems), ctx, cid, uid, offset, limit)
+}
+
+// GetCollections mocks base method.
+func (m *MockInteractRepository) GetCollections(ctx context.Context, uid int64, offset, limit int) ([]domain.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollections", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollections indicates an expected call of GetCollections.
+func (mr *MockInteractRepositoryMockRecorder) GetCollections(ctx, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollections", reflect.TypeOf((*MockInteractRepository)(nil).GetCollections), ctx, uid, offset, limit)
+}
+
+// GetLikes mocks base method.
+func (m *MockInteractRepository) GetLikes(ctx context.Context, uid int64, offset, limit int) ([]domain.UserLike, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLikes", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.UserLike)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLikes indicates an expected call of GetLikes.
+func (mr *MockInteractRepositoryMockRecorder) GetLikes(ctx, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLikes", reflect.TypeOf((*MockInteractRepository)(nil).GetLikes), ctx, uid, offset, limit)
+}
+
+// IncrLike mocks base method.
+func (m *MockInteractRepository) IncrLike(ctx context.Context, biz string, bizId, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrLike", ctx, biz, bizId, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrLike indicates an expected call of IncrLike.
+func (mr *MockInteractRepositoryMockRecorder) IncrLike(ctx, biz, bizId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrLike", reflect.TypeOf((*MockInteractRepository)(nil).IncrLike), ctx, biz, bizId, uid)
+}
+
+// IncrReadCnt mocks base method.
+func (m *MockInteractRepository) IncrReadCnt(ctx context.Context, biz string, bizId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrReadCnt", ctx, biz, bizId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrReadCnt indicates an expected call of IncrReadCnt.
+func (mr *MockInteractRepositoryMockRecorder) IncrReadCnt(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrReadCnt", reflect.TypeOf((*MockInteractRepository)(nil).IncrReadCnt), ctx, biz, bizId)
+}
+
+// Liked mocks base method.
+func (m *MockInteractRepository) Liked(ctx context.Context, biz string, bizId, uid int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Liked", ctx, biz, bizId, uid)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Liked indicates an expected call of Liked.
+func (mr *MockInteractRepositoryMockRecorder) Liked(ctx, biz, bizId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Liked", reflect.TypeOf((*MockInteractRepository)(nil).Liked), ctx, biz, bizId, uid)
+}
+
+// MergeUser mocks base method.
+func (m *MockInteractRepository) MergeUser(ctx context.Context, fromUid, toUid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeUser", ctx, fromUid, toUid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergeUser indicates an expected call of MergeUser.
+func (mr *MockInteractRepositoryMockRecorder) MergeUser(ctx, fromUid, toUid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeUser", reflect.TypeOf((*MockInteractRepository)(nil).MergeUser), ctx, fromUid, toUid)
+}
+
+// MoveCollectionItem mocks base method.
+func (m *MockInteractRepository) MoveCollectionItem(ctx context.Context, biz string, bizId, uid, cid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveCollectionItem", ctx, biz, bizId, uid, cid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveCollectionItem indicates an expected call of MoveCollectionItem.
+func (mr *MockInteractRepositoryMockRecorder) MoveCollectionItem(ctx, biz, bizId, uid, cid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveCollectionItem", reflect.TypeOf((*MockInteractRepository)(nil).MoveCollectionItem), ctx, biz, bizId, uid, cid)
+}
+
+// RenameCollection mocks base method.
+func (m *MockInteractRepository) RenameCollection(ctx context.Context, cid, uid int64, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameCollection", ctx, cid, uid, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenameCollection indicates an expected call of RenameCollection.
+func (mr *MockInteractRepositoryMockRecorder) RenameCollection(ctx, cid, uid, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameCollection", reflect.TypeOf((*MockInteractRepository)(nil).RenameCollection), ctx, cid, uid, name)
+}
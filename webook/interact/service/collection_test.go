@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/liupch66/basic-go/webook/interact/domain"
+	"github.com/liupch66/basic-go/webook/interact/events"
+	mockevt "github.com/liupch66/basic-go/webook/interact/events/mocks"
+	"github.com/liupch66/basic-go/webook/interact/repository"
+	mockrepo "github.com/liupch66/basic-go/webook/interact/repository/mocks"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
+)
+
+func Test_interactService_CreateCollection(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mock     func(ctrl *gomock.Controller) repository.InteractRepository
+		collName string
+
+		expectedId  int64
+		expectedErr error
+	}{
+		{
+			name: "创建成功，名字去掉首尾空格",
+			mock: func(ctrl *gomock.Controller) repository.InteractRepository {
+				repo := mockrepo.NewMockInteractRepository(ctrl)
+				repo.EXPECT().CreateCollection(gomock.Any(), domain.Collection{Name: "Go 学习", Uid: 123}).
+					Return(int64(1), nil)
+				return repo
+			},
+			collName:   "  Go 学习 ",
+			expectedId: 1,
+		},
+		{
+			name: "名字为空",
+			mock: func(ctrl *gomock.Controller) repository.InteractRepository {
+				return mockrepo.NewMockInteractRepository(ctrl)
+			},
+			collName:    "   ",
+			expectedErr: ErrInvalidCollectionName,
+		},
+		{
+			name: "名字太长",
+			mock: func(ctrl *gomock.Controller) repository.InteractRepository {
+				return mockrepo.NewMockInteractRepository(ctrl)
+			},
+			collName:    strings.Repeat("收", maxCollectionNameLen+1),
+			expectedErr: ErrInvalidCollectionName,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewInteractService(tc.mock(ctrl), nil, logger.NewNopLogger())
+			id, err := svc.CreateCollection(context.Background(), 123, tc.collName)
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedId, id)
+		})
+	}
+}
+
+func Test_interactService_MoveCollectItem(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) repository.InteractRepository
+
+		expectedErr error
+	}{
+		{
+			name: "移动成功",
+			mock: func(ctrl *gomock.Controller) repository.InteractRepository {
+				repo := mockrepo.NewMockInteractRepository(ctrl)
+				repo.EXPECT().MoveCollectionItem(gomock.Any(), "article", int64(1), int64(123), int64(2)).
+					Return(nil)
+				return repo
+			},
+		},
+		{
+			name: "目标收藏夹不属于自己或者没有收藏过",
+			mock: func(ctrl *gomock.Controller) repository.InteractRepository {
+				repo := mockrepo.NewMockInteractRepository(ctrl)
+				repo.EXPECT().MoveCollectionItem(gomock.Any(), "article", int64(1), int64(123), int64(2)).
+					Return(repository.ErrDataNotFound)
+				return repo
+			},
+			expectedErr: ErrCollectionNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewInteractService(tc.mock(ctrl), nil, logger.NewNopLogger())
+			err := svc.MoveCollectItem(context.Background(), "article", 1, 123, 2)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func Test_interactService_CancelCollect(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.InteractRepository, events.Producer)
+
+		expectedErr error
+	}{
+		{
+			name: "取消收藏成功，发事件",
+			mock: func(ctrl *gomock.Controller) (repository.InteractRepository, events.Producer) {
+				repo := mockrepo.NewMockInteractRepository(ctrl)
+				repo.EXPECT().DeleteCollectionItem(gomock.Any(), "article", int64(1), int64(123)).Return(nil)
+				producer := mockevt.NewMockProducer(ctrl)
+				producer.EXPECT().ProduceInteractEvent(gomock.Any()).DoAndReturn(func(evt events.InteractEvent) error {
+					assert.Equal(t, domain.InteractActionCancelCollect, evt.Action)
+					assert.Equal(t, int64(1), evt.BizId)
+					return nil
+				})
+				return repo, producer
+			},
+		},
+		{
+			name: "本来就没有收藏，不发事件",
+			mock: func(ctrl *gomock.Controller) (repository.InteractRepository, events.Producer) {
+				repo := mockrepo.NewMockInteractRepository(ctrl)
+				repo.EXPECT().DeleteCollectionItem(gomock.Any(), "article", int64(1), int64(123)).
+					Return(repository.ErrDataNotFound)
+				return repo, mockevt.NewMockProducer(ctrl)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, producer := tc.mock(ctrl)
+			svc := NewInteractService(repo, producer, logger.NewNopLogger())
+			err := svc.CancelCollect(context.Background(), "article", 1, 123)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func Test_interactService_DeleteCollection(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.InteractRepository, events.Producer)
+
+		expectedErr error
+	}{
+		{
+			name: "收藏夹里面的东西都发取消收藏事件",
+			mock: func(ctrl *gomock.Controller) (repository.InteractRepository, events.Producer) {
+				repo := mockrepo.NewMockInteractRepository(ctrl)
+				repo.EXPECT().DeleteCollection(gomock.Any(), int64(2), int64(123)).
+					Return([]domain.CollectionItem{
+						{Cid: 2, Biz: "article", BizId: 1, Uid: 123},
+						{Cid: 2, Biz: "article", BizId: 3, Uid: 123},
+					}, nil)
+				producer := mockevt.NewMockProducer(ctrl)
+				producer.EXPECT().ProduceInteractEvent(gomock.Any()).DoAndReturn(func(evt events.InteractEvent) error {
+					assert.Equal(t, domain.InteractActionCancelCollect, evt.Action)
+					assert.Equal(t, int64(123), evt.Uid)
+					return nil
+				}).Times(2)
+				return repo, producer
+			},
+		},
+		{
+			name: "收藏夹不存在",
+			mock: func(ctrl *gomock.Controller) (repository.InteractRepository, events.Producer) {
+				repo := mockrepo.NewMockInteractRepository(ctrl)
+				repo.EXPECT().DeleteCollection(gomock.Any(), int64(2), int64(123)).
+					Return(nil, repository.ErrDataNotFound)
+				return repo, mockevt.NewMockProducer(ctrl)
+			},
+			expectedErr: ErrCollectionNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, producer := tc.mock(ctrl)
+			svc := NewInteractService(repo, producer, logger.NewNopLogger())
+			err := svc.DeleteCollection(context.Background(), 2, 123)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
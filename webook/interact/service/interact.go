@@ -2,14 +2,28 @@ package service
 
 import (
 	"context"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"golang.org/x/sync/errgroup"
 
 	"github.com/liupch66/basic-go/webook/interact/domain"
+	"github.com/liupch66/basic-go/webook/interact/events"
 	"github.com/liupch66/basic-go/webook/interact/repository"
 	"github.com/liupch66/basic-go/webook/pkg/logger"
 )
 
+// 收藏夹名字最多这么多个字
+const maxCollectionNameLen = 64
+
+var (
+	ErrCollectionNotFound    = errors.New("收藏夹不存在或者没有收藏过")
+	ErrInvalidCollectionName = errors.New("收藏夹名字不能为空，也不能太长")
+	ErrInvalidMergeUid       = errors.New("合并账号的 uid 不合法")
+)
+
 //go:generate mockgen -package=mocksvc -source=interact.go -destination=mocks/mock_interact.go InteractService
 type InteractService interface {
 	IncrReadCnt(ctx context.Context, biz string, bizId int64) error
@@ -19,31 +33,78 @@ type InteractService interface {
 	Get(ctx context.Context, biz string, bizId, uid int64) (domain.Interact, error)
 	// GetByIds 这里本来返回 []domain.Interact，返回 map 是方便查找对应文章 id 的点赞数据
 	GetByIds(ctx context.Context, biz string, bizIds []int64) (map[int64]domain.Interact, error)
+	CancelCollect(ctx context.Context, biz string, bizId int64, uid int64) error
+	// MoveCollectItem 把收藏的东西移动到 uid 自己的 cid 收藏夹
+	MoveCollectItem(ctx context.Context, biz string, bizId int64, uid int64, cid int64) error
+	CreateCollection(ctx context.Context, uid int64, name string) (int64, error)
+	RenameCollection(ctx context.Context, cid, uid int64, name string) error
+	// DeleteCollection 收藏夹里面的东西会一起取消收藏
+	DeleteCollection(ctx context.Context, cid, uid int64) error
+	ListCollections(ctx context.Context, uid int64, offset, limit int) ([]domain.Collection, error)
+	ListCollectionItems(ctx context.Context, cid, uid int64, offset, limit int) ([]domain.CollectionItem, error)
+	// MergeUser 合并账号，fromUid 的互动数据都转给 toUid
+	MergeUser(ctx context.Context, fromUid, toUid int64) error
+	// ListLikes 按点赞时间倒序
+	ListLikes(ctx context.Context, uid int64, offset, limit int) ([]domain.UserLike, error)
 }
 
 type interactService struct {
-	repo repository.InteractRepository
-	l    logger.LoggerV1
+	repo     repository.InteractRepository
+	producer events.Producer
+	l        logger.LoggerV1
 }
 
-func NewInteractService(repo repository.InteractRepository, l logger.LoggerV1) InteractService {
-	return &interactService{repo: repo, l: l}
+func NewInteractService(repo repository.InteractRepository, producer events.Producer, l logger.LoggerV1) InteractService {
+	return &interactService{repo: repo, producer: producer, l: l}
 }
 
 func (svc *interactService) IncrReadCnt(ctx context.Context, biz string, bizId int64) error {
-	return svc.repo.IncrReadCnt(ctx, biz, bizId)
+	err := svc.repo.IncrReadCnt(ctx, biz, bizId)
+	if err == nil {
+		svc.produce(biz, bizId, 0, domain.InteractActionRead)
+	}
+	return err
 }
 
 func (svc *interactService) Like(ctx context.Context, biz string, bizId int64, uid int64) error {
-	return svc.repo.IncrLike(ctx, biz, bizId, uid)
+	err := svc.repo.IncrLike(ctx, biz, bizId, uid)
+	if err == nil {
+		svc.produce(biz, bizId, uid, domain.InteractActionLike)
+	}
+	return err
 }
 
 func (svc *interactService) CancelLike(ctx context.Context, biz string, bizId int64, uid int64) error {
-	return svc.repo.DecrLike(ctx, biz, bizId, uid)
+	err := svc.repo.DecrLike(ctx, biz, bizId, uid)
+	if err == nil {
+		svc.produce(biz, bizId, uid, domain.InteractActionCancelLike)
+	}
+	return err
 }
 
 func (svc *interactService) Collect(ctx context.Context, biz string, bizId int64, cid int64, uid int64) error {
-	return svc.repo.AddCollectionItem(ctx, biz, bizId, cid, uid)
+	err := svc.repo.AddCollectionItem(ctx, biz, bizId, cid, uid)
+	if err == nil {
+		svc.produce(biz, bizId, uid, domain.InteractActionCollect)
+	}
+	return err
+}
+
+// produce 在数据库写成功之后同步发互动事件，发送失败只记日志，不影响互动本身。
+// 不开 goroutine，不然 kafka 慢的时候 goroutine 会无限堆积。
+// 下游的实时热榜本来就是近似的，有定时任务全量计算兜底
+func (svc *interactService) produce(biz string, bizId, uid int64, action domain.InteractAction) {
+	er := svc.producer.ProduceInteractEvent(events.InteractEvent{
+		Biz:    biz,
+		BizId:  bizId,
+		Uid:    uid,
+		Action: action,
+		Ctime:  time.Now().UnixMilli(),
+	})
+	if er != nil {
+		svc.l.Error("发送互动事件失败", logger.String("biz", biz), logger.Int64("bizId", bizId),
+			logger.String("action", string(action)), logger.Error(er))
+	}
 }
 
 func (svc *interactService) Get(ctx context.Context, biz string, bizId, uid int64) (domain.Interact, error) {
@@ -91,3 +152,85 @@ func (svc *interactService) GetByIds(ctx context.Context, biz string, bizIds []i
 	}
 	return res, nil
 }
+
+func (svc *interactService) CancelCollect(ctx context.Context, biz string, bizId int64, uid int64) error {
+	err := svc.repo.DeleteCollectionItem(ctx, biz, bizId, uid)
+	if errors.Is(err, repository.ErrDataNotFound) {
+		// 本来就没有收藏，对调用方来说也算成功，但是不能发事件，不然下游会多扣分
+		return nil
+	}
+	if err == nil {
+		svc.produce(biz, bizId, uid, domain.InteractActionCancelCollect)
+	}
+	return err
+}
+
+func (svc *interactService) MoveCollectItem(ctx context.Context, biz string, bizId int64, uid int64, cid int64) error {
+	err := svc.repo.MoveCollectionItem(ctx, biz, bizId, uid, cid)
+	if errors.Is(err, repository.ErrDataNotFound) {
+		return ErrCollectionNotFound
+	}
+	return err
+}
+
+func (svc *interactService) CreateCollection(ctx context.Context, uid int64, name string) (int64, error) {
+	name, err := svc.checkCollectionName(name)
+	if err != nil {
+		return 0, err
+	}
+	return svc.repo.CreateCollection(ctx, domain.Collection{Name: name, Uid: uid})
+}
+
+func (svc *interactService) RenameCollection(ctx context.Context, cid, uid int64, name string) error {
+	name, err := svc.checkCollectionName(name)
+	if err != nil {
+		return err
+	}
+	err = svc.repo.RenameCollection(ctx, cid, uid, name)
+	if errors.Is(err, repository.ErrDataNotFound) {
+		return ErrCollectionNotFound
+	}
+	return err
+}
+
+func (svc *interactService) DeleteCollection(ctx context.Context, cid, uid int64) error {
+	items, err := svc.repo.DeleteCollection(ctx, cid, uid)
+	if errors.Is(err, repository.ErrDataNotFound) {
+		return ErrCollectionNotFound
+	}
+	if err != nil {
+		return err
+	}
+	// 收藏夹里面的东西都取消收藏了，和 CancelCollect 一样每一个都要发事件
+	for _, item := range items {
+		svc.produce(item.Biz, item.BizId, uid, domain.InteractActionCancelCollect)
+	}
+	return nil
+}
+
+func (svc *interactService) ListCollections(ctx context.Context, uid int64, offset, limit int) ([]domain.Collection, error) {
+	return svc.repo.GetCollections(ctx, uid, offset, limit)
+}
+
+func (svc *interactService) ListCollectionItems(ctx context.Context, cid, uid int64, offset, limit int) ([]domain.CollectionItem, error) {
+	return svc.repo.GetCollectionItems(ctx, cid, uid, offset, limit)
+}
+
+func (svc *interactService) ListLikes(ctx context.Context, uid int64, offset, limit int) ([]domain.UserLike, error) {
+	return svc.repo.GetLikes(ctx, uid, offset, limit)
+}
+
+func (svc *interactService) MergeUser(ctx context.Context, fromUid, toUid int64) error {
+	if fromUid <= 0 || toUid <= 0 || fromUid == toUid {
+		return ErrInvalidMergeUid
+	}
+	return svc.repo.MergeUser(ctx, fromUid, toUid)
+}
+
+func (svc *interactService) checkCollectionName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxCollectionNameLen {
+		return "", ErrInvalidCollectionName
+	}
+	return name, nil
+}
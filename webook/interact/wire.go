@@ -21,6 +21,7 @@ var thirdPartyProvider = wire.NewSet(
 	ioc.InitRedis,
 	ioc.InitLogger,
 	ioc.InitKafka, ioc.InitSyncProducer,
+	events.NewSaramaSyncProducer,
 )
 
 var interactServiceProvider = wire.NewSet(
@@ -33,6 +34,7 @@ var migratorProvider = wire.NewSet(
 	ioc.InitFixDataConsumer,
 	ioc.InitMigratorProducer,
 	ioc.InitMigratorWeb,
+	ioc.InitJwtHandler,
 )
 
 func InitApp() *app {
@@ -29,14 +29,16 @@ func InitApp() *app {
 	interactCache := cache.NewRedisInteractCache(cmdable)
 	loggerV1 := ioc.InitLogger()
 	interactRepository := repository.NewCachedInteractRepository(interactDAO, interactCache, loggerV1)
-	interactService := service.NewInteractService(interactRepository, loggerV1)
-	interactServiceServer := grpc.NewInteractServiceServer(interactService)
-	server := ioc.InitGRPCxServer(interactServiceServer, loggerV1)
 	client := ioc.InitKafka()
 	syncProducer := ioc.InitSyncProducer(client)
-	producer := ioc.InitMigratorProducer(syncProducer)
-	ginxServer := ioc.InitMigratorWeb(srcDB, dstDB, loggerV1, producer, doubleWritePool)
-	interactReadEventConsumer := events.NewInteractReadEventConsumer(client, interactRepository, loggerV1)
+	producer := events.NewSaramaSyncProducer(syncProducer)
+	interactService := service.NewInteractService(interactRepository, producer, loggerV1)
+	interactServiceServer := grpc.NewInteractServiceServer(interactService)
+	server := ioc.InitGRPCxServer(interactServiceServer, loggerV1)
+	eventsProducer := ioc.InitMigratorProducer(syncProducer)
+	handler := ioc.InitJwtHandler(cmdable)
+	ginxServer := ioc.InitMigratorWeb(srcDB, dstDB, loggerV1, eventsProducer, doubleWritePool, handler)
+	interactReadEventConsumer := events.NewInteractReadEventConsumer(client, interactRepository, producer, loggerV1)
 	consumer := ioc.InitFixDataConsumer(client, loggerV1, srcDB, dstDB)
 	v := ioc.NewConsumers(interactReadEventConsumer, consumer)
 	mainApp := &app{
@@ -49,8 +51,8 @@ func InitApp() *app {
 
 // wire.go:
 
-var thirdPartyProvider = wire.NewSet(ioc.InitSrcDB, ioc.InitDstDB, ioc.InitDoubleWritePool, ioc.InitBizDB, ioc.InitRedis, ioc.InitLogger, ioc.InitKafka, ioc.InitSyncProducer)
+var thirdPartyProvider = wire.NewSet(ioc.InitSrcDB, ioc.InitDstDB, ioc.InitDoubleWritePool, ioc.InitBizDB, ioc.InitRedis, ioc.InitLogger, ioc.InitKafka, ioc.InitSyncProducer, events.NewSaramaSyncProducer)
 
 var interactServiceProvider = wire.NewSet(dao.NewGORMInteractDAO, cache.NewRedisInteractCache, repository.NewCachedInteractRepository, service.NewInteractService)
 
-var migratorProvider = wire.NewSet(ioc.InitFixDataConsumer, ioc.InitMigratorProducer, ioc.InitMigratorWeb)
+var migratorProvider = wire.NewSet(ioc.InitFixDataConsumer, ioc.InitMigratorProducer, ioc.InitMigratorWeb, ioc.InitJwtHandler)
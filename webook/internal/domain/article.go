@@ -10,8 +10,10 @@ type Article struct {
 	Content string
 	Author  Author
 	Status  ArticleStatus
-	Ctime   time.Time
-	Utime   time.Time
+	// PublishAt 定时发表的时间，只有 Status 是 ArticleStatusScheduled 的时候才有意义
+	PublishAt time.Time
+	Ctime     time.Time
+	Utime     time.Time
 }
 
 func (a Article) Abstract() string {
@@ -38,6 +40,8 @@ const (
 	ArticleStatusUnpublished
 	ArticleStatusPublished
 	ArticleStatusPrivate
+	// ArticleStatusScheduled 定时发表，到了 PublishAt 之后由定时任务发表
+	ArticleStatusScheduled
 )
 
 func (s ArticleStatus) ToUnit8() uint8 {
@@ -62,6 +66,8 @@ func (s ArticleStatus) String() string {
 		return "published"
 	case ArticleStatusPrivate:
 		return "private"
+	case ArticleStatusScheduled:
+		return "scheduled"
 	default:
 		return "unknown"
 	}
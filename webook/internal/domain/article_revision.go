@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"time"
+)
+
+// ArticleRevision 文章的历史版本。每次 Save 或者 Publish 都会产生一个，只插入不修改
+type ArticleRevision struct {
+	Id        int64
+	ArticleId int64
+	// Version 同一篇文章内从 1 开始递增
+	Version int64
+	Title   string
+	Content string
+	Author  Author
+	// Status 记录产生这个版本时文章的状态，用来区分是保存草稿还是发表
+	Status ArticleStatus
+	Ctime  time.Time
+}
+
+// DiffOp 行级 diff 的操作类型
+type DiffOp uint8
+
+const (
+	DiffOpEqual DiffOp = iota
+	DiffOpInsert
+	DiffOpDelete
+)
+
+func (op DiffOp) String() string {
+	switch op {
+	case DiffOpInsert:
+		return "insert"
+	case DiffOpDelete:
+		return "delete"
+	default:
+		return "equal"
+	}
+}
+
+type DiffLine struct {
+	Op   DiffOp
+	Text string
+}
+
+// ArticleDiff 两个版本之间的差异，标题只有一行，所以单独比较
+type ArticleDiff struct {
+	From         int64
+	To           int64
+	TitleChanged bool
+	Lines        []DiffLine
+}
@@ -1,9 +1,17 @@
 package domain
 
+import (
+	"time"
+)
+
 type AsyncSms struct {
 	Id       int64
 	TplId    string
 	Args     []string
 	Numbers  []string
 	RetryMax int
+	// RetryCnt 算上这一次，一共发送了几次
+	RetryCnt int
+	// NextTime 什么时候可以被抢占发送
+	NextTime time.Time
 }
@@ -0,0 +1,39 @@
+package domain
+
+import "time"
+
+// 审计日志的操作
+const (
+	AuditActionUserBan         = "user.ban"
+	AuditActionUserUnban       = "user.unban"
+	AuditActionUserMerge       = "user.merge"
+	AuditActionRoleGrant       = "user.role.grant"
+	AuditActionRoleRevoke      = "user.role.revoke"
+	AuditActionArticleWithdraw = "article.withdraw"
+)
+
+const (
+	AuditTargetUser    = "user"
+	AuditTargetArticle = "article"
+)
+
+// AuditLog 管理员的操作都要留痕
+type AuditLog struct {
+	Id         int64
+	Operator   int64
+	Action     string
+	TargetType string
+	TargetId   int64
+	// Detail 操作原因之类的补充信息
+	Detail string
+	Ctime  time.Time
+}
+
+// AuditLogQuery 条件为零值的不参与过滤
+type AuditLogQuery struct {
+	Operator   int64
+	TargetType string
+	TargetId   int64
+	Offset     int
+	Limit      int
+}
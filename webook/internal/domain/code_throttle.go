@@ -0,0 +1,68 @@
+package domain
+
+import "time"
+
+// CodeThrottleDefault 没有单独配置的业务用这个策略
+const CodeThrottleDefault = "default"
+
+// 验证码频控的维度
+const (
+	CodeDimPhone  = "phone"
+	CodeDimIP     = "ip"
+	CodeDimDevice = "device"
+	// CodeDimBudget 所有业务、所有手机号加起来每天能发多少条
+	CodeDimBudget = "budget"
+)
+
+// CodeQuota 一个小时、一天内最多发多少条，0 就是不限制
+type CodeQuota struct {
+	Hour int64 `yaml:"hour"`
+	Day  int64 `yaml:"day"`
+}
+
+// CodeDimPolicy 超过了 Captcha 要先通过图形验证码，超过了 Reject 通过了图形验证码也不给发
+type CodeDimPolicy struct {
+	Captcha CodeQuota `yaml:"captcha"`
+	Reject  CodeQuota `yaml:"reject"`
+}
+
+// CodeThrottlePolicy 一个业务的频控策略
+type CodeThrottlePolicy struct {
+	Phone  CodeDimPolicy `yaml:"phone"`
+	IP     CodeDimPolicy `yaml:"ip"`
+	Device CodeDimPolicy `yaml:"device"`
+}
+
+// CodeSendMeta 发验证码的请求是从哪里来的，频控用
+type CodeSendMeta struct {
+	IP string
+	// Device 前端算出来的设备指纹，没有的话这个维度不限制
+	Device string
+	// CaptchaToken 用户通过图形验证码之后拿到的 token
+	CaptchaToken string
+}
+
+// CodeThrottleRule 一个计数器：维度、维度的值和窗口
+type CodeThrottleRule struct {
+	Dim    string
+	Value  string
+	Window time.Duration
+	// Captcha、Reject 0 就是不限制
+	Captcha int64
+	Reject  int64
+}
+
+type CodeThrottleDecision uint8
+
+const (
+	CodeThrottleAllow CodeThrottleDecision = iota
+	// CodeThrottleCaptcha 要先通过图形验证码
+	CodeThrottleCaptcha
+	CodeThrottleReject
+)
+
+// CodeThrottleResult Rule 是触发了限制的规则，允许发送的时候是零值
+type CodeThrottleResult struct {
+	Decision CodeThrottleDecision
+	Rule     CodeThrottleRule
+}
@@ -1,8 +1,14 @@
 package domain
 
+import (
+	"time"
+)
+
 type HistoryRecord struct {
 	// 考虑到历史记录可以支持不同的类型，例如视频之类的，这里也沿用 biz 和 bizId 的设计
 	Biz   string
 	BizId int64
 	Uid   int64
+	// Utime 最近一次阅读的时间，同一篇文章重复阅读只保留一条记录
+	Utime time.Time
 }
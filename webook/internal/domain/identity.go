@@ -0,0 +1,11 @@
+package domain
+
+// Identity 用户在第三方平台（微信、GitHub、OIDC）上的身份，一个用户可以绑定多个
+type Identity struct {
+	// Provider 平台名，和 /oauth2/:provider 里面的一致
+	Provider string
+	// Subject 平台内唯一的用户标识，微信是 openid，OIDC 是 sub
+	Subject string
+	Email   string
+	Name    string
+}
@@ -0,0 +1,17 @@
+package domain
+
+import (
+	intrDomain "github.com/liupch66/basic-go/webook/interact/domain"
+)
+
+// InteractAction 互动服务发出来的互动事件里面的动作，定义在互动服务里面，这里只是别名，
+// 消费者直接用互动事件里面的 Action，不用再转换
+type InteractAction = intrDomain.InteractAction
+
+const (
+	InteractActionRead          = intrDomain.InteractActionRead
+	InteractActionLike          = intrDomain.InteractActionLike
+	InteractActionCancelLike    = intrDomain.InteractActionCancelLike
+	InteractActionCollect       = intrDomain.InteractActionCollect
+	InteractActionCancelCollect = intrDomain.InteractActionCancelCollect
+)
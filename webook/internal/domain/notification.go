@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+type NotificationType uint8
+
+const (
+	NotificationTypeUnknown NotificationType = iota
+	// NotificationTypeLike 有人点赞了你的文章
+	NotificationTypeLike
+	// NotificationTypeCollect 有人收藏了你的文章
+	NotificationTypeCollect
+	// NotificationTypeSystem 系统消息，不折叠
+	NotificationTypeSystem
+	// NotificationTypeComment 有人评论了你的文章
+	NotificationTypeComment
+	// NotificationTypeReply 有人回复了你在这篇文章下面的评论
+	NotificationTypeReply
+)
+
+// Notification 站内通知。同一个资源上同一种未读的互动会折叠成一条：
+// "LastActorId 和其他 ActorCnt-1 人赞了你的文章"，同一个人多次互动只算一个人
+type Notification struct {
+	Id int64
+	// Uid 接收通知的人
+	Uid   int64
+	Type  NotificationType
+	Biz   string
+	BizId int64
+	// LastActorId 最近一个触发这条通知的人
+	LastActorId int64
+	ActorCnt    int64
+	// Content 只有系统消息有
+	Content string
+	Read    bool
+	Ctime   time.Time
+	Utime   time.Time
+}
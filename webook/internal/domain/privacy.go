@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+type PrivacyRequestType uint8
+
+const (
+	PrivacyRequestTypeUnknown PrivacyRequestType = iota
+	// PrivacyRequestTypeExport 导出个人数据
+	PrivacyRequestTypeExport
+	// PrivacyRequestTypeDelete 注销账号
+	PrivacyRequestTypeDelete
+)
+
+func (t PrivacyRequestType) ToUint8() uint8 {
+	return uint8(t)
+}
+
+type PrivacyRequestStatus uint8
+
+const (
+	PrivacyRequestStatusUnknown PrivacyRequestStatus = iota
+	// PrivacyRequestStatusPending 等着被执行，注销账号的冷静期里面也是这个状态
+	PrivacyRequestStatusPending
+	// PrivacyRequestStatusRunning 执行了一部分，Stage 记录了做完的最后一步
+	PrivacyRequestStatusRunning
+	PrivacyRequestStatusDone
+	PrivacyRequestStatusCancelled
+)
+
+func (s PrivacyRequestStatus) ToUint8() uint8 {
+	return uint8(s)
+}
+
+// 导出数据的步骤，每一步的结果单独写一个文件，最后打包
+const (
+	ExportStageProfile     = "profile"
+	ExportStageArticles    = "articles"
+	ExportStageLikes       = "likes"
+	ExportStageCollections = "collections"
+	ExportStageHistory     = "history"
+	ExportStageArchive     = "archive"
+)
+
+// 注销账号的步骤。先抹掉个人信息，用户的状态变成已注销之后就登录不了了，再踢掉会话，
+// 不然踢完会话到抹掉信息之间还能重新登录
+const (
+	DeleteStageUser          = "user"
+	DeleteStageSessions      = "sessions"
+	DeleteStageArticles      = "articles"
+	DeleteStageComments      = "comments"
+	DeleteStageLikes         = "likes"
+	DeleteStageHistory       = "history"
+	DeleteStageNotifications = "notifications"
+)
+
+var privacyStages = map[PrivacyRequestType][]string{
+	PrivacyRequestTypeExport: {ExportStageProfile, ExportStageArticles, ExportStageLikes,
+		ExportStageCollections, ExportStageHistory, ExportStageArchive},
+	PrivacyRequestTypeDelete: {DeleteStageUser, DeleteStageSessions, DeleteStageArticles, DeleteStageComments,
+		DeleteStageLikes, DeleteStageHistory, DeleteStageNotifications},
+}
+
+// PrivacyRequest 导出数据和注销账号都是异步执行的，由定时任务一步一步推进，
+// 每做完一步就记下来，崩溃了下一次从没做完的那一步继续
+type PrivacyRequest struct {
+	Id     int64
+	Uid    int64
+	Type   PrivacyRequestType
+	Status PrivacyRequestStatus
+	// Stage 做完的最后一步，空字符串就是还没开始
+	Stage string
+	// ExecuteAt 什么时候开始执行，注销账号要过了冷静期
+	ExecuteAt time.Time
+	// Archive 导出的压缩包的文件名
+	Archive string
+	Ctime   time.Time
+	Utime   time.Time
+}
+
+// Stages 要执行的所有步骤
+func (r PrivacyRequest) Stages() []string {
+	return privacyStages[r.Type]
+}
+
+// NextStage 下一步要做什么，全部做完了返回空字符串
+func (r PrivacyRequest) NextStage() string {
+	stages := r.Stages()
+	idx := slices.Index(stages, r.Stage)
+	if idx+1 >= len(stages) {
+		return ""
+	}
+	return stages[idx+1]
+}
+
+// Progress 做完了几步，一共几步
+func (r PrivacyRequest) Progress() (int, int) {
+	stages := r.Stages()
+	return slices.Index(stages, r.Stage) + 1, len(stages)
+}
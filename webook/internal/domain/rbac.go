@@ -0,0 +1,80 @@
+package domain
+
+import "slices"
+
+// 权限的格式是 资源:操作
+const (
+	PermUserBan   = "user:ban"
+	PermUserMerge = "user:merge"
+	// PermUserRole 给别人授予、收回角色
+	PermUserRole        = "user:role"
+	PermArticleWithdraw = "article:withdraw"
+	PermAuditRead       = "audit:read"
+	// PermMigrator 数据迁移的切换读写模式、启停校验
+	PermMigrator = "migrator:manage"
+	// PermSmsRead 查询短信的发送状态
+	PermSmsRead = "sms:read"
+)
+
+const (
+	RoleAdmin = "admin"
+	// RoleModerator 内容审核，只能封号、下架文章
+	RoleModerator = "moderator"
+	// RoleSupport 客服，帮用户查验证码短信有没有送达
+	RoleSupport = "support"
+)
+
+// rolePermissions 角色和权限的对应关系先写死在代码里面，用户身上只存角色
+var rolePermissions = map[string][]string{
+	RoleAdmin:     {PermUserBan, PermUserMerge, PermUserRole, PermArticleWithdraw, PermAuditRead, PermMigrator, PermSmsRead},
+	RoleModerator: {PermUserBan, PermArticleWithdraw, PermAuditRead},
+	RoleSupport:   {PermSmsRead},
+}
+
+// roleLevels 管理员之间互相操作的时候比较高低，没有角色的普通用户是 0
+var roleLevels = map[string]int{
+	RoleAdmin:     3,
+	RoleModerator: 2,
+	RoleSupport:   1,
+}
+
+// RoleLevel 有多个角色的取最高的那个
+func RoleLevel(roles []string) int {
+	var res int
+	for _, role := range roles {
+		res = max(res, roleLevels[role])
+	}
+	return res
+}
+
+func ValidRole(role string) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+// PermissionsOf 多个角色的权限取并集，不认识的角色忽略
+func PermissionsOf(roles []string) []string {
+	var res []string
+	for _, role := range roles {
+		for _, perm := range rolePermissions[role] {
+			if !slices.Contains(res, perm) {
+				res = append(res, perm)
+			}
+		}
+	}
+	return res
+}
+
+type UserStatus uint8
+
+const (
+	UserStatusActive UserStatus = iota
+	// UserStatusBanned 被封禁了，不能登录
+	UserStatusBanned
+	// UserStatusDeleted 注销了，个人信息已经抹掉
+	UserStatusDeleted
+)
+
+func (s UserStatus) ToUint8() uint8 {
+	return uint8(s)
+}
@@ -0,0 +1,14 @@
+package domain
+
+// ArticleSearchResult 搜索结果，Total 是命中的总数，用来分页
+type ArticleSearchResult struct {
+	Total    int64
+	Articles []ArticleSearchHit
+}
+
+type ArticleSearchHit struct {
+	Article Article
+	Score   float64
+	// Highlights 字段名到高亮片段，命中的词用 <mark></mark> 包起来
+	Highlights map[string][]string
+}
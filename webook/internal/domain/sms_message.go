@@ -0,0 +1,69 @@
+package domain
+
+import "time"
+
+type SmsStatus uint8
+
+const (
+	SmsStatusUnknown SmsStatus = iota
+	// SmsStatusAccepted 服务商受理了，还没有收到回执
+	SmsStatusAccepted
+	// SmsStatusDelivered 回执说用户收到了
+	SmsStatusDelivered
+	// SmsStatusFailed 服务商拒绝了，或者回执说没有送达
+	SmsStatusFailed
+)
+
+func (s SmsStatus) ToUint8() uint8 {
+	return uint8(s)
+}
+
+func (s SmsStatus) String() string {
+	switch s {
+	case SmsStatusAccepted:
+		return "accepted"
+	case SmsStatusDelivered:
+		return "delivered"
+	case SmsStatusFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// SmsMessage 发给一个手机号的一条短信，一次发给多个手机号的会拆成多条
+type SmsMessage struct {
+	Id     int64
+	Vendor string
+	// MsgId 服务商那边的流水号，回执靠它对上
+	MsgId  string
+	TplId  string
+	Number string
+	Status SmsStatus
+	// Reason 失败的原因，服务商给的错误码和描述
+	Reason string
+	// DeliverTime 用户收到短信的时间，没有送达的是零值
+	DeliverTime time.Time
+	Ctime       time.Time
+	Utime       time.Time
+}
+
+// SmsReceipt 服务商的回执，不管是回调推过来的还是我们主动拉的
+type SmsReceipt struct {
+	Vendor string
+	MsgId  string
+	Number string
+	// Status 只会是 SmsStatusDelivered 或者 SmsStatusFailed
+	Status      SmsStatus
+	Reason      string
+	DeliverTime time.Time
+}
+
+// SmsMessageQuery 客服查询用，条件为零值的不参与过滤
+type SmsMessageQuery struct {
+	Number string
+	Vendor string
+	MsgId  string
+	Offset int
+	Limit  int
+}
@@ -0,0 +1,15 @@
+package domain
+
+// Totp 用户绑定的 TOTP 验证器
+type Totp struct {
+	Uid    int64
+	Secret string
+	// Enabled 扫码之后还要输一次验证码确认，确认了才生效
+	Enabled bool
+}
+
+// TotpEnrollment 绑定验证器的时候给前端的数据，URI 就是二维码的内容
+type TotpEnrollment struct {
+	Secret string
+	URI    string
+}
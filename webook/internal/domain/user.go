@@ -11,8 +11,12 @@ type User struct {
 	Nickname string
 	AboutMe  string
 	Phone    string
-	// 考虑将来可能有 DingdingInfo，同名字段会冲突，所以这里没有组合
-	WechatInfo WechatInfo
+	// Identities 绑定的第三方账号
+	Identities []Identity
 	Ctime      time.Time
 	Birthday   time.Time
+
+	// EmailVerified 收到过邮箱验证码，证明邮箱确实是这个用户的
+	EmailVerified bool
+	Status        UserStatus
 }
@@ -1,6 +0,0 @@
-package domain
-
-type WechatInfo struct {
-	OpenId  string
-	UnionId string
-}
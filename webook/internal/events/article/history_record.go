@@ -43,7 +43,7 @@ func (i *HistoryRecordConsumer) Start() error {
 	go func() {
 		er := cg.Consume(context.Background(), []string{topicReadEvent}, saramax.NewHandler[ReadEvent](i.l, i.Consume))
 		if er != nil {
-			i.l.Error("退出了消费循环异常", logger.Error(err))
+			i.l.Error("退出了消费循环异常", logger.Error(er))
 		}
 	}()
 	return err
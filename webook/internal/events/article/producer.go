@@ -2,19 +2,35 @@ package article
 
 import (
 	"encoding/json"
+	"strconv"
 
 	"github.com/IBM/sarama"
 )
 
-const topicReadEvent = "article_read_event"
+const (
+	topicReadEvent      = "article_read_event"
+	topicPublishedEvent = "article_published_event"
+)
 
 type ReadEvent struct {
 	Uid int64
 	Aid int64
 }
 
+// PublishedEvent 文章发表或者撤回，下游的搜索之类的用它来同步线上库的数据
+type PublishedEvent struct {
+	Aid     int64
+	Uid     int64
+	Title   string
+	Content string
+	// Withdrawn 为 true 说明文章被撤回了（仅自己可见），这时候只有 Aid 和 Uid
+	Withdrawn bool
+	Utime     int64
+}
+
 type Producer interface {
 	ProduceReadEvent(evt ReadEvent) error
+	ProducePublishedEvent(evt PublishedEvent) error
 }
 
 type SaramaSyncProducer struct {
@@ -37,3 +53,17 @@ func (s *SaramaSyncProducer) ProduceReadEvent(evt ReadEvent) error {
 	})
 	return err
 }
+
+func (s *SaramaSyncProducer) ProducePublishedEvent(evt PublishedEvent) error {
+	data, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+	_, _, err = s.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: topicPublishedEvent,
+		// 同一篇文章的事件落到同一个分区，保证先发表后撤回的顺序
+		Key:   sarama.StringEncoder(strconv.FormatInt(evt.Aid, 10)),
+		Value: sarama.ByteEncoder(data),
+	})
+	return err
+}
@@ -0,0 +1,121 @@
+package article
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/IBM/sarama"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/events"
+	"github.com/liupch66/basic-go/webook/internal/repository"
+	artRepo "github.com/liupch66/basic-go/webook/internal/repository/article"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
+	"github.com/liupch66/basic-go/webook/pkg/saramax"
+)
+
+var _ events.Consumer = (*SearchConsumer)(nil)
+
+// SearchConsumer 把发表和撤回的文章同步到搜索索引
+type SearchConsumer struct {
+	client   sarama.Client
+	repo     repository.ArticleSearchRepository
+	artRepo  artRepo.ArticleRepository
+	userRepo repository.UserRepository
+	l        logger.LoggerV1
+	// batchSize 重建索引的时候一批查询多少篇文章
+	batchSize int
+}
+
+func NewSearchConsumer(client sarama.Client, repo repository.ArticleSearchRepository, artRepo artRepo.ArticleRepository,
+	userRepo repository.UserRepository, l logger.LoggerV1) *SearchConsumer {
+	return &SearchConsumer{client: client, repo: repo, artRepo: artRepo, userRepo: userRepo, l: l, batchSize: 100}
+}
+
+func (s *SearchConsumer) Consume(msg *sarama.ConsumerMessage, evt PublishedEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if evt.Withdrawn {
+		return s.repo.DeleteArticle(ctx, evt.Aid)
+	}
+	// 作者昵称也要能搜，消息里面没有，这里查一下
+	author, err := s.userRepo.FindById(ctx, evt.Uid)
+	if err != nil {
+		return err
+	}
+	return s.repo.InputArticle(ctx, domain.Article{
+		Id:      evt.Aid,
+		Title:   evt.Title,
+		Content: evt.Content,
+		Author:  domain.Author{Id: evt.Uid, Name: author.Nickname},
+		Utime:   time.UnixMilli(evt.Utime),
+	})
+}
+
+func (s *SearchConsumer) Start() error {
+	// 每个实例都有自己的 bleve 索引，所以每个实例都要收到全部消息，不能共用一个消费者组
+	hostname, err := os.Hostname()
+	if err != nil {
+		return err
+	}
+	cg, err := sarama.NewConsumerGroupFromClient("search-"+hostname, s.client)
+	if err != nil {
+		return err
+	}
+	go func() {
+		er := cg.Consume(context.Background(), []string{topicPublishedEvent},
+			saramax.NewHandler[PublishedEvent](s.l, s.Consume))
+		if er != nil {
+			s.l.Error("退出了消费循环异常", logger.Error(er))
+		}
+	}()
+	// 新的消费者组从最新的消息开始消费，新实例的索引是空的，之前发表的文章要从线上库补回来。
+	// 先开始消费再重建，重建期间发表的文章也不会漏掉
+	go func() {
+		er := s.reindexIfEmpty(context.Background())
+		if er != nil {
+			s.l.Error("重建搜索索引失败", logger.Error(er))
+		}
+	}()
+	return err
+}
+
+// reindexIfEmpty 索引里面已经有数据就认为是重启，重启期间的消息消费者组会接着消费，不用重建
+func (s *SearchConsumer) reindexIfEmpty(ctx context.Context) error {
+	cnt, err := s.repo.Count(ctx)
+	if err != nil || cnt > 0 {
+		return err
+	}
+	// 同一个作者的昵称只查一次
+	names := make(map[int64]string)
+	var startId int64
+	for {
+		dbCtx, cancel := context.WithTimeout(ctx, time.Second)
+		arts, err := s.artRepo.ListPubAfterId(dbCtx, startId, s.batchSize)
+		cancel()
+		if err != nil {
+			return err
+		}
+		for _, art := range arts {
+			name, ok := names[art.Author.Id]
+			if !ok {
+				author, err := s.userRepo.FindById(ctx, art.Author.Id)
+				if err != nil {
+					return err
+				}
+				name = author.Nickname
+				names[art.Author.Id] = name
+			}
+			art.Author.Name = name
+			if err = s.repo.InputArticle(ctx, art); err != nil {
+				return err
+			}
+		}
+		if len(arts) < s.batchSize {
+			s.l.Info("重建搜索索引完成")
+			return nil
+		}
+		startId = arts[len(arts)-1].Id
+	}
+}
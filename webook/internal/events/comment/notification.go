@@ -0,0 +1,48 @@
+package comment
+
+import (
+	"context"
+	"time"
+
+	"github.com/IBM/sarama"
+
+	commentEvents "github.com/liupch66/basic-go/webook/comment/events"
+	"github.com/liupch66/basic-go/webook/internal/events"
+	"github.com/liupch66/basic-go/webook/internal/service"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
+	"github.com/liupch66/basic-go/webook/pkg/saramax"
+)
+
+var _ events.Consumer = (*NotificationConsumer)(nil)
+
+// NotificationConsumer 评论、回复之后给作者发通知
+type NotificationConsumer struct {
+	client sarama.Client
+	svc    service.NotificationService
+	l      logger.LoggerV1
+}
+
+func NewNotificationConsumer(client sarama.Client, svc service.NotificationService, l logger.LoggerV1) *NotificationConsumer {
+	return &NotificationConsumer{client: client, svc: svc, l: l}
+}
+
+func (n *NotificationConsumer) Consume(msg *sarama.ConsumerMessage, t commentEvents.CommentEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	return n.svc.OnComment(ctx, t.Uid, t.Biz, t.BizId, t.ParentUid)
+}
+
+func (n *NotificationConsumer) Start() error {
+	cg, err := sarama.NewConsumerGroupFromClient("notification", n.client)
+	if err != nil {
+		return err
+	}
+	go func() {
+		er := cg.Consume(context.Background(), []string{commentEvents.TopicCommentEvent},
+			saramax.NewHandler[commentEvents.CommentEvent](n.l, n.Consume))
+		if er != nil {
+			n.l.Error("退出了消费循环异常", logger.Error(er))
+		}
+	}()
+	return err
+}
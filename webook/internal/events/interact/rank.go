@@ -0,0 +1,51 @@
+package interact
+
+import (
+	"context"
+	"time"
+
+	"github.com/IBM/sarama"
+
+	intrEvents "github.com/liupch66/basic-go/webook/interact/events"
+	"github.com/liupch66/basic-go/webook/internal/events"
+	"github.com/liupch66/basic-go/webook/internal/service"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
+	"github.com/liupch66/basic-go/webook/pkg/saramax"
+)
+
+var _ events.Consumer = (*RankConsumer)(nil)
+
+// RankConsumer 用互动事件增量更新实时热榜
+type RankConsumer struct {
+	client sarama.Client
+	svc    *service.RealtimeRankService
+	l      logger.LoggerV1
+}
+
+func NewRankConsumer(client sarama.Client, svc *service.RealtimeRankService, l logger.LoggerV1) *RankConsumer {
+	return &RankConsumer{client: client, svc: svc, l: l}
+}
+
+func (r *RankConsumer) Consume(msg *sarama.ConsumerMessage, t intrEvents.InteractEvent) error {
+	// 热榜目前只有文章
+	if t.Biz != "article" {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	return r.svc.Incr(ctx, t.BizId, t.Action, time.UnixMilli(t.Ctime))
+}
+
+func (r *RankConsumer) Start() error {
+	cg, err := sarama.NewConsumerGroupFromClient("rank_realtime", r.client)
+	if err != nil {
+		return err
+	}
+	go func() {
+		er := cg.Consume(context.Background(), []string{intrEvents.TopicInteractEvent}, saramax.NewHandler[intrEvents.InteractEvent](r.l, r.Consume))
+		if er != nil {
+			r.l.Error("退出了消费循环异常", logger.Error(er))
+		}
+	}()
+	return err
+}
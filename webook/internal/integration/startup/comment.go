@@ -0,0 +1,23 @@
+package startup
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+
+	commentv1 "github.com/liupch66/basic-go/webook/api/proto/gen/comment/v1"
+)
+
+// commentClient 集成测试不启动评论服务，ArticleHandler 只用到了 GetCount，拿不到评论数也不影响看文章
+type commentClient struct {
+	commentv1.CommentServiceClient
+}
+
+func (c commentClient) GetCount(ctx context.Context, in *commentv1.GetCountRequest, opts ...grpc.CallOption) (*commentv1.GetCountResponse, error) {
+	return nil, errors.New("集成测试没有评论服务")
+}
+
+func InitCommentClient() commentv1.CommentServiceClient {
+	return commentClient{}
+}
@@ -0,0 +1,31 @@
+package startup
+
+import (
+	"github.com/liupch66/basic-go/webook/internal/repository"
+	"github.com/liupch66/basic-go/webook/internal/service"
+	"github.com/liupch66/basic-go/webook/internal/web/jwt"
+)
+
+// InitJwtKeyrings 集成测试用固定的密钥，不读配置
+func InitJwtKeyrings() *jwt.Keyrings {
+	newKeyring := func(kid, secret string) *jwt.Keyring {
+		kr, err := jwt.NewKeyring(jwt.KeyringConfig{
+			Current: kid,
+			Keys:    []jwt.KeyConfig{{Kid: kid, Alg: "HS512", Secret: secret}},
+		})
+		if err != nil {
+			panic(err)
+		}
+		return kr
+	}
+	return &jwt.Keyrings{
+		Access:  newKeyring("at-test", "integration-test-access-secret"),
+		Refresh: newKeyring("rt-test", "integration-test-refresh-secret"),
+		State:   newKeyring("st-test", "integration-test-state-secret"),
+	}
+}
+
+// InitRBACService 集成测试里面没有超级管理员
+func InitRBACService(repo repository.UserRepository) service.RBACService {
+	return service.NewRBACService(repo, nil)
+}
@@ -0,0 +1,40 @@
+package startup
+
+import (
+	"sync"
+
+	"github.com/liupch66/basic-go/webook/internal/repository"
+	"github.com/liupch66/basic-go/webook/internal/service"
+	"github.com/liupch66/basic-go/webook/internal/service/captcha/static"
+	"github.com/liupch66/basic-go/webook/internal/service/sms"
+	"github.com/liupch66/basic-go/webook/internal/service/sms/sandbox"
+	"github.com/liupch66/basic-go/webook/internal/service/sms/template"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
+)
+
+var smsSandbox = sync.OnceValue(func() *sandbox.Service {
+	registry, err := template.NewRegistry(template.Template{
+		Name:    "verify_code",
+		Content: "{1}为您的登录验证码，请于{2}分钟内填写，如非本人操作，请忽略本短信。",
+		Params:  []template.Param{{Name: "code", MaxLen: 6}, {Name: "minutes", MaxLen: 2}},
+	})
+	if err != nil {
+		panic(err)
+	}
+	return sandbox.NewService(registry, nil)
+})
+
+// InitSmsSandbox 集成测试里面的短信都发到同一个沙箱，测试从这里读验证码
+func InitSmsSandbox() *sandbox.Service {
+	return smsSandbox()
+}
+
+// InitSmsService 直接用沙箱，不套异步发送这些装饰器，发送完了马上就能读到
+func InitSmsService(svc *sandbox.Service) sms.Service {
+	return svc
+}
+
+// InitCodeThrottleService 集成测试不限制验证码的发送次数，频控有单独的测试
+func InitCodeThrottleService(repo repository.CodeThrottleRepository, l logger.LoggerV1) service.CodeThrottleService {
+	return service.NewCodeThrottleService(repo, static.NewService(""), nil, 0, l)
+}
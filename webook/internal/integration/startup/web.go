@@ -0,0 +1,16 @@
+package startup
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/liupch66/basic-go/webook/internal/web"
+)
+
+// InitGinServer 只注册集成测试要测的 handler，不用 ioc.InitWebServer 把所有服务都拉起来
+func InitGinServer(middlewares []gin.HandlerFunc, userHdl *web.UserHandler, articleHdl *web.ArticleHandler) *gin.Engine {
+	server := gin.Default()
+	server.Use(middlewares...)
+	userHdl.RegisterRoutes(server)
+	articleHdl.RegisterRoutes(server)
+	return server
+}
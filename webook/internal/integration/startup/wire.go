@@ -6,6 +6,8 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/wire"
 
+	interactv1 "github.com/liupch66/basic-go/webook/api/proto/gen/interact/v1"
+	"github.com/liupch66/basic-go/webook/interact/events"
 	repository2 "github.com/liupch66/basic-go/webook/interact/repository"
 	cache2 "github.com/liupch66/basic-go/webook/interact/repository/cache"
 	dao2 "github.com/liupch66/basic-go/webook/interact/repository/dao"
@@ -17,8 +19,9 @@ import (
 	"github.com/liupch66/basic-go/webook/internal/repository/dao"
 	article2 "github.com/liupch66/basic-go/webook/internal/repository/dao/article"
 	"github.com/liupch66/basic-go/webook/internal/service"
-	"github.com/liupch66/basic-go/webook/internal/service/oauth2/wechat"
+	"github.com/liupch66/basic-go/webook/internal/service/oauth2"
 	"github.com/liupch66/basic-go/webook/internal/web"
+	"github.com/liupch66/basic-go/webook/internal/web/client"
 	"github.com/liupch66/basic-go/webook/internal/web/jwt"
 	"github.com/liupch66/basic-go/webook/ioc"
 )
@@ -30,15 +33,24 @@ var (
 		repository.NewUserRepository,
 		service.NewUserService)
 	codeSvcPS = wire.NewSet(cache.NewCodeCache,
-		repository.NewCodeRepository, ioc.InitSmsService,
+		repository.NewCodeRepository, InitSmsSandbox, InitSmsService,
+		cache.NewRedisCodeThrottleCache, repository.NewCodeThrottleRepository, InitCodeThrottleService,
 		service.NewCodeService)
 	articleSvcPS = wire.NewSet(article2.NewGORMArticleDAO, cache.NewRedisArticleCache,
 		article.NewCachedArticleRepository,
 		service.NewArticleService)
-	wechatSvcPS   = wire.NewSet(ioc.InitWechatService)
+	oauth2SvcPS   = wire.NewSet(ioc.InitOAuth2Registry)
 	interactSvcPS = wire.NewSet(dao2.NewGORMInteractDAO, cache2.NewRedisInteractCache,
-		repository2.NewCachedInteractRepository,
+		repository2.NewCachedInteractRepository, events.NewSaramaSyncProducer,
 		service2.NewInteractService)
+	// 文章接口走 grpc 客户端，集成测试里面直接调用本地的 InteractService
+	interactClientPS = wire.NewSet(interactSvcPS, client.NewInteractLocalAdapter,
+		wire.Bind(new(interactv1.InteractServiceClient), new(*client.InteractLocalAdapter)))
+	twoFactorSvcPS = wire.NewSet(dao.NewGORMTwoFactorDAO, cache.NewRedisTwoFactorCache,
+		repository.NewCachedTwoFactorRepository, service.NewTwoFactorService)
+	jwtHdlPS = wire.NewSet(InitJwtKeyrings, InitRBACService,
+		wire.Bind(new(jwt.Authorizer), new(service.RBACService)),
+		jwt.NewRedisJwtHandler)
 )
 
 func InitUserSvc() service.UserService {
@@ -48,22 +60,22 @@ func InitUserSvc() service.UserService {
 
 func InitCodeSvc() service.CodeService {
 	wire.Build(thirdPS, codeSvcPS)
-	return service.NewCodeService(nil, nil)
+	return service.NewCodeService(nil, nil, nil)
 }
 
-func InitWechatSvc() wechat.Service {
-	wire.Build(thirdPS, wechatSvcPS)
-	return wechat.NewWechatService("", "", nil)
+func InitOAuth2Registry() *oauth2.Registry {
+	wire.Build(thirdPS, oauth2SvcPS)
+	return oauth2.NewRegistry()
 }
 
 func InitInteractService() service2.InteractService {
 	wire.Build(thirdPS, interactSvcPS)
-	return service2.NewInteractService(nil, nil)
+	return service2.NewInteractService(nil, nil, nil)
 }
 
 // 这里注入 artDAO 是为了方便集成测试 GORM DB 和 MongoDB 实现的文章储存
 func InitArticleHandler(artDAO article2.ArticleDAO) *web.ArticleHandler {
-	wire.Build(thirdPS, interactSvcPS, userSvcPS,
+	wire.Build(thirdPS, interactClientPS, userSvcPS, InitCommentClient,
 		cache.NewRedisArticleCache,
 		article.NewCachedArticleRepository,
 		service.NewArticleService,
@@ -73,13 +85,12 @@ func InitArticleHandler(artDAO article2.ArticleDAO) *web.ArticleHandler {
 
 func InitWebServer() *gin.Engine {
 	wire.Build(
-		thirdPS, userSvcPS, codeSvcPS, wechatSvcPS,
+		thirdPS, userSvcPS, codeSvcPS, twoFactorSvcPS, jwtHdlPS,
 		article2.NewGORMArticleDAO,
 		// article2.NewMongoDBDAO, InitMongoDB, // 方便切换成 mongoDB
-		jwt.NewRedisJwtHandler, ioc.InitWechatHandlerConfig,
 		ioc.InitMiddlewares,
-		web.NewUserHandler, web.NewOAuth2WechatHandler, InitArticleHandler, // 这里注入 InitArticleHandler 是为了方便测试
-		ioc.InitWebServer,
+		web.NewUserHandler, InitArticleHandler, // 这里注入 InitArticleHandler 是为了方便测试
+		InitGinServer,
 	)
 	return &gin.Engine{}
 }
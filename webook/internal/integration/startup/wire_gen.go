@@ -9,6 +9,8 @@ package startup
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/wire"
+	"github.com/liupch66/basic-go/webook/api/proto/gen/interact/v1"
+	"github.com/liupch66/basic-go/webook/interact/events"
 	repository2 "github.com/liupch66/basic-go/webook/interact/repository"
 	cache2 "github.com/liupch66/basic-go/webook/interact/repository/cache"
 	dao2 "github.com/liupch66/basic-go/webook/interact/repository/dao"
@@ -20,8 +22,9 @@ import (
 	"github.com/liupch66/basic-go/webook/internal/repository/dao"
 	"github.com/liupch66/basic-go/webook/internal/repository/dao/article"
 	"github.com/liupch66/basic-go/webook/internal/service"
-	"github.com/liupch66/basic-go/webook/internal/service/oauth2/wechat"
+	"github.com/liupch66/basic-go/webook/internal/service/oauth2"
 	"github.com/liupch66/basic-go/webook/internal/web"
+	"github.com/liupch66/basic-go/webook/internal/web/client"
 	"github.com/liupch66/basic-go/webook/internal/web/jwt"
 	"github.com/liupch66/basic-go/webook/ioc"
 )
@@ -43,15 +46,20 @@ func InitCodeSvc() service.CodeService {
 	cmdable := InitRedis()
 	codeCache := cache.NewCodeCache(cmdable)
 	codeRepository := repository.NewCodeRepository(codeCache)
-	smsService := ioc.InitSmsService(cmdable)
-	codeService := service.NewCodeService(codeRepository, smsService)
+	sandboxService := InitSmsSandbox()
+	smsService := InitSmsService(sandboxService)
+	codeThrottleCache := cache.NewRedisCodeThrottleCache(cmdable)
+	codeThrottleRepository := repository.NewCodeThrottleRepository(codeThrottleCache)
+	loggerV1 := InitLog()
+	codeThrottleService := InitCodeThrottleService(codeThrottleRepository, loggerV1)
+	codeService := service.NewCodeService(codeRepository, smsService, codeThrottleService)
 	return codeService
 }
 
-func InitWechatSvc() wechat.Service {
+func InitOAuth2Registry() *oauth2.Registry {
 	loggerV1 := InitLog()
-	wechatService := ioc.InitWechatService(loggerV1)
-	return wechatService
+	registry := ioc.InitOAuth2Registry(loggerV1)
+	return registry
 }
 
 func InitInteractService() service2.InteractService {
@@ -61,7 +69,10 @@ func InitInteractService() service2.InteractService {
 	interactCache := cache2.NewRedisInteractCache(cmdable)
 	loggerV1 := InitLog()
 	interactRepository := repository2.NewCachedInteractRepository(interactDAO, interactCache, loggerV1)
-	interactService := service2.NewInteractService(interactRepository, loggerV1)
+	client := InitKafka()
+	syncProducer := InitSyncProducer(client)
+	producer := events.NewSaramaSyncProducer(syncProducer)
+	interactService := service2.NewInteractService(interactRepository, producer, loggerV1)
 	return interactService
 }
 
@@ -75,39 +86,49 @@ func InitArticleHandler(artDAO article.ArticleDAO) *web.ArticleHandler {
 	articleCache := cache.NewRedisArticleCache(cmdable)
 	loggerV1 := InitLog()
 	articleRepository := article2.NewCachedArticleRepository(userRepository, artDAO, articleCache, loggerV1)
-	client := InitKafka()
-	syncProducer := InitSyncProducer(client)
+	saramaClient := InitKafka()
+	syncProducer := InitSyncProducer(saramaClient)
 	producer := article3.NewSaramaSyncProducer(syncProducer)
 	articleService := service.NewArticleService(articleRepository, loggerV1, producer)
 	interactDAO := dao2.NewGORMInteractDAO(gormDB)
 	interactCache := cache2.NewRedisInteractCache(cmdable)
 	interactRepository := repository2.NewCachedInteractRepository(interactDAO, interactCache, loggerV1)
-	interactService := service2.NewInteractService(interactRepository, loggerV1)
-	articleHandler := web.NewArticleHandler(articleService, interactService, loggerV1)
+	eventsProducer := events.NewSaramaSyncProducer(syncProducer)
+	interactService := service2.NewInteractService(interactRepository, eventsProducer, loggerV1)
+	interactLocalAdapter := client.NewInteractLocalAdapter(interactService)
+	commentServiceClient := InitCommentClient()
+	articleHandler := web.NewArticleHandler(articleService, interactLocalAdapter, commentServiceClient, loggerV1)
 	return articleHandler
 }
 
 func InitWebServer() *gin.Engine {
 	loggerV1 := InitLog()
 	cmdable := InitRedis()
-	handler := jwt.NewRedisJwtHandler(cmdable)
-	v := ioc.InitMiddlewares(loggerV1, cmdable, handler)
+	keyrings := InitJwtKeyrings()
 	gormDB := InitTestDB()
 	userDAO := dao.NewUserDAO(gormDB)
 	userCache := cache.NewUserCache(cmdable)
 	userRepository := repository.NewUserRepository(userDAO, userCache)
+	rbacService := InitRBACService(userRepository)
+	handler := jwt.NewRedisJwtHandler(cmdable, keyrings, rbacService)
+	v := ioc.InitMiddlewares(loggerV1, cmdable, handler)
 	userService := service.NewUserService(userRepository, loggerV1)
 	codeCache := cache.NewCodeCache(cmdable)
 	codeRepository := repository.NewCodeRepository(codeCache)
-	smsService := ioc.InitSmsService(cmdable)
-	codeService := service.NewCodeService(codeRepository, smsService)
-	userHandler := web.NewUserHandler(userService, codeService, handler)
-	wechatService := ioc.InitWechatService(loggerV1)
-	wechatHandlerConfig := ioc.InitWechatHandlerConfig()
-	oAuth2WechatHandler := web.NewOAuth2WechatHandler(wechatService, userService, wechatHandlerConfig, handler)
+	sandboxService := InitSmsSandbox()
+	smsService := InitSmsService(sandboxService)
+	codeThrottleCache := cache.NewRedisCodeThrottleCache(cmdable)
+	codeThrottleRepository := repository.NewCodeThrottleRepository(codeThrottleCache)
+	codeThrottleService := InitCodeThrottleService(codeThrottleRepository, loggerV1)
+	codeService := service.NewCodeService(codeRepository, smsService, codeThrottleService)
+	twoFactorDAO := dao.NewGORMTwoFactorDAO(gormDB)
+	twoFactorCache := cache.NewRedisTwoFactorCache(cmdable)
+	twoFactorRepository := repository.NewCachedTwoFactorRepository(twoFactorDAO, twoFactorCache)
+	twoFactorService := service.NewTwoFactorService(twoFactorRepository, loggerV1)
+	userHandler := web.NewUserHandler(userService, codeService, handler, twoFactorService)
 	articleDAO := article.NewGORMArticleDAO(gormDB)
 	articleHandler := InitArticleHandler(articleDAO)
-	engine := ioc.InitWebServer(v, userHandler, oAuth2WechatHandler, articleHandler)
+	engine := InitGinServer(v, userHandler, articleHandler)
 	return engine
 }
 
@@ -117,8 +138,12 @@ var (
 	thirdPS = wire.NewSet(InitTestDB, InitRedis, InitLog,
 		InitKafka, InitSyncProducer, article3.NewSaramaSyncProducer)
 	userSvcPS     = wire.NewSet(dao.NewUserDAO, cache.NewUserCache, repository.NewUserRepository, service.NewUserService)
-	codeSvcPS     = wire.NewSet(cache.NewCodeCache, repository.NewCodeRepository, ioc.InitSmsService, service.NewCodeService)
+	codeSvcPS     = wire.NewSet(cache.NewCodeCache, repository.NewCodeRepository, InitSmsSandbox, InitSmsService, cache.NewRedisCodeThrottleCache, repository.NewCodeThrottleRepository, InitCodeThrottleService, service.NewCodeService)
 	articleSvcPS  = wire.NewSet(article.NewGORMArticleDAO, cache.NewRedisArticleCache, article2.NewCachedArticleRepository, service.NewArticleService)
-	wechatSvcPS   = wire.NewSet(ioc.InitWechatService)
-	interactSvcPS = wire.NewSet(dao2.NewGORMInteractDAO, cache2.NewRedisInteractCache, repository2.NewCachedInteractRepository, service2.NewInteractService)
+	oauth2SvcPS   = wire.NewSet(ioc.InitOAuth2Registry)
+	interactSvcPS = wire.NewSet(dao2.NewGORMInteractDAO, cache2.NewRedisInteractCache, repository2.NewCachedInteractRepository, events.NewSaramaSyncProducer, service2.NewInteractService)
+	// 文章接口走 grpc 客户端，集成测试里面直接调用本地的 InteractService
+	interactClientPS = wire.NewSet(interactSvcPS, client.NewInteractLocalAdapter, wire.Bind(new(interactv1.InteractServiceClient), new(*client.InteractLocalAdapter)))
+	twoFactorSvcPS   = wire.NewSet(dao.NewGORMTwoFactorDAO, cache.NewRedisTwoFactorCache, repository.NewCachedTwoFactorRepository, service.NewTwoFactorService)
+	jwtHdlPS         = wire.NewSet(InitJwtKeyrings, InitRBACService, wire.Bind(new(jwt.Authorizer), new(service.RBACService)), jwt.NewRedisJwtHandler)
 )
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -13,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/liupch66/basic-go/webook/internal/integration/startup"
+	"github.com/liupch66/basic-go/webook/internal/service/sms/sandbox"
 	"github.com/liupch66/basic-go/webook/internal/web"
 	"github.com/liupch66/basic-go/webook/ioc"
 )
@@ -119,3 +121,82 @@ func TestUserHandler_SendSmsLoginCode(t *testing.T) {
 		})
 	}
 }
+
+// TestUserHandler_LoginSms 从发验证码到用验证码登录，验证码从短信沙箱里面读
+func TestUserHandler_LoginSms(t *testing.T) {
+	server := startup.InitWebServer()
+	smsSandbox := startup.InitSmsSandbox()
+	cmd := ioc.InitRedis()
+	const phone = "15512345679"
+	post := func(path string, body string) (*httptest.ResponseRecorder, web.Result) {
+		req, err := http.NewRequest(http.MethodPost, path, bytes.NewBufferString(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+		server.ServeHTTP(resp, req)
+		var res web.Result
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+		return resp, res
+	}
+
+	testCases := []struct {
+		name string
+
+		before func(t *testing.T)
+
+		// code 为空的话用沙箱里面收到的验证码
+		code string
+
+		expectedSendRes  web.Result
+		expectedLoginRes web.Result
+		expectedLogin    bool
+	}{
+		{
+			name:             "登录成功",
+			before:           func(t *testing.T) {},
+			expectedSendRes:  web.Result{Code: 4, Msg: "发送成功"},
+			expectedLoginRes: web.Result{Code: 4, Msg: "验证码验证成功"},
+			expectedLogin:    true,
+		},
+		{
+			name:             "验证码错误",
+			before:           func(t *testing.T) {},
+			code:             "abcdef",
+			expectedSendRes:  web.Result{Code: 4, Msg: "发送成功"},
+			expectedLoginRes: web.Result{Code: 4, Msg: "验证码错误"},
+		},
+		{
+			name: "短信服务商出错",
+			before: func(t *testing.T) {
+				smsSandbox.Inject(sandbox.Fault{Err: errors.New("服务商错误"), Times: 1})
+			},
+			expectedSendRes: web.Result{Code: 5, Msg: "系统错误"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			smsSandbox.Reset()
+			cmd.Del(context.Background(), "phone_code:login:"+phone)
+			defer cmd.Del(context.Background(), "phone_code:login:"+phone)
+			tc.before(t)
+
+			_, res := post("/users/login_sms/code/send", `{"phone": "`+phone+`"}`)
+			assert.Equal(t, tc.expectedSendRes, res)
+			msg, ok := smsSandbox.Last(phone)
+			if tc.expectedSendRes.Code != 4 {
+				assert.False(t, ok)
+				return
+			}
+			require.True(t, ok)
+			code := tc.code
+			if code == "" {
+				code = msg.Params[0]
+			}
+
+			resp, res := post("/users/login_sms", `{"phone": "`+phone+`", "code": "`+code+`"}`)
+			assert.Equal(t, tc.expectedLoginRes, res)
+			assert.Equal(t, tc.expectedLogin, resp.Header().Get("x-jwt-token") != "")
+		})
+	}
+}
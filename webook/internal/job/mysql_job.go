@@ -51,6 +51,8 @@ type Scheduler struct {
 	svc       service.CronJobService
 	l         logger.LoggerV1
 	dbTimeout time.Duration
+	// interval 没有抢占到任务的时候，隔多久再试
+	interval time.Duration
 	// 用于控制并发数量。它提供了一个轻量级的计数信号量，用于限制资源的访问并协调多个 goroutine 之间的并发执行。
 	limiter *semaphore.Weighted
 }
@@ -61,6 +63,7 @@ func NewScheduler(svc service.CronJobService, l logger.LoggerV1) *Scheduler {
 		svc:       svc,
 		l:         l,
 		dbTimeout: time.Second,
+		interval:  time.Second,
 		limiter:   semaphore.NewWeighted(200),
 	}
 }
@@ -84,9 +87,11 @@ func (s *Scheduler) Start(ctx context.Context) error {
 		j, err := s.svc.Preempt(dbCtx)
 		cancel()
 		if err != nil {
-			// 没有抢占到，进入下一个循环，也可以睡眠一段时间
+			// 没有抢占到，睡眠一段时间再进入下一个循环，不然没有任务的时候会一直查数据库
 			// 也可以进一步细分错误，可以容忍就继续，否则就 return
 			s.l.Error("抢占任务失败", logger.Error(err))
+			s.limiter.Release(1)
+			s.sleep(ctx)
 			continue
 		}
 		// 执行任务
@@ -96,6 +101,7 @@ func (s *Scheduler) Start(ctx context.Context) error {
 			// DEBUG 的时候最好中断，线上就继续
 			s.l.Error("未找到对应的执行器", logger.String("executor: ", j.Executor))
 			j.CancelFunc()
+			s.limiter.Release(1)
 			continue
 		}
 		// 单独开一个 goroutine 异步执行，不要阻塞主调度循环，进入下一个循环
@@ -119,3 +125,10 @@ func (s *Scheduler) Start(ctx context.Context) error {
 		}()
 	}
 }
+
+func (s *Scheduler) sleep(ctx context.Context) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(s.interval):
+	}
+}
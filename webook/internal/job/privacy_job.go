@@ -0,0 +1,53 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/liupch66/basic-go/webook/internal/service"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
+)
+
+// PrivacyRequestExecutor 执行到了时间的数据导出和账号注销申请
+// 要在数据库里面插入一条 executor 为 privacy_request 的任务，比如每分钟执行一次：0 * * * * ?
+type PrivacyRequestExecutor struct {
+	svc       service.PrivacyService
+	l         logger.LoggerV1
+	batchSize int
+	// timeout 一个申请的执行时间，导出数据要查很多东西，给长一点
+	timeout time.Duration
+}
+
+func NewPrivacyRequestExecutor(svc service.PrivacyService, l logger.LoggerV1) *PrivacyRequestExecutor {
+	return &PrivacyRequestExecutor{
+		svc:       svc,
+		l:         l,
+		batchSize: 20,
+		timeout:   time.Minute,
+	}
+}
+
+func (e *PrivacyRequestExecutor) Name() string {
+	return "privacy_request"
+}
+
+// Exec 每一批只处理 batchSize 个申请，剩下的等下一次调度。
+// 失败了的申请进度已经保存了，下一次调度从失败的那一步接着做
+func (e *PrivacyRequestExecutor) Exec(ctx context.Context, j CronJob) error {
+	dbCtx, cancel := context.WithTimeout(ctx, time.Second)
+	rs, err := e.svc.ListDue(dbCtx, time.Now(), e.batchSize)
+	cancel()
+	if err != nil {
+		return err
+	}
+	for _, r := range rs {
+		pCtx, cancel := context.WithTimeout(ctx, e.timeout)
+		err = e.svc.Process(pCtx, r)
+		cancel()
+		if err != nil {
+			e.l.Error("执行隐私申请失败", logger.Error(err),
+				logger.Int64("id", r.Id), logger.Int64("uid", r.Uid), logger.Int64("jid", j.Id))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/liupch66/basic-go/webook/internal/service"
+)
+
+// RealtimeRankJob 把实时分数刷到实时榜上。
+// 每个节点都跑，顺带刷新自己的本地缓存，结果也都一样，所以不需要分布式锁
+type RealtimeRankJob struct {
+	svc     *service.RealtimeRankService
+	timeout time.Duration
+}
+
+func NewRealtimeRankJob(svc *service.RealtimeRankService, timeout time.Duration) *RealtimeRankJob {
+	return &RealtimeRankJob{svc: svc, timeout: timeout}
+}
+
+func (r *RealtimeRankJob) Name() string {
+	return "rank_realtime"
+}
+
+func (r *RealtimeRankJob) Run() error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+	return r.svc.Refresh(ctx)
+}
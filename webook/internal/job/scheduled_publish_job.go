@@ -0,0 +1,66 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/liupch66/basic-go/webook/internal/service"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
+)
+
+// ScheduledPublishExecutor 发表已经到时间的定时文章
+// 要在数据库里面插入一条 executor 为 scheduled_publish 的任务，比如每分钟执行一次：0 * * * * ?
+type ScheduledPublishExecutor struct {
+	svc       service.ArticleService
+	l         logger.LoggerV1
+	batchSize int
+	timeout   time.Duration
+}
+
+func NewScheduledPublishExecutor(svc service.ArticleService, l logger.LoggerV1) *ScheduledPublishExecutor {
+	return &ScheduledPublishExecutor{
+		svc:       svc,
+		l:         l,
+		batchSize: 100,
+		timeout:   time.Second,
+	}
+}
+
+func (e *ScheduledPublishExecutor) Name() string {
+	return "scheduled_publish"
+}
+
+// Exec 重复执行是安全的：发表成功之后状态就变成了 published，下一次就查不到了
+// 同一时刻只会有一个节点抢占到这个任务，所以也不会并发发表同一篇文章
+func (e *ScheduledPublishExecutor) Exec(ctx context.Context, j CronJob) error {
+	now := time.Now()
+	// 发表成功的文章会从查询结果里面消失，所以 offset 只需要跳过失败的
+	offset := 0
+	for {
+		dbCtx, cancel := context.WithTimeout(ctx, e.timeout)
+		arts, err := e.svc.ListDueScheduled(dbCtx, now, offset, e.batchSize)
+		cancel()
+		if err != nil {
+			return err
+		}
+		for _, art := range arts {
+			pubCtx, cancel := context.WithTimeout(ctx, e.timeout)
+			err = e.svc.PublishScheduled(pubCtx, art, now)
+			cancel()
+			if errors.Is(err, service.ErrArticleNotScheduled) {
+				// 查出来之后被作者改期或者取消了，也不会再出现在查询结果里面
+				continue
+			}
+			if err != nil {
+				// 失败了也继续发表下一篇，等下一次调度再重试
+				e.l.Error("定时发表文章失败", logger.Error(err),
+					logger.Int64("article_id", art.Id), logger.Int64("jid", j.Id))
+				offset++
+			}
+		}
+		if len(arts) < e.batchSize {
+			return nil
+		}
+	}
+}
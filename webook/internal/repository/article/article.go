@@ -14,6 +14,12 @@ import (
 	"github.com/liupch66/basic-go/webook/pkg/logger"
 )
 
+var (
+	// ErrArticleNotFound 默认用的是 GORM 的实现
+	ErrArticleNotFound     = gorm.ErrRecordNotFound
+	ErrArticleNotScheduled = dao.ErrNotScheduled
+)
+
 type ArticleRepository interface {
 	Create(ctx context.Context, art domain.Article) (int64, error)
 	Update(ctx context.Context, art domain.Article) error
@@ -24,6 +30,17 @@ type ArticleRepository interface {
 	GetById(ctx context.Context, id int64) (domain.Article, error)
 	GetPublishedById(ctx context.Context, id int64) (domain.Article, error)
 	ListPub(ctx context.Context, start time.Time, offset int, limit int) ([]domain.Article, error)
+	ListPubByAuthors(ctx context.Context, authorIds []int64, before time.Time, beforeId int64, limit int) ([]domain.Article, error)
+	ListPubByIds(ctx context.Context, ids []int64) ([]domain.Article, error)
+	ListPubAfterId(ctx context.Context, id int64, limit int) ([]domain.Article, error)
+	ListScheduled(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error)
+	ListDueScheduled(ctx context.Context, now time.Time, offset int, limit int) ([]domain.Article, error)
+	UpdateSchedule(ctx context.Context, id int64, uid int64, status domain.ArticleStatus, publishAt time.Time) error
+	PublishScheduled(ctx context.Context, art domain.Article, now time.Time) error
+	ListRevisions(ctx context.Context, artId int64, uid int64, offset int, limit int) ([]domain.ArticleRevision, error)
+	GetRevision(ctx context.Context, artId int64, version int64, uid int64) (domain.ArticleRevision, error)
+	// TransferAuthor 合并账号用，fromUid 的文章都转给 toUid
+	TransferAuthor(ctx context.Context, fromUid, toUid int64) error
 }
 
 type CachedArticleRepository struct {
@@ -51,8 +68,10 @@ func (repo *CachedArticleRepository) entityToDomain(ae dao.Article) domain.Artic
 		Content: ae.Content,
 		Author:  domain.Author{Id: ae.AuthorId},
 		Status:  domain.ArticleStatus(ae.Status),
-		Ctime:   time.UnixMilli(ae.Ctime),
-		Utime:   time.UnixMilli(ae.Utime),
+		// PublishAt 为 0 的时候保持零值，不要变成 1970 年
+		PublishAt: repo.toTime(ae.PublishAt),
+		Ctime:     time.UnixMilli(ae.Ctime),
+		Utime:     time.UnixMilli(ae.Utime),
 	}
 }
 
@@ -63,9 +82,25 @@ func (repo *CachedArticleRepository) domainToEntity(a domain.Article) dao.Articl
 		Content:  a.Content,
 		AuthorId: a.Author.Id,
 		Status:   a.Status.ToUnit8(),
-		Ctime:    a.Ctime.UnixMilli(),
-		Utime:    a.Utime.UnixMilli(),
+		// 零值的 UnixMilli 是个很大的负数，这里存 0
+		PublishAt: repo.toMilli(a.PublishAt),
+		Ctime:     a.Ctime.UnixMilli(),
+		Utime:     a.Utime.UnixMilli(),
+	}
+}
+
+func (repo *CachedArticleRepository) toTime(milli int64) time.Time {
+	if milli == 0 {
+		return time.Time{}
 	}
+	return time.UnixMilli(milli)
+}
+
+func (repo *CachedArticleRepository) toMilli(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
 }
 
 func (repo *CachedArticleRepository) Create(ctx context.Context, art domain.Article) (int64, error) {
@@ -248,3 +283,108 @@ func (repo *CachedArticleRepository) ListPub(ctx context.Context, start time.Tim
 		return repo.entityToDomain(src)
 	}), nil
 }
+
+func (repo *CachedArticleRepository) ListPubByAuthors(ctx context.Context, authorIds []int64, before time.Time, beforeId int64, limit int) ([]domain.Article, error) {
+	arts, err := repo.dao.ListPubByAuthors(ctx, authorIds, before, beforeId, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(arts, func(idx int, src dao.PublishedArticle) domain.Article {
+		return repo.entityToDomain(dao.Article(src))
+	}), nil
+}
+
+func (repo *CachedArticleRepository) ListPubByIds(ctx context.Context, ids []int64) ([]domain.Article, error) {
+	arts, err := repo.dao.ListPubByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(arts, func(idx int, src dao.PublishedArticle) domain.Article {
+		return repo.entityToDomain(dao.Article(src))
+	}), nil
+}
+
+func (repo *CachedArticleRepository) ListPubAfterId(ctx context.Context, id int64, limit int) ([]domain.Article, error) {
+	arts, err := repo.dao.ListPubAfterId(ctx, id, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(arts, func(idx int, src dao.PublishedArticle) domain.Article {
+		return repo.entityToDomain(dao.Article(src))
+	}), nil
+}
+
+func (repo *CachedArticleRepository) ListScheduled(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error) {
+	arts, err := repo.dao.ListScheduled(ctx, uid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(arts, func(idx int, src dao.Article) domain.Article {
+		return repo.entityToDomain(src)
+	}), nil
+}
+
+func (repo *CachedArticleRepository) ListDueScheduled(ctx context.Context, now time.Time, offset int, limit int) ([]domain.Article, error) {
+	arts, err := repo.dao.ListDueScheduled(ctx, now, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(arts, func(idx int, src dao.Article) domain.Article {
+		return repo.entityToDomain(src)
+	}), nil
+}
+
+func (repo *CachedArticleRepository) UpdateSchedule(ctx context.Context, id int64, uid int64, status domain.ArticleStatus, publishAt time.Time) error {
+	defer func() {
+		// 列表页里面有状态，清空缓存
+		repo.cache.DeleteFirstPage(ctx, uid)
+	}()
+	return repo.dao.UpdateSchedule(ctx, id, uid, status.ToUnit8(), repo.toMilli(publishAt))
+}
+
+func (repo *CachedArticleRepository) PublishScheduled(ctx context.Context, art domain.Article, now time.Time) error {
+	defer func() {
+		repo.cache.DeleteFirstPage(ctx, art.Author.Id)
+	}()
+	return repo.dao.PublishScheduled(ctx, repo.domainToEntity(art), now)
+}
+
+func (repo *CachedArticleRepository) revisionToDomain(r dao.ArticleRevision) domain.ArticleRevision {
+	return domain.ArticleRevision{
+		Id:        r.Id,
+		ArticleId: r.ArticleId,
+		Version:   r.Version,
+		Title:     r.Title,
+		Content:   r.Content,
+		Author:    domain.Author{Id: r.AuthorId},
+		Status:    domain.ArticleStatus(r.Status),
+		Ctime:     time.UnixMilli(r.Ctime),
+	}
+}
+
+func (repo *CachedArticleRepository) ListRevisions(ctx context.Context, artId int64, uid int64, offset int, limit int) ([]domain.ArticleRevision, error) {
+	revs, err := repo.dao.ListRevisions(ctx, artId, uid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(revs, func(idx int, src dao.ArticleRevision) domain.ArticleRevision {
+		return repo.revisionToDomain(src)
+	}), nil
+}
+
+func (repo *CachedArticleRepository) GetRevision(ctx context.Context, artId int64, version int64, uid int64) (domain.ArticleRevision, error) {
+	rev, err := repo.dao.GetRevision(ctx, artId, version, uid)
+	if err != nil {
+		return domain.ArticleRevision{}, err
+	}
+	return repo.revisionToDomain(rev), nil
+}
+
+func (repo *CachedArticleRepository) TransferAuthor(ctx context.Context, fromUid, toUid int64) error {
+	defer func() {
+		// 两个人的列表页都变了
+		repo.cache.DeleteFirstPage(ctx, fromUid)
+		repo.cache.DeleteFirstPage(ctx, toUid)
+	}()
+	return repo.dao.TransferAuthor(ctx, fromUid, toUid)
+}
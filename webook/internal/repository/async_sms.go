@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/ecodeclub/ekit/sqlx"
 
@@ -11,11 +12,14 @@ import (
 
 var ErrWaitingSMSNotFound = dao.ErrWaitingSMSNotFound
 
+//go:generate mockgen -package=repomocks -source=async_sms.go -destination=mocks/async_sms_mock.go AsyncSmsRepository
 type AsyncSmsRepository interface {
 	// Add 添加一个异步 SMS 记录,叫做 Create 或者 Insert 也可以
 	Add(ctx context.Context, s domain.AsyncSms) error
-	PreemptWaitingSMS(ctx context.Context) (domain.AsyncSms, error)
-	ReportScheduleResult(ctx context.Context, id int64, success bool) error
+	// PreemptWaitingSMS 抢占一个到了发送时间的短信，lease 时间内别的节点抢不到
+	PreemptWaitingSMS(ctx context.Context, lease time.Duration) (domain.AsyncSms, error)
+	// ReportScheduleResult 失败了并且还可以重试的话，nextTime 的时候再发
+	ReportScheduleResult(ctx context.Context, id int64, success bool, nextTime time.Time) error
 }
 
 type asyncSmsRepository struct {
@@ -37,11 +41,12 @@ func (a *asyncSmsRepository) Add(ctx context.Context, s domain.AsyncSms) error {
 			Valid: true,
 		},
 		RetryMax: s.RetryMax,
+		NextTime: s.NextTime.UnixMilli(),
 	})
 }
 
-func (a *asyncSmsRepository) PreemptWaitingSMS(ctx context.Context) (domain.AsyncSms, error) {
-	as, err := a.dao.GetWaitingSMS(ctx)
+func (a *asyncSmsRepository) PreemptWaitingSMS(ctx context.Context, lease time.Duration) (domain.AsyncSms, error) {
+	as, err := a.dao.GetWaitingSMS(ctx, lease)
 	if err != nil {
 		return domain.AsyncSms{}, err
 	}
@@ -51,12 +56,14 @@ func (a *asyncSmsRepository) PreemptWaitingSMS(ctx context.Context) (domain.Asyn
 		Numbers:  as.Config.Val.Numbers,
 		Args:     as.Config.Val.Args,
 		RetryMax: as.RetryMax,
+		RetryCnt: as.RetryCnt,
+		NextTime: time.UnixMilli(as.NextTime),
 	}, nil
 }
 
-func (a *asyncSmsRepository) ReportScheduleResult(ctx context.Context, id int64, success bool) error {
+func (a *asyncSmsRepository) ReportScheduleResult(ctx context.Context, id int64, success bool, nextTime time.Time) error {
 	if success {
 		return a.dao.MarkSuccess(ctx, id)
 	}
-	return a.dao.MarkFailed(ctx, id)
+	return a.dao.MarkFailed(ctx, id, nextTime.UnixMilli())
 }
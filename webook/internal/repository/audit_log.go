@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/repository/dao"
+)
+
+//go:generate mockgen -package=repomocks -source=audit_log.go -destination=mocks/audit_log_mock.go AuditLogRepository
+type AuditLogRepository interface {
+	Create(ctx context.Context, al domain.AuditLog) error
+	List(ctx context.Context, q domain.AuditLogQuery) ([]domain.AuditLog, error)
+}
+
+type auditLogRepository struct {
+	dao dao.AuditLogDAO
+}
+
+func NewAuditLogRepository(dao dao.AuditLogDAO) AuditLogRepository {
+	return &auditLogRepository{dao: dao}
+}
+
+func (repo *auditLogRepository) Create(ctx context.Context, al domain.AuditLog) error {
+	return repo.dao.Insert(ctx, dao.AuditLog{
+		Operator:   al.Operator,
+		Action:     al.Action,
+		TargetType: al.TargetType,
+		TargetId:   al.TargetId,
+		Detail:     al.Detail,
+	})
+}
+
+func (repo *auditLogRepository) List(ctx context.Context, q domain.AuditLogQuery) ([]domain.AuditLog, error) {
+	als, err := repo.dao.Find(ctx, dao.AuditLog{
+		Operator:   q.Operator,
+		TargetType: q.TargetType,
+		TargetId:   q.TargetId,
+	}, q.Offset, q.Limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(als, func(idx int, src dao.AuditLog) domain.AuditLog {
+		return domain.AuditLog{
+			Id:         src.Id,
+			Operator:   src.Operator,
+			Action:     src.Action,
+			TargetType: src.TargetType,
+			TargetId:   src.TargetId,
+			Detail:     src.Detail,
+			Ctime:      time.UnixMilli(src.Ctime),
+		}
+	}), nil
+}
@@ -5,6 +5,7 @@ import (
 	_ "embed"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
@@ -22,8 +23,13 @@ var (
 	ErrCodeVerifyExpired = errors.New("验证码已过期")
 )
 
+// codeResendInterval 验证码一分钟只能发一次，和 set_code.lua 里面的 540 秒对应
+const codeResendInterval = time.Minute
+
 type CodeCache interface {
 	Set(ctx context.Context, biz, phone, code string) error
+	// Sendable 一分钟之内发过的话返回 false。只是提前判断，真正的限制还是在 Set 里面
+	Sendable(ctx context.Context, biz, phone string) (bool, error)
 	Verify(ctx context.Context, biz, phone, inputCode string) (bool, error)
 }
 
@@ -57,6 +63,15 @@ func (cache *RedisCodeCache) Set(ctx context.Context, biz, phone, code string) e
 	}
 }
 
+func (cache *RedisCodeCache) Sendable(ctx context.Context, biz, phone string) (bool, error) {
+	// 没有发过验证码的话 ttl 是负数
+	ttl, err := cache.cmd.TTL(ctx, cache.key(biz, phone)).Result()
+	if err != nil {
+		return false, err
+	}
+	return ttl <= 10*time.Minute-codeResendInterval, nil
+}
+
 func (cache *RedisCodeCache) Verify(ctx context.Context, biz, phone, inputCode string) (bool, error) {
 	res, err := cache.cmd.Eval(ctx, luaVerifyCode, []string{cache.key(biz, phone)}, inputCode).Int()
 	if err != nil {
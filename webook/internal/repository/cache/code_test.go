@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
@@ -100,3 +101,69 @@ func TestRedisCodeCache_Set(t *testing.T) {
 		})
 	}
 }
+
+func TestRedisCodeCache_Sendable(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) redis.Cmdable
+
+		want        bool
+		expectedErr error
+	}{
+		{
+			name: "没有发过验证码",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				res := redis.NewDurationCmd(context.Background(), time.Second)
+				res.SetVal(-2)
+				cmd.EXPECT().TTL(context.Background(), "phone_code:login:15512345678").Return(res)
+				return cmd
+			},
+			want: true,
+		},
+		{
+			name: "发送超过一分钟了",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				res := redis.NewDurationCmd(context.Background(), time.Second)
+				res.SetVal(9 * time.Minute)
+				cmd.EXPECT().TTL(context.Background(), "phone_code:login:15512345678").Return(res)
+				return cmd
+			},
+			want: true,
+		},
+		{
+			name: "一分钟之内发过",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				res := redis.NewDurationCmd(context.Background(), time.Second)
+				res.SetVal(9*time.Minute + 30*time.Second)
+				cmd.EXPECT().TTL(context.Background(), "phone_code:login:15512345678").Return(res)
+				return cmd
+			},
+			want: false,
+		},
+		{
+			name: "redis 出错",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				res := redis.NewDurationCmd(context.Background(), time.Second)
+				res.SetErr(errors.New("redis 出错"))
+				cmd.EXPECT().TTL(context.Background(), "phone_code:login:15512345678").Return(res)
+				return cmd
+			},
+			expectedErr: errors.New("redis 出错"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			codeCache := NewCodeCache(tc.mock(ctrl))
+			ok, err := codeCache.Sendable(context.Background(), "login", "15512345678")
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.want, ok)
+		})
+	}
+}
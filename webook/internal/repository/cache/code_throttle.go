@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+)
+
+//go:embed lua/code_throttle.lua
+var luaCodeThrottle string
+
+type CodeThrottleCache interface {
+	// Acquire 所有规则都没有超过就计数一次。captchaPassed 为 true 的话只检查拒绝的阈值
+	Acquire(ctx context.Context, biz string, rules []domain.CodeThrottleRule, captchaPassed bool) (domain.CodeThrottleResult, error)
+}
+
+// RedisCodeThrottleCache 固定窗口计数，key 里面带上窗口的开始时间，过期时间就是窗口的长度
+type RedisCodeThrottleCache struct {
+	cmd redis.Cmdable
+	now func() time.Time
+}
+
+func NewRedisCodeThrottleCache(cmd redis.Cmdable) CodeThrottleCache {
+	return &RedisCodeThrottleCache{cmd: cmd, now: time.Now}
+}
+
+// key 设置为 code_throttle:$biz:$dim:$value:$窗口长度:$第几个窗口，预算是所有业务共用的，不带 biz
+func (cache *RedisCodeThrottleCache) key(biz string, rule domain.CodeThrottleRule, now time.Time) string {
+	// 窗口按照本地时间对齐，一天的窗口从零点开始
+	_, offset := now.Zone()
+	size := int64(rule.Window / time.Second)
+	idx := (now.Unix() + int64(offset)) / size
+	if rule.Dim == domain.CodeDimBudget {
+		return fmt.Sprintf("code_throttle:%s:%d:%d", rule.Dim, size, idx)
+	}
+	return fmt.Sprintf("code_throttle:%s:%s:%s:%d:%d", biz, rule.Dim, rule.Value, size, idx)
+}
+
+func (cache *RedisCodeThrottleCache) Acquire(ctx context.Context, biz string, rules []domain.CodeThrottleRule,
+	captchaPassed bool) (domain.CodeThrottleResult, error) {
+	if len(rules) == 0 {
+		return domain.CodeThrottleResult{}, nil
+	}
+	now := cache.now()
+	keys := make([]string, 0, len(rules))
+	args := make([]any, 0, len(rules)*3+1)
+	passed := "0"
+	if captchaPassed {
+		passed = "1"
+	}
+	args = append(args, passed)
+	for _, rule := range rules {
+		keys = append(keys, cache.key(biz, rule, now))
+		args = append(args, rule.Captcha, rule.Reject, int64(rule.Window/time.Second))
+	}
+	res, err := cache.cmd.Eval(ctx, luaCodeThrottle, keys, args...).Int64Slice()
+	if err != nil {
+		return domain.CodeThrottleResult{}, err
+	}
+	if len(res) != 2 || res[1] < 0 || res[1] > int64(len(rules)) {
+		return domain.CodeThrottleResult{}, ErrUnknownForCode
+	}
+	switch res[0] {
+	case 0:
+		return domain.CodeThrottleResult{Decision: domain.CodeThrottleAllow}, nil
+	case 1:
+		return domain.CodeThrottleResult{Decision: domain.CodeThrottleCaptcha, Rule: rules[res[1]-1]}, nil
+	case 2:
+		return domain.CodeThrottleResult{Decision: domain.CodeThrottleReject, Rule: rules[res[1]-1]}, nil
+	default:
+		return domain.CodeThrottleResult{}, ErrUnknownForCode
+	}
+}
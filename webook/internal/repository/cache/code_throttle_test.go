@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/repository/cache/redismocks"
+)
+
+func TestRedisCodeThrottleCache_Acquire(t *testing.T) {
+	// 东八区 2024-01-01 08:30，按本地时间是第 19723 天
+	now := time.Date(2024, 1, 1, 8, 30, 0, 0, time.FixedZone("CST", 8*3600))
+	rules := []domain.CodeThrottleRule{
+		{Dim: domain.CodeDimPhone, Value: "15512345678", Window: time.Hour, Captcha: 5, Reject: 10},
+		{Dim: domain.CodeDimBudget, Window: 24 * time.Hour, Reject: 1000},
+	}
+	keys := []string{"code_throttle:login:phone:15512345678:3600:473360", "code_throttle:budget:86400:19723"}
+	testCases := []struct {
+		name    string
+		val     []any
+		passed  bool
+		args    []any
+		want    domain.CodeThrottleResult
+		wantErr error
+	}{
+		{
+			name: "允许",
+			val:  []any{int64(0), int64(0)},
+			args: []any{"0", int64(5), int64(10), int64(3600), int64(0), int64(1000), int64(86400)},
+			want: domain.CodeThrottleResult{Decision: domain.CodeThrottleAllow},
+		},
+		{
+			name:   "通过了图形验证码，预算用完了",
+			val:    []any{int64(2), int64(2)},
+			passed: true,
+			args:   []any{"1", int64(5), int64(10), int64(3600), int64(0), int64(1000), int64(86400)},
+			want:   domain.CodeThrottleResult{Decision: domain.CodeThrottleReject, Rule: rules[1]},
+		},
+		{
+			name: "要图形验证码",
+			val:  []any{int64(1), int64(1)},
+			args: []any{"0", int64(5), int64(10), int64(3600), int64(0), int64(1000), int64(86400)},
+			want: domain.CodeThrottleResult{Decision: domain.CodeThrottleCaptcha, Rule: rules[0]},
+		},
+		{
+			name:    "返回值不对",
+			val:     []any{int64(1), int64(3)},
+			args:    []any{"0", int64(5), int64(10), int64(3600), int64(0), int64(1000), int64(86400)},
+			wantErr: ErrUnknownForCode,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			cmd := redismocks.NewMockCmdable(ctrl)
+			res := redis.NewCmd(context.Background())
+			res.SetVal(tc.val)
+			cmd.EXPECT().Eval(gomock.Any(), luaCodeThrottle, keys, tc.args...).Return(res)
+			c := &RedisCodeThrottleCache{cmd: cmd, now: func() time.Time { return now }}
+			got, err := c.Acquire(context.Background(), "login", rules, tc.passed)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+)
+
+type HistoryRecordCache interface {
+	// MarkRead 标记用户读过了这个资源，返回 false 说明在去重窗口内已经读过了
+	MarkRead(ctx context.Context, uid int64, biz string, bizId int64) (bool, error)
+	// UnmarkRead 删除历史记录之后再读也要记下来
+	UnmarkRead(ctx context.Context, uid int64, biz string, bizId int64) error
+	// UnmarkReads 清空历史记录的时候批量删除标记
+	UnmarkReads(ctx context.Context, uid int64, rs []domain.HistoryRecord) error
+}
+
+type RedisHistoryRecordCache struct {
+	cmd redis.Cmdable
+	// 去重窗口，窗口内重复阅读不会再写数据库
+	window time.Duration
+}
+
+func NewRedisHistoryRecordCache(cmd redis.Cmdable) HistoryRecordCache {
+	return &RedisHistoryRecordCache{cmd: cmd, window: 10 * time.Minute}
+}
+
+func (cache *RedisHistoryRecordCache) key(uid int64, biz string, bizId int64) string {
+	return fmt.Sprintf("history:read:%d:%s:%d", uid, biz, bizId)
+}
+
+func (cache *RedisHistoryRecordCache) MarkRead(ctx context.Context, uid int64, biz string, bizId int64) (bool, error) {
+	return cache.cmd.SetNX(ctx, cache.key(uid, biz, bizId), 1, cache.window).Result()
+}
+
+func (cache *RedisHistoryRecordCache) UnmarkRead(ctx context.Context, uid int64, biz string, bizId int64) error {
+	return cache.cmd.Del(ctx, cache.key(uid, biz, bizId)).Err()
+}
+
+func (cache *RedisHistoryRecordCache) UnmarkReads(ctx context.Context, uid int64, rs []domain.HistoryRecord) error {
+	if len(rs) == 0 {
+		return nil
+	}
+	// 不用一个 DEL 删多个 key，集群模式下这些 key 不一定在同一个槽
+	_, err := cache.cmd.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, r := range rs {
+			pipe.Del(ctx, cache.key(uid, r.Biz, r.BizId))
+		}
+		return nil
+	})
+	return err
+}
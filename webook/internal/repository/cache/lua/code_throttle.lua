@@ -0,0 +1,27 @@
+-- 验证码发送的频控，检查所有计数器，都没有超过才一起加一
+-- KEYS 是计数器；ARGV[1] 是否已经通过了图形验证码，之后每个计数器三个参数：图形验证码阈值、拒绝阈值、过期时间（秒）
+-- 返回 {决定, 第几个计数器}，决定 0 允许，1 要图形验证码，2 拒绝
+local passed = ARGV[1] == "1"
+local counts = {}
+for i, key in ipairs(KEYS) do
+    -- get 不存在的 key 返回的是 false
+    counts[i] = tonumber(redis.call("get", key) or "0")
+    local reject = tonumber(ARGV[(i - 1) * 3 + 3])
+    if reject > 0 and counts[i] >= reject then
+        return { 2, i }
+    end
+end
+if not passed then
+    for i = 1, #KEYS do
+        local captcha = tonumber(ARGV[(i - 1) * 3 + 2])
+        if captcha > 0 and counts[i] >= captcha then
+            return { 1, i }
+        end
+    end
+end
+for i, key in ipairs(KEYS) do
+    if redis.call("incr", key) == 1 then
+        redis.call("expire", key, tonumber(ARGV[(i - 1) * 3 + 4]))
+    end
+end
+return { 0, 0 }
@@ -0,0 +1,20 @@
+-- KEYS[1] 临时合并用的 key，KEYS[2...] 时间窗口里面的各个桶
+-- ARGV[1] 要取多少个正分的，ARGV[2...] 各个桶的权重，和 KEYS[2...] 一一对应
+-- 返回分数最高的 ARGV[1] 个，加上所有不是正分的，member 和 score 交替排列
+local dst = KEYS[1]
+local args = {'ZUNIONSTORE', dst, #KEYS - 1}
+for i = 2, #KEYS do
+    table.insert(args, KEYS[i])
+end
+table.insert(args, 'WEIGHTS')
+for i = 2, #ARGV do
+    table.insert(args, ARGV[i])
+end
+redis.call(unpack(args))
+local res = redis.call('ZREVRANGEBYSCORE', dst, '+inf', '(0', 'WITHSCORES', 'LIMIT', 0, tonumber(ARGV[1]))
+local neg = redis.call('ZRANGEBYSCORE', dst, '-inf', '0', 'WITHSCORES')
+redis.call('DEL', dst)
+for i = 1, #neg do
+    table.insert(res, neg[i])
+end
+return res
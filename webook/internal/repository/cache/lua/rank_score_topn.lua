@@ -0,0 +1,16 @@
+-- KEYS[1] 临时合并用的 key，KEYS[2...] 时间窗口里面的各个桶
+-- ARGV[1] 要取多少个，ARGV[2...] 各个桶的权重，和 KEYS[2...] 一一对应
+local dst = KEYS[1]
+local args = {'ZUNIONSTORE', dst, #KEYS - 1}
+for i = 2, #KEYS do
+    table.insert(args, KEYS[i])
+end
+table.insert(args, 'WEIGHTS')
+for i = 2, #ARGV do
+    table.insert(args, ARGV[i])
+end
+redis.call(unpack(args))
+-- 取消点赞之类的会把分数扣成负数，只要正分的
+local res = redis.call('ZREVRANGEBYSCORE', dst, '+inf', '(0', 'LIMIT', 0, tonumber(ARGV[1]))
+redis.call('DEL', dst)
+return res
@@ -0,0 +1,13 @@
+-- 两步验证的每一次尝试都先扣一次次数，和 verify_code.lua 一样限制猜的次数
+-- 返回 uid；-1 次数用完了；-3 已经过期
+local key = KEYS[1]
+local uid = redis.call("HGET", key, "uid")
+if uid == false then
+    return -3
+end
+local cnt = tonumber(redis.call("HGET", key, "cnt"))
+if cnt <= 0 then
+    return -1
+end
+redis.call("HINCRBY", key, "cnt", -1)
+return tonumber(uid)
@@ -0,0 +1,42 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// NotificationCache 只缓存未读数，列表页每次打开都要查它
+type NotificationCache interface {
+	GetUnreadCnt(ctx context.Context, uid int64) (int64, error)
+	SetUnreadCnt(ctx context.Context, uid int64, cnt int64) error
+	// DelUnreadCnt 有新通知或者标记已读之后删掉，下一次查询再回写
+	DelUnreadCnt(ctx context.Context, uid int64) error
+}
+
+type RedisNotificationCache struct {
+	cmd        redis.Cmdable
+	expiration time.Duration
+}
+
+func NewRedisNotificationCache(cmd redis.Cmdable) NotificationCache {
+	return &RedisNotificationCache{cmd: cmd, expiration: 15 * time.Minute}
+}
+
+func (cache *RedisNotificationCache) key(uid int64) string {
+	return fmt.Sprintf("notification:unread:%d", uid)
+}
+
+func (cache *RedisNotificationCache) GetUnreadCnt(ctx context.Context, uid int64) (int64, error) {
+	return cache.cmd.Get(ctx, cache.key(uid)).Int64()
+}
+
+func (cache *RedisNotificationCache) SetUnreadCnt(ctx context.Context, uid int64, cnt int64) error {
+	return cache.cmd.Set(ctx, cache.key(uid), cnt, cache.expiration).Err()
+}
+
+func (cache *RedisNotificationCache) DelUnreadCnt(ctx context.Context, uid int64) error {
+	return cache.cmd.Del(ctx, cache.key(uid)).Err()
+}
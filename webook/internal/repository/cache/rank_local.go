@@ -3,50 +3,52 @@ package cache
 import (
 	"context"
 	"errors"
+	"sync"
 	"time"
 
-	"github.com/ecodeclub/ekit/syncx/atomicx"
-
 	"github.com/liupch66/basic-go/webook/internal/domain"
 )
 
+// RankLocalCache 每个榜单一份，榜单数量是固定的几个，所以直接用 map
 type RankLocalCache struct {
 	// 可以考虑直接使用 uber 的，或者 SDK 自带的
-	topN       *atomicx.Value[[]domain.Article]
-	ddl        *atomicx.Value[time.Time]
+	// 把 arts 和 ddl 放到同一个 item 里面整体替换，避免两个原子操作之间的并发问题
+	boards     sync.Map
 	expiration time.Duration
 }
 
 func NewRankLocalCache() *RankLocalCache {
 	return &RankLocalCache{
-		topN:       atomicx.NewValue[[]domain.Article](),
-		ddl:        atomicx.NewValueOf(time.Now()),
 		expiration: 10 * time.Minute,
 	}
 }
 
-func (r *RankLocalCache) Set(ctx context.Context, arts []domain.Article) error {
-	// 可能会有并发问题，因为是两个原子操作。解决：原子操作下面的 item
-	r.topN.Store(arts)
-	ddl := time.Now().Add(r.expiration)
-	r.ddl.Store(ddl)
+func (r *RankLocalCache) Set(ctx context.Context, board string, arts []domain.Article) error {
+	r.boards.Store(board, item{arts: arts, ddl: time.Now().Add(r.expiration)})
 	return nil
 	// 也可以按照 id => article 提前缓存好
 }
 
-func (r *RankLocalCache) Get(ctx context.Context) ([]domain.Article, error) {
-	ddl := r.ddl.Load()
-	arts := r.topN.Load()
-	// 初始化后 arts 是空的，这里做个判断
-	if ddl.Before(time.Now()) || len(arts) == 0 {
+func (r *RankLocalCache) Get(ctx context.Context, board string) ([]domain.Article, error) {
+	val, ok := r.boards.Load(board)
+	if !ok {
+		return nil, errors.New("本地缓存未命中")
+	}
+	it := val.(item)
+	// 过期了或者还没算出来
+	if it.ddl.Before(time.Now()) || len(it.arts) == 0 {
 		return nil, errors.New("本地缓存未命中")
 	}
-	return arts, nil
+	return it.arts, nil
 }
 
-func (r *RankLocalCache) ForceGet(ctx context.Context) ([]domain.Article, error) {
-	arts := r.topN.Load()
-	return arts, nil
+// ForceGet 不管有没有过期都返回，Redis 不可用的时候兜底
+func (r *RankLocalCache) ForceGet(ctx context.Context, board string) ([]domain.Article, error) {
+	val, ok := r.boards.Load(board)
+	if !ok {
+		return nil, nil
+	}
+	return val.(item).arts, nil
 }
 
 type item struct {
@@ -0,0 +1,154 @@
+package cache
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	//go:embed lua/rank_score_topn.lua
+	luaRankScoreTopN string
+	//go:embed lua/rank_score_reconcile.lua
+	luaRankScoreReconcile string
+)
+
+// RankScoreCache 实时热榜的分数，按照时间分桶放在 Redis 的 ZSET 里面，
+// 查询的时候把窗口里面的桶按照衰减的权重合并起来，太老的桶自然过期，这样就是一个滑动窗口
+type RankScoreCache interface {
+	IncrScore(ctx context.Context, aid int64, delta float64, t time.Time) error
+	// TopN 取 now 所在窗口里面合并分数最高的 n 篇文章的 id，分数从高到低
+	TopN(ctx context.Context, now time.Time, n int) ([]int64, error)
+	// Remove 把文章从窗口里面所有的桶删掉，比如文章被撤回了
+	Remove(ctx context.Context, aids []int64, now time.Time) error
+	// Scores 对账用，返回合并分数最高的 n 篇文章，加上所有合并分数不是正数的文章，以及它们合并之后的分数
+	Scores(ctx context.Context, now time.Time, n int) (map[int64]float64, error)
+	// Reset 把文章在窗口里面的分数重置成 score，全部放到 now 所在的桶
+	Reset(ctx context.Context, aid int64, score float64, now time.Time) error
+}
+
+type RedisRankScoreCache struct {
+	cmd redis.Cmdable
+	// bucket 每个桶的时间跨度，buckets 窗口里面有多少个桶
+	bucket  time.Duration
+	buckets int
+	// decay 每老一个桶，分数乘以一次 decay
+	decay float64
+}
+
+func NewRedisRankScoreCache(cmd redis.Cmdable) *RedisRankScoreCache {
+	// 一个小时一个桶，窗口是最近一天，每过一个小时分数打八折
+	return &RedisRankScoreCache{cmd: cmd, bucket: time.Hour, buckets: 24, decay: 0.8}
+}
+
+func (cache *RedisRankScoreCache) IncrScore(ctx context.Context, aid int64, delta float64, t time.Time) error {
+	key := cache.key(cache.bucketOf(t))
+	pipe := cache.cmd.TxPipeline()
+	pipe.ZIncrBy(ctx, key, delta, strconv.FormatInt(aid, 10))
+	// 多留一个桶的时间，保证整个窗口的桶都还在
+	pipe.Expire(ctx, key, cache.bucket*time.Duration(cache.buckets+1))
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (cache *RedisRankScoreCache) TopN(ctx context.Context, now time.Time, n int) ([]int64, error) {
+	keys, args := cache.windowArgs(now, n)
+	vals, err := cache.cmd.Eval(ctx, luaRankScoreTopN, keys, args...).StringSlice()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]int64, 0, len(vals))
+	for _, val := range vals {
+		aid, er := strconv.ParseInt(val, 10, 64)
+		if er != nil {
+			return nil, er
+		}
+		res = append(res, aid)
+	}
+	return res, nil
+}
+
+func (cache *RedisRankScoreCache) Remove(ctx context.Context, aids []int64, now time.Time) error {
+	if len(aids) == 0 {
+		return nil
+	}
+	members := make([]any, 0, len(aids))
+	for _, aid := range aids {
+		members = append(members, strconv.FormatInt(aid, 10))
+	}
+	cur := cache.bucketOf(now)
+	pipe := cache.cmd.Pipeline()
+	for i := 0; i < cache.buckets; i++ {
+		pipe.ZRem(ctx, cache.key(cur-int64(i)), members...)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (cache *RedisRankScoreCache) Scores(ctx context.Context, now time.Time, n int) (map[int64]float64, error) {
+	keys, args := cache.windowArgs(now, n)
+	vals, err := cache.cmd.Eval(ctx, luaRankScoreReconcile, keys, args...).StringSlice()
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]float64, len(vals)/2)
+	for i := 0; i+1 < len(vals); i += 2 {
+		aid, er := strconv.ParseInt(vals[i], 10, 64)
+		if er != nil {
+			return nil, er
+		}
+		score, er := strconv.ParseFloat(vals[i+1], 64)
+		if er != nil {
+			return nil, er
+		}
+		res[aid] = score
+	}
+	return res, nil
+}
+
+func (cache *RedisRankScoreCache) Reset(ctx context.Context, aid int64, score float64, now time.Time) error {
+	member := strconv.FormatInt(aid, 10)
+	cur := cache.bucketOf(now)
+	pipe := cache.cmd.TxPipeline()
+	for i := 0; i < cache.buckets; i++ {
+		pipe.ZRem(ctx, cache.key(cur-int64(i)), member)
+	}
+	if score != 0 {
+		key := cache.key(cur)
+		pipe.ZAdd(ctx, key, redis.Z{Score: score, Member: member})
+		pipe.Expire(ctx, key, cache.bucket*time.Duration(cache.buckets+1))
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// windowArgs now 所在窗口的 lua 参数，KEYS 是临时 key 加上各个桶，ARGV 是 n 加上各个桶的权重
+func (cache *RedisRankScoreCache) windowArgs(now time.Time, n int) ([]string, []any) {
+	cur := cache.bucketOf(now)
+	keys := make([]string, 0, cache.buckets+1)
+	keys = append(keys, cache.key(-1))
+	args := make([]any, 0, cache.buckets+1)
+	args = append(args, n)
+	for i := 0; i < cache.buckets; i++ {
+		keys = append(keys, cache.key(cur-int64(i)))
+		args = append(args, math.Pow(cache.decay, float64(i)))
+	}
+	return keys, args
+}
+
+func (cache *RedisRankScoreCache) bucketOf(t time.Time) int64 {
+	return t.UnixMilli() / cache.bucket.Milliseconds()
+}
+
+// key 用 hash tag 保证所有的桶在 Redis Cluster 的同一个 slot，lua 脚本才能一起操作。-1 是合并用的临时 key
+func (cache *RedisRankScoreCache) key(bucket int64) string {
+	if bucket < 0 {
+		return "rank:score:{realtime}:union"
+	}
+	return fmt.Sprintf("rank:score:{realtime}:%d", bucket)
+}
@@ -0,0 +1,80 @@
+package cache
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	//go:embed lua/two_factor_attempt.lua
+	luaTwoFactorAttempt string
+
+	ErrTwoFactorExpired        = errors.New("两步验证已过期")
+	ErrTwoFactorAttemptTooMany = errors.New("两步验证尝试次数太多")
+)
+
+const (
+	// twoFactorPendingExpiration 输完密码之后要在这段时间里面完成两步验证
+	twoFactorPendingExpiration = time.Minute * 5
+	twoFactorMaxAttempts       = 5
+)
+
+type TwoFactorCache interface {
+	// SetPending 密码校验通过之后，记录 token 对应的用户，等着输验证码
+	SetPending(ctx context.Context, token string, uid int64) error
+	// Attempt 扣掉一次尝试次数，返回 token 对应的用户
+	Attempt(ctx context.Context, token string) (int64, error)
+	DelPending(ctx context.Context, token string) error
+	// MarkTotpUsed 同一个时间窗口的验证码只能用一次，返回 false 说明已经用过了
+	MarkTotpUsed(ctx context.Context, uid int64, step int64) (bool, error)
+}
+
+type RedisTwoFactorCache struct {
+	cmd redis.Cmdable
+}
+
+func NewRedisTwoFactorCache(cmd redis.Cmdable) TwoFactorCache {
+	return &RedisTwoFactorCache{cmd: cmd}
+}
+
+func (c *RedisTwoFactorCache) SetPending(ctx context.Context, token string, uid int64) error {
+	key := c.pendingKey(token)
+	pipe := c.cmd.TxPipeline()
+	pipe.HSet(ctx, key, "uid", uid, "cnt", twoFactorMaxAttempts)
+	pipe.Expire(ctx, key, twoFactorPendingExpiration)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (c *RedisTwoFactorCache) Attempt(ctx context.Context, token string) (int64, error) {
+	res, err := c.cmd.Eval(ctx, luaTwoFactorAttempt, []string{c.pendingKey(token)}).Int64()
+	if err != nil {
+		return 0, err
+	}
+	switch res {
+	case -3:
+		return 0, ErrTwoFactorExpired
+	case -1:
+		return 0, ErrTwoFactorAttemptTooMany
+	default:
+		return res, nil
+	}
+}
+
+func (c *RedisTwoFactorCache) DelPending(ctx context.Context, token string) error {
+	return c.cmd.Del(ctx, c.pendingKey(token)).Err()
+}
+
+func (c *RedisTwoFactorCache) MarkTotpUsed(ctx context.Context, uid int64, step int64) (bool, error) {
+	// 允许前后一个时间窗口的偏差，一个验证码最多 90 秒有效
+	return c.cmd.SetNX(ctx, fmt.Sprintf("users:2fa:totp_used:%d:%d", uid, step), "", time.Second*90).Result()
+}
+
+func (c *RedisTwoFactorCache) pendingKey(token string) string {
+	return fmt.Sprintf("users:2fa:pending:%s", token)
+}
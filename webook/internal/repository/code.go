@@ -15,6 +15,8 @@ var (
 //go:generate mockgen -package=repomocks -source=code.go -destination=mocks/code_mock.go CodeRepository
 type CodeRepository interface {
 	Store(ctx context.Context, biz, phone, code string) error
+	// Sendable 一分钟之内发过的话返回 false
+	Sendable(ctx context.Context, biz, phone string) (bool, error)
 	Verify(ctx context.Context, biz, phone, inputCode string) (bool, error)
 }
 
@@ -32,6 +34,10 @@ func (repo *CachedCodeRepository) Store(ctx context.Context, biz, phone, code st
 	return repo.cache.Set(ctx, biz, phone, code)
 }
 
+func (repo *CachedCodeRepository) Sendable(ctx context.Context, biz, phone string) (bool, error) {
+	return repo.cache.Sendable(ctx, biz, phone)
+}
+
 func (repo *CachedCodeRepository) Verify(ctx context.Context, biz, phone, inputCode string) (bool, error) {
 	return repo.cache.Verify(ctx, biz, phone, inputCode)
 }
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/repository/cache"
+)
+
+//go:generate mockgen -package=repomocks -source=code_throttle.go -destination=mocks/code_throttle_mock.go CodeThrottleRepository
+type CodeThrottleRepository interface {
+	// Acquire 所有规则都没有超过就计数一次，超过了的话返回是哪条规则
+	Acquire(ctx context.Context, biz string, rules []domain.CodeThrottleRule, captchaPassed bool) (domain.CodeThrottleResult, error)
+}
+
+type CachedCodeThrottleRepository struct {
+	cache cache.CodeThrottleCache
+}
+
+func NewCodeThrottleRepository(cache cache.CodeThrottleCache) CodeThrottleRepository {
+	return &CachedCodeThrottleRepository{cache: cache}
+}
+
+func (repo *CachedCodeThrottleRepository) Acquire(ctx context.Context, biz string, rules []domain.CodeThrottleRule,
+	captchaPassed bool) (domain.CodeThrottleResult, error) {
+	return repo.cache.Acquire(ctx, biz, rules, captchaPassed)
+}
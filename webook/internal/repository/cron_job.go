@@ -30,8 +30,12 @@ func (repo *PreemptCronJobRepository) Preempt(ctx context.Context) (domain.CronJ
 		return domain.CronJob{}, err
 	}
 	return domain.CronJob{
-		Id:  j.Id,
-		Cfg: j.Cfg,
+		Id:             j.Id,
+		Name:           j.Name,
+		Cfg:            j.Cfg,
+		CronExpression: j.CronExpression,
+		Executor:       j.Executor,
+		NextTime:       time.UnixMilli(j.NextTime),
 	}, nil
 }
 
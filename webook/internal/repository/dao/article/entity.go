@@ -8,10 +8,27 @@ type Article struct {
 	// 在数据库中，BLOB（Binary Large Object）是一个数据类型，用来存储大块的二进制数据。
 	Content  string `gorm:"type:blob" bson:"content,omitempty"`
 	AuthorId int64  `gorm:"index" bson:"author_id,omitempty"`
-	Status   uint8  `bson:"status,omitempty"`
-	Ctime    int64  `bson:"ctime,omitempty"`
-	Utime    int64  `bson:"utime,omitempty"`
+	// 定时任务查询条件：WHERE status = ? AND publish_at <= ?
+	Status uint8 `gorm:"index:status_publish_at" bson:"status,omitempty"`
+	// PublishAt 定时发表时间，毫秒数，0 表示没有定时
+	PublishAt int64 `gorm:"index:status_publish_at" bson:"publish_at,omitempty"`
+	Ctime     int64 `bson:"ctime,omitempty"`
+	Utime     int64 `bson:"utime,omitempty"`
 }
 
 // PublishedArticle 衍生类型,自定义类型;还可以考虑组合和重新定义表结构
 type PublishedArticle Article
+
+// ArticleRevision 文章历史版本表，只插入不更新，用来查看历史、对比和回滚
+type ArticleRevision struct {
+	Id int64 `gorm:"primaryKey,autoIncrement" bson:"id,omitempty"`
+	// 查询条件：WHERE article_id = ? ORDER BY version DESC
+	ArticleId int64  `gorm:"uniqueIndex:article_id_version" bson:"article_id,omitempty"`
+	Version   int64  `gorm:"uniqueIndex:article_id_version" bson:"version,omitempty"`
+	Title     string `gorm:"type:varchar(1024)" bson:"title,omitempty"`
+	Content   string `gorm:"type:blob" bson:"content,omitempty"`
+	// 冗余作者 ID，校验只能看自己文章的历史版本
+	AuthorId int64 `bson:"author_id,omitempty"`
+	Status   uint8 `bson:"status,omitempty"`
+	Ctime    int64 `bson:"ctime,omitempty"`
+}
@@ -6,6 +6,13 @@ import (
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+)
+
+var (
+	statusScheduled = domain.ArticleStatusScheduled.ToUnit8()
+	statusPublished = domain.ArticleStatusPublished.ToUnit8()
 )
 
 type GORMArticleDAO struct {
@@ -20,27 +27,56 @@ func (dao *GORMArticleDAO) Insert(ctx context.Context, art Article) (int64, erro
 	now := time.Now().UnixMilli()
 	art.Ctime = now
 	art.Utime = now
-	err := dao.db.WithContext(ctx).Create(&art).Error
+	// 在 Sync 里面调用的时候 db 本身就是事务，这里会变成 SAVEPOINT
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&art).Error; err != nil {
+			return err
+		}
+		return dao.insertRevision(tx, art)
+	})
 	return art.Id, err
 }
 
 func (dao *GORMArticleDAO) UpdateById(ctx context.Context, art Article) error {
 	art.Utime = time.Now().UnixMilli()
-	// 防止修改别人的帖子, where id = ? ----> where id = ? and author_id = ?
-	res := dao.db.WithContext(ctx).Model(&art).Where("author_id = ?", art.AuthorId).Updates(map[string]any{
-		"title":   art.Title,
-		"content": art.Content,
-		"status":  art.Status,
-		"utime":   art.Utime,
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 防止修改别人的帖子, where id = ? ----> where id = ? and author_id = ?
+		res := tx.Model(&art).Where("author_id = ?", art.AuthorId).Updates(map[string]any{
+			"title":      art.Title,
+			"content":    art.Content,
+			"status":     art.Status,
+			"publish_at": art.PublishAt,
+			"utime":      art.Utime,
+		})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			// return fmt.Errorf("更新失败,可能是创作者非法, article_id: %d, user_id: %d", art.Id, art.AuthorId)
+			return ErrPossibleIncorrectAuthor
+		}
+		return dao.insertRevision(tx, art)
 	})
-	if res.Error != nil {
-		return res.Error
-	}
-	if res.RowsAffected == 0 {
-		// return fmt.Errorf("更新失败,可能是创作者非法, article_id: %d, user_id: %d", art.Id, art.AuthorId)
-		return ErrPossibleIncorrectAuthor
+}
+
+// insertRevision 记录一个历史版本，版本号取当前最大版本号加一
+// 同一篇文章的并发保存会撞上 article_id_version 唯一索引，整个事务回滚，前端重试就可以
+func (dao *GORMArticleDAO) insertRevision(tx *gorm.DB, art Article) error {
+	var version int64
+	err := tx.Model(&ArticleRevision{}).Select("COALESCE(MAX(version), 0)").
+		Where("article_id = ?", art.Id).Scan(&version).Error
+	if err != nil {
+		return err
 	}
-	return nil
+	return tx.Create(&ArticleRevision{
+		ArticleId: art.Id,
+		Version:   version + 1,
+		Title:     art.Title,
+		Content:   art.Content,
+		AuthorId:  art.AuthorId,
+		Status:    art.Status,
+		Ctime:     art.Utime,
+	}).Error
 }
 
 // Upsert INSERT OR UPDATE
@@ -130,3 +166,117 @@ func (dao *GORMArticleDAO) ListPub(ctx context.Context, start time.Time, offset
 		Offset(offset).Limit(limit).Find(&res).Error
 	return res, err
 }
+
+func (dao *GORMArticleDAO) ListPubByAuthors(ctx context.Context, authorIds []int64, before time.Time, beforeId int64, limit int) ([]PublishedArticle, error) {
+	var res []PublishedArticle
+	utime := before.UnixMilli()
+	// 仅自己可见的文章线上表里面也有，要排除掉
+	err := dao.db.WithContext(ctx).
+		Where("author_id IN ? AND status = ? AND (utime < ? OR (utime = ? AND id < ?))",
+			authorIds, statusPublished, utime, utime, beforeId).
+		Order("utime DESC, id DESC").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMArticleDAO) ListPubByIds(ctx context.Context, ids []int64) ([]PublishedArticle, error) {
+	var res []PublishedArticle
+	err := dao.db.WithContext(ctx).Where("id IN ? AND status = ?", ids, statusPublished).Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMArticleDAO) ListPubAfterId(ctx context.Context, id int64, limit int) ([]PublishedArticle, error) {
+	var res []PublishedArticle
+	// 用 id 翻页而不是 offset，遍历全表的时候不会越翻越慢
+	err := dao.db.WithContext(ctx).Where("id > ? AND status = ?", id, statusPublished).
+		Order("id ASC").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMArticleDAO) ListScheduled(ctx context.Context, authorId int64, offset int, limit int) ([]Article, error) {
+	var res []Article
+	err := dao.db.WithContext(ctx).Where("author_id = ? AND status = ?", authorId, statusScheduled).
+		Order("publish_at ASC").Offset(offset).Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMArticleDAO) ListDueScheduled(ctx context.Context, now time.Time, offset int, limit int) ([]Article, error) {
+	var res []Article
+	err := dao.db.WithContext(ctx).Where("status = ? AND publish_at <= ?", statusScheduled, now.UnixMilli()).
+		Order("publish_at ASC").Offset(offset).Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMArticleDAO) UpdateSchedule(ctx context.Context, id int64, authorId int64, status uint8, publishAt int64) error {
+	// 带上 status 条件，已经被定时任务发表了的文章就改不了了
+	res := dao.db.WithContext(ctx).Model(&Article{}).
+		Where("id = ? AND author_id = ? AND status = ?", id, authorId, statusScheduled).
+		Updates(map[string]any{
+			"status":     status,
+			"publish_at": publishAt,
+			"utime":      time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrNotScheduled
+	}
+	return nil
+}
+
+func (dao *GORMArticleDAO) PublishScheduled(ctx context.Context, art Article, now time.Time) error {
+	art.Utime = now.UnixMilli()
+	art.Status = statusPublished
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 和 UpdateSchedule 一样带上 status 条件，改期或者取消和定时任务并发的时候，谁先改了状态谁生效
+		res := tx.Model(&Article{}).
+			Where("id = ? AND status = ? AND publish_at <= ?", art.Id, statusScheduled, art.Utime).
+			Updates(map[string]any{
+				"status": art.Status,
+				"utime":  art.Utime,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrNotScheduled
+		}
+		return NewGORMArticleDAO(tx).Upsert(ctx, PublishedArticle(art))
+	})
+}
+
+func (dao *GORMArticleDAO) ListRevisions(ctx context.Context, artId int64, authorId int64, offset int, limit int) ([]ArticleRevision, error) {
+	var res []ArticleRevision
+	// 列表页不需要内容
+	err := dao.db.WithContext(ctx).Model(&ArticleRevision{}).
+		Select("id", "article_id", "version", "title", "author_id", "status", "ctime").
+		Where("article_id = ? AND author_id = ?", artId, authorId).
+		Order("version DESC").Offset(offset).Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMArticleDAO) GetRevision(ctx context.Context, artId int64, version int64, authorId int64) (ArticleRevision, error) {
+	var res ArticleRevision
+	err := dao.db.WithContext(ctx).Where("article_id = ? AND version = ? AND author_id = ?", artId, version, authorId).
+		First(&res).Error
+	return res, err
+}
+
+func (dao *GORMArticleDAO) TransferAuthor(ctx context.Context, fromAuthorId, toAuthorId int64) error {
+	now := time.Now().UnixMilli()
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&Article{}).Where("author_id = ?", fromAuthorId).
+			Updates(map[string]any{"author_id": toAuthorId, "utime": now}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&PublishedArticle{}).Where("author_id = ?", fromAuthorId).
+			Updates(map[string]any{"author_id": toAuthorId, "utime": now}).Error
+		if err != nil {
+			return err
+		}
+		// 历史版本只插入不更新，这里是唯一的例外，不然合并之后就看不到历史版本了
+		return tx.Model(&ArticleRevision{}).Where("author_id = ?", fromAuthorId).
+			Update("author_id", toAuthorId).Error
+	})
+}
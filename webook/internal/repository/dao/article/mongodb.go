@@ -2,6 +2,7 @@ package article
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/bwmarrin/snowflake"
@@ -18,13 +19,16 @@ type MongoDBDAO struct {
 	coll *mongo.Collection
 	// 线上表
 	liveColl *mongo.Collection
-	node     *snowflake.Node
+	// 历史版本表
+	revColl *mongo.Collection
+	node    *snowflake.Node
 }
 
 func NewMongoDBDAO(db *mongo.Database, node *snowflake.Node) ArticleDAO {
 	return &MongoDBDAO{
 		coll:     db.Collection("articles"),
 		liveColl: db.Collection("published_articles"),
+		revColl:  db.Collection("article_revisions"),
 		node:     node,
 	}
 }
@@ -48,6 +52,13 @@ func InitCollections(db *mongo.Database) error {
 		return err
 	}
 	_, err = db.Collection("published_articles").Indexes().CreateMany(ctx, idx)
+	if err != nil {
+		return err
+	}
+	_, err = db.Collection("article_revisions").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{"article_id", 1}, {"version", 1}},
+		Options: options.Index().SetUnique(true),
+	})
 	return err
 }
 
@@ -61,15 +72,18 @@ func (m *MongoDBDAO) Insert(ctx context.Context, art Article) (int64, error) {
 	if err != nil {
 		return 0, err
 	}
-	return id, nil
+	// MongoDB 这边没有开事务，历史版本写失败了也不影响文章本身
+	return id, m.insertRevision(ctx, art)
 }
 
 func (m *MongoDBDAO) UpdateById(ctx context.Context, art Article) error {
+	art.Utime = time.Now().UnixMilli()
 	res, err := m.coll.UpdateOne(ctx, bson.M{"id": art.Id, "author_id": art.AuthorId}, bson.M{"$set": bson.M{
-		"title":   art.Title,
-		"content": art.Content,
-		"status":  art.Status,
-		"utime":   time.Now().UnixMilli(),
+		"title":      art.Title,
+		"content":    art.Content,
+		"status":     art.Status,
+		"publish_at": art.PublishAt,
+		"utime":      art.Utime,
 	}})
 	if err != nil {
 		return err
@@ -78,7 +92,27 @@ func (m *MongoDBDAO) UpdateById(ctx context.Context, art Article) error {
 	if res.ModifiedCount == 0 {
 		return ErrPossibleIncorrectAuthor
 	}
-	return nil
+	return m.insertRevision(ctx, art)
+}
+
+func (m *MongoDBDAO) insertRevision(ctx context.Context, art Article) error {
+	var latest ArticleRevision
+	err := m.revColl.FindOne(ctx, bson.M{"article_id": art.Id},
+		options.FindOne().SetSort(bson.M{"version": -1})).Decode(&latest)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return err
+	}
+	_, err = m.revColl.InsertOne(ctx, ArticleRevision{
+		Id:        m.node.Generate().Int64(),
+		ArticleId: art.Id,
+		Version:   latest.Version + 1,
+		Title:     art.Title,
+		Content:   art.Content,
+		AuthorId:  art.AuthorId,
+		Status:    art.Status,
+		Ctime:     art.Utime,
+	})
+	return err
 }
 
 func (m *MongoDBDAO) Upsert(ctx context.Context, art PublishedArticle) error {
@@ -128,3 +162,139 @@ func (m *MongoDBDAO) ListPub(ctx context.Context, start time.Time, offset int, l
 	// TODO implement me
 	panic("implement me")
 }
+
+func (m *MongoDBDAO) ListPubByAuthors(ctx context.Context, authorIds []int64, before time.Time, beforeId int64, limit int) ([]PublishedArticle, error) {
+	utime := before.UnixMilli()
+	filter := bson.M{
+		"author_id": bson.M{"$in": authorIds},
+		"status":    statusPublished,
+		"$or": bson.A{
+			bson.M{"utime": bson.M{"$lt": utime}},
+			bson.M{"utime": utime, "id": bson.M{"$lt": beforeId}},
+		},
+	}
+	// 多个字段排序要用 bson.D 保证顺序
+	cursor, err := m.liveColl.Find(ctx, filter,
+		options.Find().SetSort(bson.D{{Key: "utime", Value: -1}, {Key: "id", Value: -1}}).SetLimit(int64(limit)))
+	if err != nil {
+		return nil, err
+	}
+	var res []PublishedArticle
+	err = cursor.All(ctx, &res)
+	return res, err
+}
+
+func (m *MongoDBDAO) ListPubByIds(ctx context.Context, ids []int64) ([]PublishedArticle, error) {
+	cursor, err := m.liveColl.Find(ctx, bson.M{"id": bson.M{"$in": ids}, "status": statusPublished})
+	if err != nil {
+		return nil, err
+	}
+	var res []PublishedArticle
+	err = cursor.All(ctx, &res)
+	return res, err
+}
+
+func (m *MongoDBDAO) ListPubAfterId(ctx context.Context, id int64, limit int) ([]PublishedArticle, error) {
+	cursor, err := m.liveColl.Find(ctx, bson.M{"id": bson.M{"$gt": id}, "status": statusPublished},
+		options.Find().SetSort(bson.M{"id": 1}).SetLimit(int64(limit)))
+	if err != nil {
+		return nil, err
+	}
+	var res []PublishedArticle
+	err = cursor.All(ctx, &res)
+	return res, err
+}
+
+func (m *MongoDBDAO) ListScheduled(ctx context.Context, authorId int64, offset int, limit int) ([]Article, error) {
+	cursor, err := m.coll.Find(ctx, bson.M{"author_id": authorId, "status": statusScheduled},
+		options.Find().SetSort(bson.M{"publish_at": 1}).SetSkip(int64(offset)).SetLimit(int64(limit)))
+	if err != nil {
+		return nil, err
+	}
+	var res []Article
+	err = cursor.All(ctx, &res)
+	return res, err
+}
+
+func (m *MongoDBDAO) ListDueScheduled(ctx context.Context, now time.Time, offset int, limit int) ([]Article, error) {
+	cursor, err := m.coll.Find(ctx, bson.M{"status": statusScheduled, "publish_at": bson.M{"$lte": now.UnixMilli()}},
+		options.Find().SetSort(bson.M{"publish_at": 1}).SetSkip(int64(offset)).SetLimit(int64(limit)))
+	if err != nil {
+		return nil, err
+	}
+	var res []Article
+	err = cursor.All(ctx, &res)
+	return res, err
+}
+
+func (m *MongoDBDAO) UpdateSchedule(ctx context.Context, id int64, authorId int64, status uint8, publishAt int64) error {
+	res, err := m.coll.UpdateOne(ctx, bson.M{"id": id, "author_id": authorId, "status": statusScheduled},
+		bson.M{"$set": bson.M{
+			"status":     status,
+			"publish_at": publishAt,
+			"utime":      time.Now().UnixMilli(),
+		}})
+	if err != nil {
+		return err
+	}
+	if res.ModifiedCount == 0 {
+		return ErrNotScheduled
+	}
+	return nil
+}
+
+func (m *MongoDBDAO) PublishScheduled(ctx context.Context, art Article, now time.Time) error {
+	art.Utime = now.UnixMilli()
+	art.Status = statusPublished
+	res, err := m.coll.UpdateOne(ctx, bson.M{
+		"id":         art.Id,
+		"status":     statusScheduled,
+		"publish_at": bson.M{"$lte": art.Utime},
+	}, bson.M{"$set": bson.M{
+		"status": art.Status,
+		"utime":  art.Utime,
+	}})
+	if err != nil {
+		return err
+	}
+	if res.ModifiedCount == 0 {
+		return ErrNotScheduled
+	}
+	_, err = m.liveColl.UpdateOne(ctx, bson.M{"id": art.Id}, bson.M{
+		"$set":         PublishedArticle(art),
+		"$setOnInsert": bson.M{"ctime": art.Utime},
+	}, options.Update().SetUpsert(true))
+	return err
+}
+
+func (m *MongoDBDAO) ListRevisions(ctx context.Context, artId int64, authorId int64, offset int, limit int) ([]ArticleRevision, error) {
+	cursor, err := m.revColl.Find(ctx, bson.M{"article_id": artId, "author_id": authorId},
+		options.Find().SetSort(bson.M{"version": -1}).SetSkip(int64(offset)).SetLimit(int64(limit)).
+			SetProjection(bson.M{"content": 0}))
+	if err != nil {
+		return nil, err
+	}
+	var res []ArticleRevision
+	err = cursor.All(ctx, &res)
+	return res, err
+}
+
+func (m *MongoDBDAO) GetRevision(ctx context.Context, artId int64, version int64, authorId int64) (ArticleRevision, error) {
+	var res ArticleRevision
+	err := m.revColl.FindOne(ctx, bson.M{"article_id": artId, "version": version, "author_id": authorId}).Decode(&res)
+	return res, err
+}
+
+// TransferAuthor MongoDB 这里没有用事务，中途失败了重新执行一遍就可以
+func (m *MongoDBDAO) TransferAuthor(ctx context.Context, fromAuthorId, toAuthorId int64) error {
+	filter := bson.M{"author_id": fromAuthorId}
+	update := bson.M{"$set": bson.M{"author_id": toAuthorId, "utime": time.Now().UnixMilli()}}
+	if _, err := m.coll.UpdateMany(ctx, filter, update); err != nil {
+		return err
+	}
+	if _, err := m.liveColl.UpdateMany(ctx, filter, update); err != nil {
+		return err
+	}
+	_, err := m.revColl.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"author_id": toAuthorId}})
+	return err
+}
@@ -6,7 +6,11 @@ import (
 	"time"
 )
 
-var ErrPossibleIncorrectAuthor = errors.New("用户在尝试操作非本人数据")
+var (
+	ErrPossibleIncorrectAuthor = errors.New("用户在尝试操作非本人数据")
+	// ErrNotScheduled 文章已经不是定时发表状态了（已经发表或者取消了），或者不是本人的文章
+	ErrNotScheduled = errors.New("文章不是定时发表状态")
+)
 
 type ArticleDAO interface {
 	// Insert 和 Update 操作制作表
@@ -20,4 +24,24 @@ type ArticleDAO interface {
 	GetByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]Article, error)
 	GetById(ctx context.Context, id int64) (Article, error)
 	ListPub(ctx context.Context, start time.Time, offset int, limit int) ([]Article, error)
+	// ListPubByAuthors 查询线上表里面这些作者排在 (before, beforeId) 后面的文章，按 utime, id 降序，给关注流用
+	// utime 一样的时候按 id 排，beforeId 传 0 就只看 utime
+	ListPubByAuthors(ctx context.Context, authorIds []int64, before time.Time, beforeId int64, limit int) ([]PublishedArticle, error)
+	// ListPubByIds 按照 id 批量查询线上表里面已发表的文章，不保证顺序
+	ListPubByIds(ctx context.Context, ids []int64) ([]PublishedArticle, error)
+	// ListPubAfterId 按照 id 升序遍历线上表里面已发表的文章，重建索引之类的全量同步用
+	ListPubAfterId(ctx context.Context, id int64, limit int) ([]PublishedArticle, error)
+	// ListScheduled 查询某个作者还没有到时间的定时发表
+	ListScheduled(ctx context.Context, authorId int64, offset int, limit int) ([]Article, error)
+	// ListDueScheduled 查询所有 publish_at 在 now 之前的定时发表，给定时任务用
+	ListDueScheduled(ctx context.Context, now time.Time, offset int, limit int) ([]Article, error)
+	// UpdateSchedule 只修改还处于定时发表状态的文章，用来改期或者取消
+	UpdateSchedule(ctx context.Context, id int64, authorId int64, status uint8, publishAt int64) error
+	// PublishScheduled 定时任务发表到了时间的文章，文章必须还处于定时发表状态，不然返回 ErrNotScheduled
+	PublishScheduled(ctx context.Context, art Article, now time.Time) error
+	// ListRevisions 和 GetRevision 查询历史版本，历史版本在 Insert 和 UpdateById 的时候顺带写入
+	ListRevisions(ctx context.Context, artId int64, authorId int64, offset int, limit int) ([]ArticleRevision, error)
+	GetRevision(ctx context.Context, artId int64, version int64, authorId int64) (ArticleRevision, error)
+	// TransferAuthor 合并账号的时候把 fromAuthorId 的文章（包括线上表和历史版本）都转给 toAuthorId
+	TransferAuthor(ctx context.Context, fromAuthorId, toAuthorId int64) error
 }
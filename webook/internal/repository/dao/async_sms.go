@@ -30,16 +30,20 @@ type AsyncSms struct {
 	Config   sqlx.JsonColumn[SmsConfig]
 	RetryCnt int
 	RetryMax int
-	Status   uint8
+	Status   uint8 `gorm:"index:status_next_time"`
+	// NextTime 下一次可以被抢占的时间，发送失败之后按照退避策略往后推
+	NextTime int64 `gorm:"index:status_next_time"`
 	Ctime    int64
-	Utime    int64 `gorm:"index"`
+	Utime    int64
 }
 
 type AsyncSmsDAO interface {
 	Insert(ctx context.Context, s AsyncSms) error
-	GetWaitingSMS(ctx context.Context) (AsyncSms, error)
+	// GetWaitingSMS 抢占一个到了发送时间的短信，lease 时间内别的节点抢不到
+	GetWaitingSMS(ctx context.Context, lease time.Duration) (AsyncSms, error)
 	MarkSuccess(ctx context.Context, id int64) error
-	MarkFailed(ctx context.Context, id int64) error
+	// MarkFailed 到了重试次数就标记为失败，不然 nextTime 的时候再重试
+	MarkFailed(ctx context.Context, id int64, nextTime int64) error
 }
 
 type GORMAsyncSmsDAO struct {
@@ -51,30 +55,33 @@ func NewGORMAsyncSmsDAO(db *gorm.DB) AsyncSmsDAO {
 }
 
 func (g *GORMAsyncSmsDAO) Insert(ctx context.Context, s AsyncSms) error {
-	return g.db.Create(&s).Error
+	now := time.Now().UnixMilli()
+	s.Ctime, s.Utime = now, now
+	return g.db.WithContext(ctx).Create(&s).Error
 }
 
-func (g *GORMAsyncSmsDAO) GetWaitingSMS(ctx context.Context) (AsyncSms, error) {
+func (g *GORMAsyncSmsDAO) GetWaitingSMS(ctx context.Context, lease time.Duration) (AsyncSms, error) {
 	// 如果在高并发情况下, SELECT for UPDATE 对数据库的压力很大
 	// 但是我们不是高并发，因为你部署 N 台机器，才有 N 个goroutine 来查询,并发不过百，随便写
 	var s AsyncSms
 	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// 为了避开一些偶发性的失败，我们只找 1 分钟前的异步短信发送
 		now := time.Now().UnixMilli()
-		endTime := now - time.Minute.Milliseconds()
+		// 节点发送到一半崩溃了，lease 之后会被重新抢占，这时候重试次数可能已经用完了，不能再发
 		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
-			Where("utime < ? and status = ?", endTime, asyncStatusWaiting).First(&s).Error
+			Where("status = ? AND next_time <= ? AND retry_cnt < retry_max", asyncStatusWaiting, now).
+			First(&s).Error
 		// SELECT xx FROM xxx WHERE xx FOR UPDATE，锁住了
 		if err != nil {
 			return err
 		}
 
-		// 只要更新了更新时间，根据我们前面的规则，就不可能被别的节点抢占了
+		// 把 next_time 往后推，确保我在发送过程中，没人会再次抢到它。
+		// 发送完了会按照结果重新设置 next_time，节点崩溃了的话 lease 之后别的节点会接着发
+		s.RetryCnt++
 		return tx.Model(&AsyncSms{}).Where("id = ?", s.Id).Updates(map[string]any{
 			"retry_cnt": gorm.Expr("retry_cnt + 1"),
-			// 更新成了当前时间戳，确保我在发送过程中，没人会再次抢到它
-			// 也相当于，重试间隔一分钟
-			"utime": now,
+			"next_time": now + lease.Milliseconds(),
+			"utime":     now,
 		}).Error
 	})
 	return s, err
@@ -88,12 +95,12 @@ func (g *GORMAsyncSmsDAO) MarkSuccess(ctx context.Context, id int64) error {
 	}).Error
 }
 
-func (g *GORMAsyncSmsDAO) MarkFailed(ctx context.Context, id int64) error {
+func (g *GORMAsyncSmsDAO) MarkFailed(ctx context.Context, id int64, nextTime int64) error {
 	now := time.Now().UnixMilli()
-	return g.db.WithContext(ctx).Model(&AsyncSms{}).
-		// 只有到达了重试次数才会更新
-		Where("id =? and `retry_cnt`>=`retry_max`", id).Updates(map[string]any{
-		"utime":  now,
-		"status": asyncStatusFailed,
+	return g.db.WithContext(ctx).Model(&AsyncSms{}).Where("id = ?", id).Updates(map[string]any{
+		"utime": now,
+		// 只有到达了重试次数才会标记为失败，没到的话等 next_time 再重试
+		"status":    gorm.Expr("CASE WHEN `retry_cnt` >= `retry_max` THEN ? ELSE `status` END", asyncStatusFailed),
+		"next_time": nextTime,
 	}).Error
 }
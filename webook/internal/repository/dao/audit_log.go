@@ -0,0 +1,50 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AuditLog 管理员操作的审计日志，只插入不修改
+type AuditLog struct {
+	Id       int64  `gorm:"primaryKey,autoIncrement"`
+	Operator int64  `gorm:"index"`
+	Action   string `gorm:"type:varchar(64)"`
+	// 查询某个用户、某篇文章的操作记录：WHERE target_type = ? AND target_id = ?
+	TargetType string `gorm:"type:varchar(32);index:target"`
+	TargetId   int64  `gorm:"index:target"`
+	Detail     string `gorm:"type:varchar(1024)"`
+	Ctime      int64
+}
+
+type AuditLogDAO interface {
+	Insert(ctx context.Context, al AuditLog) error
+	// Find 零值的条件不参与过滤，按时间倒序
+	Find(ctx context.Context, cond AuditLog, offset int, limit int) ([]AuditLog, error)
+}
+
+type GORMAuditLogDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMAuditLogDAO(db *gorm.DB) AuditLogDAO {
+	return &GORMAuditLogDAO{db: db}
+}
+
+func (dao *GORMAuditLogDAO) Insert(ctx context.Context, al AuditLog) error {
+	al.Ctime = time.Now().UnixMilli()
+	return dao.db.WithContext(ctx).Create(&al).Error
+}
+
+func (dao *GORMAuditLogDAO) Find(ctx context.Context, cond AuditLog, offset int, limit int) ([]AuditLog, error) {
+	var res []AuditLog
+	// gorm 用结构体做条件的时候会忽略零值
+	err := dao.db.WithContext(ctx).Where(&AuditLog{
+		Operator:   cond.Operator,
+		TargetType: cond.TargetType,
+		TargetId:   cond.TargetId,
+	}).Order("id DESC").Offset(offset).Limit(limit).Find(&res).Error
+	return res, err
+}
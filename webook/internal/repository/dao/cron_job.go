@@ -20,7 +20,7 @@ type CronJob struct {
 	CronExpression string
 	Executor       string
 	// 标记哪些任务可以抢占，哪些已经被抢占，哪些永远不会调度之类的
-	status  int
+	Status  int
 	Version int
 	// 下次被调度时间
 	// 查询可抢占任务条件：status = 0 AND next_time <= now
@@ -0,0 +1,81 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// HistoryRecord 同一个用户同一个资源只有一条，重复阅读只更新 utime
+type HistoryRecord struct {
+	Id    int64  `gorm:"primaryKey,autoIncrement"`
+	Uid   int64  `gorm:"uniqueIndex:uid_biz_id;index:uid_utime"`
+	Biz   string `gorm:"type:varchar(128);uniqueIndex:uid_biz_id"`
+	BizId int64  `gorm:"uniqueIndex:uid_biz_id"`
+	Ctime int64
+	// 查询历史记录：WHERE uid = ? ORDER BY utime DESC
+	Utime int64 `gorm:"index:uid_utime"`
+}
+
+type HistoryRecordDAO interface {
+	Upsert(ctx context.Context, r HistoryRecord) error
+	FindByUid(ctx context.Context, uid int64, offset int, limit int) ([]HistoryRecord, error)
+	Delete(ctx context.Context, uid int64, biz string, bizId int64) error
+	DeleteAll(ctx context.Context, uid int64) error
+	// Trim 只保留最近的 keep 条，更早的删掉
+	Trim(ctx context.Context, uid int64, keep int) error
+}
+
+type GORMHistoryRecordDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMHistoryRecordDAO(db *gorm.DB) HistoryRecordDAO {
+	return &GORMHistoryRecordDAO{db: db}
+}
+
+func (dao *GORMHistoryRecordDAO) Upsert(ctx context.Context, r HistoryRecord) error {
+	now := time.Now().UnixMilli()
+	r.Ctime = now
+	r.Utime = now
+	return dao.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"utime": now,
+		}),
+	}).Create(&r).Error
+}
+
+func (dao *GORMHistoryRecordDAO) FindByUid(ctx context.Context, uid int64, offset int, limit int) ([]HistoryRecord, error) {
+	var res []HistoryRecord
+	err := dao.db.WithContext(ctx).Where("uid = ?", uid).
+		Order("utime DESC, id DESC").Offset(offset).Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMHistoryRecordDAO) Delete(ctx context.Context, uid int64, biz string, bizId int64) error {
+	return dao.db.WithContext(ctx).Where("uid = ? AND biz = ? AND biz_id = ?", uid, biz, bizId).
+		Delete(&HistoryRecord{}).Error
+}
+
+func (dao *GORMHistoryRecordDAO) DeleteAll(ctx context.Context, uid int64) error {
+	return dao.db.WithContext(ctx).Where("uid = ?", uid).Delete(&HistoryRecord{}).Error
+}
+
+func (dao *GORMHistoryRecordDAO) Trim(ctx context.Context, uid int64, keep int) error {
+	db := dao.db.WithContext(ctx)
+	// 找到第 keep+1 条，它和它后面的都要删掉
+	var r HistoryRecord
+	err := db.Where("uid = ?", uid).Order("utime DESC, id DESC").Offset(keep).Take(&r).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// 还没有超过上限
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return db.Where("uid = ? AND (utime < ? OR (utime = ? AND id <= ?))", uid, r.Utime, r.Utime, r.Id).
+		Delete(&HistoryRecord{}).Error
+}
@@ -0,0 +1,80 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gormMysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestGORMHistoryRecordDAO_Trim(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		mock func(t *testing.T) *sql.DB
+
+		expectedErr error
+	}{
+		{
+			name: "没有超过上限",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectQuery("SELECT \\* FROM `history_records` WHERE uid = .* ORDER BY utime DESC, id DESC LIMIT \\? OFFSET \\?").
+					WithArgs(123, 1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "uid", "biz", "biz_id", "ctime", "utime"}))
+				return mockDB
+			},
+		},
+		{
+			name: "删除超出上限的记录",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectQuery("SELECT \\* FROM `history_records` WHERE uid = .* ORDER BY utime DESC, id DESC LIMIT \\? OFFSET \\?").
+					WithArgs(123, 1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "uid", "biz", "biz_id", "ctime", "utime"}).
+						AddRow(3, 123, "article", 3, 100, 100))
+				mock.ExpectExec("DELETE FROM `history_records` WHERE .*").
+					WithArgs(123, 100, 100, 3).
+					WillReturnResult(sqlmock.NewResult(0, 5))
+				return mockDB
+			},
+		},
+		{
+			name: "查询失败",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectQuery("SELECT \\* FROM `history_records` .*").WillReturnError(errors.New("数据库错误"))
+				return mockDB
+			},
+			expectedErr: errors.New("数据库错误"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sqlDB := tc.mock(t)
+			db, err := gorm.Open(gormMysql.New(gormMysql.Config{
+				Conn:                      sqlDB,
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing:   true,
+				SkipDefaultTransaction: true,
+				Logger:                 logger.Default.LogMode(logger.Info),
+			})
+			assert.NoError(t, err)
+			hd := NewGORMHistoryRecordDAO(db)
+			err = hd.Trim(context.Background(), 123, 2)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
@@ -9,9 +9,21 @@ import (
 func InitTables(db *gorm.DB) error {
 	return db.AutoMigrate(
 		&User{},
+		&UserIdentity{},
+		&UserRole{},
+		&AuditLog{},
+		&PrivacyRequest{},
+		&PrivacyExportFile{},
 		&article.Article{},
 		&article.PublishedArticle{},
+		&article.ArticleRevision{},
 		&AsyncSms{},
+		&SmsMessage{},
 		&CronJob{},
+		&HistoryRecord{},
+		&Notification{},
+		&NotificationActor{},
+		&UserTotp{},
+		&RecoveryCode{},
 	)
 }
@@ -0,0 +1,142 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Notification 站内通知
+type Notification struct {
+	Id  int64 `gorm:"primaryKey,autoIncrement"`
+	Uid int64 `gorm:"uniqueIndex:uid_fold_read;index:uid_utime;index:uid_read_at"`
+	// FoldKey 同一个 FoldKey 的未读通知折叠成一条。系统消息不折叠，是 NULL，唯一索引不管 NULL
+	FoldKey sql.NullString `gorm:"type:varchar(256);uniqueIndex:uid_fold_read"`
+	// ReadAt 0 就是未读。已读的通知 ReadAt 各不相同，所以不会和新的未读通知冲突
+	ReadAt      int64 `gorm:"uniqueIndex:uid_fold_read;index:uid_read_at"`
+	Type        uint8
+	Biz         string `gorm:"type:varchar(128)"`
+	BizId       int64
+	LastActorId int64
+	ActorCnt    int64
+	Content     string `gorm:"type:varchar(1024)"`
+	Ctime       int64
+	// 查询通知列表：WHERE uid = ? ORDER BY utime DESC
+	Utime int64 `gorm:"index:uid_utime"`
+}
+
+// NotificationActor 折叠进一条通知的人，ActorCnt 按人去重，同一个人赞了又取消再赞只算一次
+type NotificationActor struct {
+	Id             int64 `gorm:"primaryKey,autoIncrement"`
+	NotificationId int64 `gorm:"uniqueIndex:notification_actor"`
+	ActorId        int64 `gorm:"uniqueIndex:notification_actor"`
+	Ctime          int64
+}
+
+type NotificationDAO interface {
+	// Upsert 有未读的同一个 FoldKey 的通知就折叠进去，没有就插入一条新的
+	Upsert(ctx context.Context, n Notification) error
+	Insert(ctx context.Context, n Notification) error
+	FindByUid(ctx context.Context, uid int64, offset int, limit int) ([]Notification, error)
+	CountUnread(ctx context.Context, uid int64) (int64, error)
+	MarkRead(ctx context.Context, uid int64, ids []int64) error
+	MarkAllRead(ctx context.Context, uid int64) error
+	// DeleteByUid 删掉用户收到的所有通知，还有这个用户在别人的通知里面留下的记录
+	DeleteByUid(ctx context.Context, uid int64) error
+}
+
+type GORMNotificationDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMNotificationDAO(db *gorm.DB) NotificationDAO {
+	return &GORMNotificationDAO{db: db}
+}
+
+func (dao *GORMNotificationDAO) Upsert(ctx context.Context, n Notification) error {
+	now := time.Now().UnixMilli()
+	n.Ctime = now
+	n.Utime = now
+	n.ActorCnt = 1
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&n)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 1 {
+			return tx.Create(&NotificationActor{NotificationId: n.Id, ActorId: n.LastActorId, Ctime: now}).Error
+		}
+		// 已经有未读的了，锁住这一条，免得并发的时候重复计数
+		var old Notification
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("uid = ? AND fold_key = ? AND read_at = ?", n.Uid, n.FoldKey, 0).First(&old).Error
+		if err != nil {
+			return err
+		}
+		res = tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&NotificationActor{NotificationId: old.Id, ActorId: n.LastActorId, Ctime: now})
+		if res.Error != nil {
+			return res.Error
+		}
+		updates := map[string]any{
+			"last_actor_id": n.LastActorId,
+			"utime":         now,
+		}
+		// 这个人之前已经算过了
+		if res.RowsAffected == 1 {
+			updates["actor_cnt"] = gorm.Expr("actor_cnt + 1")
+		}
+		return tx.Model(&Notification{}).Where("id = ?", old.Id).Updates(updates).Error
+	})
+}
+
+func (dao *GORMNotificationDAO) Insert(ctx context.Context, n Notification) error {
+	now := time.Now().UnixMilli()
+	n.Ctime = now
+	n.Utime = now
+	return dao.db.WithContext(ctx).Create(&n).Error
+}
+
+func (dao *GORMNotificationDAO) FindByUid(ctx context.Context, uid int64, offset int, limit int) ([]Notification, error) {
+	var res []Notification
+	err := dao.db.WithContext(ctx).Where("uid = ?", uid).
+		Order("utime DESC, id DESC").Offset(offset).Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMNotificationDAO) CountUnread(ctx context.Context, uid int64) (int64, error) {
+	var res int64
+	err := dao.db.WithContext(ctx).Model(&Notification{}).
+		Where("uid = ? AND read_at = ?", uid, 0).Count(&res).Error
+	return res, err
+}
+
+func (dao *GORMNotificationDAO) MarkRead(ctx context.Context, uid int64, ids []int64) error {
+	now := time.Now().UnixMilli()
+	// 带上 uid，只能标记自己的通知
+	return dao.db.WithContext(ctx).Model(&Notification{}).
+		Where("id IN ? AND uid = ? AND read_at = ?", ids, uid, 0).
+		Update("read_at", now).Error
+}
+
+func (dao *GORMNotificationDAO) MarkAllRead(ctx context.Context, uid int64) error {
+	now := time.Now().UnixMilli()
+	return dao.db.WithContext(ctx).Model(&Notification{}).
+		Where("uid = ? AND read_at = ?", uid, 0).
+		Update("read_at", now).Error
+}
+
+func (dao *GORMNotificationDAO) DeleteByUid(ctx context.Context, uid int64) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("notification_id IN (?) OR actor_id = ?",
+			tx.Model(&Notification{}).Select("id").Where("uid = ?", uid), uid).
+			Delete(&NotificationActor{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("uid = ?", uid).Delete(&Notification{}).Error
+	})
+}
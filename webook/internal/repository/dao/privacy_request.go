@@ -0,0 +1,158 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PrivacyRequest 导出个人数据、注销账号的申请
+type PrivacyRequest struct {
+	Id  int64 `gorm:"primaryKey,autoIncrement"`
+	Uid int64 `gorm:"index"`
+	// Type 1 导出，2 注销
+	Type uint8
+	// 定时任务查询条件：WHERE status IN ? AND execute_at <= ?
+	Status    uint8  `gorm:"index:status_execute_at"`
+	ExecuteAt int64  `gorm:"index:status_execute_at"`
+	Stage     string `gorm:"type:varchar(32)"`
+	Archive   string `gorm:"type:varchar(512)"`
+	Ctime     int64
+	Utime     int64
+}
+
+// PrivacyExportFile 导出数据每一步的结果和最后的压缩包。定时任务的每一步可能在不同的实例上执行，
+// 下载的请求也可能落到任何一个实例上，所以不能放在本地磁盘，导出的数据量不大，直接放数据库
+type PrivacyExportFile struct {
+	Id        int64  `gorm:"primaryKey,autoIncrement"`
+	RequestId int64  `gorm:"uniqueIndex:request_id_name"`
+	Name      string `gorm:"type:varchar(64);uniqueIndex:request_id_name"`
+	Content   []byte `gorm:"type:longblob"`
+	Ctime     int64
+	Utime     int64
+}
+
+type PrivacyRequestDAO interface {
+	Insert(ctx context.Context, r PrivacyRequest) (int64, error)
+	FindById(ctx context.Context, id int64) (PrivacyRequest, error)
+	// FindByUid 按申请时间倒序
+	FindByUid(ctx context.Context, uid int64, offset int, limit int) ([]PrivacyRequest, error)
+	// FindActive 还没有执行完的某一类申请，没有返回 ErrDataNotFound
+	FindActive(ctx context.Context, uid int64, typ uint8, statuses []uint8) (PrivacyRequest, error)
+	FindDue(ctx context.Context, statuses []uint8, now int64, limit int) ([]PrivacyRequest, error)
+	// UpdateStage 只更新 status 在 from 里面的，被取消的申请不会被定时任务改回来
+	UpdateStage(ctx context.Context, id int64, from []uint8, status uint8, stage string, archive string) error
+	// UpdateStatus 只更新 uid 自己的、status 为 from 的申请，没有返回 ErrDataNotFound
+	UpdateStatus(ctx context.Context, id int64, uid int64, from uint8, to uint8) error
+	// UpsertFile 重复执行的时候直接覆盖
+	UpsertFile(ctx context.Context, f PrivacyExportFile) error
+	FindFiles(ctx context.Context, requestId int64) ([]PrivacyExportFile, error)
+	FindFile(ctx context.Context, requestId int64, name string) (PrivacyExportFile, error)
+	DeleteFiles(ctx context.Context, requestId int64, names []string) error
+}
+
+type GORMPrivacyRequestDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMPrivacyRequestDAO(db *gorm.DB) PrivacyRequestDAO {
+	return &GORMPrivacyRequestDAO{db: db}
+}
+
+func (dao *GORMPrivacyRequestDAO) Insert(ctx context.Context, r PrivacyRequest) (int64, error) {
+	now := time.Now().UnixMilli()
+	r.Ctime = now
+	r.Utime = now
+	err := dao.db.WithContext(ctx).Create(&r).Error
+	return r.Id, err
+}
+
+func (dao *GORMPrivacyRequestDAO) FindById(ctx context.Context, id int64) (PrivacyRequest, error) {
+	var r PrivacyRequest
+	err := dao.db.WithContext(ctx).Where("id = ?", id).First(&r).Error
+	return r, err
+}
+
+func (dao *GORMPrivacyRequestDAO) FindByUid(ctx context.Context, uid int64, offset int, limit int) ([]PrivacyRequest, error) {
+	var res []PrivacyRequest
+	err := dao.db.WithContext(ctx).Where("uid = ?", uid).
+		Order("id DESC").Offset(offset).Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMPrivacyRequestDAO) FindActive(ctx context.Context, uid int64, typ uint8, statuses []uint8) (PrivacyRequest, error) {
+	var r PrivacyRequest
+	err := dao.db.WithContext(ctx).Where("uid = ? AND type = ? AND status IN ?", uid, typ, statuses).
+		Order("id DESC").First(&r).Error
+	return r, err
+}
+
+func (dao *GORMPrivacyRequestDAO) FindDue(ctx context.Context, statuses []uint8, now int64, limit int) ([]PrivacyRequest, error) {
+	var res []PrivacyRequest
+	err := dao.db.WithContext(ctx).Where("status IN ? AND execute_at <= ?", statuses, now).
+		Order("execute_at ASC").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMPrivacyRequestDAO) UpdateStage(ctx context.Context, id int64, from []uint8, status uint8, stage string, archive string) error {
+	res := dao.db.WithContext(ctx).Model(&PrivacyRequest{}).Where("id = ? AND status IN ?", id, from).
+		Updates(map[string]any{
+			"status":  status,
+			"stage":   stage,
+			"archive": archive,
+			"utime":   time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrDataNotFound
+	}
+	return nil
+}
+
+func (dao *GORMPrivacyRequestDAO) UpdateStatus(ctx context.Context, id int64, uid int64, from uint8, to uint8) error {
+	res := dao.db.WithContext(ctx).Model(&PrivacyRequest{}).Where("id = ? AND uid = ? AND status = ?", id, uid, from).
+		Updates(map[string]any{
+			"status": to,
+			"utime":  time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrDataNotFound
+	}
+	return nil
+}
+
+func (dao *GORMPrivacyRequestDAO) UpsertFile(ctx context.Context, f PrivacyExportFile) error {
+	now := time.Now().UnixMilli()
+	f.Ctime = now
+	f.Utime = now
+	return dao.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"content": f.Content,
+			"utime":   now,
+		}),
+	}).Create(&f).Error
+}
+
+func (dao *GORMPrivacyRequestDAO) FindFiles(ctx context.Context, requestId int64) ([]PrivacyExportFile, error) {
+	var res []PrivacyExportFile
+	err := dao.db.WithContext(ctx).Where("request_id = ?", requestId).Order("name ASC").Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMPrivacyRequestDAO) FindFile(ctx context.Context, requestId int64, name string) (PrivacyExportFile, error) {
+	var res PrivacyExportFile
+	err := dao.db.WithContext(ctx).Where("request_id = ? AND name = ?", requestId, name).First(&res).Error
+	return res, err
+}
+
+func (dao *GORMPrivacyRequestDAO) DeleteFiles(ctx context.Context, requestId int64, names []string) error {
+	return dao.db.WithContext(ctx).Where("request_id = ? AND name IN ?", requestId, names).
+		Delete(&PrivacyExportFile{}).Error
+}
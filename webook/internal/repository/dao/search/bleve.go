@@ -0,0 +1,112 @@
+package search
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/lang/cjk"
+	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/search/highlight/highlighter/html"
+	"github.com/blevesearch/bleve/v2/search/query"
+)
+
+// BleveArticleDAO 基于 bleve 的嵌入式索引，数据存在本地磁盘，不依赖 ES 之类的外部搜索引擎
+// 多个实例部署的时候每个实例各自消费事件、各自维护一份索引
+type BleveArticleDAO struct {
+	index bleve.Index
+}
+
+func NewBleveArticleDAO(index bleve.Index) ArticleSearchDAO {
+	return &BleveArticleDAO{index: index}
+}
+
+// NewArticleIndexMapping 中文没有空格，用 cjk 分词器按二元组切分，
+// 比如 "分布式锁" 会切成 "分布"、"布式"、"式锁"，英文和数字还是按单词切分
+func NewArticleIndexMapping() mapping.IndexMapping {
+	text := bleve.NewTextFieldMapping()
+	text.Analyzer = cjk.AnalyzerName
+	// 高亮要用到词的位置
+	text.IncludeTermVectors = true
+
+	num := bleve.NewNumericFieldMapping()
+	num.Index = false
+
+	doc := bleve.NewDocumentMapping()
+	doc.AddFieldMappingsAt("title", text)
+	doc.AddFieldMappingsAt("content", text)
+	doc.AddFieldMappingsAt("author_name", text)
+	doc.AddFieldMappingsAt("author_id", num)
+	doc.AddFieldMappingsAt("utime", num)
+	doc.AddFieldMappingsAt("id", num)
+
+	im := bleve.NewIndexMapping()
+	im.DefaultMapping = doc
+	im.DefaultAnalyzer = cjk.AnalyzerName
+	return im
+}
+
+func (b *BleveArticleDAO) InputArticle(ctx context.Context, art Article) error {
+	return b.index.Index(strconv.FormatInt(art.Id, 10), art)
+}
+
+func (b *BleveArticleDAO) DeleteArticle(ctx context.Context, id int64) error {
+	return b.index.Delete(strconv.FormatInt(id, 10))
+}
+
+func (b *BleveArticleDAO) Count(ctx context.Context) (uint64, error) {
+	return b.index.DocCount()
+}
+
+func (b *BleveArticleDAO) SearchArticle(ctx context.Context, keyword string, offset int, limit int) (ArticleResult, error) {
+	// 标题命中比内容命中更重要
+	title := bleve.NewMatchQuery(keyword)
+	title.SetField("title")
+	title.SetBoost(3)
+	author := bleve.NewMatchQuery(keyword)
+	author.SetField("author_name")
+	author.SetBoost(2)
+	content := bleve.NewMatchQuery(keyword)
+	content.SetField("content")
+	q := bleve.NewDisjunctionQuery([]query.Query{title, author, content}...)
+
+	req := bleve.NewSearchRequestOptions(q, limit, offset, false)
+	req.Fields = []string{"title", "author_id", "author_name", "utime"}
+	req.Highlight = bleve.NewHighlightWithStyle(html.Name)
+	req.Highlight.AddField("title")
+	req.Highlight.AddField("content")
+	res, err := b.index.SearchInContext(ctx, req)
+	if err != nil {
+		return ArticleResult{}, err
+	}
+	hits := make([]ArticleHit, 0, len(res.Hits))
+	for _, h := range res.Hits {
+		id, err := strconv.ParseInt(h.ID, 10, 64)
+		if err != nil {
+			return ArticleResult{}, err
+		}
+		hits = append(hits, ArticleHit{
+			Article: Article{
+				Id:         id,
+				Title:      b.stringField(h.Fields, "title"),
+				AuthorId:   b.int64Field(h.Fields, "author_id"),
+				AuthorName: b.stringField(h.Fields, "author_name"),
+				Utime:      b.int64Field(h.Fields, "utime"),
+			},
+			Score:      h.Score,
+			Highlights: h.Fragments,
+		})
+	}
+	return ArticleResult{Total: res.Total, Hits: hits}, nil
+}
+
+func (b *BleveArticleDAO) stringField(fields map[string]any, name string) string {
+	val, _ := fields[name].(string)
+	return val
+}
+
+// int64Field bleve 里面数字都是按 float64 存的
+func (b *BleveArticleDAO) int64Field(fields map[string]any, name string) int64 {
+	val, _ := fields[name].(float64)
+	return int64(val)
+}
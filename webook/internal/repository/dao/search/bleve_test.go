@@ -0,0 +1,99 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBleveArticleDAO(t *testing.T) {
+	index, err := bleve.NewMemOnly(NewArticleIndexMapping())
+	require.NoError(t, err)
+	defer index.Close()
+	dao := NewBleveArticleDAO(index)
+	ctx := context.Background()
+
+	arts := []Article{
+		{Id: 1, Title: "Redis 分布式锁", Content: "用 SETNX 实现分布式锁，还要考虑续约", AuthorId: 11, AuthorName: "张三", Utime: 100},
+		{Id: 2, Title: "MySQL 索引", Content: "联合索引的最左匹配原则，和分布式没有关系", AuthorId: 12, AuthorName: "李四", Utime: 200},
+		{Id: 3, Title: "Kafka 入门", Content: "消费者组和分区", AuthorId: 11, AuthorName: "张三", Utime: 300},
+	}
+	for _, art := range arts {
+		require.NoError(t, dao.InputArticle(ctx, art))
+	}
+	// 重复写入是覆盖
+	require.NoError(t, dao.InputArticle(ctx, arts[0]))
+	cnt, err := dao.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), cnt)
+
+	testCases := []struct {
+		name     string
+		keyword  string
+		offset   int
+		limit    int
+		wantIds  []int64
+		wantHigh string
+	}{
+		{
+			name:    "中文标题命中排在前面",
+			keyword: "分布式锁",
+			limit:   10,
+			wantIds: []int64{1, 2},
+		},
+		{
+			name:    "英文单词",
+			keyword: "kafka",
+			limit:   10,
+			wantIds: []int64{3},
+		},
+		{
+			name:    "作者昵称",
+			keyword: "李四",
+			limit:   10,
+			wantIds: []int64{2},
+		},
+		{
+			name:    "分页",
+			keyword: "分布式锁",
+			offset:  1,
+			limit:   1,
+			wantIds: []int64{2},
+		},
+		{
+			name:    "没有命中",
+			keyword: "Go 语言",
+			limit:   10,
+			wantIds: []int64{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := dao.SearchArticle(ctx, tc.keyword, tc.offset, tc.limit)
+			require.NoError(t, err)
+			ids := make([]int64, 0, len(res.Hits))
+			for _, h := range res.Hits {
+				ids = append(ids, h.Id)
+			}
+			assert.Equal(t, tc.wantIds, ids)
+		})
+	}
+
+	res, err := dao.SearchArticle(ctx, "分布式锁", 0, 1)
+	require.NoError(t, err)
+	require.Len(t, res.Hits, 1)
+	assert.Equal(t, uint64(2), res.Total)
+	assert.Equal(t, "Redis 分布式锁", res.Hits[0].Title)
+	assert.Equal(t, "张三", res.Hits[0].AuthorName)
+	assert.Equal(t, int64(11), res.Hits[0].AuthorId)
+	assert.Contains(t, res.Hits[0].Highlights["title"][0], "<mark>")
+
+	// 撤回之后就搜不到了
+	require.NoError(t, dao.DeleteArticle(ctx, 1))
+	res, err = dao.SearchArticle(ctx, "分布式锁", 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), res.Total)
+}
@@ -0,0 +1,24 @@
+package search
+
+// Article 索引里面的文章，只存线上库的数据
+// 搜索的时候需要返回的字段要 store，要高亮的字段还要保留词的位置
+type Article struct {
+	Id         int64  `json:"id"`
+	Title      string `json:"title"`
+	Content    string `json:"content"`
+	AuthorId   int64  `json:"author_id"`
+	AuthorName string `json:"author_name"`
+	Utime      int64  `json:"utime"`
+}
+
+type ArticleHit struct {
+	Article
+	Score float64
+	// Highlights 字段名到高亮片段
+	Highlights map[string][]string
+}
+
+type ArticleResult struct {
+	Total uint64
+	Hits  []ArticleHit
+}
@@ -0,0 +1,14 @@
+package search
+
+import (
+	"context"
+)
+
+type ArticleSearchDAO interface {
+	// InputArticle 同一篇文章重复写入会覆盖，所以可以重复消费
+	InputArticle(ctx context.Context, art Article) error
+	DeleteArticle(ctx context.Context, id int64) error
+	SearchArticle(ctx context.Context, keyword string, offset int, limit int) (ArticleResult, error)
+	// Count 索引里面文章的数量
+	Count(ctx context.Context) (uint64, error)
+}
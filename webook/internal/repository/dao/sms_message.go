@@ -0,0 +1,78 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SmsMessage 和 async_sms 不一样，这里是每个手机号一条，记录服务商受理之后的状态
+type SmsMessage struct {
+	Id int64 `gorm:"primaryKey,autoIncrement"`
+	// 回执靠服务商和流水号找到短信。服务商直接拒绝的短信没有流水号，所以不能是唯一索引
+	Vendor string `gorm:"type:varchar(32);index:vendor_msg_id"`
+	MsgId  string `gorm:"type:varchar(128);index:vendor_msg_id"`
+	TplId  string `gorm:"type:varchar(64)"`
+	// 客服一般是按手机号查最近的短信
+	Number      string `gorm:"type:varchar(32);index:number_ctime"`
+	Status      uint8
+	Reason      string `gorm:"type:varchar(256)"`
+	DeliverTime int64
+	Ctime       int64 `gorm:"index:number_ctime"`
+	Utime       int64
+}
+
+type SmsMessageDAO interface {
+	Insert(ctx context.Context, msgs []SmsMessage) error
+	// UpdateStatus 按照 Vendor 和 MsgId 更新状态，只更新状态还是 from 的，回执重复推过来的时候返回 false
+	UpdateStatus(ctx context.Context, msg SmsMessage, from uint8) (bool, error)
+	FindByMsgId(ctx context.Context, vendor string, msgId string) (SmsMessage, error)
+	// Find 零值的条件不参与过滤，按时间倒序
+	Find(ctx context.Context, cond SmsMessage, offset int, limit int) ([]SmsMessage, error)
+}
+
+type GORMSmsMessageDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMSmsMessageDAO(db *gorm.DB) SmsMessageDAO {
+	return &GORMSmsMessageDAO{db: db}
+}
+
+func (dao *GORMSmsMessageDAO) Insert(ctx context.Context, msgs []SmsMessage) error {
+	now := time.Now().UnixMilli()
+	for i := range msgs {
+		msgs[i].Ctime, msgs[i].Utime = now, now
+	}
+	return dao.db.WithContext(ctx).Create(&msgs).Error
+}
+
+func (dao *GORMSmsMessageDAO) UpdateStatus(ctx context.Context, msg SmsMessage, from uint8) (bool, error) {
+	res := dao.db.WithContext(ctx).Model(&SmsMessage{}).
+		Where("vendor = ? AND msg_id = ? AND msg_id != '' AND status = ?", msg.Vendor, msg.MsgId, from).
+		Updates(map[string]any{
+			"status":       msg.Status,
+			"reason":       msg.Reason,
+			"deliver_time": msg.DeliverTime,
+			"utime":        time.Now().UnixMilli(),
+		})
+	return res.RowsAffected > 0, res.Error
+}
+
+func (dao *GORMSmsMessageDAO) FindByMsgId(ctx context.Context, vendor string, msgId string) (SmsMessage, error) {
+	var res SmsMessage
+	err := dao.db.WithContext(ctx).Where("vendor = ? AND msg_id = ?", vendor, msgId).First(&res).Error
+	return res, err
+}
+
+func (dao *GORMSmsMessageDAO) Find(ctx context.Context, cond SmsMessage, offset int, limit int) ([]SmsMessage, error) {
+	var res []SmsMessage
+	// gorm 用结构体做条件的时候会忽略零值
+	err := dao.db.WithContext(ctx).Where(&SmsMessage{
+		Vendor: cond.Vendor,
+		MsgId:  cond.MsgId,
+		Number: cond.Number,
+	}).Order("id DESC").Offset(offset).Limit(limit).Find(&res).Error
+	return res, err
+}
@@ -0,0 +1,107 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrTotpNotPending = errors.New("没有待确认的 TOTP 密钥")
+
+// UserTotp 用户绑定的 TOTP 密钥
+type UserTotp struct {
+	Id  int64 `gorm:"primaryKey,autoIncrement"`
+	Uid int64 `gorm:"unique"`
+	// Secret base32 编码，生产环境应该加密存储
+	Secret  string `gorm:"type:varchar(64)"`
+	Enabled bool
+	Ctime   int64
+	Utime   int64
+}
+
+// RecoveryCode 验证器丢了的时候用的恢复码，只存哈希，每个只能用一次
+type RecoveryCode struct {
+	Id       int64  `gorm:"primaryKey,autoIncrement"`
+	Uid      int64  `gorm:"uniqueIndex:uid_code"`
+	CodeHash string `gorm:"type:varchar(64);uniqueIndex:uid_code"`
+	// UsedAt 0 就是还没用过
+	UsedAt int64
+	Ctime  int64
+}
+
+type TwoFactorDAO interface {
+	// UpsertPending 保存一个待确认的密钥，已经启用的不会被覆盖
+	UpsertPending(ctx context.Context, uid int64, secret string) error
+	FindTotp(ctx context.Context, uid int64) (UserTotp, error)
+	// Enable 确认启用，同时换掉所有的恢复码
+	Enable(ctx context.Context, uid int64, codeHashes []string) error
+	Delete(ctx context.Context, uid int64) error
+	// UseRecoveryCode 恢复码存在并且没用过就标记为已使用，返回 true
+	UseRecoveryCode(ctx context.Context, uid int64, codeHash string) (bool, error)
+}
+
+type GORMTwoFactorDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMTwoFactorDAO(db *gorm.DB) TwoFactorDAO {
+	return &GORMTwoFactorDAO{db: db}
+}
+
+func (dao *GORMTwoFactorDAO) UpsertPending(ctx context.Context, uid int64, secret string) error {
+	now := time.Now().UnixMilli()
+	return dao.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			// 已经启用了就保留原来的密钥，避免并发的时候把正在用的密钥覆盖掉
+			"secret": gorm.Expr("IF(enabled, secret, ?)", secret),
+			"utime":  now,
+		}),
+	}).Create(&UserTotp{Uid: uid, Secret: secret, Ctime: now, Utime: now}).Error
+}
+
+func (dao *GORMTwoFactorDAO) FindTotp(ctx context.Context, uid int64) (UserTotp, error) {
+	var res UserTotp
+	err := dao.db.WithContext(ctx).Where("uid = ?", uid).First(&res).Error
+	return res, err
+}
+
+func (dao *GORMTwoFactorDAO) Enable(ctx context.Context, uid int64, codeHashes []string) error {
+	now := time.Now().UnixMilli()
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&UserTotp{}).Where("uid = ? AND enabled = ?", uid, false).
+			Updates(map[string]any{"enabled": true, "utime": now})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrTotpNotPending
+		}
+		if err := tx.Where("uid = ?", uid).Delete(&RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		codes := make([]RecoveryCode, 0, len(codeHashes))
+		for _, h := range codeHashes {
+			codes = append(codes, RecoveryCode{Uid: uid, CodeHash: h, Ctime: now})
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+func (dao *GORMTwoFactorDAO) Delete(ctx context.Context, uid int64) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("uid = ?", uid).Delete(&RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("uid = ?", uid).Delete(&UserTotp{}).Error
+	})
+}
+
+func (dao *GORMTwoFactorDAO) UseRecoveryCode(ctx context.Context, uid int64, codeHash string) (bool, error) {
+	res := dao.db.WithContext(ctx).Model(&RecoveryCode{}).
+		Where("uid = ? AND code_hash = ? AND used_at = ?", uid, codeHash, 0).
+		Update("used_at", time.Now().UnixMilli())
+	return res.RowsAffected == 1, res.Error
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/go-sql-driver/mysql"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var (
@@ -23,11 +24,15 @@ type User struct {
 	// 正确处理 phone 的 NULL 值
 	// 在有唯一索引的字段中，可以有多个 NULL 值，
 	// 但如果字段是空字符串 ("")，则不允许有多个空字符串，数据库会将其视为相同的值，从而违反唯一索引约束
-	Phone         sql.NullString `gorm:"unique"`
+	Phone sql.NullString `gorm:"unique"`
+	// Deprecated: 第三方账号都放到 UserIdentity 里面了，这两列只用来兼容还没迁移的老数据
 	WechatOpenId  sql.NullString `gorm:"unique"`
 	WechatUnionId sql.NullString `gorm:"unique"`
-	Ctime         int64
-	Utime         int64
+	EmailVerified bool
+	// Status 0 正常，1 封禁
+	Status uint8
+	Ctime  int64
+	Utime  int64
 }
 
 // TableName 自定义表名
@@ -38,10 +43,36 @@ type User struct {
 type UserDAO interface {
 	Insert(ctx context.Context, u User) error
 	FindByEmail(ctx context.Context, email string) (User, error)
+	// UpdatePassword 同时把邮箱标记为已验证，能走到改密码说明收到了邮箱验证码
+	UpdatePassword(ctx context.Context, id int64, password string) error
+	SetEmailVerified(ctx context.Context, id int64) error
 	UpdateNonZeroFields(ctx context.Context, u User) error
 	FindById(ctx context.Context, id int64) (User, error)
 	FindByPhone(ctx context.Context, phone string) (User, error)
+	// InsertWithIdentity 第三方登录的新用户，用户和绑定关系在一个事务里面插入
+	InsertWithIdentity(ctx context.Context, u User, identity UserIdentity) error
+	InsertIdentity(ctx context.Context, identity UserIdentity) error
+	FindByIdentity(ctx context.Context, provider, subject string) (User, error)
+	FindIdentities(ctx context.Context, uid int64) ([]UserIdentity, error)
 	FindByWechat(ctx context.Context, openId string) (User, error)
+	// UpdatePhone phone 为空就是解绑，已经被别人绑定了返回 ErrUserDuplicate
+	UpdatePhone(ctx context.Context, id int64, phone string) error
+	// UpdateEmail email 为空就是解绑，绑定的邮箱都是验证过的
+	UpdateEmail(ctx context.Context, id int64, email string) error
+	// DeleteIdentity 解绑第三方账号，没有绑定过返回 ErrDataNotFound
+	DeleteIdentity(ctx context.Context, uid int64, provider string) error
+	// ClearWechat 清掉老数据里面的微信账号
+	ClearWechat(ctx context.Context, id int64) error
+	// Merge 把 fromId 的第三方账号转给 toId，toId 没有手机号或者邮箱的话把 fromId 的也转过去，最后删掉 fromId
+	Merge(ctx context.Context, fromId, toId int64) error
+	UpdateStatus(ctx context.Context, id int64, status uint8) error
+	FindRoles(ctx context.Context, uid int64) ([]UserRole, error)
+	// InsertRole 已经有这个角色了也算成功
+	InsertRole(ctx context.Context, uid int64, role string) error
+	// DeleteRole 没有这个角色返回 ErrDataNotFound
+	DeleteRole(ctx context.Context, uid int64, role string) error
+	// Anonymize 注销账号，抹掉个人信息和所有登录方式，用户记录保留下来，文章、评论还能关联上
+	Anonymize(ctx context.Context, id int64, nickname string, status uint8) error
 }
 
 type GORMUserDAO struct {
@@ -59,6 +90,10 @@ func (dao *GORMUserDAO) Insert(ctx context.Context, u User) error {
 	u.Ctime = now
 	u.Utime = now
 	err := dao.db.WithContext(ctx).Create(&u).Error
+	return dao.duplicateErr(err)
+}
+
+func (dao *GORMUserDAO) duplicateErr(err error) error {
 	var mysqlErr *mysql.MySQLError
 	if errors.As(err, &mysqlErr) {
 		const uniqueIndexErrNo uint16 = 1062
@@ -85,6 +120,21 @@ func (dao *GORMUserDAO) UpdateNonZeroFields(ctx context.Context, u User) error {
 	return dao.db.WithContext(ctx).Updates(&u).Error
 }
 
+func (dao *GORMUserDAO) UpdatePassword(ctx context.Context, id int64, password string) error {
+	return dao.db.WithContext(ctx).Model(&User{}).Where("id = ?", id).Updates(map[string]any{
+		"password":       password,
+		"email_verified": true,
+		"utime":          time.Now().UnixMilli(),
+	}).Error
+}
+
+func (dao *GORMUserDAO) SetEmailVerified(ctx context.Context, id int64) error {
+	return dao.db.WithContext(ctx).Model(&User{}).Where("id = ?", id).Updates(map[string]any{
+		"email_verified": true,
+		"utime":          time.Now().UnixMilli(),
+	}).Error
+}
+
 func (dao *GORMUserDAO) FindById(ctx context.Context, id int64) (User, error) {
 	var u User
 	err := dao.db.WithContext(ctx).Where("id = ?", id).First(&u).Error
@@ -108,3 +158,194 @@ func (dao *GORMUserDAO) FindByWechat(ctx context.Context, openId string) (User,
 	}
 	return u, nil
 }
+
+func (dao *GORMUserDAO) InsertWithIdentity(ctx context.Context, u User, identity UserIdentity) error {
+	now := time.Now().UnixMilli()
+	u.Ctime, u.Utime = now, now
+	identity.Ctime, identity.Utime = now, now
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&u).Error; err != nil {
+			return err
+		}
+		identity.Uid = u.Id
+		return tx.Create(&identity).Error
+	})
+	return dao.duplicateErr(err)
+}
+
+func (dao *GORMUserDAO) InsertIdentity(ctx context.Context, identity UserIdentity) error {
+	now := time.Now().UnixMilli()
+	identity.Ctime, identity.Utime = now, now
+	return dao.duplicateErr(dao.db.WithContext(ctx).Create(&identity).Error)
+}
+
+func (dao *GORMUserDAO) FindByIdentity(ctx context.Context, provider, subject string) (User, error) {
+	var u User
+	err := dao.db.WithContext(ctx).
+		Joins("JOIN user_identities ON user_identities.uid = users.id").
+		Where("user_identities.provider = ? AND user_identities.subject = ?", provider, subject).
+		First(&u).Error
+	return u, err
+}
+
+func (dao *GORMUserDAO) FindIdentities(ctx context.Context, uid int64) ([]UserIdentity, error) {
+	var res []UserIdentity
+	err := dao.db.WithContext(ctx).Where("uid = ?", uid).Order("id").Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMUserDAO) UpdatePhone(ctx context.Context, id int64, phone string) error {
+	err := dao.db.WithContext(ctx).Model(&User{}).Where("id = ?", id).Updates(map[string]any{
+		"phone": sql.NullString{String: phone, Valid: phone != ""},
+		"utime": time.Now().UnixMilli(),
+	}).Error
+	return dao.duplicateErr(err)
+}
+
+func (dao *GORMUserDAO) UpdateEmail(ctx context.Context, id int64, email string) error {
+	err := dao.db.WithContext(ctx).Model(&User{}).Where("id = ?", id).Updates(map[string]any{
+		"email":          sql.NullString{String: email, Valid: email != ""},
+		"email_verified": email != "",
+		"utime":          time.Now().UnixMilli(),
+	}).Error
+	return dao.duplicateErr(err)
+}
+
+func (dao *GORMUserDAO) DeleteIdentity(ctx context.Context, uid int64, provider string) error {
+	res := dao.db.WithContext(ctx).Where("uid = ? AND provider = ?", uid, provider).Delete(&UserIdentity{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrDataNotFound
+	}
+	return nil
+}
+
+func (dao *GORMUserDAO) ClearWechat(ctx context.Context, id int64) error {
+	return dao.db.WithContext(ctx).Model(&User{}).Where("id = ?", id).Updates(map[string]any{
+		"wechat_open_id":  sql.NullString{},
+		"wechat_union_id": sql.NullString{},
+		"utime":           time.Now().UnixMilli(),
+	}).Error
+}
+
+func (dao *GORMUserDAO) Merge(ctx context.Context, fromId, toId int64) error {
+	now := time.Now().UnixMilli()
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var from, to User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", fromId).First(&from).Error; err != nil {
+			return err
+		}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", toId).First(&to).Error; err != nil {
+			return err
+		}
+		err := tx.Model(&UserIdentity{}).Where("uid = ?", fromId).
+			Updates(map[string]any{"uid": toId, "utime": now}).Error
+		if err != nil {
+			return err
+		}
+		updates := map[string]any{"utime": now}
+		if !to.Phone.Valid && from.Phone.Valid {
+			updates["phone"] = from.Phone
+		}
+		if !to.Email.Valid && from.Email.Valid {
+			updates["email"] = from.Email
+			updates["email_verified"] = from.EmailVerified
+			// 密码是跟着邮箱走的
+			if to.Password == "" {
+				updates["password"] = from.Password
+			}
+		}
+		// 角色不合并，免得合并账号变成了提权
+		if err = tx.Where("uid = ?", fromId).Delete(&UserRole{}).Error; err != nil {
+			return err
+		}
+		// 唯一索引，先删掉 from 才能把手机号和邮箱转过去
+		if err = tx.Delete(&User{}, fromId).Error; err != nil {
+			return err
+		}
+		return tx.Model(&User{}).Where("id = ?", toId).Updates(updates).Error
+	})
+}
+
+func (dao *GORMUserDAO) UpdateStatus(ctx context.Context, id int64, status uint8) error {
+	res := dao.db.WithContext(ctx).Model(&User{}).Where("id = ?", id).Updates(map[string]any{
+		"status": status,
+		"utime":  time.Now().UnixMilli(),
+	})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrDataNotFound
+	}
+	return nil
+}
+
+func (dao *GORMUserDAO) FindRoles(ctx context.Context, uid int64) ([]UserRole, error) {
+	var res []UserRole
+	err := dao.db.WithContext(ctx).Where("uid = ?", uid).Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMUserDAO) InsertRole(ctx context.Context, uid int64, role string) error {
+	return dao.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&UserRole{
+		Uid:   uid,
+		Role:  role,
+		Ctime: time.Now().UnixMilli(),
+	}).Error
+}
+
+func (dao *GORMUserDAO) DeleteRole(ctx context.Context, uid int64, role string) error {
+	res := dao.db.WithContext(ctx).Where("uid = ? AND role = ?", uid, role).Delete(&UserRole{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrDataNotFound
+	}
+	return nil
+}
+
+func (dao *GORMUserDAO) Anonymize(ctx context.Context, id int64, nickname string, status uint8) error {
+	now := time.Now().UnixMilli()
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, model := range []any{&UserIdentity{}, &UserRole{}, &UserTotp{}, &RecoveryCode{}} {
+			if err := tx.Where("uid = ?", id).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&User{}).Where("id = ?", id).Updates(map[string]any{
+			"nickname":        sql.NullString{String: nickname, Valid: true},
+			"email":           sql.NullString{},
+			"phone":           sql.NullString{},
+			"password":        "",
+			"wechat_open_id":  sql.NullString{},
+			"wechat_union_id": sql.NullString{},
+			"email_verified":  false,
+			"status":          status,
+			"utime":           now,
+		}).Error
+	})
+}
+
+// UserRole 用户的角色，一个用户可以有多个角色，角色对应哪些权限见 domain.PermissionsOf
+type UserRole struct {
+	Id    int64  `gorm:"primaryKey,autoIncrement"`
+	Uid   int64  `gorm:"uniqueIndex:uid_role"`
+	Role  string `gorm:"type:varchar(64);uniqueIndex:uid_role"`
+	Ctime int64
+}
+
+// UserIdentity 用户绑定的第三方账号，同一个平台的同一个账号只能绑定一个用户
+type UserIdentity struct {
+	Id       int64  `gorm:"primaryKey,autoIncrement"`
+	Uid      int64  `gorm:"index"`
+	Provider string `gorm:"type:varchar(64);uniqueIndex:provider_subject"`
+	Subject  string `gorm:"type:varchar(255);uniqueIndex:provider_subject"`
+	Email    string `gorm:"type:varchar(255)"`
+	Name     string `gorm:"type:varchar(255)"`
+	Ctime    int64
+	Utime    int64
+}
@@ -2,10 +2,104 @@ package repository
 
 import (
 	"context"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
 
 	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/repository/cache"
+	"github.com/liupch66/basic-go/webook/internal/repository/dao"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
 )
 
 type HistoryRecordRepository interface {
 	AddRecord(ctx context.Context, r domain.HistoryRecord) error
+	List(ctx context.Context, uid int64, offset int, limit int) ([]domain.HistoryRecord, error)
+	DeleteRecord(ctx context.Context, uid int64, biz string, bizId int64) error
+	ClearRecords(ctx context.Context, uid int64) error
+}
+
+type CachedHistoryRecordRepository struct {
+	dao   dao.HistoryRecordDAO
+	cache cache.HistoryRecordCache
+	l     logger.LoggerV1
+	// 每个用户最多保留这么多条
+	maxRecords int
+}
+
+func NewCachedHistoryRecordRepository(dao dao.HistoryRecordDAO, cache cache.HistoryRecordCache,
+	l logger.LoggerV1) HistoryRecordRepository {
+	return &CachedHistoryRecordRepository{dao: dao, cache: cache, l: l, maxRecords: 1000}
+}
+
+func (repo *CachedHistoryRecordRepository) AddRecord(ctx context.Context, r domain.HistoryRecord) error {
+	ok, err := repo.cache.MarkRead(ctx, r.Uid, r.Biz, r.BizId)
+	if err != nil {
+		// redis 出问题了就直接写数据库，多写几次也没什么
+		repo.l.Error("历史记录去重失败", logger.Int64("uid", r.Uid), logger.Int64("biz_id", r.BizId), logger.Error(err))
+	} else if !ok {
+		// 刚刚读过，不用再写了
+		return nil
+	}
+	marked := err == nil
+	err = repo.dao.Upsert(ctx, dao.HistoryRecord{Uid: r.Uid, Biz: r.Biz, BizId: r.BizId})
+	if err != nil {
+		// 标记是先打上的，数据库没写进去就要删掉，不然窗口内重试的消息都会被当成重复
+		if marked {
+			if er := repo.cache.UnmarkRead(ctx, r.Uid, r.Biz, r.BizId); er != nil {
+				repo.l.Error("删除历史记录去重标记失败", logger.Int64("uid", r.Uid), logger.Int64("biz_id", r.BizId), logger.Error(er))
+			}
+		}
+		return err
+	}
+	// 清理超出上限的记录失败了也不影响这一次的记录，下一次再清理
+	if er := repo.dao.Trim(ctx, r.Uid, repo.maxRecords); er != nil {
+		repo.l.Error("清理历史记录失败", logger.Int64("uid", r.Uid), logger.Error(er))
+	}
+	return nil
+}
+
+func (repo *CachedHistoryRecordRepository) List(ctx context.Context, uid int64, offset int, limit int) ([]domain.HistoryRecord, error) {
+	rs, err := repo.dao.FindByUid(ctx, uid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(rs, func(idx int, src dao.HistoryRecord) domain.HistoryRecord {
+		return domain.HistoryRecord{
+			Biz:   src.Biz,
+			BizId: src.BizId,
+			Uid:   src.Uid,
+			Utime: time.UnixMilli(src.Utime),
+		}
+	}), nil
+}
+
+func (repo *CachedHistoryRecordRepository) DeleteRecord(ctx context.Context, uid int64, biz string, bizId int64) error {
+	err := repo.dao.Delete(ctx, uid, biz, bizId)
+	if err != nil {
+		return err
+	}
+	if er := repo.cache.UnmarkRead(ctx, uid, biz, bizId); er != nil {
+		repo.l.Error("删除历史记录去重标记失败", logger.Int64("uid", uid), logger.Int64("biz_id", bizId), logger.Error(er))
+	}
+	return nil
+}
+
+func (repo *CachedHistoryRecordRepository) ClearRecords(ctx context.Context, uid int64) error {
+	// 每个用户最多 maxRecords 条，先查出来，删完之后再删掉它们的去重标记
+	rs, err := repo.dao.FindByUid(ctx, uid, 0, repo.maxRecords)
+	if err != nil {
+		return err
+	}
+	err = repo.dao.DeleteAll(ctx, uid)
+	if err != nil {
+		return err
+	}
+	er := repo.cache.UnmarkReads(ctx, uid, slice.Map(rs, func(idx int, src dao.HistoryRecord) domain.HistoryRecord {
+		return domain.HistoryRecord{Biz: src.Biz, BizId: src.BizId}
+	}))
+	if er != nil {
+		repo.l.Error("删除历史记录去重标记失败", logger.Int64("uid", uid), logger.Error(er))
+	}
+	return nil
 }
@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: async_sms.go
+//
+// Generated by this command:
+//
+//	mockgen -package=repomocks -source=async_sms.go -destination=mocks/async_sms_mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/liupch66/basic-go/webook/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAsyncSmsRepository is a mock of AsyncSmsRepository interface.
+type MockAsyncSmsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAsyncSmsRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAsyncSmsRepositoryMockRecorder is the mock recorder for MockAsyncSmsRepository.
+type MockAsyncSmsRepositoryMockRecorder struct {
+	mock *MockAsyncSmsRepository
+}
+
+// NewMockAsyncSmsRepository creates a new mock instance.
+func NewMockAsyncSmsRepository(ctrl *gomock.Controller) *MockAsyncSmsRepository {
+	mock := &MockAsyncSmsRepository{ctrl: ctrl}
+	mock.recorder = &MockAsyncSmsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAsyncSmsRepository) EXPECT() *MockAsyncSmsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockAsyncSmsRepository) Add(ctx context.Context, s domain.AsyncSms) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, s)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockAsyncSmsRepositoryMockRecorder) Add(ctx, s any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockAsyncSmsRepository)(nil).Add), ctx, s)
+}
+
+// PreemptWaitingSMS mocks base method.
+func (m *MockAsyncSmsRepository) PreemptWaitingSMS(ctx context.Context, lease time.Duration) (domain.AsyncSms, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreemptWaitingSMS", ctx, lease)
+	ret0, _ := ret[0].(domain.AsyncSms)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreemptWaitingSMS indicates an expected call of PreemptWaitingSMS.
+func (mr *MockAsyncSmsRepositoryMockRecorder) PreemptWaitingSMS(ctx, lease any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreemptWaitingSMS", reflect.TypeOf((*MockAsyncSmsRepository)(nil).PreemptWaitingSMS), ctx, lease)
+}
+
+// ReportScheduleResult mocks base method.
+func (m *MockAsyncSmsRepository) ReportScheduleResult(ctx context.Context, id int64, success bool, nextTime time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReportScheduleResult", ctx, id, success, nextTime)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReportScheduleResult indicates an expected call of ReportScheduleResult.
+func (mr *MockAsyncSmsRepositoryMockRecorder) ReportScheduleResult(ctx, id, success, nextTime any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportScheduleResult", reflect.TypeOf((*MockAsyncSmsRepository)(nil).ReportScheduleResult), ctx, id, success, nextTime)
+}
@@ -40,6 +40,21 @@ func (m *MockCodeRepository) EXPECT() *MockCodeRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Sendable mocks base method.
+func (m *MockCodeRepository) Sendable(ctx context.Context, biz, phone string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sendable", ctx, biz, phone)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sendable indicates an expected call of Sendable.
+func (mr *MockCodeRepositoryMockRecorder) Sendable(ctx, biz, phone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sendable", reflect.TypeOf((*MockCodeRepository)(nil).Sendable), ctx, biz, phone)
+}
+
 // Store mocks base method.
 func (m *MockCodeRepository) Store(ctx context.Context, biz, phone, code string) error {
 	m.ctrl.T.Helper()
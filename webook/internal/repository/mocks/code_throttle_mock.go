@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: code_throttle.go
+//
+// Generated by this command:
+//
+//	mockgen -package=repomocks -source=code_throttle.go -destination=mocks/code_throttle_mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/liupch66/basic-go/webook/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCodeThrottleRepository is a mock of CodeThrottleRepository interface.
+type MockCodeThrottleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCodeThrottleRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCodeThrottleRepositoryMockRecorder is the mock recorder for MockCodeThrottleRepository.
+type MockCodeThrottleRepositoryMockRecorder struct {
+	mock *MockCodeThrottleRepository
+}
+
+// NewMockCodeThrottleRepository creates a new mock instance.
+func NewMockCodeThrottleRepository(ctrl *gomock.Controller) *MockCodeThrottleRepository {
+	mock := &MockCodeThrottleRepository{ctrl: ctrl}
+	mock.recorder = &MockCodeThrottleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCodeThrottleRepository) EXPECT() *MockCodeThrottleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Acquire mocks base method.
+func (m *MockCodeThrottleRepository) Acquire(ctx context.Context, biz string, rules []domain.CodeThrottleRule, captchaPassed bool) (domain.CodeThrottleResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Acquire", ctx, biz, rules, captchaPassed)
+	ret0, _ := ret[0].(domain.CodeThrottleResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Acquire indicates an expected call of Acquire.
+func (mr *MockCodeThrottleRepositoryMockRecorder) Acquire(ctx, biz, rules, captchaPassed any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Acquire", reflect.TypeOf((*MockCodeThrottleRepository)(nil).Acquire), ctx, biz, rules, captchaPassed)
+}
@@ -0,0 +1,204 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: privacy_request.go
+//
+// Generated by this command:
+//
+//	mockgen -package=repomocks -source=privacy_request.go -destination=mocks/privacy_request_mock.go PrivacyRequestRepository
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/liupch66/basic-go/webook/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPrivacyRequestRepository is a mock of PrivacyRequestRepository interface.
+type MockPrivacyRequestRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPrivacyRequestRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPrivacyRequestRepositoryMockRecorder is the mock recorder for MockPrivacyRequestRepository.
+type MockPrivacyRequestRepositoryMockRecorder struct {
+	mock *MockPrivacyRequestRepository
+}
+
+// NewMockPrivacyRequestRepository creates a new mock instance.
+func NewMockPrivacyRequestRepository(ctrl *gomock.Controller) *MockPrivacyRequestRepository {
+	mock := &MockPrivacyRequestRepository{ctrl: ctrl}
+	mock.recorder = &MockPrivacyRequestRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPrivacyRequestRepository) EXPECT() *MockPrivacyRequestRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Cancel mocks base method.
+func (m *MockPrivacyRequestRepository) Cancel(ctx context.Context, id, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, id, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockPrivacyRequestRepositoryMockRecorder) Cancel(ctx, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockPrivacyRequestRepository)(nil).Cancel), ctx, id, uid)
+}
+
+// Create mocks base method.
+func (m *MockPrivacyRequestRepository) Create(ctx context.Context, r domain.PrivacyRequest) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, r)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPrivacyRequestRepositoryMockRecorder) Create(ctx, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPrivacyRequestRepository)(nil).Create), ctx, r)
+}
+
+// DeleteFiles mocks base method.
+func (m *MockPrivacyRequestRepository) DeleteFiles(ctx context.Context, id int64, names []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFiles", ctx, id, names)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFiles indicates an expected call of DeleteFiles.
+func (mr *MockPrivacyRequestRepositoryMockRecorder) DeleteFiles(ctx, id, names any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFiles", reflect.TypeOf((*MockPrivacyRequestRepository)(nil).DeleteFiles), ctx, id, names)
+}
+
+// FindActive mocks base method.
+func (m *MockPrivacyRequestRepository) FindActive(ctx context.Context, uid int64, typ domain.PrivacyRequestType) (domain.PrivacyRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActive", ctx, uid, typ)
+	ret0, _ := ret[0].(domain.PrivacyRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActive indicates an expected call of FindActive.
+func (mr *MockPrivacyRequestRepositoryMockRecorder) FindActive(ctx, uid, typ any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActive", reflect.TypeOf((*MockPrivacyRequestRepository)(nil).FindActive), ctx, uid, typ)
+}
+
+// FindById mocks base method.
+func (m *MockPrivacyRequestRepository) FindById(ctx context.Context, id int64) (domain.PrivacyRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, id)
+	ret0, _ := ret[0].(domain.PrivacyRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockPrivacyRequestRepositoryMockRecorder) FindById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockPrivacyRequestRepository)(nil).FindById), ctx, id)
+}
+
+// FindDue mocks base method.
+func (m *MockPrivacyRequestRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]domain.PrivacyRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDue", ctx, now, limit)
+	ret0, _ := ret[0].([]domain.PrivacyRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDue indicates an expected call of FindDue.
+func (mr *MockPrivacyRequestRepositoryMockRecorder) FindDue(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDue", reflect.TypeOf((*MockPrivacyRequestRepository)(nil).FindDue), ctx, now, limit)
+}
+
+// GetFile mocks base method.
+func (m *MockPrivacyRequestRepository) GetFile(ctx context.Context, id int64, name string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFile", ctx, id, name)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFile indicates an expected call of GetFile.
+func (mr *MockPrivacyRequestRepositoryMockRecorder) GetFile(ctx, id, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFile", reflect.TypeOf((*MockPrivacyRequestRepository)(nil).GetFile), ctx, id, name)
+}
+
+// List mocks base method.
+func (m *MockPrivacyRequestRepository) List(ctx context.Context, uid int64, offset, limit int) ([]domain.PrivacyRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.PrivacyRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockPrivacyRequestRepositoryMockRecorder) List(ctx, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPrivacyRequestRepository)(nil).List), ctx, uid, offset, limit)
+}
+
+// ListFiles mocks base method.
+func (m *MockPrivacyRequestRepository) ListFiles(ctx context.Context, id int64) (map[string][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFiles", ctx, id)
+	ret0, _ := ret[0].(map[string][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFiles indicates an expected call of ListFiles.
+func (mr *MockPrivacyRequestRepositoryMockRecorder) ListFiles(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFiles", reflect.TypeOf((*MockPrivacyRequestRepository)(nil).ListFiles), ctx, id)
+}
+
+// SaveFile mocks base method.
+func (m *MockPrivacyRequestRepository) SaveFile(ctx context.Context, id int64, name string, content []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveFile", ctx, id, name, content)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveFile indicates an expected call of SaveFile.
+func (mr *MockPrivacyRequestRepositoryMockRecorder) SaveFile(ctx, id, name, content any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveFile", reflect.TypeOf((*MockPrivacyRequestRepository)(nil).SaveFile), ctx, id, name, content)
+}
+
+// SaveProgress mocks base method.
+func (m *MockPrivacyRequestRepository) SaveProgress(ctx context.Context, r domain.PrivacyRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveProgress", ctx, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveProgress indicates an expected call of SaveProgress.
+func (mr *MockPrivacyRequestRepositoryMockRecorder) SaveProgress(ctx, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveProgress", reflect.TypeOf((*MockPrivacyRequestRepository)(nil).SaveProgress), ctx, r)
+}
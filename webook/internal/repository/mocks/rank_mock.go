@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: rank.go
+//
+// Generated by this command:
+//
+//	mockgen -package=repomocks -source=rank.go -destination=mocks/rank_mock.go RankRepository
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/liupch66/basic-go/webook/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRankRepository is a mock of RankRepository interface.
+type MockRankRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRankRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRankRepositoryMockRecorder is the mock recorder for MockRankRepository.
+type MockRankRepositoryMockRecorder struct {
+	mock *MockRankRepository
+}
+
+// NewMockRankRepository creates a new mock instance.
+func NewMockRankRepository(ctrl *gomock.Controller) *MockRankRepository {
+	mock := &MockRankRepository{ctrl: ctrl}
+	mock.recorder = &MockRankRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRankRepository) EXPECT() *MockRankRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetAuthorTopN mocks base method.
+func (m *MockRankRepository) GetAuthorTopN(ctx context.Context, authorId int64) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthorTopN", ctx, authorId)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthorTopN indicates an expected call of GetAuthorTopN.
+func (mr *MockRankRepositoryMockRecorder) GetAuthorTopN(ctx, authorId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthorTopN", reflect.TypeOf((*MockRankRepository)(nil).GetAuthorTopN), ctx, authorId)
+}
+
+// GetTopN mocks base method.
+func (m *MockRankRepository) GetTopN(ctx context.Context, board string) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTopN", ctx, board)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTopN indicates an expected call of GetTopN.
+func (mr *MockRankRepositoryMockRecorder) GetTopN(ctx, board any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopN", reflect.TypeOf((*MockRankRepository)(nil).GetTopN), ctx, board)
+}
+
+// ReplaceAuthorTopN mocks base method.
+func (m *MockRankRepository) ReplaceAuthorTopN(ctx context.Context, authorId int64, arts []domain.Article) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceAuthorTopN", ctx, authorId, arts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceAuthorTopN indicates an expected call of ReplaceAuthorTopN.
+func (mr *MockRankRepositoryMockRecorder) ReplaceAuthorTopN(ctx, authorId, arts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceAuthorTopN", reflect.TypeOf((*MockRankRepository)(nil).ReplaceAuthorTopN), ctx, authorId, arts)
+}
+
+// ReplaceTopN mocks base method.
+func (m *MockRankRepository) ReplaceTopN(ctx context.Context, board string, arts []domain.Article) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceTopN", ctx, board, arts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceTopN indicates an expected call of ReplaceTopN.
+func (mr *MockRankRepositoryMockRecorder) ReplaceTopN(ctx, board, arts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceTopN", reflect.TypeOf((*MockRankRepository)(nil).ReplaceTopN), ctx, board, arts)
+}
@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: rank_score.go
+//
+// Generated by this command:
+//
+//	mockgen -package=repomocks -source=rank_score.go -destination=mocks/rank_score_mock.go RankScoreRepository
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRankScoreRepository is a mock of RankScoreRepository interface.
+type MockRankScoreRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRankScoreRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRankScoreRepositoryMockRecorder is the mock recorder for MockRankScoreRepository.
+type MockRankScoreRepositoryMockRecorder struct {
+	mock *MockRankScoreRepository
+}
+
+// NewMockRankScoreRepository creates a new mock instance.
+func NewMockRankScoreRepository(ctrl *gomock.Controller) *MockRankScoreRepository {
+	mock := &MockRankScoreRepository{ctrl: ctrl}
+	mock.recorder = &MockRankScoreRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRankScoreRepository) EXPECT() *MockRankScoreRepositoryMockRecorder {
+	return m.recorder
+}
+
+// IncrScore mocks base method.
+func (m *MockRankScoreRepository) IncrScore(ctx context.Context, aid int64, delta float64, t time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrScore", ctx, aid, delta, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrScore indicates an expected call of IncrScore.
+func (mr *MockRankScoreRepositoryMockRecorder) IncrScore(ctx, aid, delta, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrScore", reflect.TypeOf((*MockRankScoreRepository)(nil).IncrScore), ctx, aid, delta, t)
+}
+
+// RemoveScore mocks base method.
+func (m *MockRankScoreRepository) RemoveScore(ctx context.Context, aids []int64, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveScore", ctx, aids, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveScore indicates an expected call of RemoveScore.
+func (mr *MockRankScoreRepositoryMockRecorder) RemoveScore(ctx, aids, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveScore", reflect.TypeOf((*MockRankScoreRepository)(nil).RemoveScore), ctx, aids, now)
+}
+
+// ResetScore mocks base method.
+func (m *MockRankScoreRepository) ResetScore(ctx context.Context, aid int64, score float64, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetScore", ctx, aid, score, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetScore indicates an expected call of ResetScore.
+func (mr *MockRankScoreRepositoryMockRecorder) ResetScore(ctx, aid, score, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetScore", reflect.TypeOf((*MockRankScoreRepository)(nil).ResetScore), ctx, aid, score, now)
+}
+
+// Scores mocks base method.
+func (m *MockRankScoreRepository) Scores(ctx context.Context, now time.Time, n int) (map[int64]float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Scores", ctx, now, n)
+	ret0, _ := ret[0].(map[int64]float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Scores indicates an expected call of Scores.
+func (mr *MockRankScoreRepositoryMockRecorder) Scores(ctx, now, n any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scores", reflect.TypeOf((*MockRankScoreRepository)(nil).Scores), ctx, now, n)
+}
+
+// TopIds mocks base method.
+func (m *MockRankScoreRepository) TopIds(ctx context.Context, now time.Time, n int) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopIds", ctx, now, n)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TopIds indicates an expected call of TopIds.
+func (mr *MockRankScoreRepositoryMockRecorder) TopIds(ctx, now, n any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopIds", reflect.TypeOf((*MockRankScoreRepository)(nil).TopIds), ctx, now, n)
+}
@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sms_message.go
+//
+// Generated by this command:
+//
+//	mockgen -package=repomocks -source=sms_message.go -destination=mocks/sms_message_mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/liupch66/basic-go/webook/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSmsMessageRepository is a mock of SmsMessageRepository interface.
+type MockSmsMessageRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSmsMessageRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSmsMessageRepositoryMockRecorder is the mock recorder for MockSmsMessageRepository.
+type MockSmsMessageRepositoryMockRecorder struct {
+	mock *MockSmsMessageRepository
+}
+
+// NewMockSmsMessageRepository creates a new mock instance.
+func NewMockSmsMessageRepository(ctrl *gomock.Controller) *MockSmsMessageRepository {
+	mock := &MockSmsMessageRepository{ctrl: ctrl}
+	mock.recorder = &MockSmsMessageRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSmsMessageRepository) EXPECT() *MockSmsMessageRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSmsMessageRepository) Create(ctx context.Context, msgs []domain.SmsMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, msgs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSmsMessageRepositoryMockRecorder) Create(ctx, msgs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSmsMessageRepository)(nil).Create), ctx, msgs)
+}
+
+// FindByMsgId mocks base method.
+func (m *MockSmsMessageRepository) FindByMsgId(ctx context.Context, vendor, msgId string) (domain.SmsMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByMsgId", ctx, vendor, msgId)
+	ret0, _ := ret[0].(domain.SmsMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByMsgId indicates an expected call of FindByMsgId.
+func (mr *MockSmsMessageRepositoryMockRecorder) FindByMsgId(ctx, vendor, msgId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByMsgId", reflect.TypeOf((*MockSmsMessageRepository)(nil).FindByMsgId), ctx, vendor, msgId)
+}
+
+// List mocks base method.
+func (m *MockSmsMessageRepository) List(ctx context.Context, q domain.SmsMessageQuery) ([]domain.SmsMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, q)
+	ret0, _ := ret[0].([]domain.SmsMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockSmsMessageRepositoryMockRecorder) List(ctx, q any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockSmsMessageRepository)(nil).List), ctx, q)
+}
+
+// UpdateByReceipt mocks base method.
+func (m *MockSmsMessageRepository) UpdateByReceipt(ctx context.Context, r domain.SmsReceipt) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateByReceipt", ctx, r)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateByReceipt indicates an expected call of UpdateByReceipt.
+func (mr *MockSmsMessageRepositoryMockRecorder) UpdateByReceipt(ctx, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateByReceipt", reflect.TypeOf((*MockSmsMessageRepository)(nil).UpdateByReceipt), ctx, r)
+}
@@ -0,0 +1,172 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: two_factor.go
+//
+// Generated by this command:
+//
+//	mockgen -package=repomocks -source=two_factor.go -destination=mocks/two_factor_mock.go TwoFactorRepository
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/liupch66/basic-go/webook/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTwoFactorRepository is a mock of TwoFactorRepository interface.
+type MockTwoFactorRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTwoFactorRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTwoFactorRepositoryMockRecorder is the mock recorder for MockTwoFactorRepository.
+type MockTwoFactorRepositoryMockRecorder struct {
+	mock *MockTwoFactorRepository
+}
+
+// NewMockTwoFactorRepository creates a new mock instance.
+func NewMockTwoFactorRepository(ctrl *gomock.Controller) *MockTwoFactorRepository {
+	mock := &MockTwoFactorRepository{ctrl: ctrl}
+	mock.recorder = &MockTwoFactorRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTwoFactorRepository) EXPECT() *MockTwoFactorRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Attempt mocks base method.
+func (m *MockTwoFactorRepository) Attempt(ctx context.Context, token string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Attempt", ctx, token)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Attempt indicates an expected call of Attempt.
+func (mr *MockTwoFactorRepositoryMockRecorder) Attempt(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Attempt", reflect.TypeOf((*MockTwoFactorRepository)(nil).Attempt), ctx, token)
+}
+
+// DelPending mocks base method.
+func (m *MockTwoFactorRepository) DelPending(ctx context.Context, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DelPending", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DelPending indicates an expected call of DelPending.
+func (mr *MockTwoFactorRepositoryMockRecorder) DelPending(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DelPending", reflect.TypeOf((*MockTwoFactorRepository)(nil).DelPending), ctx, token)
+}
+
+// DeleteTotp mocks base method.
+func (m *MockTwoFactorRepository) DeleteTotp(ctx context.Context, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTotp", ctx, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTotp indicates an expected call of DeleteTotp.
+func (mr *MockTwoFactorRepositoryMockRecorder) DeleteTotp(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTotp", reflect.TypeOf((*MockTwoFactorRepository)(nil).DeleteTotp), ctx, uid)
+}
+
+// EnableTotp mocks base method.
+func (m *MockTwoFactorRepository) EnableTotp(ctx context.Context, uid int64, codeHashes []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableTotp", ctx, uid, codeHashes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableTotp indicates an expected call of EnableTotp.
+func (mr *MockTwoFactorRepositoryMockRecorder) EnableTotp(ctx, uid, codeHashes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableTotp", reflect.TypeOf((*MockTwoFactorRepository)(nil).EnableTotp), ctx, uid, codeHashes)
+}
+
+// FindTotp mocks base method.
+func (m *MockTwoFactorRepository) FindTotp(ctx context.Context, uid int64) (domain.Totp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTotp", ctx, uid)
+	ret0, _ := ret[0].(domain.Totp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTotp indicates an expected call of FindTotp.
+func (mr *MockTwoFactorRepositoryMockRecorder) FindTotp(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTotp", reflect.TypeOf((*MockTwoFactorRepository)(nil).FindTotp), ctx, uid)
+}
+
+// MarkTotpUsed mocks base method.
+func (m *MockTwoFactorRepository) MarkTotpUsed(ctx context.Context, uid, step int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkTotpUsed", ctx, uid, step)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkTotpUsed indicates an expected call of MarkTotpUsed.
+func (mr *MockTwoFactorRepositoryMockRecorder) MarkTotpUsed(ctx, uid, step any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkTotpUsed", reflect.TypeOf((*MockTwoFactorRepository)(nil).MarkTotpUsed), ctx, uid, step)
+}
+
+// SavePendingTotp mocks base method.
+func (m *MockTwoFactorRepository) SavePendingTotp(ctx context.Context, uid int64, secret string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePendingTotp", ctx, uid, secret)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SavePendingTotp indicates an expected call of SavePendingTotp.
+func (mr *MockTwoFactorRepositoryMockRecorder) SavePendingTotp(ctx, uid, secret any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePendingTotp", reflect.TypeOf((*MockTwoFactorRepository)(nil).SavePendingTotp), ctx, uid, secret)
+}
+
+// SetPending mocks base method.
+func (m *MockTwoFactorRepository) SetPending(ctx context.Context, token string, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPending", ctx, token, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPending indicates an expected call of SetPending.
+func (mr *MockTwoFactorRepositoryMockRecorder) SetPending(ctx, token, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPending", reflect.TypeOf((*MockTwoFactorRepository)(nil).SetPending), ctx, token, uid)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockTwoFactorRepository) UseRecoveryCode(ctx context.Context, uid int64, codeHash string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", ctx, uid, codeHash)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockTwoFactorRepositoryMockRecorder) UseRecoveryCode(ctx, uid, codeHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockTwoFactorRepository)(nil).UseRecoveryCode), ctx, uid, codeHash)
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/repository/cache"
+	"github.com/liupch66/basic-go/webook/internal/repository/dao"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
+)
+
+//go:generate mockgen -package=repomocks -source=notification.go -destination=mocks/notification_mock.go NotificationRepository
+type NotificationRepository interface {
+	// Create 系统消息不折叠，其它的按照 类型+资源 折叠到未读的那一条上
+	Create(ctx context.Context, n domain.Notification) error
+	List(ctx context.Context, uid int64, offset int, limit int) ([]domain.Notification, error)
+	UnreadCnt(ctx context.Context, uid int64) (int64, error)
+	MarkRead(ctx context.Context, uid int64, ids []int64) error
+	MarkAllRead(ctx context.Context, uid int64) error
+	DeleteAll(ctx context.Context, uid int64) error
+}
+
+type CachedNotificationRepository struct {
+	dao   dao.NotificationDAO
+	cache cache.NotificationCache
+	l     logger.LoggerV1
+}
+
+func NewCachedNotificationRepository(dao dao.NotificationDAO, cache cache.NotificationCache,
+	l logger.LoggerV1) NotificationRepository {
+	return &CachedNotificationRepository{dao: dao, cache: cache, l: l}
+}
+
+func (repo *CachedNotificationRepository) Create(ctx context.Context, n domain.Notification) error {
+	var err error
+	if n.Type == domain.NotificationTypeSystem {
+		err = repo.dao.Insert(ctx, repo.toEntity(n))
+	} else {
+		entity := repo.toEntity(n)
+		entity.FoldKey = sql.NullString{
+			String: fmt.Sprintf("%d:%s:%d", n.Type, n.Biz, n.BizId),
+			Valid:  true,
+		}
+		err = repo.dao.Upsert(ctx, entity)
+	}
+	if err != nil {
+		return err
+	}
+	repo.delUnreadCnt(ctx, n.Uid)
+	return nil
+}
+
+func (repo *CachedNotificationRepository) List(ctx context.Context, uid int64, offset int, limit int) ([]domain.Notification, error) {
+	ns, err := repo.dao.FindByUid(ctx, uid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(ns, func(idx int, src dao.Notification) domain.Notification {
+		return repo.toDomain(src)
+	}), nil
+}
+
+func (repo *CachedNotificationRepository) UnreadCnt(ctx context.Context, uid int64) (int64, error) {
+	cnt, err := repo.cache.GetUnreadCnt(ctx, uid)
+	if err == nil {
+		return cnt, nil
+	}
+	cnt, err = repo.dao.CountUnread(ctx, uid)
+	if err != nil {
+		return 0, err
+	}
+	if er := repo.cache.SetUnreadCnt(ctx, uid, cnt); er != nil {
+		repo.l.Error("回写未读通知数失败", logger.Int64("uid", uid), logger.Error(er))
+	}
+	return cnt, nil
+}
+
+func (repo *CachedNotificationRepository) MarkRead(ctx context.Context, uid int64, ids []int64) error {
+	err := repo.dao.MarkRead(ctx, uid, ids)
+	if err != nil {
+		return err
+	}
+	repo.delUnreadCnt(ctx, uid)
+	return nil
+}
+
+func (repo *CachedNotificationRepository) MarkAllRead(ctx context.Context, uid int64) error {
+	err := repo.dao.MarkAllRead(ctx, uid)
+	if err != nil {
+		return err
+	}
+	repo.delUnreadCnt(ctx, uid)
+	return nil
+}
+
+func (repo *CachedNotificationRepository) DeleteAll(ctx context.Context, uid int64) error {
+	err := repo.dao.DeleteByUid(ctx, uid)
+	if err != nil {
+		return err
+	}
+	repo.delUnreadCnt(ctx, uid)
+	return nil
+}
+
+// delUnreadCnt 删缓存失败了也就是未读数不准一会儿，缓存过期之后就好了
+func (repo *CachedNotificationRepository) delUnreadCnt(ctx context.Context, uid int64) {
+	if err := repo.cache.DelUnreadCnt(ctx, uid); err != nil {
+		repo.l.Error("删除未读通知数缓存失败", logger.Int64("uid", uid), logger.Error(err))
+	}
+}
+
+func (repo *CachedNotificationRepository) toEntity(n domain.Notification) dao.Notification {
+	return dao.Notification{
+		Uid:         n.Uid,
+		Type:        uint8(n.Type),
+		Biz:         n.Biz,
+		BizId:       n.BizId,
+		LastActorId: n.LastActorId,
+		ActorCnt:    n.ActorCnt,
+		Content:     n.Content,
+	}
+}
+
+func (repo *CachedNotificationRepository) toDomain(n dao.Notification) domain.Notification {
+	return domain.Notification{
+		Id:          n.Id,
+		Uid:         n.Uid,
+		Type:        domain.NotificationType(n.Type),
+		Biz:         n.Biz,
+		BizId:       n.BizId,
+		LastActorId: n.LastActorId,
+		ActorCnt:    n.ActorCnt,
+		Content:     n.Content,
+		Read:        n.ReadAt > 0,
+		Ctime:       time.UnixMilli(n.Ctime),
+		Utime:       time.UnixMilli(n.Utime),
+	}
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/repository/dao"
+)
+
+var ErrPrivacyRequestNotFound = dao.ErrDataNotFound
+
+// 还没有执行完的申请
+var activePrivacyStatuses = []uint8{
+	domain.PrivacyRequestStatusPending.ToUint8(),
+	domain.PrivacyRequestStatusRunning.ToUint8(),
+}
+
+//go:generate mockgen -package=repomocks -source=privacy_request.go -destination=mocks/privacy_request_mock.go PrivacyRequestRepository
+type PrivacyRequestRepository interface {
+	Create(ctx context.Context, r domain.PrivacyRequest) (int64, error)
+	FindById(ctx context.Context, id int64) (domain.PrivacyRequest, error)
+	List(ctx context.Context, uid int64, offset int, limit int) ([]domain.PrivacyRequest, error)
+	// FindActive 还没有执行完的某一类申请，没有返回 ErrPrivacyRequestNotFound
+	FindActive(ctx context.Context, uid int64, typ domain.PrivacyRequestType) (domain.PrivacyRequest, error)
+	// FindDue 到了执行时间、还没有执行完的申请
+	FindDue(ctx context.Context, now time.Time, limit int) ([]domain.PrivacyRequest, error)
+	// SaveProgress 做完一步之后调用，申请被取消了返回 ErrPrivacyRequestNotFound
+	SaveProgress(ctx context.Context, r domain.PrivacyRequest) error
+	// Cancel 只能取消自己的、还没有开始执行的申请
+	Cancel(ctx context.Context, id int64, uid int64) error
+	// SaveFile 保存导出的文件，同名的直接覆盖
+	SaveFile(ctx context.Context, id int64, name string, content []byte) error
+	// ListFiles 申请导出的所有文件，文件名到内容
+	ListFiles(ctx context.Context, id int64) (map[string][]byte, error)
+	// GetFile 没有返回 ErrPrivacyRequestNotFound
+	GetFile(ctx context.Context, id int64, name string) ([]byte, error)
+	DeleteFiles(ctx context.Context, id int64, names []string) error
+}
+
+type privacyRequestRepository struct {
+	dao dao.PrivacyRequestDAO
+}
+
+func NewPrivacyRequestRepository(dao dao.PrivacyRequestDAO) PrivacyRequestRepository {
+	return &privacyRequestRepository{dao: dao}
+}
+
+func (repo *privacyRequestRepository) Create(ctx context.Context, r domain.PrivacyRequest) (int64, error) {
+	return repo.dao.Insert(ctx, dao.PrivacyRequest{
+		Uid:       r.Uid,
+		Type:      r.Type.ToUint8(),
+		Status:    r.Status.ToUint8(),
+		ExecuteAt: r.ExecuteAt.UnixMilli(),
+	})
+}
+
+func (repo *privacyRequestRepository) FindById(ctx context.Context, id int64) (domain.PrivacyRequest, error) {
+	r, err := repo.dao.FindById(ctx, id)
+	if err != nil {
+		return domain.PrivacyRequest{}, err
+	}
+	return repo.toDomain(r), nil
+}
+
+func (repo *privacyRequestRepository) List(ctx context.Context, uid int64, offset int, limit int) ([]domain.PrivacyRequest, error) {
+	rs, err := repo.dao.FindByUid(ctx, uid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(rs, func(idx int, src dao.PrivacyRequest) domain.PrivacyRequest {
+		return repo.toDomain(src)
+	}), nil
+}
+
+func (repo *privacyRequestRepository) FindActive(ctx context.Context, uid int64, typ domain.PrivacyRequestType) (domain.PrivacyRequest, error) {
+	r, err := repo.dao.FindActive(ctx, uid, typ.ToUint8(), activePrivacyStatuses)
+	if err != nil {
+		return domain.PrivacyRequest{}, err
+	}
+	return repo.toDomain(r), nil
+}
+
+func (repo *privacyRequestRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]domain.PrivacyRequest, error) {
+	rs, err := repo.dao.FindDue(ctx, activePrivacyStatuses, now.UnixMilli(), limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(rs, func(idx int, src dao.PrivacyRequest) domain.PrivacyRequest {
+		return repo.toDomain(src)
+	}), nil
+}
+
+func (repo *privacyRequestRepository) SaveProgress(ctx context.Context, r domain.PrivacyRequest) error {
+	return repo.dao.UpdateStage(ctx, r.Id, activePrivacyStatuses, r.Status.ToUint8(), r.Stage, r.Archive)
+}
+
+func (repo *privacyRequestRepository) Cancel(ctx context.Context, id int64, uid int64) error {
+	return repo.dao.UpdateStatus(ctx, id, uid,
+		domain.PrivacyRequestStatusPending.ToUint8(), domain.PrivacyRequestStatusCancelled.ToUint8())
+}
+
+func (repo *privacyRequestRepository) SaveFile(ctx context.Context, id int64, name string, content []byte) error {
+	return repo.dao.UpsertFile(ctx, dao.PrivacyExportFile{RequestId: id, Name: name, Content: content})
+}
+
+func (repo *privacyRequestRepository) ListFiles(ctx context.Context, id int64) (map[string][]byte, error) {
+	fs, err := repo.dao.FindFiles(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string][]byte, len(fs))
+	for _, f := range fs {
+		res[f.Name] = f.Content
+	}
+	return res, nil
+}
+
+func (repo *privacyRequestRepository) GetFile(ctx context.Context, id int64, name string) ([]byte, error) {
+	f, err := repo.dao.FindFile(ctx, id, name)
+	return f.Content, err
+}
+
+func (repo *privacyRequestRepository) DeleteFiles(ctx context.Context, id int64, names []string) error {
+	return repo.dao.DeleteFiles(ctx, id, names)
+}
+
+func (repo *privacyRequestRepository) toDomain(r dao.PrivacyRequest) domain.PrivacyRequest {
+	return domain.PrivacyRequest{
+		Id:        r.Id,
+		Uid:       r.Uid,
+		Type:      domain.PrivacyRequestType(r.Type),
+		Status:    domain.PrivacyRequestStatus(r.Status),
+		Stage:     r.Stage,
+		ExecuteAt: time.UnixMilli(r.ExecuteAt),
+		Archive:   r.Archive,
+		Ctime:     time.UnixMilli(r.Ctime),
+		Utime:     time.UnixMilli(r.Utime),
+	}
+}
@@ -2,14 +2,19 @@ package repository
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/liupch66/basic-go/webook/internal/domain"
 	"github.com/liupch66/basic-go/webook/internal/repository/cache"
 )
 
+//go:generate mockgen -package=repomocks -source=rank.go -destination=mocks/rank_mock.go RankRepository
 type RankRepository interface {
-	ReplaceTopN(ctx context.Context, arts []domain.Article) error
-	GetTopN(ctx context.Context) ([]domain.Article, error)
+	ReplaceTopN(ctx context.Context, board string, arts []domain.Article) error
+	GetTopN(ctx context.Context, board string) ([]domain.Article, error)
+	// ReplaceAuthorTopN 作者榜的数量和作者一样多，所以只放 Redis，不放本地缓存
+	ReplaceAuthorTopN(ctx context.Context, authorId int64, arts []domain.Article) error
+	GetAuthorTopN(ctx context.Context, authorId int64) ([]domain.Article, error)
 }
 
 type CachedRankRepository struct {
@@ -25,24 +30,36 @@ func NewCachedRankRepository(local *cache.RankLocalCache, redis *cache.RedisRank
 	return &CachedRankRepository{local: local, redis: redis}
 }
 
-func (repo *CachedRankRepository) ReplaceTopN(ctx context.Context, arts []domain.Article) error {
-	_ = repo.local.Set(ctx, arts)
-	return repo.redis.Set(ctx, arts)
+func (repo *CachedRankRepository) ReplaceTopN(ctx context.Context, board string, arts []domain.Article) error {
+	_ = repo.local.Set(ctx, board, arts)
+	return repo.redis.Set(ctx, board, arts)
 }
 
-func (repo *CachedRankRepository) GetTopN(ctx context.Context) ([]domain.Article, error) {
-	arts, err := repo.local.Get(ctx)
+func (repo *CachedRankRepository) GetTopN(ctx context.Context, board string) ([]domain.Article, error) {
+	arts, err := repo.local.Get(ctx, board)
 	if err == nil {
 		return arts, nil
 	}
 
-	arts, err = repo.redis.Get(ctx)
+	arts, err = repo.redis.Get(ctx, board)
 	// 回写本地缓存
 	if err == nil {
-		_ = repo.local.Set(ctx, arts)
+		_ = repo.local.Set(ctx, board, arts)
 	} else {
 		// 如果 Redis 崩了，这里设置一个本地缓存兜底方案，实际意义不大
-		return repo.local.ForceGet(ctx)
+		return repo.local.ForceGet(ctx, board)
 	}
 	return arts, err
 }
+
+func (repo *CachedRankRepository) ReplaceAuthorTopN(ctx context.Context, authorId int64, arts []domain.Article) error {
+	return repo.redis.Set(ctx, repo.authorBoard(authorId), arts)
+}
+
+func (repo *CachedRankRepository) GetAuthorTopN(ctx context.Context, authorId int64) ([]domain.Article, error) {
+	return repo.redis.Get(ctx, repo.authorBoard(authorId))
+}
+
+func (repo *CachedRankRepository) authorBoard(authorId int64) string {
+	return "author:" + strconv.FormatInt(authorId, 10)
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/liupch66/basic-go/webook/internal/repository/cache"
+)
+
+//go:generate mockgen -package=repomocks -source=rank_score.go -destination=mocks/rank_score_mock.go RankScoreRepository
+type RankScoreRepository interface {
+	IncrScore(ctx context.Context, aid int64, delta float64, t time.Time) error
+	// TopIds 实时分数最高的 n 篇文章，分数从高到低
+	TopIds(ctx context.Context, now time.Time, n int) ([]int64, error)
+	RemoveScore(ctx context.Context, aids []int64, now time.Time) error
+	// Scores 对账用，分数最高的 n 篇文章和分数不是正数的文章，以及它们的分数
+	Scores(ctx context.Context, now time.Time, n int) (map[int64]float64, error)
+	// ResetScore 把文章的分数重置成 score
+	ResetScore(ctx context.Context, aid int64, score float64, now time.Time) error
+}
+
+type CachedRankScoreRepository struct {
+	cache cache.RankScoreCache
+}
+
+func NewCachedRankScoreRepository(cache *cache.RedisRankScoreCache) RankScoreRepository {
+	return &CachedRankScoreRepository{cache: cache}
+}
+
+func (repo *CachedRankScoreRepository) IncrScore(ctx context.Context, aid int64, delta float64, t time.Time) error {
+	return repo.cache.IncrScore(ctx, aid, delta, t)
+}
+
+func (repo *CachedRankScoreRepository) TopIds(ctx context.Context, now time.Time, n int) ([]int64, error) {
+	return repo.cache.TopN(ctx, now, n)
+}
+
+func (repo *CachedRankScoreRepository) RemoveScore(ctx context.Context, aids []int64, now time.Time) error {
+	return repo.cache.Remove(ctx, aids, now)
+}
+
+func (repo *CachedRankScoreRepository) Scores(ctx context.Context, now time.Time, n int) (map[int64]float64, error) {
+	return repo.cache.Scores(ctx, now, n)
+}
+
+func (repo *CachedRankScoreRepository) ResetScore(ctx context.Context, aid int64, score float64, now time.Time) error {
+	return repo.cache.Reset(ctx, aid, score, now)
+}
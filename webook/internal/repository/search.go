@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/repository/dao/search"
+)
+
+type ArticleSearchRepository interface {
+	InputArticle(ctx context.Context, art domain.Article) error
+	DeleteArticle(ctx context.Context, id int64) error
+	SearchArticle(ctx context.Context, keyword string, offset int, limit int) (domain.ArticleSearchResult, error)
+	Count(ctx context.Context) (uint64, error)
+}
+
+type articleSearchRepository struct {
+	dao search.ArticleSearchDAO
+}
+
+func NewArticleSearchRepository(dao search.ArticleSearchDAO) ArticleSearchRepository {
+	return &articleSearchRepository{dao: dao}
+}
+
+func (repo *articleSearchRepository) InputArticle(ctx context.Context, art domain.Article) error {
+	return repo.dao.InputArticle(ctx, search.Article{
+		Id:         art.Id,
+		Title:      art.Title,
+		Content:    art.Content,
+		AuthorId:   art.Author.Id,
+		AuthorName: art.Author.Name,
+		Utime:      art.Utime.UnixMilli(),
+	})
+}
+
+func (repo *articleSearchRepository) DeleteArticle(ctx context.Context, id int64) error {
+	return repo.dao.DeleteArticle(ctx, id)
+}
+
+func (repo *articleSearchRepository) Count(ctx context.Context) (uint64, error) {
+	return repo.dao.Count(ctx)
+}
+
+func (repo *articleSearchRepository) SearchArticle(ctx context.Context, keyword string, offset int, limit int) (domain.ArticleSearchResult, error) {
+	res, err := repo.dao.SearchArticle(ctx, keyword, offset, limit)
+	if err != nil {
+		return domain.ArticleSearchResult{}, err
+	}
+	return domain.ArticleSearchResult{
+		Total: int64(res.Total),
+		Articles: slice.Map(res.Hits, func(idx int, src search.ArticleHit) domain.ArticleSearchHit {
+			return domain.ArticleSearchHit{
+				Article: domain.Article{
+					Id:     src.Id,
+					Title:  src.Title,
+					Author: domain.Author{Id: src.AuthorId, Name: src.AuthorName},
+					Utime:  time.UnixMilli(src.Utime),
+				},
+				Score:      src.Score,
+				Highlights: src.Highlights,
+			}
+		}),
+	}, nil
+}
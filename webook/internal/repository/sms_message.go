@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/repository/dao"
+)
+
+//go:generate mockgen -package=repomocks -source=sms_message.go -destination=mocks/sms_message_mock.go SmsMessageRepository
+type SmsMessageRepository interface {
+	Create(ctx context.Context, msgs []domain.SmsMessage) error
+	// UpdateByReceipt 只有还在等回执的短信才会更新，重复的回执返回 false
+	UpdateByReceipt(ctx context.Context, r domain.SmsReceipt) (bool, error)
+	FindByMsgId(ctx context.Context, vendor string, msgId string) (domain.SmsMessage, error)
+	List(ctx context.Context, q domain.SmsMessageQuery) ([]domain.SmsMessage, error)
+}
+
+type smsMessageRepository struct {
+	dao dao.SmsMessageDAO
+}
+
+func NewSmsMessageRepository(dao dao.SmsMessageDAO) SmsMessageRepository {
+	return &smsMessageRepository{dao: dao}
+}
+
+func (repo *smsMessageRepository) Create(ctx context.Context, msgs []domain.SmsMessage) error {
+	return repo.dao.Insert(ctx, slice.Map(msgs, func(idx int, src domain.SmsMessage) dao.SmsMessage {
+		return dao.SmsMessage{
+			Vendor:      src.Vendor,
+			MsgId:       src.MsgId,
+			TplId:       src.TplId,
+			Number:      src.Number,
+			Status:      src.Status.ToUint8(),
+			Reason:      src.Reason,
+			DeliverTime: repo.toMilli(src.DeliverTime),
+		}
+	}))
+}
+
+func (repo *smsMessageRepository) UpdateByReceipt(ctx context.Context, r domain.SmsReceipt) (bool, error) {
+	return repo.dao.UpdateStatus(ctx, dao.SmsMessage{
+		Vendor:      r.Vendor,
+		MsgId:       r.MsgId,
+		Status:      r.Status.ToUint8(),
+		Reason:      r.Reason,
+		DeliverTime: repo.toMilli(r.DeliverTime),
+	}, domain.SmsStatusAccepted.ToUint8())
+}
+
+func (repo *smsMessageRepository) FindByMsgId(ctx context.Context, vendor string, msgId string) (domain.SmsMessage, error) {
+	msg, err := repo.dao.FindByMsgId(ctx, vendor, msgId)
+	if err != nil {
+		return domain.SmsMessage{}, err
+	}
+	return repo.toDomain(msg), nil
+}
+
+func (repo *smsMessageRepository) List(ctx context.Context, q domain.SmsMessageQuery) ([]domain.SmsMessage, error) {
+	msgs, err := repo.dao.Find(ctx, dao.SmsMessage{
+		Vendor: q.Vendor,
+		MsgId:  q.MsgId,
+		Number: q.Number,
+	}, q.Offset, q.Limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(msgs, func(idx int, src dao.SmsMessage) domain.SmsMessage {
+		return repo.toDomain(src)
+	}), nil
+}
+
+func (repo *smsMessageRepository) toDomain(msg dao.SmsMessage) domain.SmsMessage {
+	res := domain.SmsMessage{
+		Id:     msg.Id,
+		Vendor: msg.Vendor,
+		MsgId:  msg.MsgId,
+		TplId:  msg.TplId,
+		Number: msg.Number,
+		Status: domain.SmsStatus(msg.Status),
+		Reason: msg.Reason,
+		Ctime:  time.UnixMilli(msg.Ctime),
+		Utime:  time.UnixMilli(msg.Utime),
+	}
+	if msg.DeliverTime > 0 {
+		res.DeliverTime = time.UnixMilli(msg.DeliverTime)
+	}
+	return res
+}
+
+// toMilli 零值的时间存 0，不然会存成一个负数
+func (repo *smsMessageRepository) toMilli(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
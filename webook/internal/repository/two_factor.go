@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/repository/cache"
+	"github.com/liupch66/basic-go/webook/internal/repository/dao"
+)
+
+var (
+	ErrTotpNotFound            = dao.ErrDataNotFound
+	ErrTotpNotPending          = dao.ErrTotpNotPending
+	ErrTwoFactorExpired        = cache.ErrTwoFactorExpired
+	ErrTwoFactorAttemptTooMany = cache.ErrTwoFactorAttemptTooMany
+)
+
+//go:generate mockgen -package=repomocks -source=two_factor.go -destination=mocks/two_factor_mock.go TwoFactorRepository
+type TwoFactorRepository interface {
+	SavePendingTotp(ctx context.Context, uid int64, secret string) error
+	FindTotp(ctx context.Context, uid int64) (domain.Totp, error)
+	// EnableTotp codeHashes 是恢复码的哈希，会替换掉之前的恢复码
+	EnableTotp(ctx context.Context, uid int64, codeHashes []string) error
+	DeleteTotp(ctx context.Context, uid int64) error
+	UseRecoveryCode(ctx context.Context, uid int64, codeHash string) (bool, error)
+
+	SetPending(ctx context.Context, token string, uid int64) error
+	Attempt(ctx context.Context, token string) (int64, error)
+	DelPending(ctx context.Context, token string) error
+	MarkTotpUsed(ctx context.Context, uid int64, step int64) (bool, error)
+}
+
+type CachedTwoFactorRepository struct {
+	dao   dao.TwoFactorDAO
+	cache cache.TwoFactorCache
+}
+
+func NewCachedTwoFactorRepository(dao dao.TwoFactorDAO, cache cache.TwoFactorCache) TwoFactorRepository {
+	return &CachedTwoFactorRepository{dao: dao, cache: cache}
+}
+
+func (repo *CachedTwoFactorRepository) SavePendingTotp(ctx context.Context, uid int64, secret string) error {
+	return repo.dao.UpsertPending(ctx, uid, secret)
+}
+
+func (repo *CachedTwoFactorRepository) FindTotp(ctx context.Context, uid int64) (domain.Totp, error) {
+	t, err := repo.dao.FindTotp(ctx, uid)
+	if err != nil {
+		return domain.Totp{}, err
+	}
+	return domain.Totp{Uid: t.Uid, Secret: t.Secret, Enabled: t.Enabled}, nil
+}
+
+func (repo *CachedTwoFactorRepository) EnableTotp(ctx context.Context, uid int64, codeHashes []string) error {
+	return repo.dao.Enable(ctx, uid, codeHashes)
+}
+
+func (repo *CachedTwoFactorRepository) DeleteTotp(ctx context.Context, uid int64) error {
+	return repo.dao.Delete(ctx, uid)
+}
+
+func (repo *CachedTwoFactorRepository) UseRecoveryCode(ctx context.Context, uid int64, codeHash string) (bool, error) {
+	return repo.dao.UseRecoveryCode(ctx, uid, codeHash)
+}
+
+func (repo *CachedTwoFactorRepository) SetPending(ctx context.Context, token string, uid int64) error {
+	return repo.cache.SetPending(ctx, token, uid)
+}
+
+func (repo *CachedTwoFactorRepository) Attempt(ctx context.Context, token string) (int64, error) {
+	return repo.cache.Attempt(ctx, token)
+}
+
+func (repo *CachedTwoFactorRepository) DelPending(ctx context.Context, token string) error {
+	return repo.cache.DelPending(ctx, token)
+}
+
+func (repo *CachedTwoFactorRepository) MarkTotpUsed(ctx context.Context, uid int64, step int64) (bool, error) {
+	return repo.cache.MarkTotpUsed(ctx, uid, step)
+}
@@ -16,14 +16,40 @@ var (
 	ErrUserNotFound  = dao.ErrDataNotFound
 )
 
+const (
+	// legacyWechatProvider 老数据里面微信账号的 provider
+	legacyWechatProvider = "wechat"
+	// anonymousNickname 注销了的用户统一显示成这个
+	anonymousNickname = "已注销用户"
+)
+
 //go:generate mockgen -package=repomocks -source=user.go -destination=mocks/user_mock.go UserRepository
 type UserRepository interface {
 	Create(ctx context.Context, u domain.User) error
 	FindByEmail(ctx context.Context, email string) (domain.User, error)
 	Update(ctx context.Context, u domain.User) error
+	// UpdatePassword password 是加密之后的，同时会把邮箱标记为已验证
+	UpdatePassword(ctx context.Context, id int64, password string) error
+	SetEmailVerified(ctx context.Context, id int64) error
 	FindById(ctx context.Context, id int64) (domain.User, error)
 	FindByPhone(ctx context.Context, phone string) (domain.User, error)
-	FindByWechat(ctx context.Context, openId string) (domain.User, error)
+	// FindByIdentity 按照绑定的第三方账号找用户
+	FindByIdentity(ctx context.Context, provider, subject string) (domain.User, error)
+	// CreateWithIdentity 第三方账号第一次登录，创建用户的同时绑定
+	CreateWithIdentity(ctx context.Context, u domain.User, identity domain.Identity) error
+	// UpdatePhone 和 UpdateEmail 传空字符串就是解绑
+	UpdatePhone(ctx context.Context, id int64, phone string) error
+	UpdateEmail(ctx context.Context, id int64, email string) error
+	AddIdentity(ctx context.Context, uid int64, identity domain.Identity) error
+	RemoveIdentity(ctx context.Context, uid int64, provider string) error
+	// Merge 合并用户表里面的数据，fromId 会被删掉
+	Merge(ctx context.Context, fromId, toId int64) error
+	UpdateStatus(ctx context.Context, id int64, status domain.UserStatus) error
+	FindRoles(ctx context.Context, uid int64) ([]string, error)
+	AddRole(ctx context.Context, uid int64, role string) error
+	RemoveRole(ctx context.Context, uid int64, role string) error
+	// Anonymize 注销账号，抹掉个人信息和所有登录方式
+	Anonymize(ctx context.Context, id int64) error
 }
 
 type CachedUserRepository struct {
@@ -40,16 +66,14 @@ func NewUserRepository(dao dao.UserDAO, cache cache.UserCache) UserRepository {
 
 func (repo *CachedUserRepository) entityToDomain(ue dao.User) domain.User {
 	return domain.User{
-		Id:       ue.Id,
-		Email:    ue.Email.String,
-		Password: ue.Password,
-		Nickname: ue.Nickname.String,
-		Phone:    ue.Phone.String,
-		WechatInfo: domain.WechatInfo{
-			OpenId:  ue.WechatOpenId.String,
-			UnionId: ue.WechatUnionId.String,
-		},
-		Ctime: time.UnixMilli(ue.Ctime),
+		Id:            ue.Id,
+		Email:         ue.Email.String,
+		Password:      ue.Password,
+		Nickname:      ue.Nickname.String,
+		Phone:         ue.Phone.String,
+		EmailVerified: ue.EmailVerified,
+		Status:        domain.UserStatus(ue.Status),
+		Ctime:         time.UnixMilli(ue.Ctime),
 	}
 }
 
@@ -69,15 +93,8 @@ func (repo *CachedUserRepository) domainToEntity(u domain.User) dao.User {
 			String: u.Phone,
 			Valid:  u.Phone != "",
 		},
-		WechatOpenId: sql.NullString{
-			String: u.WechatInfo.OpenId,
-			Valid:  u.WechatInfo.OpenId != "",
-		},
-		WechatUnionId: sql.NullString{
-			String: u.WechatInfo.UnionId,
-			Valid:  u.WechatInfo.UnionId != "",
-		},
-		Ctime: u.Ctime.UnixMilli(),
+		EmailVerified: u.EmailVerified,
+		Ctime:         u.Ctime.UnixMilli(),
 	}
 }
 
@@ -101,6 +118,20 @@ func (repo *CachedUserRepository) Update(ctx context.Context, u domain.User) err
 	return repo.cache.Delete(ctx, u.Id)
 }
 
+func (repo *CachedUserRepository) UpdatePassword(ctx context.Context, id int64, password string) error {
+	if err := repo.dao.UpdatePassword(ctx, id, password); err != nil {
+		return err
+	}
+	return repo.cache.Delete(ctx, id)
+}
+
+func (repo *CachedUserRepository) SetEmailVerified(ctx context.Context, id int64) error {
+	if err := repo.dao.SetEmailVerified(ctx, id); err != nil {
+		return err
+	}
+	return repo.cache.Delete(ctx, id)
+}
+
 func (repo *CachedUserRepository) FindById(ctx context.Context, id int64) (domain.User, error) {
 	// 这里注意处理方式
 	u, err := repo.cache.Get(ctx, id)
@@ -114,6 +145,11 @@ func (repo *CachedUserRepository) FindById(ctx context.Context, id int64) (domai
 			return domain.User{}, err
 		}
 		u = repo.entityToDomain(ue)
+		// 绑定的第三方账号一起缓存起来
+		u.Identities, err = repo.findIdentities(ctx, ue)
+		if err != nil {
+			return domain.User{}, err
+		}
 		// err = repo.cache.Set(ctx, u)
 		// if err != nil {
 		// 	// 打日志
@@ -133,10 +169,166 @@ func (repo *CachedUserRepository) FindByPhone(ctx context.Context, phone string)
 	return repo.entityToDomain(ue), nil
 }
 
-func (repo *CachedUserRepository) FindByWechat(ctx context.Context, openId string) (domain.User, error) {
+func (repo *CachedUserRepository) FindByIdentity(ctx context.Context, provider, subject string) (domain.User, error) {
+	ue, err := repo.dao.FindByIdentity(ctx, provider, subject)
+	if errors.Is(err, dao.ErrDataNotFound) && provider == legacyWechatProvider {
+		return repo.findByLegacyWechat(ctx, subject)
+	}
+	if err != nil {
+		return domain.User{}, err
+	}
+	return repo.entityToDomain(ue), nil
+}
+
+// findByLegacyWechat 以前微信登录的用户存在 users 表的 wechat_open_id 里面，找到了顺便迁移到 user_identities
+func (repo *CachedUserRepository) findByLegacyWechat(ctx context.Context, openId string) (domain.User, error) {
 	ue, err := repo.dao.FindByWechat(ctx, openId)
 	if err != nil {
 		return domain.User{}, err
 	}
+	err = repo.dao.InsertIdentity(ctx, dao.UserIdentity{Uid: ue.Id, Provider: legacyWechatProvider, Subject: openId})
+	if err != nil && !errors.Is(err, dao.ErrUserDuplicate) {
+		return domain.User{}, err
+	}
 	return repo.entityToDomain(ue), nil
 }
+
+func (repo *CachedUserRepository) findIdentities(ctx context.Context, ue dao.User) ([]domain.Identity, error) {
+	identities, err := repo.dao.FindIdentities(ctx, ue.Id)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.Identity, 0, len(identities)+1)
+	migrated := false
+	for _, identity := range identities {
+		if identity.Provider == legacyWechatProvider && identity.Subject == ue.WechatOpenId.String {
+			migrated = true
+		}
+		res = append(res, repo.identityToDomain(identity))
+	}
+	// 还没迁移的老微信用户
+	if ue.WechatOpenId.Valid && !migrated {
+		res = append(res, domain.Identity{Provider: legacyWechatProvider, Subject: ue.WechatOpenId.String})
+	}
+	return res, nil
+}
+
+func (repo *CachedUserRepository) CreateWithIdentity(ctx context.Context, u domain.User, identity domain.Identity) error {
+	return repo.dao.InsertWithIdentity(ctx, repo.domainToEntity(u), repo.identityToEntity(identity))
+}
+
+func (repo *CachedUserRepository) UpdatePhone(ctx context.Context, id int64, phone string) error {
+	if err := repo.dao.UpdatePhone(ctx, id, phone); err != nil {
+		return err
+	}
+	return repo.cache.Delete(ctx, id)
+}
+
+func (repo *CachedUserRepository) UpdateEmail(ctx context.Context, id int64, email string) error {
+	if err := repo.dao.UpdateEmail(ctx, id, email); err != nil {
+		return err
+	}
+	return repo.cache.Delete(ctx, id)
+}
+
+func (repo *CachedUserRepository) AddIdentity(ctx context.Context, uid int64, identity domain.Identity) error {
+	entity := repo.identityToEntity(identity)
+	entity.Uid = uid
+	if err := repo.dao.InsertIdentity(ctx, entity); err != nil {
+		return err
+	}
+	return repo.cache.Delete(ctx, uid)
+}
+
+func (repo *CachedUserRepository) RemoveIdentity(ctx context.Context, uid int64, provider string) error {
+	err := repo.dao.DeleteIdentity(ctx, uid, provider)
+	if provider == legacyWechatProvider && (err == nil || errors.Is(err, dao.ErrDataNotFound)) {
+		// 迁移过的老数据 users 表里面也还留着一份，一起清掉
+		ue, er := repo.dao.FindById(ctx, uid)
+		if er != nil {
+			return er
+		}
+		if ue.WechatOpenId.Valid {
+			err = repo.dao.ClearWechat(ctx, uid)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return repo.cache.Delete(ctx, uid)
+}
+
+func (repo *CachedUserRepository) Merge(ctx context.Context, fromId, toId int64) error {
+	// 还没迁移的老微信账号先迁移到 user_identities，不然删掉用户之后就丢了
+	from, err := repo.dao.FindById(ctx, fromId)
+	if err != nil {
+		return err
+	}
+	if from.WechatOpenId.Valid {
+		_, err = repo.findByLegacyWechat(ctx, from.WechatOpenId.String)
+		if err != nil {
+			return err
+		}
+	}
+	if err = repo.dao.Merge(ctx, fromId, toId); err != nil {
+		return err
+	}
+	if err = repo.cache.Delete(ctx, fromId); err != nil {
+		return err
+	}
+	return repo.cache.Delete(ctx, toId)
+}
+
+func (repo *CachedUserRepository) UpdateStatus(ctx context.Context, id int64, status domain.UserStatus) error {
+	if err := repo.dao.UpdateStatus(ctx, id, status.ToUint8()); err != nil {
+		return err
+	}
+	return repo.cache.Delete(ctx, id)
+}
+
+// FindRoles 角色只在登录和刷新 token 的时候查，不缓存
+func (repo *CachedUserRepository) FindRoles(ctx context.Context, uid int64) ([]string, error) {
+	roles, err := repo.dao.FindRoles(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]string, 0, len(roles))
+	for _, r := range roles {
+		res = append(res, r.Role)
+	}
+	return res, nil
+}
+
+func (repo *CachedUserRepository) AddRole(ctx context.Context, uid int64, role string) error {
+	return repo.dao.InsertRole(ctx, uid, role)
+}
+
+func (repo *CachedUserRepository) RemoveRole(ctx context.Context, uid int64, role string) error {
+	return repo.dao.DeleteRole(ctx, uid, role)
+}
+
+func (repo *CachedUserRepository) Anonymize(ctx context.Context, id int64) error {
+	err := repo.dao.Anonymize(ctx, id, anonymousNickname, domain.UserStatusDeleted.ToUint8())
+	if err != nil {
+		return err
+	}
+	return repo.cache.Delete(ctx, id)
+}
+
+func (repo *CachedUserRepository) identityToEntity(identity domain.Identity) dao.UserIdentity {
+	return dao.UserIdentity{
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+		Email:    identity.Email,
+		Name:     identity.Name,
+	}
+}
+
+func (repo *CachedUserRepository) identityToDomain(identity dao.UserIdentity) domain.Identity {
+	return domain.Identity{
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+		Email:    identity.Email,
+		Name:     identity.Name,
+	}
+}
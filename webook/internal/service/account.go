@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	interactv1 "github.com/liupch66/basic-go/webook/api/proto/gen/interact/v1"
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/repository"
+	"github.com/liupch66/basic-go/webook/internal/repository/article"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
+)
+
+var (
+	ErrAlreadyBound     = errors.New("已经被其他账号绑定了")
+	ErrNotBound         = errors.New("没有绑定")
+	ErrLastLoginMethod  = errors.New("不能解绑最后一种登录方式")
+	ErrInvalidMergeUser = errors.New("合并的账号不合法")
+)
+
+// AccountService 账号绑定和合并。绑定之前的验证码校验、第三方授权由 web 层负责
+type AccountService interface {
+	BindPhone(ctx context.Context, uid int64, phone string) error
+	UnbindPhone(ctx context.Context, uid int64) error
+	BindEmail(ctx context.Context, uid int64, email string) error
+	UnbindEmail(ctx context.Context, uid int64) error
+	// BindIdentity 已经绑定在自己身上的算成功
+	BindIdentity(ctx context.Context, uid int64, identity domain.Identity) error
+	UnbindIdentity(ctx context.Context, uid int64, provider string) error
+	// Merge 管理员操作，fromUid 的文章、互动和收藏都转给 toUid，登录方式也尽量转过去，然后删掉 fromUid
+	Merge(ctx context.Context, fromUid, toUid int64) error
+}
+
+type accountService struct {
+	repo        repository.UserRepository
+	artRepo     article.ArticleRepository
+	interactSvc interactv1.InteractServiceClient
+	l           logger.LoggerV1
+}
+
+func NewAccountService(repo repository.UserRepository, artRepo article.ArticleRepository,
+	interactSvc interactv1.InteractServiceClient, l logger.LoggerV1) AccountService {
+	return &accountService{repo: repo, artRepo: artRepo, interactSvc: interactSvc, l: l}
+}
+
+func (svc *accountService) BindPhone(ctx context.Context, uid int64, phone string) error {
+	err := svc.repo.UpdatePhone(ctx, uid, phone)
+	if errors.Is(err, repository.ErrUserDuplicate) {
+		return ErrAlreadyBound
+	}
+	return err
+}
+
+func (svc *accountService) UnbindPhone(ctx context.Context, uid int64) error {
+	u, err := svc.repo.FindById(ctx, uid)
+	if err != nil {
+		return err
+	}
+	if u.Phone == "" {
+		return ErrNotBound
+	}
+	if svc.loginMethods(u) <= 1 {
+		return ErrLastLoginMethod
+	}
+	return svc.repo.UpdatePhone(ctx, uid, "")
+}
+
+func (svc *accountService) BindEmail(ctx context.Context, uid int64, email string) error {
+	err := svc.repo.UpdateEmail(ctx, uid, email)
+	if errors.Is(err, repository.ErrUserDuplicate) {
+		return ErrAlreadyBound
+	}
+	return err
+}
+
+func (svc *accountService) UnbindEmail(ctx context.Context, uid int64) error {
+	u, err := svc.repo.FindById(ctx, uid)
+	if err != nil {
+		return err
+	}
+	if u.Email == "" {
+		return ErrNotBound
+	}
+	if svc.loginMethods(u) <= 1 {
+		return ErrLastLoginMethod
+	}
+	return svc.repo.UpdateEmail(ctx, uid, "")
+}
+
+func (svc *accountService) BindIdentity(ctx context.Context, uid int64, identity domain.Identity) error {
+	err := svc.repo.AddIdentity(ctx, uid, identity)
+	if !errors.Is(err, repository.ErrUserDuplicate) {
+		return err
+	}
+	owner, err := svc.repo.FindByIdentity(ctx, identity.Provider, identity.Subject)
+	if err != nil {
+		return err
+	}
+	if owner.Id != uid {
+		return ErrAlreadyBound
+	}
+	return nil
+}
+
+func (svc *accountService) UnbindIdentity(ctx context.Context, uid int64, provider string) error {
+	u, err := svc.repo.FindById(ctx, uid)
+	if err != nil {
+		return err
+	}
+	// 同一个平台绑定了多个账号的话会一起解绑
+	bound := 0
+	for _, identity := range u.Identities {
+		if identity.Provider == provider {
+			bound++
+		}
+	}
+	if bound == 0 {
+		return ErrNotBound
+	}
+	if svc.loginMethods(u) <= bound {
+		return ErrLastLoginMethod
+	}
+	err = svc.repo.RemoveIdentity(ctx, uid, provider)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return ErrNotBound
+	}
+	return err
+}
+
+// loginMethods 还剩几种登录方式。这里先查后改有并发问题，不过同一个人同时解绑两种登录方式的可能性很小
+func (svc *accountService) loginMethods(u domain.User) int {
+	cnt := len(u.Identities)
+	// 邮箱可以通过重置密码登录
+	if u.Email != "" {
+		cnt++
+	}
+	if u.Phone != "" {
+		cnt++
+	}
+	return cnt
+}
+
+func (svc *accountService) Merge(ctx context.Context, fromUid, toUid int64) error {
+	if fromUid <= 0 || toUid <= 0 || fromUid == toUid {
+		return ErrInvalidMergeUser
+	}
+	// 两个用户都要存在，fromUid 不存在说明已经合并过了
+	for _, uid := range []int64{fromUid, toUid} {
+		if _, err := svc.repo.FindById(ctx, uid); err != nil {
+			return fmt.Errorf("查找用户 %d 失败: %w", uid, err)
+		}
+	}
+	// 每一步都可以重复执行，用户表放在最后，中途失败了管理员重试就行
+	_, err := svc.interactSvc.MergeUser(ctx, &interactv1.MergeUserRequest{FromUid: fromUid, ToUid: toUid})
+	if err != nil {
+		return fmt.Errorf("合并互动数据失败: %w", err)
+	}
+	if err = svc.artRepo.TransferAuthor(ctx, fromUid, toUid); err != nil {
+		return fmt.Errorf("转移文章失败: %w", err)
+	}
+	if err = svc.repo.Merge(ctx, fromUid, toUid); err != nil {
+		return fmt.Errorf("合并用户失败: %w", err)
+	}
+	svc.l.Info("合并账号", logger.Int64("from_uid", fromUid), logger.Int64("to_uid", toUid))
+	return nil
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/repository"
+	repomocks "github.com/liupch66/basic-go/webook/internal/repository/mocks"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
+)
+
+func Test_accountService_UnbindIdentity(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mock     func(ctrl *gomock.Controller) repository.UserRepository
+		provider string
+		wantErr  error
+	}{
+		{
+			name: "解绑成功",
+			mock: func(ctrl *gomock.Controller) repository.UserRepository {
+				repo := repomocks.NewMockUserRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(1)).Return(domain.User{
+					Id:         1,
+					Phone:      "15512345678",
+					Identities: []domain.Identity{{Provider: "github", Subject: "123"}},
+				}, nil)
+				repo.EXPECT().RemoveIdentity(gomock.Any(), int64(1), "github").Return(nil)
+				return repo
+			},
+			provider: "github",
+		},
+		{
+			name: "没有绑定",
+			mock: func(ctrl *gomock.Controller) repository.UserRepository {
+				repo := repomocks.NewMockUserRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(1)).Return(domain.User{
+					Id:    1,
+					Phone: "15512345678",
+				}, nil)
+				return repo
+			},
+			provider: "github",
+			wantErr:  ErrNotBound,
+		},
+		{
+			name: "最后一种登录方式",
+			mock: func(ctrl *gomock.Controller) repository.UserRepository {
+				repo := repomocks.NewMockUserRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(1)).Return(domain.User{
+					Id: 1,
+					Identities: []domain.Identity{
+						{Provider: "github", Subject: "123"},
+						{Provider: "github", Subject: "456"},
+					},
+				}, nil)
+				return repo
+			},
+			provider: "github",
+			wantErr:  ErrLastLoginMethod,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewAccountService(tc.mock(ctrl), nil, nil, logger.NewNopLogger())
+			err := svc.UnbindIdentity(context.Background(), 1, tc.provider)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func Test_accountService_BindIdentity(t *testing.T) {
+	identity := domain.Identity{Provider: "github", Subject: "123"}
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) repository.UserRepository
+		wantErr error
+	}{
+		{
+			name: "已经绑定在自己身上",
+			mock: func(ctrl *gomock.Controller) repository.UserRepository {
+				repo := repomocks.NewMockUserRepository(ctrl)
+				repo.EXPECT().AddIdentity(gomock.Any(), int64(1), identity).Return(repository.ErrUserDuplicate)
+				repo.EXPECT().FindByIdentity(gomock.Any(), "github", "123").Return(domain.User{Id: 1}, nil)
+				return repo
+			},
+		},
+		{
+			name: "被其他账号绑定了",
+			mock: func(ctrl *gomock.Controller) repository.UserRepository {
+				repo := repomocks.NewMockUserRepository(ctrl)
+				repo.EXPECT().AddIdentity(gomock.Any(), int64(1), identity).Return(repository.ErrUserDuplicate)
+				repo.EXPECT().FindByIdentity(gomock.Any(), "github", "123").Return(domain.User{Id: 2}, nil)
+				return repo
+			},
+			wantErr: ErrAlreadyBound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewAccountService(tc.mock(ctrl), nil, nil, logger.NewNopLogger())
+			err := svc.BindIdentity(context.Background(), 1, identity)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/repository"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
+)
+
+var (
+	ErrInvalidRole = errors.New("角色不存在")
+	ErrBanSelf     = errors.New("不能封禁自己")
+	// ErrBanPrivileged 只能封禁角色比自己低的用户，审核员不能互相封禁，也不能封禁管理员
+	ErrBanPrivileged = errors.New("不能封禁权限不低于自己的用户")
+)
+
+// AdminService 管理员的操作，每一个操作都会记审计日志，operator 是操作的管理员。
+// 权限由 web 层的中间件检查
+type AdminService interface {
+	// BanUser 封禁之后不能登录，已经登录的会话要调用方踢掉
+	BanUser(ctx context.Context, operator, uid int64, reason string) error
+	UnbanUser(ctx context.Context, operator, uid int64) error
+	MergeUsers(ctx context.Context, operator, fromUid, toUid int64) error
+	GrantRole(ctx context.Context, operator, uid int64, role string) error
+	RevokeRole(ctx context.Context, operator, uid int64, role string) error
+	// WithdrawArticle 强制下架文章，会给作者发一条系统通知
+	WithdrawArticle(ctx context.Context, operator, artId int64, reason string) error
+	ListAuditLogs(ctx context.Context, q domain.AuditLogQuery) ([]domain.AuditLog, error)
+}
+
+type adminService struct {
+	userRepo        repository.UserRepository
+	auditRepo       repository.AuditLogRepository
+	rbacSvc         RBACService
+	accountSvc      AccountService
+	artSvc          ArticleService
+	notificationSvc NotificationService
+	l               logger.LoggerV1
+}
+
+func NewAdminService(userRepo repository.UserRepository, auditRepo repository.AuditLogRepository,
+	rbacSvc RBACService, accountSvc AccountService, artSvc ArticleService, notificationSvc NotificationService,
+	l logger.LoggerV1) AdminService {
+	return &adminService{
+		userRepo:        userRepo,
+		auditRepo:       auditRepo,
+		rbacSvc:         rbacSvc,
+		accountSvc:      accountSvc,
+		artSvc:          artSvc,
+		notificationSvc: notificationSvc,
+		l:               l,
+	}
+}
+
+func (svc *adminService) BanUser(ctx context.Context, operator, uid int64, reason string) error {
+	if operator == uid {
+		return ErrBanSelf
+	}
+	operatorRoles, err := svc.rbacSvc.Roles(ctx, operator)
+	if err != nil {
+		return err
+	}
+	roles, err := svc.rbacSvc.Roles(ctx, uid)
+	if err != nil {
+		return err
+	}
+	if domain.RoleLevel(roles) >= domain.RoleLevel(operatorRoles) {
+		return ErrBanPrivileged
+	}
+	if err := svc.userRepo.UpdateStatus(ctx, uid, domain.UserStatusBanned); err != nil {
+		return err
+	}
+	svc.audit(ctx, operator, domain.AuditActionUserBan, domain.AuditTargetUser, uid, reason)
+	return nil
+}
+
+func (svc *adminService) UnbanUser(ctx context.Context, operator, uid int64) error {
+	if err := svc.userRepo.UpdateStatus(ctx, uid, domain.UserStatusActive); err != nil {
+		return err
+	}
+	svc.audit(ctx, operator, domain.AuditActionUserUnban, domain.AuditTargetUser, uid, "")
+	return nil
+}
+
+func (svc *adminService) MergeUsers(ctx context.Context, operator, fromUid, toUid int64) error {
+	if err := svc.accountSvc.Merge(ctx, fromUid, toUid); err != nil {
+		return err
+	}
+	svc.audit(ctx, operator, domain.AuditActionUserMerge, domain.AuditTargetUser, toUid,
+		"from_uid="+strconv.FormatInt(fromUid, 10))
+	return nil
+}
+
+func (svc *adminService) GrantRole(ctx context.Context, operator, uid int64, role string) error {
+	if !domain.ValidRole(role) {
+		return ErrInvalidRole
+	}
+	if _, err := svc.userRepo.FindById(ctx, uid); err != nil {
+		return err
+	}
+	if err := svc.userRepo.AddRole(ctx, uid, role); err != nil {
+		return err
+	}
+	svc.audit(ctx, operator, domain.AuditActionRoleGrant, domain.AuditTargetUser, uid, role)
+	return nil
+}
+
+func (svc *adminService) RevokeRole(ctx context.Context, operator, uid int64, role string) error {
+	if err := svc.userRepo.RemoveRole(ctx, uid, role); err != nil {
+		return err
+	}
+	svc.audit(ctx, operator, domain.AuditActionRoleRevoke, domain.AuditTargetUser, uid, role)
+	return nil
+}
+
+func (svc *adminService) WithdrawArticle(ctx context.Context, operator, artId int64, reason string) error {
+	art, err := svc.artSvc.GetById(ctx, artId)
+	if err != nil {
+		return err
+	}
+	// 用真正的作者 ID 去撤回，DAO 里面作者的校验不用改
+	err = svc.artSvc.Withdraw(ctx, domain.Article{Id: art.Id, Author: domain.Author{Id: art.Author.Id}})
+	if err != nil {
+		return err
+	}
+	svc.audit(ctx, operator, domain.AuditActionArticleWithdraw, domain.AuditTargetArticle, artId, reason)
+	content := fmt.Sprintf("你的文章《%s》被管理员下架了", art.Title)
+	if reason != "" {
+		content += "，原因：" + reason
+	}
+	if er := svc.notificationSvc.SendSystem(ctx, art.Author.Id, content); er != nil {
+		svc.l.Error("发送文章下架通知失败", logger.Int64("article_id", artId), logger.Error(er))
+	}
+	return nil
+}
+
+func (svc *adminService) ListAuditLogs(ctx context.Context, q domain.AuditLogQuery) ([]domain.AuditLog, error) {
+	return svc.auditRepo.List(ctx, q)
+}
+
+// audit 操作已经成功了，审计日志写失败只记录错误，不影响返回
+func (svc *adminService) audit(ctx context.Context, operator int64, action, targetType string, targetId int64, detail string) {
+	err := svc.auditRepo.Create(ctx, domain.AuditLog{
+		Operator:   operator,
+		Action:     action,
+		TargetType: targetType,
+		TargetId:   targetId,
+		Detail:     detail,
+	})
+	if err != nil {
+		svc.l.Error("写审计日志失败", logger.Int64("operator", operator), logger.String("action", action),
+			logger.Int64("target_id", targetId), logger.Error(err))
+	}
+}
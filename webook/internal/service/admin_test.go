@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/repository"
+	repomocks "github.com/liupch66/basic-go/webook/internal/repository/mocks"
+	svcmocks "github.com/liupch66/basic-go/webook/internal/service/mocks"
+)
+
+func Test_adminService_BanUser(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mock     func(ctrl *gomock.Controller) (repository.UserRepository, repository.AuditLogRepository, RBACService)
+		operator int64
+		uid      int64
+
+		expectedErr error
+	}{
+		{
+			name: "管理员封禁普通用户",
+			mock: func(ctrl *gomock.Controller) (repository.UserRepository, repository.AuditLogRepository, RBACService) {
+				rbacSvc := svcmocks.NewMockRBACService(ctrl)
+				rbacSvc.EXPECT().Roles(gomock.Any(), int64(1)).Return([]string{domain.RoleAdmin}, nil)
+				rbacSvc.EXPECT().Roles(gomock.Any(), int64(2)).Return([]string{}, nil)
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				userRepo.EXPECT().UpdateStatus(gomock.Any(), int64(2), domain.UserStatusBanned).Return(nil)
+				auditRepo := repomocks.NewMockAuditLogRepository(ctrl)
+				auditRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				return userRepo, auditRepo, rbacSvc
+			},
+			operator: 1,
+			uid:      2,
+		},
+		{
+			name: "审核员封禁审核员",
+			mock: func(ctrl *gomock.Controller) (repository.UserRepository, repository.AuditLogRepository, RBACService) {
+				rbacSvc := svcmocks.NewMockRBACService(ctrl)
+				rbacSvc.EXPECT().Roles(gomock.Any(), int64(1)).Return([]string{domain.RoleModerator}, nil)
+				rbacSvc.EXPECT().Roles(gomock.Any(), int64(2)).Return([]string{domain.RoleModerator}, nil)
+				return repomocks.NewMockUserRepository(ctrl), repomocks.NewMockAuditLogRepository(ctrl), rbacSvc
+			},
+			operator:    1,
+			uid:         2,
+			expectedErr: ErrBanPrivileged,
+		},
+		{
+			name: "审核员封禁管理员",
+			mock: func(ctrl *gomock.Controller) (repository.UserRepository, repository.AuditLogRepository, RBACService) {
+				rbacSvc := svcmocks.NewMockRBACService(ctrl)
+				rbacSvc.EXPECT().Roles(gomock.Any(), int64(1)).Return([]string{domain.RoleModerator}, nil)
+				rbacSvc.EXPECT().Roles(gomock.Any(), int64(2)).Return([]string{domain.RoleSupport, domain.RoleAdmin}, nil)
+				return repomocks.NewMockUserRepository(ctrl), repomocks.NewMockAuditLogRepository(ctrl), rbacSvc
+			},
+			operator:    1,
+			uid:         2,
+			expectedErr: ErrBanPrivileged,
+		},
+		{
+			name: "封禁自己",
+			mock: func(ctrl *gomock.Controller) (repository.UserRepository, repository.AuditLogRepository, RBACService) {
+				return repomocks.NewMockUserRepository(ctrl), repomocks.NewMockAuditLogRepository(ctrl),
+					svcmocks.NewMockRBACService(ctrl)
+			},
+			operator:    1,
+			uid:         1,
+			expectedErr: ErrBanSelf,
+		},
+		{
+			name: "查角色失败",
+			mock: func(ctrl *gomock.Controller) (repository.UserRepository, repository.AuditLogRepository, RBACService) {
+				rbacSvc := svcmocks.NewMockRBACService(ctrl)
+				rbacSvc.EXPECT().Roles(gomock.Any(), int64(1)).Return(nil, errors.New("mock db error"))
+				return repomocks.NewMockUserRepository(ctrl), repomocks.NewMockAuditLogRepository(ctrl), rbacSvc
+			},
+			operator:    1,
+			uid:         2,
+			expectedErr: errors.New("mock db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			userRepo, auditRepo, rbacSvc := tc.mock(ctrl)
+			svc := NewAdminService(userRepo, auditRepo, rbacSvc, nil, nil, nil, nil)
+			err := svc.BanUser(context.Background(), tc.operator, tc.uid, "广告")
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
@@ -21,6 +21,29 @@ type ArticleService interface {
 	GetPublishedById(ctx context.Context, id, uid int64) (domain.Article, error)
 	// ListPub 因为是分批次查询，要考虑耗时的影响，保证取的都是 start 之前的文章
 	ListPub(ctx context.Context, start time.Time, offset int, limit int) ([]domain.Article, error)
+	// ListPubByAuthors 查询这些作者在 (before, beforeId) 之后的文章，按更新时间倒序，更新时间一样的按 id 倒序
+	ListPubByAuthors(ctx context.Context, authorIds []int64, before time.Time, beforeId int64, limit int) ([]domain.Article, error)
+	// ListPubByIds 批量查询已发表的文章，撤回了的不会返回，也不保证顺序
+	ListPubByIds(ctx context.Context, ids []int64) ([]domain.Article, error)
+	// Schedule 保存文章并且定时在 publishAt 发表，返回文章 ID
+	Schedule(ctx context.Context, art domain.Article, publishAt time.Time) (int64, error)
+	// ListScheduled 查询作者还没有发表的定时文章，按发表时间升序
+	ListScheduled(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error)
+	Reschedule(ctx context.Context, id int64, uid int64, publishAt time.Time) error
+	// CancelSchedule 取消定时发表，文章回到未发表状态
+	CancelSchedule(ctx context.Context, id int64, uid int64) error
+	// ListDueScheduled 查询已经到了发表时间的定时文章，给定时任务用
+	ListDueScheduled(ctx context.Context, now time.Time, offset int, limit int) ([]domain.Article, error)
+	// PublishScheduled 定时任务发表文章，文章已经被改期或者取消了会返回 ErrArticleNotScheduled
+	PublishScheduled(ctx context.Context, art domain.Article, now time.Time) error
+	// ListRevisions 查询历史版本列表，按版本号倒序，不返回内容
+	ListRevisions(ctx context.Context, artId int64, uid int64, offset int, limit int) ([]domain.ArticleRevision, error)
+	GetRevision(ctx context.Context, artId int64, version int64, uid int64) (domain.ArticleRevision, error)
+	// DiffRevisions 比较同一篇文章的两个历史版本，from 是旧版本
+	DiffRevisions(ctx context.Context, artId int64, from int64, to int64, uid int64) (domain.ArticleDiff, error)
+	// Rollback 回滚到指定版本，published 为 true 时连同线上库一起回滚
+	// 回滚本身也会产生一个新的版本，所以是可以"撤销回滚"的
+	Rollback(ctx context.Context, artId int64, version int64, uid int64, published bool) (int64, error)
 }
 
 type articleService struct {
@@ -56,7 +79,27 @@ func (svc *articleService) Save(ctx context.Context, art domain.Article) (int64,
 
 func (svc *articleService) Publish(ctx context.Context, art domain.Article) (int64, error) {
 	art.Status = domain.ArticleStatusPublished
-	return svc.repo.Sync(ctx, art)
+	id, err := svc.repo.Sync(ctx, art)
+	if err != nil {
+		return 0, err
+	}
+	art.Id = id
+	svc.producePublished(art)
+	return id, nil
+}
+
+// producePublished 消息发送失败不影响发表，搜索之类的下游晚一点或者下次发表再同步
+func (svc *articleService) producePublished(art domain.Article) {
+	er := svc.producer.ProducePublishedEvent(events.PublishedEvent{
+		Aid:     art.Id,
+		Uid:     art.Author.Id,
+		Title:   art.Title,
+		Content: art.Content,
+		Utime:   time.Now().UnixMilli(),
+	})
+	if er != nil {
+		svc.l.Error("发送文章发表消息失败", logger.Error(er), logger.Int64("article_id", art.Id))
+	}
 }
 
 func (svc *articleService) PublishV1(ctx context.Context, art domain.Article) (int64, error) {
@@ -103,7 +146,20 @@ func (svc *articleService) PublishV1(ctx context.Context, art domain.Article) (i
 
 func (svc *articleService) Withdraw(ctx context.Context, art domain.Article) error {
 	// 也可以设置 art.Status = domain.ArticleStatusPrivate,再接着往下传 art
-	return svc.repo.SyncStatus(ctx, art.Id, art.Author.Id, domain.ArticleStatusPrivate)
+	err := svc.repo.SyncStatus(ctx, art.Id, art.Author.Id, domain.ArticleStatusPrivate)
+	if err != nil {
+		return err
+	}
+	er := svc.producer.ProducePublishedEvent(events.PublishedEvent{
+		Aid:       art.Id,
+		Uid:       art.Author.Id,
+		Withdrawn: true,
+		Utime:     time.Now().UnixMilli(),
+	})
+	if er != nil {
+		svc.l.Error("发送文章撤回消息失败", logger.Error(er), logger.Int64("article_id", art.Id))
+	}
+	return nil
 }
 
 func (svc *articleService) List(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error) {
@@ -128,3 +184,11 @@ func (svc *articleService) GetPublishedById(ctx context.Context, id, uid int64)
 func (svc *articleService) ListPub(ctx context.Context, start time.Time, offset int, limit int) ([]domain.Article, error) {
 	return svc.repo.ListPub(ctx, start, offset, limit)
 }
+
+func (svc *articleService) ListPubByAuthors(ctx context.Context, authorIds []int64, before time.Time, beforeId int64, limit int) ([]domain.Article, error) {
+	return svc.repo.ListPubByAuthors(ctx, authorIds, before, beforeId, limit)
+}
+
+func (svc *articleService) ListPubByIds(ctx context.Context, ids []int64) ([]domain.Article, error) {
+	return svc.repo.ListPubByIds(ctx, ids)
+}
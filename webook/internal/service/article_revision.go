@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/repository/article"
+	"github.com/liupch66/basic-go/webook/pkg/diffx"
+)
+
+var (
+	// ErrRevisionNotFound 查询历史版本的时候带上了作者，别人的版本也是这个错误
+	ErrRevisionNotFound = article.ErrArticleNotFound
+	ErrDiffTooLarge     = diffx.ErrTooLarge
+)
+
+func (svc *articleService) ListRevisions(ctx context.Context, artId int64, uid int64, offset int, limit int) ([]domain.ArticleRevision, error) {
+	return svc.repo.ListRevisions(ctx, artId, uid, offset, limit)
+}
+
+func (svc *articleService) GetRevision(ctx context.Context, artId int64, version int64, uid int64) (domain.ArticleRevision, error) {
+	return svc.repo.GetRevision(ctx, artId, version, uid)
+}
+
+func (svc *articleService) DiffRevisions(ctx context.Context, artId int64, from int64, to int64, uid int64) (domain.ArticleDiff, error) {
+	var (
+		eg       errgroup.Group
+		src, dst domain.ArticleRevision
+	)
+	eg.Go(func() error {
+		var er error
+		src, er = svc.repo.GetRevision(ctx, artId, from, uid)
+		return er
+	})
+	eg.Go(func() error {
+		var er error
+		dst, er = svc.repo.GetRevision(ctx, artId, to, uid)
+		return er
+	})
+	if err := eg.Wait(); err != nil {
+		return domain.ArticleDiff{}, err
+	}
+	edits, err := diffx.Lines(src.Content, dst.Content)
+	if err != nil {
+		return domain.ArticleDiff{}, err
+	}
+	lines := make([]domain.DiffLine, 0, len(edits))
+	for _, e := range edits {
+		lines = append(lines, domain.DiffLine{Op: svc.toDiffOp(e.Op), Text: e.Text})
+	}
+	return domain.ArticleDiff{
+		From:         from,
+		To:           to,
+		TitleChanged: src.Title != dst.Title,
+		Lines:        lines,
+	}, nil
+}
+
+func (svc *articleService) toDiffOp(op diffx.Op) domain.DiffOp {
+	switch op {
+	case diffx.OpInsert:
+		return domain.DiffOpInsert
+	case diffx.OpDelete:
+		return domain.DiffOpDelete
+	default:
+		return domain.DiffOpEqual
+	}
+}
+
+func (svc *articleService) Rollback(ctx context.Context, artId int64, version int64, uid int64, published bool) (int64, error) {
+	// 查询的时候带上了 author_id，所以别人的历史版本是查不到的
+	rev, err := svc.repo.GetRevision(ctx, artId, version, uid)
+	if err != nil {
+		return 0, err
+	}
+	art := domain.Article{
+		Id:      artId,
+		Title:   rev.Title,
+		Content: rev.Content,
+		Author:  domain.Author{Id: uid},
+	}
+	// 复用 Save 和 Publish，这样回滚也会留下一个新的历史版本
+	if published {
+		return svc.Publish(ctx, art)
+	}
+	return svc.Save(ctx, art)
+}
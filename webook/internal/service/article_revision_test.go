@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	events "github.com/liupch66/basic-go/webook/internal/events/article"
+	evtmocks "github.com/liupch66/basic-go/webook/internal/events/article/mocks"
+	"github.com/liupch66/basic-go/webook/internal/repository/article"
+	artRepomocks "github.com/liupch66/basic-go/webook/internal/repository/article/mocks"
+)
+
+func Test_articleService_DiffRevisions(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) article.ArticleRepository
+
+		expectedDiff domain.ArticleDiff
+		expectedErr  error
+	}{
+		{
+			name: "对比成功",
+			mock: func(ctrl *gomock.Controller) article.ArticleRepository {
+				repo := artRepomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetRevision(gomock.Any(), int64(1), int64(1), int64(123)).
+					Return(domain.ArticleRevision{Version: 1, Title: "旧标题", Content: "a\nb"}, nil)
+				repo.EXPECT().GetRevision(gomock.Any(), int64(1), int64(2), int64(123)).
+					Return(domain.ArticleRevision{Version: 2, Title: "新标题", Content: "a\nc"}, nil)
+				return repo
+			},
+			expectedDiff: domain.ArticleDiff{
+				From:         1,
+				To:           2,
+				TitleChanged: true,
+				Lines: []domain.DiffLine{
+					{Op: domain.DiffOpEqual, Text: "a"},
+					{Op: domain.DiffOpDelete, Text: "b"},
+					{Op: domain.DiffOpInsert, Text: "c"},
+				},
+			},
+		},
+		{
+			name: "版本不存在",
+			mock: func(ctrl *gomock.Controller) article.ArticleRepository {
+				repo := artRepomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetRevision(gomock.Any(), int64(1), int64(1), int64(123)).
+					Return(domain.ArticleRevision{}, errors.New("mock not found"))
+				repo.EXPECT().GetRevision(gomock.Any(), int64(1), int64(2), int64(123)).
+					Return(domain.ArticleRevision{Version: 2}, nil)
+				return repo
+			},
+			expectedErr: errors.New("mock not found"),
+		},
+		{
+			name: "差异太大",
+			mock: func(ctrl *gomock.Controller) article.ArticleRepository {
+				repo := artRepomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetRevision(gomock.Any(), int64(1), int64(1), int64(123)).
+					Return(domain.ArticleRevision{Version: 1, Content: strings.Repeat("a\n", 2000)}, nil)
+				repo.EXPECT().GetRevision(gomock.Any(), int64(1), int64(2), int64(123)).
+					Return(domain.ArticleRevision{Version: 2, Content: strings.Repeat("b\n", 2000)}, nil)
+				return repo
+			},
+			expectedErr: ErrDiffTooLarge,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewArticleService(tc.mock(ctrl), nil, nil)
+			diff, err := svc.DiffRevisions(context.Background(), 1, 1, 2, 123)
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedDiff, diff)
+		})
+	}
+}
+
+func Test_articleService_Rollback(t *testing.T) {
+	testCases := []struct {
+		name      string
+		mock      func(ctrl *gomock.Controller) (article.ArticleRepository, events.Producer)
+		published bool
+
+		expectedId  int64
+		expectedErr error
+	}{
+		{
+			name: "回滚草稿",
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, events.Producer) {
+				repo := artRepomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetRevision(gomock.Any(), int64(1), int64(3), int64(123)).
+					Return(domain.ArticleRevision{Version: 3, Title: "标题", Content: "内容"}, nil)
+				repo.EXPECT().Update(gomock.Any(), domain.Article{Id: 1, Title: "标题", Content: "内容",
+					Author: domain.Author{Id: 123}, Status: domain.ArticleStatusUnpublished}).Return(nil)
+				return repo, evtmocks.NewMockProducer(ctrl)
+			},
+			expectedId: 1,
+		},
+		{
+			name: "连同线上库一起回滚",
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, events.Producer) {
+				repo := artRepomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetRevision(gomock.Any(), int64(1), int64(3), int64(123)).
+					Return(domain.ArticleRevision{Version: 3, Title: "标题", Content: "内容"}, nil)
+				repo.EXPECT().Sync(gomock.Any(), domain.Article{Id: 1, Title: "标题", Content: "内容",
+					Author: domain.Author{Id: 123}, Status: domain.ArticleStatusPublished}).Return(int64(1), nil)
+				producer := evtmocks.NewMockProducer(ctrl)
+				producer.EXPECT().ProducePublishedEvent(gomock.Any()).Return(nil)
+				return repo, producer
+			},
+			published:  true,
+			expectedId: 1,
+		},
+		{
+			name: "不是自己的版本",
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, events.Producer) {
+				repo := artRepomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetRevision(gomock.Any(), int64(1), int64(3), int64(123)).
+					Return(domain.ArticleRevision{}, errors.New("mock not found"))
+				return repo, evtmocks.NewMockProducer(ctrl)
+			},
+			expectedErr: errors.New("mock not found"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, producer := tc.mock(ctrl)
+			svc := NewArticleService(repo, nil, producer)
+			id, err := svc.Rollback(context.Background(), 1, 3, 123, tc.published)
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedId, id)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/repository/article"
+)
+
+var (
+	ErrInvalidPublishTime = errors.New("定时发表的时间必须晚于当前时间")
+	// ErrArticleNotScheduled 文章不是定时发表状态，或者不是本人的文章
+	ErrArticleNotScheduled = article.ErrArticleNotScheduled
+)
+
+func (svc *articleService) Schedule(ctx context.Context, art domain.Article, publishAt time.Time) (int64, error) {
+	if !publishAt.After(time.Now()) {
+		return 0, ErrInvalidPublishTime
+	}
+	// 和 Save 一样只保存到制作库，到时间了再由定时任务调用 Publish 同步到线上库
+	art.Status = domain.ArticleStatusScheduled
+	art.PublishAt = publishAt
+	if art.Id > 0 {
+		err := svc.repo.Update(ctx, art)
+		return art.Id, err
+	}
+	return svc.repo.Create(ctx, art)
+}
+
+func (svc *articleService) ListScheduled(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error) {
+	return svc.repo.ListScheduled(ctx, uid, offset, limit)
+}
+
+func (svc *articleService) Reschedule(ctx context.Context, id int64, uid int64, publishAt time.Time) error {
+	if !publishAt.After(time.Now()) {
+		return ErrInvalidPublishTime
+	}
+	return svc.repo.UpdateSchedule(ctx, id, uid, domain.ArticleStatusScheduled, publishAt)
+}
+
+func (svc *articleService) CancelSchedule(ctx context.Context, id int64, uid int64) error {
+	return svc.repo.UpdateSchedule(ctx, id, uid, domain.ArticleStatusUnpublished, time.Time{})
+}
+
+func (svc *articleService) ListDueScheduled(ctx context.Context, now time.Time, offset int, limit int) ([]domain.Article, error) {
+	return svc.repo.ListDueScheduled(ctx, now, offset, limit)
+}
+
+func (svc *articleService) PublishScheduled(ctx context.Context, art domain.Article, now time.Time) error {
+	// 不走 Publish，要在数据库里面确认文章还是定时发表状态，防止和改期、取消并发的时候把取消了的文章发出去
+	art.Status = domain.ArticleStatusPublished
+	err := svc.repo.PublishScheduled(ctx, art, now)
+	if err != nil {
+		return err
+	}
+	svc.producePublished(art)
+	return nil
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	events "github.com/liupch66/basic-go/webook/internal/events/article"
+	evtmocks "github.com/liupch66/basic-go/webook/internal/events/article/mocks"
+	"github.com/liupch66/basic-go/webook/internal/repository/article"
+	artRepomocks "github.com/liupch66/basic-go/webook/internal/repository/article/mocks"
+)
+
+func Test_articleService_Schedule(t *testing.T) {
+	publishAt := time.Now().Add(time.Hour)
+	testCases := []struct {
+		name      string
+		mock      func(ctrl *gomock.Controller) article.ArticleRepository
+		art       domain.Article
+		publishAt time.Time
+
+		expectedId  int64
+		expectedErr error
+	}{
+		{
+			name: "新建并定时发表",
+			mock: func(ctrl *gomock.Controller) article.ArticleRepository {
+				repo := artRepomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().Create(gomock.Any(), domain.Article{Title: "标题", Content: "内容",
+					Author: domain.Author{Id: 123}, Status: domain.ArticleStatusScheduled, PublishAt: publishAt}).
+					Return(int64(1), nil)
+				return repo
+			},
+			art:        domain.Article{Title: "标题", Content: "内容", Author: domain.Author{Id: 123}},
+			publishAt:  publishAt,
+			expectedId: 1,
+		},
+		{
+			name: "修改已有文章并定时发表",
+			mock: func(ctrl *gomock.Controller) article.ArticleRepository {
+				repo := artRepomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().Update(gomock.Any(), domain.Article{Id: 2, Title: "标题", Content: "内容",
+					Author: domain.Author{Id: 123}, Status: domain.ArticleStatusScheduled, PublishAt: publishAt}).
+					Return(nil)
+				return repo
+			},
+			art:        domain.Article{Id: 2, Title: "标题", Content: "内容", Author: domain.Author{Id: 123}},
+			publishAt:  publishAt,
+			expectedId: 2,
+		},
+		{
+			name: "发表时间已经过去了",
+			mock: func(ctrl *gomock.Controller) article.ArticleRepository {
+				return artRepomocks.NewMockArticleRepository(ctrl)
+			},
+			art:         domain.Article{Title: "标题", Content: "内容", Author: domain.Author{Id: 123}},
+			publishAt:   time.Now().Add(-time.Minute),
+			expectedErr: ErrInvalidPublishTime,
+		},
+		{
+			name: "保存失败",
+			mock: func(ctrl *gomock.Controller) article.ArticleRepository {
+				repo := artRepomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(int64(0), errors.New("mock db error"))
+				return repo
+			},
+			art:         domain.Article{Title: "标题", Content: "内容", Author: domain.Author{Id: 123}},
+			publishAt:   publishAt,
+			expectedErr: errors.New("mock db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewArticleService(tc.mock(ctrl), nil, nil)
+			id, err := svc.Schedule(context.Background(), tc.art, tc.publishAt)
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedId, id)
+		})
+	}
+}
+
+func Test_articleService_CancelSchedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := artRepomocks.NewMockArticleRepository(ctrl)
+	repo.EXPECT().UpdateSchedule(gomock.Any(), int64(1), int64(123), domain.ArticleStatusUnpublished, time.Time{}).
+		Return(nil)
+	svc := NewArticleService(repo, nil, nil)
+	err := svc.CancelSchedule(context.Background(), 1, 123)
+	assert.NoError(t, err)
+}
+
+func Test_articleService_PublishScheduled(t *testing.T) {
+	now := time.Now()
+	art := domain.Article{Id: 1, Title: "标题", Content: "内容", Author: domain.Author{Id: 123},
+		Status: domain.ArticleStatusScheduled, PublishAt: now.Add(-time.Minute)}
+	published := art
+	published.Status = domain.ArticleStatusPublished
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (article.ArticleRepository, events.Producer)
+
+		expectedErr error
+	}{
+		{
+			name: "发表成功",
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, events.Producer) {
+				repo := artRepomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().PublishScheduled(gomock.Any(), published, now).Return(nil)
+				producer := evtmocks.NewMockProducer(ctrl)
+				producer.EXPECT().ProducePublishedEvent(gomock.Any()).Return(nil)
+				return repo, producer
+			},
+		},
+		{
+			name: "已经被取消了",
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, events.Producer) {
+				repo := artRepomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().PublishScheduled(gomock.Any(), published, now).Return(article.ErrArticleNotScheduled)
+				// 没有发表就不能发消息
+				return repo, evtmocks.NewMockProducer(ctrl)
+			},
+			expectedErr: ErrArticleNotScheduled,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, producer := tc.mock(ctrl)
+			svc := NewArticleService(repo, nil, producer)
+			err := svc.PublishScheduled(context.Background(), art, now)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package siteverify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type Config struct {
+	// URL reCAPTCHA、hCaptcha、Turnstile 的校验接口都是一样的协议，换个地址就行
+	URL    string `yaml:"url"`
+	Secret string `yaml:"secret"`
+}
+
+// Service 调用图形验证码服务商的 siteverify 接口校验 token，token 只能用一次
+type Service struct {
+	cfg    Config
+	client *http.Client
+}
+
+func NewService(cfg Config) *Service {
+	return &Service{cfg: cfg, client: &http.Client{Timeout: 3 * time.Second}}
+}
+
+func (s *Service) Verify(ctx context.Context, token string, ip string) (bool, error) {
+	form := url.Values{}
+	form.Set("secret", s.cfg.Secret)
+	form.Set("response", token)
+	if ip != "" {
+		form.Set("remoteip", ip)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.URL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("图形验证码校验接口返回 %d", resp.StatusCode)
+	}
+	var res struct {
+		Success bool `json:"success"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return false, err
+	}
+	return res.Success, nil
+}
@@ -0,0 +1,11 @@
+package captcha
+
+import (
+	"context"
+)
+
+//go:generate mockgen -package=captchamocks -source=types.go -destination=mocks/captcha.mock.go Service
+type Service interface {
+	// Verify token 是前端通过图形验证码之后拿到的，ip 是用户的 IP，服务商用来做风控
+	Verify(ctx context.Context, token string, ip string) (bool, error)
+}
@@ -6,11 +6,13 @@ import (
 	"fmt"
 	"math/rand"
 
+	"github.com/liupch66/basic-go/webook/internal/domain"
 	"github.com/liupch66/basic-go/webook/internal/repository"
 	"github.com/liupch66/basic-go/webook/internal/service/sms"
 )
 
-const codeTplId = "1977183"
+// codeTpl 验证码短信的模板名字，各个服务商的模板 ID 在短信模板注册中心里面配置
+const codeTpl = "verify_code"
 
 var (
 	ErrCodeSendTooMany   = repository.ErrCodeSendTooMany
@@ -19,19 +21,22 @@ var (
 )
 
 type CodeService interface {
-	Send(ctx context.Context, biz, phone string) error
+	// Send 被频控拦下来的话返回 *CodeDeniedError
+	Send(ctx context.Context, biz, phone string, meta domain.CodeSendMeta) error
 	Verify(ctx context.Context, biz, phone, inputCode string) (bool, error)
 }
 
 type codeService struct {
-	repo   repository.CodeRepository
-	smsSvc sms.Service
+	repo     repository.CodeRepository
+	smsSvc   sms.Service
+	throttle CodeThrottleService
 }
 
-func NewCodeService(repo repository.CodeRepository, smsSvc sms.Service) CodeService {
+func NewCodeService(repo repository.CodeRepository, smsSvc sms.Service, throttle CodeThrottleService) CodeService {
 	return &codeService{
-		repo:   repo,
-		smsSvc: smsSvc,
+		repo:     repo,
+		smsSvc:   smsSvc,
+		throttle: throttle,
 	}
 }
 
@@ -40,15 +45,28 @@ func (svc *codeService) generateCode() string {
 	return fmt.Sprintf("%06d", rand.Intn(1000000))
 }
 
-func (svc *codeService) Send(ctx context.Context, biz, phone string) error {
+func (svc *codeService) Send(ctx context.Context, biz, phone string, meta domain.CodeSendMeta) error {
+	// 一分钟之内重复发的先拦下来，不然频控已经计了数，短信却没发出去。
+	// 并发的请求还是可能都通过这里，最后由 Store 保证一分钟只发一次
+	ok, err := svc.repo.Sendable(ctx, biz, phone)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrCodeSendTooMany
+	}
+	// 再过频控，换着手机号刷的也能拦下来
+	if err = svc.throttle.Check(ctx, biz, phone, meta); err != nil {
+		return err
+	}
 	code := svc.generateCode()
 	// 塞进 redis
-	err := svc.repo.Store(ctx, biz, phone, code)
+	err = svc.repo.Store(ctx, biz, phone, code)
 	if err != nil {
 		return err
 	}
 	// 发送模板： {1}为您的登录验证码，请于{2}分钟内填写，如非本人操作，请忽略本短信。
-	return svc.smsSvc.Send(ctx, codeTplId, []string{code, "10"}, phone)
+	return svc.smsSvc.Send(ctx, codeTpl, []string{code, "10"}, phone)
 }
 
 func (svc *codeService) Verify(ctx context.Context, biz, phone, inputCode string) (bool, error) {
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/repository"
+	repomocks "github.com/liupch66/basic-go/webook/internal/repository/mocks"
+	svcmocks "github.com/liupch66/basic-go/webook/internal/service/mocks"
+	"github.com/liupch66/basic-go/webook/internal/service/sms"
+	smsmocks "github.com/liupch66/basic-go/webook/internal/service/sms/mocks"
+)
+
+func Test_codeService_Send(t *testing.T) {
+	meta := domain.CodeSendMeta{IP: "127.0.0.1", Device: "device"}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.CodeRepository, sms.Service, CodeThrottleService)
+
+		wantErr error
+	}{
+		{
+			name: "发送成功",
+			mock: func(ctrl *gomock.Controller) (repository.CodeRepository, sms.Service, CodeThrottleService) {
+				repo := repomocks.NewMockCodeRepository(ctrl)
+				smsSvc := smsmocks.NewMockService(ctrl)
+				throttle := svcmocks.NewMockCodeThrottleService(ctrl)
+				gomock.InOrder(
+					repo.EXPECT().Sendable(gomock.Any(), "login", "13800000000").Return(true, nil),
+					throttle.EXPECT().Check(gomock.Any(), "login", "13800000000", meta).Return(nil),
+					repo.EXPECT().Store(gomock.Any(), "login", "13800000000", gomock.Any()).Return(nil),
+					smsSvc.EXPECT().Send(gomock.Any(), codeTpl, gomock.Any(), "13800000000").Return(nil),
+				)
+				return repo, smsSvc, throttle
+			},
+		},
+		{
+			name: "一分钟之内重复发送，不计频控",
+			mock: func(ctrl *gomock.Controller) (repository.CodeRepository, sms.Service, CodeThrottleService) {
+				repo := repomocks.NewMockCodeRepository(ctrl)
+				repo.EXPECT().Sendable(gomock.Any(), "login", "13800000000").Return(false, nil)
+				return repo, smsmocks.NewMockService(ctrl), svcmocks.NewMockCodeThrottleService(ctrl)
+			},
+			wantErr: ErrCodeSendTooMany,
+		},
+		{
+			name: "被频控拦下来，不存验证码",
+			mock: func(ctrl *gomock.Controller) (repository.CodeRepository, sms.Service, CodeThrottleService) {
+				repo := repomocks.NewMockCodeRepository(ctrl)
+				throttle := svcmocks.NewMockCodeThrottleService(ctrl)
+				repo.EXPECT().Sendable(gomock.Any(), "login", "13800000000").Return(true, nil)
+				throttle.EXPECT().Check(gomock.Any(), "login", "13800000000", meta).
+					Return(&CodeDeniedError{Reason: CodeDenyCaptchaRequired})
+				return repo, smsmocks.NewMockService(ctrl), throttle
+			},
+			wantErr: &CodeDeniedError{Reason: CodeDenyCaptchaRequired},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, smsSvc, throttle := tc.mock(ctrl)
+			svc := NewCodeService(repo, smsSvc, throttle)
+			err := svc.Send(context.Background(), "login", "13800000000", meta)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
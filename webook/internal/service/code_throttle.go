@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/repository"
+	"github.com/liupch66/basic-go/webook/internal/service/captcha"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
+)
+
+// 验证码被频控拦下来的原因，超过了配额的是 维度_窗口，比如 phone_hour、ip_day、budget_day
+const (
+	CodeDenyCaptchaRequired = "captcha_required"
+	CodeDenyCaptchaInvalid  = "captcha_invalid"
+)
+
+const day = 24 * time.Hour
+
+// CodeDeniedError 发送验证码被频控拦下来了，Reason 告诉前端为什么
+type CodeDeniedError struct {
+	Reason string
+	// Rule 触发了限制的规则，图形验证码不对的时候是零值
+	Rule domain.CodeThrottleRule
+}
+
+func (e *CodeDeniedError) Error() string {
+	return fmt.Sprintf("验证码发送被频控拒绝：%s", e.Reason)
+}
+
+//go:generate mockgen -package=svcmocks -source=code_throttle.go -destination=mocks/code_throttle_mock.go CodeThrottleService
+type CodeThrottleService interface {
+	// Check 允许发送的话计数一次，不允许的话返回 *CodeDeniedError
+	Check(ctx context.Context, biz, phone string, meta domain.CodeSendMeta) error
+}
+
+// codeThrottleService 按手机号、IP、设备指纹分别计数，超过了先要求图形验证码，再超过直接拒绝。
+// 每天的总预算是所有业务共用的，超过了通过图形验证码也不给发
+type codeThrottleService struct {
+	repo     repository.CodeThrottleRepository
+	captcha  captcha.Service
+	policies map[string]domain.CodeThrottlePolicy
+	// dailyBudget 0 就是不限制
+	dailyBudget int64
+	l           logger.LoggerV1
+}
+
+// NewCodeThrottleService policies 的 key 是 biz，没有配置的 biz 用 domain.CodeThrottleDefault
+func NewCodeThrottleService(repo repository.CodeThrottleRepository, captcha captcha.Service,
+	policies map[string]domain.CodeThrottlePolicy, dailyBudget int64, l logger.LoggerV1) CodeThrottleService {
+	return &codeThrottleService{repo: repo, captcha: captcha, policies: policies, dailyBudget: dailyBudget, l: l}
+}
+
+func (svc *codeThrottleService) Check(ctx context.Context, biz, phone string, meta domain.CodeSendMeta) error {
+	passed := false
+	if meta.CaptchaToken != "" {
+		ok, err := svc.captcha.Verify(ctx, meta.CaptchaToken, meta.IP)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return &CodeDeniedError{Reason: CodeDenyCaptchaInvalid}
+		}
+		passed = true
+	}
+	res, err := svc.repo.Acquire(ctx, biz, svc.rules(biz, phone, meta), passed)
+	if err != nil {
+		return err
+	}
+	switch res.Decision {
+	case domain.CodeThrottleAllow:
+		return nil
+	case domain.CodeThrottleCaptcha:
+		return &CodeDeniedError{Reason: CodeDenyCaptchaRequired, Rule: res.Rule}
+	default:
+		// 被拒绝的一般就是在刷短信了，不打手机号
+		svc.l.Warn("验证码发送超过配额", logger.String("biz", biz), logger.String("dim", res.Rule.Dim),
+			logger.String("ip", meta.IP), logger.String("device", meta.Device))
+		return &CodeDeniedError{Reason: res.Rule.Dim + "_" + windowName(res.Rule.Window), Rule: res.Rule}
+	}
+}
+
+func (svc *codeThrottleService) rules(biz, phone string, meta domain.CodeSendMeta) []domain.CodeThrottleRule {
+	policy, ok := svc.policies[biz]
+	if !ok {
+		policy = svc.policies[domain.CodeThrottleDefault]
+	}
+	var rules []domain.CodeThrottleRule
+	add := func(dim, value string, p domain.CodeDimPolicy) {
+		// 拿不到 IP、设备指纹的，这个维度就不限制了
+		if value == "" {
+			return
+		}
+		if p.Captcha.Hour > 0 || p.Reject.Hour > 0 {
+			rules = append(rules, domain.CodeThrottleRule{Dim: dim, Value: value, Window: time.Hour,
+				Captcha: p.Captcha.Hour, Reject: p.Reject.Hour})
+		}
+		if p.Captcha.Day > 0 || p.Reject.Day > 0 {
+			rules = append(rules, domain.CodeThrottleRule{Dim: dim, Value: value, Window: day,
+				Captcha: p.Captcha.Day, Reject: p.Reject.Day})
+		}
+	}
+	add(domain.CodeDimPhone, phone, policy.Phone)
+	add(domain.CodeDimIP, meta.IP, policy.IP)
+	add(domain.CodeDimDevice, meta.Device, policy.Device)
+	if svc.dailyBudget > 0 {
+		rules = append(rules, domain.CodeThrottleRule{Dim: domain.CodeDimBudget, Window: day, Reject: svc.dailyBudget})
+	}
+	return rules
+}
+
+func windowName(window time.Duration) string {
+	if window == time.Hour {
+		return "hour"
+	}
+	return "day"
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/repository"
+	repomocks "github.com/liupch66/basic-go/webook/internal/repository/mocks"
+	"github.com/liupch66/basic-go/webook/internal/service/captcha"
+	captchamocks "github.com/liupch66/basic-go/webook/internal/service/captcha/mocks"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
+)
+
+func Test_codeThrottleService_Check(t *testing.T) {
+	policies := map[string]domain.CodeThrottlePolicy{
+		domain.CodeThrottleDefault: {
+			Phone: domain.CodeDimPolicy{Captcha: domain.CodeQuota{Hour: 5}, Reject: domain.CodeQuota{Hour: 10, Day: 20}},
+			IP:    domain.CodeDimPolicy{Reject: domain.CodeQuota{Day: 100}},
+		},
+		"bind_phone": {
+			Phone: domain.CodeDimPolicy{Reject: domain.CodeQuota{Day: 3}},
+		},
+	}
+	phoneHour := domain.CodeThrottleRule{Dim: domain.CodeDimPhone, Value: "15512345678", Window: time.Hour, Captcha: 5, Reject: 10}
+	defaultRules := []domain.CodeThrottleRule{
+		phoneHour,
+		{Dim: domain.CodeDimPhone, Value: "15512345678", Window: day, Reject: 20},
+		{Dim: domain.CodeDimIP, Value: "1.1.1.1", Window: day, Reject: 100},
+		{Dim: domain.CodeDimBudget, Window: day, Reject: 1000},
+	}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.CodeThrottleRepository, captcha.Service)
+		biz  string
+		meta domain.CodeSendMeta
+
+		wantReason string
+		wantErr    error
+	}{
+		{
+			name: "允许发送，没有设备指纹的不限制设备",
+			mock: func(ctrl *gomock.Controller) (repository.CodeThrottleRepository, captcha.Service) {
+				repo := repomocks.NewMockCodeThrottleRepository(ctrl)
+				repo.EXPECT().Acquire(gomock.Any(), "login", defaultRules, false).Return(domain.CodeThrottleResult{}, nil)
+				return repo, captchamocks.NewMockService(ctrl)
+			},
+			biz:  "login",
+			meta: domain.CodeSendMeta{IP: "1.1.1.1"},
+		},
+		{
+			name: "业务单独配置的策略",
+			mock: func(ctrl *gomock.Controller) (repository.CodeThrottleRepository, captcha.Service) {
+				repo := repomocks.NewMockCodeThrottleRepository(ctrl)
+				repo.EXPECT().Acquire(gomock.Any(), "bind_phone", []domain.CodeThrottleRule{
+					{Dim: domain.CodeDimPhone, Value: "15512345678", Window: day, Reject: 3},
+					{Dim: domain.CodeDimBudget, Window: day, Reject: 1000},
+				}, false).Return(domain.CodeThrottleResult{}, nil)
+				return repo, captchamocks.NewMockService(ctrl)
+			},
+			biz:  "bind_phone",
+			meta: domain.CodeSendMeta{IP: "1.1.1.1", Device: "abc"},
+		},
+		{
+			name: "要图形验证码",
+			mock: func(ctrl *gomock.Controller) (repository.CodeThrottleRepository, captcha.Service) {
+				repo := repomocks.NewMockCodeThrottleRepository(ctrl)
+				repo.EXPECT().Acquire(gomock.Any(), "login", defaultRules, false).
+					Return(domain.CodeThrottleResult{Decision: domain.CodeThrottleCaptcha, Rule: phoneHour}, nil)
+				return repo, captchamocks.NewMockService(ctrl)
+			},
+			biz:        "login",
+			meta:       domain.CodeSendMeta{IP: "1.1.1.1"},
+			wantReason: CodeDenyCaptchaRequired,
+		},
+		{
+			name: "通过了图形验证码，还是超过了拒绝的阈值",
+			mock: func(ctrl *gomock.Controller) (repository.CodeThrottleRepository, captcha.Service) {
+				repo := repomocks.NewMockCodeThrottleRepository(ctrl)
+				c := captchamocks.NewMockService(ctrl)
+				c.EXPECT().Verify(gomock.Any(), "token", "1.1.1.1").Return(true, nil)
+				repo.EXPECT().Acquire(gomock.Any(), "login", defaultRules, true).
+					Return(domain.CodeThrottleResult{Decision: domain.CodeThrottleReject, Rule: phoneHour}, nil)
+				return repo, c
+			},
+			biz:        "login",
+			meta:       domain.CodeSendMeta{IP: "1.1.1.1", CaptchaToken: "token"},
+			wantReason: "phone_hour",
+		},
+		{
+			name: "图形验证码不对，不计数",
+			mock: func(ctrl *gomock.Controller) (repository.CodeThrottleRepository, captcha.Service) {
+				c := captchamocks.NewMockService(ctrl)
+				c.EXPECT().Verify(gomock.Any(), "token", "1.1.1.1").Return(false, nil)
+				return repomocks.NewMockCodeThrottleRepository(ctrl), c
+			},
+			biz:        "login",
+			meta:       domain.CodeSendMeta{IP: "1.1.1.1", CaptchaToken: "token"},
+			wantReason: CodeDenyCaptchaInvalid,
+		},
+		{
+			name: "Redis 出错",
+			mock: func(ctrl *gomock.Controller) (repository.CodeThrottleRepository, captcha.Service) {
+				repo := repomocks.NewMockCodeThrottleRepository(ctrl)
+				repo.EXPECT().Acquire(gomock.Any(), "login", defaultRules, false).
+					Return(domain.CodeThrottleResult{}, errors.New("redis 错误"))
+				return repo, captchamocks.NewMockService(ctrl)
+			},
+			biz:     "login",
+			meta:    domain.CodeSendMeta{IP: "1.1.1.1"},
+			wantErr: errors.New("redis 错误"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, c := tc.mock(ctrl)
+			svc := NewCodeThrottleService(repo, c, policies, 1000, logger.NewNopLogger())
+			err := svc.Check(context.Background(), tc.biz, "15512345678", tc.meta)
+			var de *CodeDeniedError
+			switch {
+			case tc.wantReason != "":
+				assert.ErrorAs(t, err, &de)
+				assert.Equal(t, tc.wantReason, de.Reason)
+			default:
+				assert.Equal(t, tc.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package file
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/liupch66/basic-go/webook/internal/service/email"
+)
+
+// Service 把邮件写成 .eml 文件放到目录里面，本地开发的时候直接用邮件客户端打开
+type Service struct {
+	dir  string
+	from string
+	tpls *email.TemplateEngine
+}
+
+func NewService(dir, from string, tpls *email.TemplateEngine) *Service {
+	return &Service{dir: dir, from: from, tpls: tpls}
+}
+
+func (s *Service) Send(ctx context.Context, tplId string, params map[string]string, to ...string) error {
+	msg, err := s.tpls.Render(tplId, params, to...)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(s.dir, 0o755); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%d-%s-%s.eml", time.Now().UnixNano(), tplId, strings.Join(to, "_"))
+	return os.WriteFile(filepath.Join(s.dir, filepath.Base(name)), msg.Bytes(s.from), 0o644)
+}
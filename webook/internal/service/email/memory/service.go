@@ -0,0 +1,41 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/liupch66/basic-go/webook/internal/service/email"
+)
+
+// Service 不真的发邮件，渲染好之后存在内存里面，测试的时候可以拿出来看
+type Service struct {
+	tpls *email.TemplateEngine
+	mu   sync.RWMutex
+	msgs []email.Message
+}
+
+func NewService(tpls *email.TemplateEngine) *Service {
+	return &Service{tpls: tpls}
+}
+
+func (s *Service) Send(ctx context.Context, tplId string, params map[string]string, to ...string) error {
+	msg, err := s.tpls.Render(tplId, params, to...)
+	if err != nil {
+		return err
+	}
+	fmt.Println(msg.To, msg.Subject, params)
+	s.mu.Lock()
+	s.msgs = append(s.msgs, msg)
+	s.mu.Unlock()
+	return nil
+}
+
+// Messages 发过的所有邮件
+func (s *Service) Messages() []email.Message {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	res := make([]email.Message, len(s.msgs))
+	copy(res, s.msgs)
+	return res
+}
@@ -0,0 +1,30 @@
+package email
+
+import (
+	"bytes"
+	"encoding/base64"
+	"mime"
+	"strings"
+	"time"
+)
+
+// Bytes 生成 RFC 5322 格式的邮件，SMTP 发送和落盘都用它
+func (m Message) Bytes(from string) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("From: " + from + "\r\n")
+	buf.WriteString("To: " + strings.Join(m.To, ", ") + "\r\n")
+	// 中文标题要编码
+	buf.WriteString("Subject: " + mime.BEncoding.Encode("UTF-8", m.Subject) + "\r\n")
+	buf.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/html; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: base64\r\n\r\n")
+	body := base64.StdEncoding.EncodeToString([]byte(m.HTML))
+	// base64 每行不超过 76 个字符
+	for len(body) > 76 {
+		buf.WriteString(body[:76] + "\r\n")
+		body = body[76:]
+	}
+	buf.WriteString(body + "\r\n")
+	return buf.Bytes()
+}
@@ -0,0 +1,101 @@
+package smtp
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/smtp"
+	"strconv"
+
+	"github.com/liupch66/basic-go/webook/internal/service/email"
+)
+
+type Config struct {
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	From     string `yaml:"from"`
+	// TLS 465 端口是一上来就 TLS；25、587 端口不用开，服务器支持的话会 STARTTLS
+	TLS bool `yaml:"tls"`
+}
+
+type Service struct {
+	cfg  Config
+	tpls *email.TemplateEngine
+}
+
+func NewService(cfg Config, tpls *email.TemplateEngine) *Service {
+	return &Service{cfg: cfg, tpls: tpls}
+}
+
+func (s *Service) Send(ctx context.Context, tplId string, params map[string]string, to ...string) error {
+	msg, err := s.tpls.Render(tplId, params, to...)
+	if err != nil {
+		return err
+	}
+	c, err := s.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	if s.cfg.Username != "" {
+		if err = c.Auth(smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)); err != nil {
+			return err
+		}
+	}
+	if err = c.Mail(s.cfg.From); err != nil {
+		return err
+	}
+	for _, addr := range to {
+		if err = c.Rcpt(addr); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(msg.Bytes(s.cfg.From)); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+func (s *Service) dial(ctx context.Context) (*smtp.Client, error) {
+	addr := net.JoinHostPort(s.cfg.Host, strconv.Itoa(s.cfg.Port))
+	tlsCfg := &tls.Config{ServerName: s.cfg.Host}
+	var (
+		conn net.Conn
+		err  error
+	)
+	if s.cfg.TLS {
+		conn, err = (&tls.Dialer{Config: tlsCfg}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// 整个发送过程都受 ctx 的超时控制
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	c, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	if !s.cfg.TLS {
+		if ok, _ := c.Extension("STARTTLS"); ok {
+			if err = c.StartTLS(tlsCfg); err != nil {
+				_ = c.Close()
+				return nil, err
+			}
+		}
+	}
+	return c, nil
+}
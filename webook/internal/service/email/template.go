@@ -0,0 +1,69 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+//go:embed templates/*.tmpl
+var defaultTemplates embed.FS
+
+var ErrUnknownTemplate = errors.New("未知的邮件模板")
+
+// TemplateEngine 每个模板文件里面用 define 定义 subject 和 body 两部分，文件名（去掉 .tmpl）就是 tplId
+type TemplateEngine struct {
+	tpls map[string]*template.Template
+}
+
+// NewDefaultTemplateEngine 内置的模板
+func NewDefaultTemplateEngine() *TemplateEngine {
+	engine, err := NewTemplateEngine(defaultTemplates, "templates/*.tmpl")
+	if err != nil {
+		panic(err)
+	}
+	return engine
+}
+
+func NewTemplateEngine(fsys fs.FS, pattern string) (*TemplateEngine, error) {
+	files, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return nil, err
+	}
+	tpls := make(map[string]*template.Template, len(files))
+	for _, file := range files {
+		tpl, err := template.ParseFS(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		if tpl.Lookup("subject") == nil || tpl.Lookup("body") == nil {
+			return nil, fmt.Errorf("邮件模板 %s 缺少 subject 或者 body", file)
+		}
+		tpls[strings.TrimSuffix(path.Base(file), ".tmpl")] = tpl
+	}
+	return &TemplateEngine{tpls: tpls}, nil
+}
+
+func (e *TemplateEngine) Render(tplId string, params map[string]string, to ...string) (Message, error) {
+	tpl, ok := e.tpls[tplId]
+	if !ok {
+		return Message{}, fmt.Errorf("%w: %s", ErrUnknownTemplate, tplId)
+	}
+	var subject, body bytes.Buffer
+	if err := tpl.ExecuteTemplate(&subject, "subject", params); err != nil {
+		return Message{}, err
+	}
+	if err := tpl.ExecuteTemplate(&body, "body", params); err != nil {
+		return Message{}, err
+	}
+	return Message{
+		To:      to,
+		Subject: strings.TrimSpace(subject.String()),
+		HTML:    body.String(),
+	}, nil
+}
@@ -0,0 +1,25 @@
+package email
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateEngine_Render(t *testing.T) {
+	engine := NewDefaultTemplateEngine()
+	msg, err := engine.Render("reset_password_code", map[string]string{"code": "123456", "minutes": "10"}, "a@qq.com")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a@qq.com"}, msg.To)
+	assert.Equal(t, "webook 重置密码", msg.Subject)
+	assert.Contains(t, msg.HTML, "<b>123456</b>")
+
+	// 参数会被转义
+	msg, err = engine.Render("signup_code", map[string]string{"code": "<script>"}, "a@qq.com")
+	require.NoError(t, err)
+	assert.NotContains(t, msg.HTML, "<script>")
+
+	_, err = engine.Render("unknown", nil, "a@qq.com")
+	assert.ErrorIs(t, err, ErrUnknownTemplate)
+}
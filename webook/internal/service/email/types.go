@@ -0,0 +1,19 @@
+package email
+
+import (
+	"context"
+)
+
+//go:generate mockgen -package=emailmocks -source=types.go -destination=mocks/email_mock.go Service
+type Service interface {
+	// Send 和 sms.Service 一样按模板发送，tplId 是 TemplateEngine 里面的模板名
+	Send(ctx context.Context, tplId string, params map[string]string, to ...string) error
+}
+
+// Message 渲染好的邮件
+type Message struct {
+	To      []string
+	Subject string
+	// HTML 邮件正文
+	HTML string
+}
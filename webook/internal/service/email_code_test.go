@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/liupch66/basic-go/webook/internal/repository"
+	repomocks "github.com/liupch66/basic-go/webook/internal/repository/mocks"
+	"github.com/liupch66/basic-go/webook/internal/service/email"
+	emailmocks "github.com/liupch66/basic-go/webook/internal/service/email/mocks"
+)
+
+func Test_emailCodeService_Send(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.CodeRepository, email.Service)
+		biz  string
+
+		expectedErr error
+	}{
+		{
+			name: "发送重置密码验证码",
+			mock: func(ctrl *gomock.Controller) (repository.CodeRepository, email.Service) {
+				repo := repomocks.NewMockCodeRepository(ctrl)
+				emailSvc := emailmocks.NewMockService(ctrl)
+				repo.EXPECT().Store(gomock.Any(), EmailBizResetPassword, "a@qq.com", gomock.Any()).Return(nil)
+				emailSvc.EXPECT().Send(gomock.Any(), "reset_password_code", gomock.Any(), "a@qq.com").Return(nil)
+				return repo, emailSvc
+			},
+			biz: EmailBizResetPassword,
+		},
+		{
+			name: "发送太频繁",
+			mock: func(ctrl *gomock.Controller) (repository.CodeRepository, email.Service) {
+				repo := repomocks.NewMockCodeRepository(ctrl)
+				repo.EXPECT().Store(gomock.Any(), EmailBizSignup, "a@qq.com", gomock.Any()).Return(ErrCodeSendTooMany)
+				return repo, emailmocks.NewMockService(ctrl)
+			},
+			biz:         EmailBizSignup,
+			expectedErr: ErrCodeSendTooMany,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewEmailCodeService(tc.mock(ctrl))
+			err := svc.Send(context.Background(), tc.biz, "a@qq.com")
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	followv1 "github.com/liupch66/basic-go/webook/api/proto/gen/follow/v1"
+	"github.com/liupch66/basic-go/webook/internal/domain"
+)
+
+// FeedService 关注流，拉模式：每次都先查关注了谁，再去查这些人发表的文章
+// 关注的人很多的时候应该改成推模式或者推拉结合，这里先不管
+type FeedService interface {
+	// GetFeed 查询 uid 关注的人排在 (before, beforeId) 后面的文章，按更新时间倒序
+	// 翻页的时候 before 和 beforeId 传上一页最后一篇文章的更新时间和 id，更新时间一样的文章就不会被跳过
+	GetFeed(ctx context.Context, uid int64, before time.Time, beforeId int64, limit int) ([]domain.Article, error)
+}
+
+type followFeedService struct {
+	artSvc    ArticleService
+	followSvc followv1.FollowServiceClient
+	batchSize int64
+	// 最多只看这么多关注的人，再多了 IN 查询也扛不住
+	maxFollowees int
+}
+
+func NewFollowFeedService(artSvc ArticleService, followSvc followv1.FollowServiceClient) FeedService {
+	return &followFeedService{
+		artSvc:       artSvc,
+		followSvc:    followSvc,
+		batchSize:    100,
+		maxFollowees: 1000,
+	}
+}
+
+func (svc *followFeedService) GetFeed(ctx context.Context, uid int64, before time.Time, beforeId int64, limit int) ([]domain.Article, error) {
+	followees, err := svc.followees(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	if len(followees) == 0 {
+		return []domain.Article{}, nil
+	}
+	return svc.artSvc.ListPubByAuthors(ctx, followees, before, beforeId, limit)
+}
+
+func (svc *followFeedService) followees(ctx context.Context, uid int64) ([]int64, error) {
+	var (
+		res   []int64
+		minId int64
+	)
+	for len(res) < svc.maxFollowees {
+		resp, err := svc.followSvc.GetFollowee(ctx, &followv1.GetFolloweeRequest{
+			Follower: uid,
+			MinId:    minId,
+			Limit:    svc.batchSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+		relations := resp.GetFollowRelations()
+		for _, r := range relations {
+			res = append(res, r.GetFollowee())
+		}
+		if int64(len(relations)) < svc.batchSize {
+			break
+		}
+		minId = relations[len(relations)-1].GetId()
+	}
+	return res, nil
+}
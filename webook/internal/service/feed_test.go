@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	followv1 "github.com/liupch66/basic-go/webook/api/proto/gen/follow/v1"
+	mockfollow "github.com/liupch66/basic-go/webook/api/proto/gen/follow/v1/mocks"
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	svcmocks "github.com/liupch66/basic-go/webook/internal/service/mocks"
+)
+
+func Test_followFeedService_GetFeed(t *testing.T) {
+	before := time.Now()
+	testCases := []struct {
+		name      string
+		mock      func(ctrl *gomock.Controller) (ArticleService, followv1.FollowServiceClient)
+		batchSize int64
+
+		expectedArts []domain.Article
+		expectedErr  error
+	}{
+		{
+			name: "分批查询关注的人",
+			mock: func(ctrl *gomock.Controller) (ArticleService, followv1.FollowServiceClient) {
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				followSvc := mockfollow.NewMockFollowServiceClient(ctrl)
+				followSvc.EXPECT().GetFollowee(gomock.Any(), &followv1.GetFolloweeRequest{Follower: 1, Limit: 2}).
+					Return(&followv1.GetFolloweeResponse{FollowRelations: []*followv1.FollowRelation{
+						{Id: 10, Follower: 1, Followee: 2}, {Id: 9, Follower: 1, Followee: 3}}}, nil)
+				followSvc.EXPECT().GetFollowee(gomock.Any(), &followv1.GetFolloweeRequest{Follower: 1, MinId: 9, Limit: 2}).
+					Return(&followv1.GetFolloweeResponse{FollowRelations: []*followv1.FollowRelation{
+						{Id: 8, Follower: 1, Followee: 4}}}, nil)
+				artSvc.EXPECT().ListPubByAuthors(gomock.Any(), []int64{2, 3, 4}, before, int64(5), 10).
+					Return([]domain.Article{{Id: 1, Author: domain.Author{Id: 2}}}, nil)
+				return artSvc, followSvc
+			},
+			batchSize:    2,
+			expectedArts: []domain.Article{{Id: 1, Author: domain.Author{Id: 2}}},
+		},
+		{
+			name: "没有关注任何人",
+			mock: func(ctrl *gomock.Controller) (ArticleService, followv1.FollowServiceClient) {
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				followSvc := mockfollow.NewMockFollowServiceClient(ctrl)
+				followSvc.EXPECT().GetFollowee(gomock.Any(), gomock.Any()).
+					Return(&followv1.GetFolloweeResponse{}, nil)
+				return artSvc, followSvc
+			},
+			batchSize:    2,
+			expectedArts: []domain.Article{},
+		},
+		{
+			name: "查询关注的人失败",
+			mock: func(ctrl *gomock.Controller) (ArticleService, followv1.FollowServiceClient) {
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				followSvc := mockfollow.NewMockFollowServiceClient(ctrl)
+				followSvc.EXPECT().GetFollowee(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("mock rpc error"))
+				return artSvc, followSvc
+			},
+			batchSize:   2,
+			expectedErr: errors.New("mock rpc error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			artSvc, followSvc := tc.mock(ctrl)
+			svc := NewFollowFeedService(artSvc, followSvc).(*followFeedService)
+			svc.batchSize = tc.batchSize
+			arts, err := svc.GetFeed(context.Background(), 1, before, 5, 10)
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedArts, arts)
+		})
+	}
+}
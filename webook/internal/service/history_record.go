@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/repository"
+)
+
+// HistoryRecordService 阅读历史，写入是消费 article_read_event 的时候直接走 repository
+type HistoryRecordService interface {
+	// List 按最近阅读时间倒序
+	List(ctx context.Context, uid int64, offset int, limit int) ([]domain.HistoryRecord, error)
+	Delete(ctx context.Context, uid int64, biz string, bizId int64) error
+	Clear(ctx context.Context, uid int64) error
+}
+
+type historyRecordService struct {
+	repo repository.HistoryRecordRepository
+}
+
+func NewHistoryRecordService(repo repository.HistoryRecordRepository) HistoryRecordService {
+	return &historyRecordService{repo: repo}
+}
+
+func (svc *historyRecordService) List(ctx context.Context, uid int64, offset int, limit int) ([]domain.HistoryRecord, error) {
+	return svc.repo.List(ctx, uid, offset, limit)
+}
+
+func (svc *historyRecordService) Delete(ctx context.Context, uid int64, biz string, bizId int64) error {
+	return svc.repo.DeleteRecord(ctx, uid, biz, bizId)
+}
+
+func (svc *historyRecordService) Clear(ctx context.Context, uid int64) error {
+	return svc.repo.ClearRecords(ctx, uid)
+}
@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./webook/internal/service/admin.go
+//
+// Generated by this command:
+//
+//	mockgen -package=svcmocks -source=./webook/internal/service/admin.go -destination=./webook/internal/service/mocks/admin.mock.go
+//
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/liupch66/basic-go/webook/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAdminService is a mock of AdminService interface.
+type MockAdminService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockAdminServiceMockRecorder is the mock recorder for MockAdminService.
+type MockAdminServiceMockRecorder struct {
+	mock *MockAdminService
+}
+
+// NewMockAdminService creates a new mock instance.
+func NewMockAdminService(ctrl *gomock.Controller) *MockAdminService {
+	mock := &MockAdminService{ctrl: ctrl}
+	mock.recorder = &MockAdminServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdminService) EXPECT() *MockAdminServiceMockRecorder {
+	return m.recorder
+}
+
+// BanUser mocks base method.
+func (m *MockAdminService) BanUser(ctx context.Context, operator, uid int64, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BanUser", ctx, operator, uid, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BanUser indicates an expected call of BanUser.
+func (mr *MockAdminServiceMockRecorder) BanUser(ctx, operator, uid, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BanUser", reflect.TypeOf((*MockAdminService)(nil).BanUser), ctx, operator, uid, reason)
+}
+
+// GrantRole mocks base method.
+func (m *MockAdminService) GrantRole(ctx context.Context, operator, uid int64, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantRole", ctx, operator, uid, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GrantRole indicates an expected call of GrantRole.
+func (mr *MockAdminServiceMockRecorder) GrantRole(ctx, operator, uid, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantRole", reflect.TypeOf((*MockAdminService)(nil).GrantRole), ctx, operator, uid, role)
+}
+
+// ListAuditLogs mocks base method.
+func (m *MockAdminService) ListAuditLogs(ctx context.Context, q domain.AuditLogQuery) ([]domain.AuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditLogs", ctx, q)
+	ret0, _ := ret[0].([]domain.AuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuditLogs indicates an expected call of ListAuditLogs.
+func (mr *MockAdminServiceMockRecorder) ListAuditLogs(ctx, q any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditLogs", reflect.TypeOf((*MockAdminService)(nil).ListAuditLogs), ctx, q)
+}
+
+// MergeUsers mocks base method.
+func (m *MockAdminService) MergeUsers(ctx context.Context, operator, fromUid, toUid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeUsers", ctx, operator, fromUid, toUid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergeUsers indicates an expected call of MergeUsers.
+func (mr *MockAdminServiceMockRecorder) MergeUsers(ctx, operator, fromUid, toUid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeUsers", reflect.TypeOf((*MockAdminService)(nil).MergeUsers), ctx, operator, fromUid, toUid)
+}
+
+// RevokeRole mocks base method.
+func (m *MockAdminService) RevokeRole(ctx context.Context, operator, uid int64, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRole", ctx, operator, uid, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRole indicates an expected call of RevokeRole.
+func (mr *MockAdminServiceMockRecorder) RevokeRole(ctx, operator, uid, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRole", reflect.TypeOf((*MockAdminService)(nil).RevokeRole), ctx, operator, uid, role)
+}
+
+// UnbanUser mocks base method.
+func (m *MockAdminService) UnbanUser(ctx context.Context, operator, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnbanUser", ctx, operator, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnbanUser indicates an expected call of UnbanUser.
+func (mr *MockAdminServiceMockRecorder) UnbanUser(ctx, operator, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnbanUser", reflect.TypeOf((*MockAdminService)(nil).UnbanUser), ctx, operator, uid)
+}
+
+// WithdrawArticle mocks base method.
+func (m *MockAdminService) WithdrawArticle(ctx context.Context, operator, artId int64, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithdrawArticle", ctx, operator, artId, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithdrawArticle indicates an expected call of WithdrawArticle.
+func (mr *MockAdminServiceMockRecorder) WithdrawArticle(ctx, operator, artId, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithdrawArticle", reflect.TypeOf((*MockAdminService)(nil).WithdrawArticle), ctx, operator, artId, reason)
+}
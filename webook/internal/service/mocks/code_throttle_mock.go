@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: code_throttle.go
+//
+// Generated by this command:
+//
+//	mockgen -package=svcmocks -source=code_throttle.go -destination=mocks/code_throttle_mock.go
+//
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/liupch66/basic-go/webook/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCodeThrottleService is a mock of CodeThrottleService interface.
+type MockCodeThrottleService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCodeThrottleServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockCodeThrottleServiceMockRecorder is the mock recorder for MockCodeThrottleService.
+type MockCodeThrottleServiceMockRecorder struct {
+	mock *MockCodeThrottleService
+}
+
+// NewMockCodeThrottleService creates a new mock instance.
+func NewMockCodeThrottleService(ctrl *gomock.Controller) *MockCodeThrottleService {
+	mock := &MockCodeThrottleService{ctrl: ctrl}
+	mock.recorder = &MockCodeThrottleServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCodeThrottleService) EXPECT() *MockCodeThrottleServiceMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockCodeThrottleService) Check(ctx context.Context, biz, phone string, meta domain.CodeSendMeta) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx, biz, phone, meta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockCodeThrottleServiceMockRecorder) Check(ctx, biz, phone, meta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockCodeThrottleService)(nil).Check), ctx, biz, phone, meta)
+}
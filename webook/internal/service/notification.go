@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/repository"
+)
+
+//go:generate mockgen -package=svcmocks -source=notification.go -destination=mocks/notification_mock.go NotificationService
+type NotificationService interface {
+	// OnInteract 有人点赞、收藏了文章，通知文章的作者
+	OnInteract(ctx context.Context, actorUid int64, action domain.InteractAction, biz string, bizId int64) error
+	// OnComment 有人发表了评论，通知文章的作者，是回复的话还要通知被回复的人
+	OnComment(ctx context.Context, actorUid int64, biz string, bizId int64, parentUid int64) error
+	SendSystem(ctx context.Context, uid int64, content string) error
+	// List 按照最近更新的时间倒序，折叠的通知有新的互动也会排到前面
+	List(ctx context.Context, uid int64, offset int, limit int) ([]domain.Notification, error)
+	UnreadCnt(ctx context.Context, uid int64) (int64, error)
+	MarkRead(ctx context.Context, uid int64, ids []int64) error
+	MarkAllRead(ctx context.Context, uid int64) error
+	// DeleteAll 注销账号的时候删掉用户所有的通知
+	DeleteAll(ctx context.Context, uid int64) error
+}
+
+type notificationService struct {
+	repo   repository.NotificationRepository
+	artSvc ArticleService
+}
+
+func NewNotificationService(repo repository.NotificationRepository, artSvc ArticleService) NotificationService {
+	return &notificationService{repo: repo, artSvc: artSvc}
+}
+
+func (svc *notificationService) OnInteract(ctx context.Context, actorUid int64, action domain.InteractAction,
+	biz string, bizId int64) error {
+	var typ domain.NotificationType
+	switch action {
+	case domain.InteractActionLike:
+		typ = domain.NotificationTypeLike
+	case domain.InteractActionCollect:
+		typ = domain.NotificationTypeCollect
+	default:
+		// 阅读不通知，取消点赞、取消收藏也不去撤回已经发出去的通知
+		return nil
+	}
+	// 目前只有文章
+	if biz != "article" {
+		return nil
+	}
+	author, err := svc.articleAuthor(ctx, bizId)
+	// 文章已经撤回了，或者自己给自己点赞就不用通知了
+	if err != nil || author == 0 || author == actorUid {
+		return err
+	}
+	return svc.repo.Create(ctx, domain.Notification{
+		Uid:         author,
+		Type:        typ,
+		Biz:         biz,
+		BizId:       bizId,
+		LastActorId: actorUid,
+	})
+}
+
+func (svc *notificationService) OnComment(ctx context.Context, actorUid int64, biz string, bizId int64, parentUid int64) error {
+	if biz != "article" {
+		return nil
+	}
+	author, err := svc.articleAuthor(ctx, bizId)
+	if err != nil || author == 0 {
+		return err
+	}
+	if parentUid > 0 && parentUid != actorUid {
+		err = svc.repo.Create(ctx, domain.Notification{
+			Uid:         parentUid,
+			Type:        domain.NotificationTypeReply,
+			Biz:         biz,
+			BizId:       bizId,
+			LastActorId: actorUid,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	// 回复的就是作者的评论，上面已经通知过了
+	if author == actorUid || author == parentUid {
+		return nil
+	}
+	return svc.repo.Create(ctx, domain.Notification{
+		Uid:         author,
+		Type:        domain.NotificationTypeComment,
+		Biz:         biz,
+		BizId:       bizId,
+		LastActorId: actorUid,
+	})
+}
+
+// articleAuthor 文章已经撤回了就返回 0
+func (svc *notificationService) articleAuthor(ctx context.Context, aid int64) (int64, error) {
+	arts, err := svc.artSvc.ListPubByIds(ctx, []int64{aid})
+	if err != nil || len(arts) == 0 {
+		return 0, err
+	}
+	return arts[0].Author.Id, nil
+}
+
+func (svc *notificationService) SendSystem(ctx context.Context, uid int64, content string) error {
+	return svc.repo.Create(ctx, domain.Notification{
+		Uid:     uid,
+		Type:    domain.NotificationTypeSystem,
+		Content: content,
+	})
+}
+
+func (svc *notificationService) List(ctx context.Context, uid int64, offset int, limit int) ([]domain.Notification, error) {
+	return svc.repo.List(ctx, uid, offset, limit)
+}
+
+func (svc *notificationService) UnreadCnt(ctx context.Context, uid int64) (int64, error) {
+	return svc.repo.UnreadCnt(ctx, uid)
+}
+
+func (svc *notificationService) MarkRead(ctx context.Context, uid int64, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return svc.repo.MarkRead(ctx, uid, ids)
+}
+
+func (svc *notificationService) MarkAllRead(ctx context.Context, uid int64) error {
+	return svc.repo.MarkAllRead(ctx, uid)
+}
+
+func (svc *notificationService) DeleteAll(ctx context.Context, uid int64) error {
+	return svc.repo.DeleteAll(ctx, uid)
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/repository"
+	repomocks "github.com/liupch66/basic-go/webook/internal/repository/mocks"
+	svcmocks "github.com/liupch66/basic-go/webook/internal/service/mocks"
+)
+
+func Test_notificationService_OnInteract(t *testing.T) {
+	testCases := []struct {
+		name   string
+		mock   func(ctrl *gomock.Controller) (repository.NotificationRepository, ArticleService)
+		action domain.InteractAction
+		actor  int64
+
+		expectedErr error
+	}{
+		{
+			name: "点赞通知作者",
+			mock: func(ctrl *gomock.Controller) (repository.NotificationRepository, ArticleService) {
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				artSvc.EXPECT().ListPubByIds(gomock.Any(), []int64{1}).
+					Return([]domain.Article{{Id: 1, Author: domain.Author{Id: 123}}}, nil)
+				repo := repomocks.NewMockNotificationRepository(ctrl)
+				repo.EXPECT().Create(gomock.Any(), domain.Notification{
+					Uid:         123,
+					Type:        domain.NotificationTypeLike,
+					Biz:         "article",
+					BizId:       1,
+					LastActorId: 456,
+				}).Return(nil)
+				return repo, artSvc
+			},
+			action: domain.InteractActionLike,
+			actor:  456,
+		},
+		{
+			name: "自己收藏自己的文章",
+			mock: func(ctrl *gomock.Controller) (repository.NotificationRepository, ArticleService) {
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				artSvc.EXPECT().ListPubByIds(gomock.Any(), []int64{1}).
+					Return([]domain.Article{{Id: 1, Author: domain.Author{Id: 123}}}, nil)
+				return repomocks.NewMockNotificationRepository(ctrl), artSvc
+			},
+			action: domain.InteractActionCollect,
+			actor:  123,
+		},
+		{
+			name: "文章已经撤回了",
+			mock: func(ctrl *gomock.Controller) (repository.NotificationRepository, ArticleService) {
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				artSvc.EXPECT().ListPubByIds(gomock.Any(), []int64{1}).Return([]domain.Article{}, nil)
+				return repomocks.NewMockNotificationRepository(ctrl), artSvc
+			},
+			action: domain.InteractActionLike,
+			actor:  456,
+		},
+		{
+			name: "阅读不通知",
+			mock: func(ctrl *gomock.Controller) (repository.NotificationRepository, ArticleService) {
+				return repomocks.NewMockNotificationRepository(ctrl), svcmocks.NewMockArticleService(ctrl)
+			},
+			action: domain.InteractActionRead,
+			actor:  456,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, artSvc := tc.mock(ctrl)
+			svc := NewNotificationService(repo, artSvc)
+			err := svc.OnInteract(context.Background(), tc.actor, tc.action, "article", 1)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func Test_notificationService_OnComment(t *testing.T) {
+	testCases := []struct {
+		name      string
+		mock      func(ctrl *gomock.Controller) (repository.NotificationRepository, ArticleService)
+		actor     int64
+		parentUid int64
+
+		expectedErr error
+	}{
+		{
+			name: "评论通知作者",
+			mock: func(ctrl *gomock.Controller) (repository.NotificationRepository, ArticleService) {
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				artSvc.EXPECT().ListPubByIds(gomock.Any(), []int64{1}).
+					Return([]domain.Article{{Id: 1, Author: domain.Author{Id: 123}}}, nil)
+				repo := repomocks.NewMockNotificationRepository(ctrl)
+				repo.EXPECT().Create(gomock.Any(), domain.Notification{
+					Uid:         123,
+					Type:        domain.NotificationTypeComment,
+					Biz:         "article",
+					BizId:       1,
+					LastActorId: 456,
+				}).Return(nil)
+				return repo, artSvc
+			},
+			actor: 456,
+		},
+		{
+			name: "回复别人的评论，通知被回复的人和作者",
+			mock: func(ctrl *gomock.Controller) (repository.NotificationRepository, ArticleService) {
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				artSvc.EXPECT().ListPubByIds(gomock.Any(), []int64{1}).
+					Return([]domain.Article{{Id: 1, Author: domain.Author{Id: 123}}}, nil)
+				repo := repomocks.NewMockNotificationRepository(ctrl)
+				repo.EXPECT().Create(gomock.Any(), domain.Notification{
+					Uid:         789,
+					Type:        domain.NotificationTypeReply,
+					Biz:         "article",
+					BizId:       1,
+					LastActorId: 456,
+				}).Return(nil)
+				repo.EXPECT().Create(gomock.Any(), domain.Notification{
+					Uid:         123,
+					Type:        domain.NotificationTypeComment,
+					Biz:         "article",
+					BizId:       1,
+					LastActorId: 456,
+				}).Return(nil)
+				return repo, artSvc
+			},
+			actor:     456,
+			parentUid: 789,
+		},
+		{
+			name: "回复作者的评论，只通知一次",
+			mock: func(ctrl *gomock.Controller) (repository.NotificationRepository, ArticleService) {
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				artSvc.EXPECT().ListPubByIds(gomock.Any(), []int64{1}).
+					Return([]domain.Article{{Id: 1, Author: domain.Author{Id: 123}}}, nil)
+				repo := repomocks.NewMockNotificationRepository(ctrl)
+				repo.EXPECT().Create(gomock.Any(), domain.Notification{
+					Uid:         123,
+					Type:        domain.NotificationTypeReply,
+					Biz:         "article",
+					BizId:       1,
+					LastActorId: 456,
+				}).Return(nil)
+				return repo, artSvc
+			},
+			actor:     456,
+			parentUid: 123,
+		},
+		{
+			name: "作者回复自己的评论",
+			mock: func(ctrl *gomock.Controller) (repository.NotificationRepository, ArticleService) {
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				artSvc.EXPECT().ListPubByIds(gomock.Any(), []int64{1}).
+					Return([]domain.Article{{Id: 1, Author: domain.Author{Id: 123}}}, nil)
+				return repomocks.NewMockNotificationRepository(ctrl), artSvc
+			},
+			actor:     123,
+			parentUid: 123,
+		},
+		{
+			name: "文章已经撤回了",
+			mock: func(ctrl *gomock.Controller) (repository.NotificationRepository, ArticleService) {
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				artSvc.EXPECT().ListPubByIds(gomock.Any(), []int64{1}).Return([]domain.Article{}, nil)
+				return repomocks.NewMockNotificationRepository(ctrl), artSvc
+			},
+			actor:     456,
+			parentUid: 789,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, artSvc := tc.mock(ctrl)
+			svc := NewNotificationService(repo, artSvc)
+			err := svc.OnComment(context.Background(), tc.actor, "article", 1, tc.parentUid)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
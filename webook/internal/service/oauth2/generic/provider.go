@@ -0,0 +1,131 @@
+package generic
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/service/oauth2"
+)
+
+// Config 普通的 OAuth2 平台，比如 GitHub。
+// 用户信息接口返回的是 JSON 对象，SubjectField、EmailField、NameField 指定用哪个字段
+type Config struct {
+	Name         string   `yaml:"name"`
+	ClientId     string   `yaml:"clientId"`
+	ClientSecret string   `yaml:"clientSecret"`
+	AuthURL      string   `yaml:"authURL"`
+	TokenURL     string   `yaml:"tokenURL"`
+	UserInfoURL  string   `yaml:"userInfoURL"`
+	RedirectURL  string   `yaml:"redirectURL"`
+	Scopes       []string `yaml:"scopes"`
+	PKCE         bool     `yaml:"pkce"`
+	// SubjectField 默认 id
+	SubjectField string `yaml:"subjectField"`
+	// EmailField 默认 email
+	EmailField string `yaml:"emailField"`
+	// NameField 默认 name
+	NameField string `yaml:"nameField"`
+}
+
+var _ oauth2.Provider = (*Provider)(nil)
+
+type Provider struct {
+	cfg    Config
+	client *http.Client
+}
+
+func NewProvider(cfg Config, client *http.Client) *Provider {
+	if cfg.SubjectField == "" {
+		cfg.SubjectField = "id"
+	}
+	if cfg.EmailField == "" {
+		cfg.EmailField = "email"
+	}
+	if cfg.NameField == "" {
+		cfg.NameField = "name"
+	}
+	return &Provider{cfg: cfg, client: client}
+}
+
+// NewGitHubProvider GitHub 的地址都是固定的，不支持 PKCE；name 很多人没填，昵称用 login
+func NewGitHubProvider(clientId, clientSecret, redirectURL string, client *http.Client) *Provider {
+	return NewProvider(Config{
+		Name:         "github",
+		ClientId:     clientId,
+		ClientSecret: clientSecret,
+		AuthURL:      "https://github.com/login/oauth/authorize",
+		TokenURL:     "https://github.com/login/oauth/access_token",
+		UserInfoURL:  "https://api.github.com/user",
+		RedirectURL:  redirectURL,
+		Scopes:       []string{"read:user", "user:email"},
+		NameField:    "login",
+	}, client)
+}
+
+func (p *Provider) Name() string {
+	return p.cfg.Name
+}
+
+func (p *Provider) AuthURL(ctx context.Context, req oauth2.AuthRequest) (string, error) {
+	q := url.Values{}
+	q.Set("response_type", "code")
+	q.Set("client_id", p.cfg.ClientId)
+	q.Set("redirect_uri", p.cfg.RedirectURL)
+	q.Set("state", req.State)
+	if len(p.cfg.Scopes) > 0 {
+		q.Set("scope", strings.Join(p.cfg.Scopes, " "))
+	}
+	if p.cfg.PKCE {
+		q.Set("code_challenge", oauth2.CodeChallenge(req.Verifier))
+		q.Set("code_challenge_method", "S256")
+	}
+	return p.cfg.AuthURL + "?" + q.Encode(), nil
+}
+
+func (p *Provider) VerifyCode(ctx context.Context, code string, req oauth2.AuthRequest) (domain.Identity, error) {
+	form := url.Values{}
+	form.Set("code", code)
+	form.Set("client_id", p.cfg.ClientId)
+	form.Set("client_secret", p.cfg.ClientSecret)
+	form.Set("redirect_uri", p.cfg.RedirectURL)
+	if p.cfg.PKCE {
+		form.Set("code_verifier", req.Verifier)
+	}
+	token, err := oauth2.ExchangeCode(ctx, p.client, p.cfg.TokenURL, form)
+	if err != nil {
+		return domain.Identity{}, err
+	}
+	var info map[string]any
+	if err = oauth2.GetJSON(ctx, p.client, p.cfg.UserInfoURL, token.AccessToken, &info); err != nil {
+		return domain.Identity{}, err
+	}
+	subject := field(info, p.cfg.SubjectField)
+	if subject == "" {
+		return domain.Identity{}, fmt.Errorf("%s 返回的用户信息里面没有 %s", p.cfg.Name, p.cfg.SubjectField)
+	}
+	return domain.Identity{
+		Provider: p.cfg.Name,
+		Subject:  subject,
+		Email:    field(info, p.cfg.EmailField),
+		Name:     field(info, p.cfg.NameField),
+	}, nil
+}
+
+// field 数字 ID（比如 GitHub 的 id）按照整数格式化，不能变成科学计数法
+func field(info map[string]any, key string) string {
+	switch val := info[key].(type) {
+	case string:
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(val)
+	}
+}
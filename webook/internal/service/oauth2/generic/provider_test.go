@@ -0,0 +1,61 @@
+package generic
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/service/oauth2"
+	"github.com/liupch66/basic-go/webook/internal/service/oauth2/oidc/oidctest"
+)
+
+// 假的 OIDC 平台也是一个普通的 OAuth2 平台，用户信息走 userinfo 接口
+func TestProvider_VerifyCode(t *testing.T) {
+	server := oidctest.NewServer("webook", "webook-secret")
+	defer server.Close()
+	p := NewProvider(Config{
+		Name:         "test",
+		ClientId:     "webook",
+		ClientSecret: "webook-secret",
+		AuthURL:      server.URL + "/authorize",
+		TokenURL:     server.URL + "/token",
+		UserInfoURL:  server.URL + "/userinfo",
+		RedirectURL:  "http://localhost:8080/oauth2/test/callback",
+		PKCE:         true,
+		SubjectField: "sub",
+	}, server.Client())
+
+	req := oauth2.NewAuthRequest()
+	authURL, err := p.AuthURL(context.Background(), req)
+	require.NoError(t, err)
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Get(authURL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	location, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	code := location.Query().Get("code")
+
+	identity, err := p.VerifyCode(context.Background(), code, req)
+	require.NoError(t, err)
+	assert.Equal(t, domain.Identity{Provider: "test", Subject: "10086", Email: "oidc@test.com", Name: "oidc 用户"}, identity)
+
+	// code 只能用一次
+	_, err = p.VerifyCode(context.Background(), code, req)
+	assert.Error(t, err)
+}
+
+func TestField(t *testing.T) {
+	info := map[string]any{"id": float64(12345678901), "login": "webook", "admin": true}
+	assert.Equal(t, "12345678901", field(info, "id"))
+	assert.Equal(t, "webook", field(info, "login"))
+	assert.Equal(t, "true", field(info, "admin"))
+	assert.Equal(t, "", field(info, "email"))
+}
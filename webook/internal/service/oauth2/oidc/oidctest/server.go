@@ -0,0 +1,250 @@
+// Package oidctest 本地的假 OIDC 平台，测试里面用来跑完整的授权码流程
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// User 登录到假平台上的用户，authorize 的时候直接认为是他同意了授权
+type User struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+type authCode struct {
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+}
+
+type Server struct {
+	*httptest.Server
+	ClientId     string
+	ClientSecret string
+
+	mu     sync.Mutex
+	user   User
+	kid    string
+	key    *rsa.PrivateKey
+	keySeq int
+	codes  map[string]authCode
+	tokens map[string]User
+}
+
+func NewServer(clientId, clientSecret string) *Server {
+	s := &Server{
+		ClientId:     clientId,
+		ClientSecret: clientSecret,
+		user:         User{Subject: "10086", Email: "oidc@test.com", EmailVerified: true, Name: "oidc 用户"},
+		codes:        make(map[string]authCode),
+		tokens:       make(map[string]User),
+	}
+	s.RotateKey()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", s.discovery)
+	mux.HandleFunc("/authorize", s.authorize)
+	mux.HandleFunc("/token", s.token)
+	mux.HandleFunc("/jwks", s.jwks)
+	mux.HandleFunc("/userinfo", s.userInfo)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// Issuer 就是服务器地址
+func (s *Server) Issuer() string {
+	return s.URL
+}
+
+// SetUser 后面的 authorize 都是这个用户
+func (s *Server) SetUser(u User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.user = u
+}
+
+// RotateKey 换一把新的签名密钥，老密钥直接从 JWKS 里面去掉
+func (s *Server) RotateKey() {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keySeq++
+	s.kid = "test-" + strconv.Itoa(s.keySeq)
+	s.key = key
+}
+
+// SignIDToken 用当前密钥签一个 ID Token，测试里面可以用来构造各种非法的 token
+func (s *Server) SignIDToken(claims jwt.MapClaims) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = s.kid
+	str, err := token.SignedString(s.key)
+	if err != nil {
+		panic(err)
+	}
+	return str
+}
+
+func (s *Server) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"issuer":                 s.Issuer(),
+		"authorization_endpoint": s.URL + "/authorize",
+		"token_endpoint":         s.URL + "/token",
+		"jwks_uri":               s.URL + "/jwks",
+		"userinfo_endpoint":      s.URL + "/userinfo",
+	})
+}
+
+// authorize 不展示授权页面，直接带着 code 跳回 redirect_uri
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("client_id") != s.ClientId || q.Get("response_type") != "code" {
+		http.Error(w, "invalid_request", http.StatusBadRequest)
+		return
+	}
+	challenge := q.Get("code_challenge")
+	if challenge != "" && q.Get("code_challenge_method") != "S256" {
+		http.Error(w, "只支持 S256", http.StatusBadRequest)
+		return
+	}
+	redirectURI, err := url.Parse(q.Get("redirect_uri"))
+	if err != nil || redirectURI.String() == "" {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+	code := randomString()
+	s.mu.Lock()
+	s.codes[code] = authCode{redirectURI: redirectURI.String(), nonce: q.Get("nonce"), codeChallenge: challenge}
+	s.mu.Unlock()
+	rq := redirectURI.Query()
+	rq.Set("code", code)
+	rq.Set("state", q.Get("state"))
+	redirectURI.RawQuery = rq.Encode()
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "authorization_code" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+	clientId, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientId, clientSecret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if clientId != s.ClientId || clientSecret != s.ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+	s.mu.Lock()
+	code, ok := s.codes[r.PostForm.Get("code")]
+	// code 只能用一次
+	delete(s.codes, r.PostForm.Get("code"))
+	user := s.user
+	s.mu.Unlock()
+	if !ok || code.redirectURI != r.PostForm.Get("redirect_uri") {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+	if code.codeChallenge != "" {
+		sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if base64.RawURLEncoding.EncodeToString(sum[:]) != code.codeChallenge {
+			writeJSON(w, http.StatusBadRequest, map[string]string{
+				"error": "invalid_grant", "error_description": "code_verifier 不对"})
+			return
+		}
+	}
+	now := time.Now()
+	idToken := s.SignIDToken(jwt.MapClaims{
+		"iss":            s.Issuer(),
+		"sub":            user.Subject,
+		"aud":            s.ClientId,
+		"exp":            now.Add(time.Hour).Unix(),
+		"iat":            now.Unix(),
+		"nonce":          code.nonce,
+		"email":          user.Email,
+		"email_verified": user.EmailVerified,
+		"name":           user.Name,
+	})
+	accessToken := randomString()
+	s.mu.Lock()
+	s.tokens[accessToken] = user
+	s.mu.Unlock()
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": accessToken,
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     idToken,
+	})
+}
+
+func (s *Server) jwks(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	pub := s.key.PublicKey
+	kid := s.kid
+	s.mu.Unlock()
+	enc := base64.RawURLEncoding
+	writeJSON(w, http.StatusOK, map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": kid,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   enc.EncodeToString(pub.N.Bytes()),
+			"e":   enc.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+}
+
+// userInfo 字段名字和 GitHub 不一样，按照 OIDC 的标准来
+func (s *Server) userInfo(w http.ResponseWriter, r *http.Request) {
+	const prefix = "Bearer "
+	auth := r.Header.Get("Authorization")
+	if len(auth) <= len(prefix) || auth[:len(prefix)] != prefix {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	s.mu.Lock()
+	user, ok := s.tokens[auth[len(prefix):]]
+	s.mu.Unlock()
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"sub":            user.Subject,
+		"email":          user.Email,
+		"email_verified": user.EmailVerified,
+		"name":           user.Name,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, val any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(val)
+}
+
+func randomString() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,300 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/service/oauth2"
+)
+
+var (
+	ErrNonceMismatch = errors.New("ID Token 的 nonce 不匹配")
+	ErrUnknownKid    = errors.New("ID Token 的 kid 不在 JWKS 里面")
+)
+
+// jwksRefreshInterval 碰到不认识的 kid 会重新拉 JWKS，限制一下频率，防止被人拿假 token 刷
+const jwksRefreshInterval = time.Minute
+
+// Config 标准的 OIDC 平台，其他地址都通过 Issuer 的 discovery 拿到
+type Config struct {
+	Name         string   `yaml:"name"`
+	Issuer       string   `yaml:"issuer"`
+	ClientId     string   `yaml:"clientId"`
+	ClientSecret string   `yaml:"clientSecret"`
+	RedirectURL  string   `yaml:"redirectURL"`
+	Scopes       []string `yaml:"scopes"`
+	PKCE         bool     `yaml:"pkce"`
+}
+
+// Discovery /.well-known/openid-configuration 里面用得到的字段
+type Discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+	UserInfoEndpoint      string `json:"userinfo_endpoint"`
+}
+
+type IDTokenClaims struct {
+	jwt.RegisteredClaims
+	Nonce         string `json:"nonce"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+}
+
+var _ oauth2.Provider = (*Provider)(nil)
+
+type Provider struct {
+	cfg    Config
+	client *http.Client
+
+	// discovery 第一次用到的时候才去拉，拉失败了下次再试，启动的时候平台挂了不影响其他功能
+	mu        sync.Mutex
+	discovery *Discovery
+
+	keysMu     sync.RWMutex
+	keys       map[string]any
+	keysUpdate time.Time
+}
+
+func NewProvider(cfg Config, client *http.Client) *Provider {
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"openid", "email", "profile"}
+	}
+	return &Provider{cfg: cfg, client: client}
+}
+
+func (p *Provider) Name() string {
+	return p.cfg.Name
+}
+
+func (p *Provider) AuthURL(ctx context.Context, req oauth2.AuthRequest) (string, error) {
+	d, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	q := url.Values{}
+	q.Set("response_type", "code")
+	q.Set("client_id", p.cfg.ClientId)
+	q.Set("redirect_uri", p.cfg.RedirectURL)
+	q.Set("scope", strings.Join(p.cfg.Scopes, " "))
+	q.Set("state", req.State)
+	q.Set("nonce", req.Nonce)
+	if p.cfg.PKCE {
+		q.Set("code_challenge", oauth2.CodeChallenge(req.Verifier))
+		q.Set("code_challenge_method", "S256")
+	}
+	sep := "?"
+	if strings.Contains(d.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return d.AuthorizationEndpoint + sep + q.Encode(), nil
+}
+
+func (p *Provider) VerifyCode(ctx context.Context, code string, req oauth2.AuthRequest) (domain.Identity, error) {
+	d, err := p.discover(ctx)
+	if err != nil {
+		return domain.Identity{}, err
+	}
+	form := url.Values{}
+	form.Set("code", code)
+	form.Set("client_id", p.cfg.ClientId)
+	form.Set("client_secret", p.cfg.ClientSecret)
+	form.Set("redirect_uri", p.cfg.RedirectURL)
+	if p.cfg.PKCE {
+		form.Set("code_verifier", req.Verifier)
+	}
+	token, err := oauth2.ExchangeCode(ctx, p.client, d.TokenEndpoint, form)
+	if err != nil {
+		return domain.Identity{}, err
+	}
+	if token.IDToken == "" {
+		return domain.Identity{}, fmt.Errorf("%s 没有返回 id_token", p.cfg.Name)
+	}
+	claims, err := p.VerifyIDToken(ctx, token.IDToken, req.Nonce)
+	if err != nil {
+		return domain.Identity{}, err
+	}
+	identity := domain.Identity{Provider: p.cfg.Name, Subject: claims.Subject, Name: claims.Name}
+	// 没验证过的邮箱不能拿来用，不然别人可以冒用邮箱
+	if claims.EmailVerified {
+		identity.Email = claims.Email
+	}
+	return identity, nil
+}
+
+// VerifyIDToken 校验签名、iss、aud、exp 和 nonce
+func (p *Provider) VerifyIDToken(ctx context.Context, idToken string, nonce string) (IDTokenClaims, error) {
+	d, err := p.discover(ctx)
+	if err != nil {
+		return IDTokenClaims{}, err
+	}
+	var claims IDTokenClaims
+	_, err = jwt.ParseWithClaims(idToken, &claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.key(ctx, d.JWKSURI, kid)
+	},
+		// 不接受 HMAC 和 none，防止算法混淆
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "EdDSA"}),
+		jwt.WithIssuer(d.Issuer),
+		jwt.WithAudience(p.cfg.ClientId),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(30*time.Second),
+	)
+	if err != nil {
+		return IDTokenClaims{}, err
+	}
+	if claims.Subject == "" {
+		return IDTokenClaims{}, errors.New("ID Token 没有 sub")
+	}
+	if claims.Nonce != nonce {
+		return IDTokenClaims{}, ErrNonceMismatch
+	}
+	return claims, nil
+}
+
+func (p *Provider) discover(ctx context.Context) (*Discovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+	var d Discovery
+	target := strings.TrimSuffix(p.cfg.Issuer, "/") + "/.well-known/openid-configuration"
+	if err := oauth2.GetJSON(ctx, p.client, target, "", &d); err != nil {
+		return nil, fmt.Errorf("OIDC discovery 失败: %w", err)
+	}
+	// 规范要求 discovery 里面的 issuer 必须和配置的一致
+	if d.Issuer != p.cfg.Issuer {
+		return nil, fmt.Errorf("OIDC discovery 的 issuer %s 和配置的 %s 不一致", d.Issuer, p.cfg.Issuer)
+	}
+	p.discovery = &d
+	return p.discovery, nil
+}
+
+// key 平台轮换密钥之后会出现不认识的 kid，这时候重新拉一次 JWKS
+func (p *Provider) key(ctx context.Context, jwksURI, kid string) (any, error) {
+	p.keysMu.RLock()
+	key, ok := p.keys[kid]
+	stale := time.Since(p.keysUpdate) > jwksRefreshInterval
+	p.keysMu.RUnlock()
+	if ok {
+		return key, nil
+	}
+	if !stale {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKid, kid)
+	}
+	if err := p.refreshKeys(ctx, jwksURI); err != nil {
+		return nil, err
+	}
+	p.keysMu.RLock()
+	defer p.keysMu.RUnlock()
+	if key, ok = p.keys[kid]; ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownKid, kid)
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC 和 OKP
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (p *Provider) refreshKeys(ctx context.Context, jwksURI string) error {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := oauth2.GetJSON(ctx, p.client, jwksURI, "", &set); err != nil {
+		return fmt.Errorf("拉取 JWKS 失败: %w", err)
+	}
+	keys := make(map[string]any, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		// 不认识的密钥类型直接跳过，不影响其他密钥
+		if key, err := parseJWK(jwk); err == nil {
+			keys[jwk.Kid] = key
+		}
+	}
+	p.keysMu.Lock()
+	p.keys = keys
+	p.keysUpdate = time.Now()
+	p.keysMu.Unlock()
+	return nil
+}
+
+func parseJWK(jwk jsonWebKey) (any, error) {
+	dec := base64.RawURLEncoding
+	switch jwk.Kty {
+	case "RSA":
+		n, err := dec.DecodeString(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := dec.DecodeString(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("不支持的曲线 %s", jwk.Crv)
+		}
+		x, err := dec.DecodeString(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := dec.DecodeString(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	case "OKP":
+		if jwk.Crv != "Ed25519" {
+			return nil, fmt.Errorf("不支持的曲线 %s", jwk.Crv)
+		}
+		x, err := dec.DecodeString(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("Ed25519 公钥长度不对")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("不支持的密钥类型 %s", jwk.Kty)
+	}
+}
@@ -0,0 +1,198 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/service/oauth2"
+	"github.com/liupch66/basic-go/webook/internal/service/oauth2/oidc/oidctest"
+)
+
+const redirectURL = "http://localhost:8080/oauth2/test/callback"
+
+func newTestProvider(t *testing.T) (*Provider, *oidctest.Server) {
+	server := oidctest.NewServer("webook", "webook-secret")
+	t.Cleanup(server.Close)
+	p := NewProvider(Config{
+		Name:         "test",
+		Issuer:       server.Issuer(),
+		ClientId:     "webook",
+		ClientSecret: "webook-secret",
+		RedirectURL:  redirectURL,
+		PKCE:         true,
+	}, server.Client())
+	return p, server
+}
+
+// authorize 模拟浏览器打开授权页面，拿到跳回来的 code
+func authorize(t *testing.T, p *Provider, req oauth2.AuthRequest) string {
+	authURL, err := p.AuthURL(context.Background(), req)
+	require.NoError(t, err)
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Get(authURL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+	location, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	assert.Equal(t, req.State, location.Query().Get("state"))
+	return location.Query().Get("code")
+}
+
+func TestProvider_VerifyCode(t *testing.T) {
+	testCases := []struct {
+		name string
+		// 在授权和回调之间做手脚
+		mock         func(server *oidctest.Server, req *oauth2.AuthRequest)
+		wantIdentity domain.Identity
+		wantErr      error
+		wantAnyErr   bool
+	}{
+		{
+			name: "登录成功",
+			mock: func(server *oidctest.Server, req *oauth2.AuthRequest) {},
+			wantIdentity: domain.Identity{Provider: "test", Subject: "10086",
+				Email: "oidc@test.com", Name: "oidc 用户"},
+		},
+		{
+			name: "邮箱没有验证过",
+			mock: func(server *oidctest.Server, req *oauth2.AuthRequest) {
+				server.SetUser(oidctest.User{Subject: "10010", Email: "fake@test.com", Name: "未验证"})
+			},
+			wantIdentity: domain.Identity{Provider: "test", Subject: "10010", Name: "未验证"},
+		},
+		{
+			name: "code_verifier 不对",
+			mock: func(server *oidctest.Server, req *oauth2.AuthRequest) {
+				req.Verifier = "被篡改的 verifier"
+			},
+			wantAnyErr: true,
+		},
+		{
+			name: "nonce 不对",
+			mock: func(server *oidctest.Server, req *oauth2.AuthRequest) {
+				req.Nonce = "被篡改的 nonce"
+			},
+			wantErr: ErrNonceMismatch,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, server := newTestProvider(t)
+			req := oauth2.NewAuthRequest()
+			code := authorize(t, p, req)
+			tc.mock(server, &req)
+			identity, err := p.VerifyCode(context.Background(), code, req)
+			switch {
+			case tc.wantErr != nil:
+				assert.ErrorIs(t, err, tc.wantErr)
+			case tc.wantAnyErr:
+				assert.Error(t, err)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, tc.wantIdentity, identity)
+			}
+		})
+	}
+}
+
+func TestProvider_KeyRotation(t *testing.T) {
+	p, server := newTestProvider(t)
+	req := oauth2.NewAuthRequest()
+	_, err := p.VerifyCode(context.Background(), authorize(t, p, req), req)
+	require.NoError(t, err)
+
+	// 刚拉过 JWKS，不会马上再拉
+	server.RotateKey()
+	req = oauth2.NewAuthRequest()
+	_, err = p.VerifyCode(context.Background(), authorize(t, p, req), req)
+	assert.ErrorIs(t, err, ErrUnknownKid)
+
+	// 过了刷新间隔，碰到新的 kid 会重新拉 JWKS
+	p.keysUpdate = time.Now().Add(-jwksRefreshInterval - time.Second)
+	req = oauth2.NewAuthRequest()
+	identity, err := p.VerifyCode(context.Background(), authorize(t, p, req), req)
+	require.NoError(t, err)
+	assert.Equal(t, "10086", identity.Subject)
+}
+
+func TestProvider_VerifyIDToken(t *testing.T) {
+	p, server := newTestProvider(t)
+	now := time.Now()
+	valid := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":   server.Issuer(),
+			"sub":   "10086",
+			"aud":   "webook",
+			"exp":   now.Add(time.Hour).Unix(),
+			"nonce": "abc",
+		}
+	}
+	testCases := []struct {
+		name    string
+		claims  func() jwt.MapClaims
+		wantErr error
+	}{
+		{
+			name:   "合法",
+			claims: valid,
+		},
+		{
+			name: "aud 不对",
+			claims: func() jwt.MapClaims {
+				c := valid()
+				c["aud"] = "other-client"
+				return c
+			},
+			wantErr: jwt.ErrTokenInvalidAudience,
+		},
+		{
+			name: "iss 不对",
+			claims: func() jwt.MapClaims {
+				c := valid()
+				c["iss"] = "https://evil.com"
+				return c
+			},
+			wantErr: jwt.ErrTokenInvalidIssuer,
+		},
+		{
+			name: "过期了",
+			claims: func() jwt.MapClaims {
+				c := valid()
+				c["exp"] = now.Add(-time.Hour).Unix()
+				return c
+			},
+			wantErr: jwt.ErrTokenExpired,
+		},
+		{
+			name: "没有 exp",
+			claims: func() jwt.MapClaims {
+				c := valid()
+				delete(c, "exp")
+				return c
+			},
+			wantErr: jwt.ErrTokenRequiredClaimMissing,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			claims, err := p.VerifyIDToken(context.Background(), server.SignIDToken(tc.claims()), "abc")
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "10086", claims.Subject)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package oauth2
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+)
+
+var ErrUnknownProvider = errors.New("未知的第三方登录平台")
+
+// AuthRequest 一次授权流程里面需要在回调的时候对上的数据，web 层放在 state cookie 里面
+type AuthRequest struct {
+	State string
+	// Verifier PKCE 的 code_verifier，平台不支持 PKCE 的时候忽略
+	Verifier string
+	// Nonce OIDC 的 nonce，会出现在 ID Token 里面
+	Nonce string
+}
+
+// NewAuthRequest 生成随机的 state、code_verifier 和 nonce
+func NewAuthRequest() AuthRequest {
+	return AuthRequest{State: randomString(16), Verifier: randomString(32), Nonce: randomString(16)}
+}
+
+func randomString(n int) string {
+	b := make([]byte, n)
+	// crypto/rand 读失败的时候会直接崩溃，不需要处理 err
+	_, _ = rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// CodeChallenge PKCE S256：BASE64URL(SHA256(code_verifier))
+func CodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// Provider 一个第三方登录平台
+type Provider interface {
+	// Name 平台名字，同时也是路由里面的 :provider 和 user_identities 里面的 provider
+	Name() string
+	// AuthURL 跳转到平台授权页面的地址
+	AuthURL(ctx context.Context, req AuthRequest) (string, error)
+	// VerifyCode 回调的时候拿 code 换用户在这个平台上的身份
+	VerifyCode(ctx context.Context, code string, req AuthRequest) (domain.Identity, error)
+}
+
+// Registry 按名字找 Provider
+type Registry struct {
+	providers map[string]Provider
+}
+
+func NewRegistry(providers ...Provider) *Registry {
+	r := &Registry{providers: make(map[string]Provider, len(providers))}
+	for _, p := range providers {
+		r.providers[p.Name()] = p
+	}
+	return r
+}
+
+func (r *Registry) Get(name string) (Provider, error) {
+	p, ok := r.providers[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownProvider, name)
+	}
+	return p, nil
+}
+
+// Token token 端点的响应，OIDC 会多一个 id_token
+type Token struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	IDToken     string `json:"id_token"`
+
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// ExchangeCode 标准的 authorization_code 换 token
+func ExchangeCode(ctx context.Context, client *http.Client, tokenURL string, form url.Values) (Token, error) {
+	form.Set("grant_type", "authorization_code")
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return Token{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	// GitHub 默认返回的是 form 格式，要显式要求 JSON
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return Token{}, err
+	}
+	defer resp.Body.Close()
+	var token Token
+	if err = json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return Token{}, fmt.Errorf("解析 token 响应失败, HTTP 状态码 %d: %w", resp.StatusCode, err)
+	}
+	if token.Error != "" {
+		return Token{}, fmt.Errorf("换取 token 失败: %s %s", token.Error, token.ErrorDescription)
+	}
+	if token.AccessToken == "" {
+		return Token{}, fmt.Errorf("换取 token 失败, HTTP 状态码 %d", resp.StatusCode)
+	}
+	return token, nil
+}
+
+// GetJSON 带着 access_token 调用平台的接口
+func GetJSON(ctx context.Context, client *http.Client, target, accessToken string, val any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return err
+	}
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("请求 %s 失败, HTTP 状态码 %d", target, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(val)
+}
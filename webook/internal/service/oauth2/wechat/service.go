@@ -5,37 +5,42 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 
 	"go.uber.org/zap"
 
 	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/service/oauth2"
 	"github.com/liupch66/basic-go/webook/pkg/logger"
 )
 
-const authURLPattern = "https://open.weixin.qq.com/connect/qrconnect?appid=%s&redirect_uri=%s&response_type=code&scope=snsapi_login&state=%s#wechat_redire"
+const authURLPattern = "https://open.weixin.qq.com/connect/qrconnect?appid=%s&redirect_uri=%s&response_type=code&scope=snsapi_login&state=%s#wechat_redirect"
 
-// var redirectURL = url.PathEscape("https://meoying.com/oauth2/wechat/callback")
-var redirectURL = `https%3A%2F%2Fpassport.yhd.com%2Fwechat%2Fcallback.do`
+// ProviderName 老用户迁移过来的数据也是这个名字，不能改
+const ProviderName = "wechat"
 
-type Service interface {
-	AuthURL(ctx context.Context, state string) (string, error)
-	VerifyCode(ctx context.Context, code string) (domain.WechatInfo, error)
-}
+var _ oauth2.Provider = (*service)(nil)
 
 type service struct {
-	appId     string
-	appSecret string
-	client    *http.Client
-	l         logger.LoggerV1
+	appId       string
+	appSecret   string
+	redirectURL string
+	client      *http.Client
+	l           logger.LoggerV1
 }
 
-func NewWechatService(appId string, appSecret string, l logger.LoggerV1) Service {
+// NewWechatService 微信不支持 PKCE 和 OIDC，AuthRequest 里面只用到了 State
+func NewWechatService(appId string, appSecret string, redirectURL string, l logger.LoggerV1) oauth2.Provider {
 	// 这里 client 不是严格的依赖注入
-	return &service{appId: appId, appSecret: appSecret, client: http.DefaultClient, l: l}
+	return &service{appId: appId, appSecret: appSecret, redirectURL: redirectURL, client: http.DefaultClient, l: l}
+}
+
+func (s *service) Name() string {
+	return ProviderName
 }
 
-func (s *service) AuthURL(ctx context.Context, state string) (string, error) {
-	return fmt.Sprintf(authURLPattern, s.appId, redirectURL, state), nil
+func (s *service) AuthURL(ctx context.Context, req oauth2.AuthRequest) (string, error) {
+	return fmt.Sprintf(authURLPattern, s.appId, url.QueryEscape(s.redirectURL), req.State), nil
 }
 
 type Result struct {
@@ -50,17 +55,18 @@ type Result struct {
 	ErrMsg  string `json:"errmsg"`
 }
 
-func (s *service) VerifyCode(ctx context.Context, code string) (domain.WechatInfo, error) {
+func (s *service) VerifyCode(ctx context.Context, code string, _ oauth2.AuthRequest) (domain.Identity, error) {
 	const targetPath = `https://api.weixin.qq.com/sns/oauth2/access_token?appid=%s&secret=%s&code=%s&grant_type=authorization_code`
 	target := fmt.Sprintf(targetPath, s.appId, s.appSecret, code)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
 	if err != nil {
-		return domain.WechatInfo{}, err
+		return domain.Identity{}, err
 	}
 	resp, err := s.client.Do(req)
 	if err != nil {
-		return domain.WechatInfo{}, err
+		return domain.Identity{}, err
 	}
+	defer resp.Body.Close()
 	var res Result
 	// 直接从 resp.Body（HTTP 响应体的流）中逐渐解码数据，而不需要将整个响应体先加载到内存中
 	err = json.NewDecoder(resp.Body).Decode(&res)
@@ -68,11 +74,12 @@ func (s *service) VerifyCode(ctx context.Context, code string) (domain.WechatInf
 	// body, err := io.ReadAll(resp.Body)
 	// err = json.Unmarshal(body, &res)
 	if err != nil {
-		return domain.WechatInfo{}, err
+		return domain.Identity{}, err
 	}
 	if res.ErrCode != 0 {
-		return domain.WechatInfo{}, fmt.Errorf("微信返回了错误响应，错误码：%d，错误信息：%s", res.ErrCode, res.ErrMsg)
+		return domain.Identity{}, fmt.Errorf("微信返回了错误响应，错误码：%d，错误信息：%s", res.ErrCode, res.ErrMsg)
 	}
 	zap.L().Info("调用微信,拿到用户信息", zap.String("openId", res.OpenId), zap.String("unionId", res.UnionId))
-	return domain.WechatInfo{OpenId: res.OpenId, UnionId: res.UnionId}, err
+	// 以 openId 作为 subject，和以前存在 users 表里面的保持一致
+	return domain.Identity{Provider: ProviderName, Subject: res.OpenId}, nil
 }
@@ -4,9 +4,12 @@ package wechat
 
 import (
 	"context"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/liupch66/basic-go/webook/internal/service/oauth2"
 )
 
 func Test_service_manual_VerifyCode(t *testing.T) {
@@ -18,9 +21,9 @@ func Test_service_manual_VerifyCode(t *testing.T) {
 	if !ok {
 		panic("没有找到环境变量 WECHAT_APP_SECRET")
 	}
-	svc := NewWechatService(appId, appSecret)
+	svc := NewWechatService(appId, appSecret, "https://meoying.com/oauth2/wechat/callback", nil)
 	// 从微信扫码那里拿一下
-	res, err := svc.VerifyCode(context.Background(), "0238M6000sOXgT1imz2005q17c48M60S")
+	res, err := svc.VerifyCode(context.Background(), "0238M6000sOXgT1imz2005q17c48M60S", oauth2.AuthRequest{})
 	require.NoError(t, err)
 	t.Log(res)
 }
@@ -0,0 +1,507 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+
+	commentv1 "github.com/liupch66/basic-go/webook/api/proto/gen/comment/v1"
+	interactv1 "github.com/liupch66/basic-go/webook/api/proto/gen/interact/v1"
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/repository"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
+)
+
+var (
+	ErrPrivacyRequestNotFound = repository.ErrPrivacyRequestNotFound
+	// ErrPrivacyRequestExists 同一类申请还有没执行完的
+	ErrPrivacyRequestExists = errors.New("已经有正在处理的申请了")
+	ErrExportNotReady       = errors.New("数据还没有导出完")
+)
+
+const (
+	// privacyBatchSize 导出和注销的时候分批查询，每批的数量
+	privacyBatchSize = 100
+	// exportArchiveName 每一步导出一个 <stage>.json，最后打包成这个文件
+	exportArchiveName = "export.zip"
+)
+
+// SessionRevoker 注销账号的时候踢掉所有登录会话，curSsid 传空字符串就是全部踢掉
+type SessionRevoker interface {
+	RevokeOtherSessions(ctx context.Context, uid int64, curSsid string) error
+}
+
+//go:generate mockgen -package=svcmocks -source=privacy.go -destination=mocks/privacy.mock.go PrivacyService
+type PrivacyService interface {
+	// RequestExport 申请导出个人数据，马上就可以被执行
+	RequestExport(ctx context.Context, uid int64) (domain.PrivacyRequest, error)
+	// RequestDeletion 申请注销账号，过了冷静期才会执行，冷静期内可以撤销
+	RequestDeletion(ctx context.Context, uid int64) (domain.PrivacyRequest, error)
+	// CancelDeletion 撤销还没开始执行的注销申请，没有返回 ErrPrivacyRequestNotFound
+	CancelDeletion(ctx context.Context, uid int64) error
+	// List 查询自己的申请和进度，按申请时间倒序
+	List(ctx context.Context, uid int64, offset int, limit int) ([]domain.PrivacyRequest, error)
+	// ExportArchive 导出完成的压缩包，还没导出完返回 ErrExportNotReady
+	ExportArchive(ctx context.Context, uid int64, id int64) ([]byte, error)
+	// ListDue 给定时任务用，查询到了执行时间的申请
+	ListDue(ctx context.Context, now time.Time, limit int) ([]domain.PrivacyRequest, error)
+	// Process 从没做完的那一步开始往下执行，每做完一步都会保存进度。
+	// 每一步都可以重复执行，中途失败或者崩溃了，下一次调度会接着做
+	Process(ctx context.Context, r domain.PrivacyRequest) error
+}
+
+type privacyService struct {
+	repo            repository.PrivacyRequestRepository
+	userRepo        repository.UserRepository
+	artSvc          ArticleService
+	historySvc      HistoryRecordService
+	notificationSvc NotificationService
+	interactSvc     interactv1.InteractServiceClient
+	commentSvc      commentv1.CommentServiceClient
+	revoker         SessionRevoker
+	// coolingOff 注销账号的冷静期
+	coolingOff time.Duration
+	l          logger.LoggerV1
+}
+
+func NewPrivacyService(repo repository.PrivacyRequestRepository, userRepo repository.UserRepository,
+	artSvc ArticleService, historySvc HistoryRecordService, notificationSvc NotificationService,
+	interactSvc interactv1.InteractServiceClient, commentSvc commentv1.CommentServiceClient,
+	revoker SessionRevoker, coolingOff time.Duration, l logger.LoggerV1) PrivacyService {
+	return &privacyService{
+		repo:            repo,
+		userRepo:        userRepo,
+		artSvc:          artSvc,
+		historySvc:      historySvc,
+		notificationSvc: notificationSvc,
+		interactSvc:     interactSvc,
+		commentSvc:      commentSvc,
+		revoker:         revoker,
+		coolingOff:      coolingOff,
+		l:               l,
+	}
+}
+
+func (svc *privacyService) RequestExport(ctx context.Context, uid int64) (domain.PrivacyRequest, error) {
+	return svc.create(ctx, uid, domain.PrivacyRequestTypeExport, time.Now())
+}
+
+func (svc *privacyService) RequestDeletion(ctx context.Context, uid int64) (domain.PrivacyRequest, error) {
+	return svc.create(ctx, uid, domain.PrivacyRequestTypeDelete, time.Now().Add(svc.coolingOff))
+}
+
+// create 先查后插有并发问题，不过同一个人同时点两次的结果也就是多执行一次，每一步都是可以重复执行的
+func (svc *privacyService) create(ctx context.Context, uid int64, typ domain.PrivacyRequestType,
+	executeAt time.Time) (domain.PrivacyRequest, error) {
+	_, err := svc.repo.FindActive(ctx, uid, typ)
+	switch {
+	case err == nil:
+		return domain.PrivacyRequest{}, ErrPrivacyRequestExists
+	case !errors.Is(err, repository.ErrPrivacyRequestNotFound):
+		return domain.PrivacyRequest{}, err
+	}
+	now := time.Now()
+	r := domain.PrivacyRequest{
+		Uid:       uid,
+		Type:      typ,
+		Status:    domain.PrivacyRequestStatusPending,
+		ExecuteAt: executeAt,
+		Ctime:     now,
+		Utime:     now,
+	}
+	r.Id, err = svc.repo.Create(ctx, r)
+	return r, err
+}
+
+func (svc *privacyService) CancelDeletion(ctx context.Context, uid int64) error {
+	r, err := svc.repo.FindActive(ctx, uid, domain.PrivacyRequestTypeDelete)
+	if err != nil {
+		return err
+	}
+	// 已经开始执行了就不能撤销了，Cancel 会返回 ErrPrivacyRequestNotFound
+	return svc.repo.Cancel(ctx, r.Id, uid)
+}
+
+func (svc *privacyService) List(ctx context.Context, uid int64, offset int, limit int) ([]domain.PrivacyRequest, error) {
+	return svc.repo.List(ctx, uid, offset, limit)
+}
+
+func (svc *privacyService) ExportArchive(ctx context.Context, uid int64, id int64) ([]byte, error) {
+	r, err := svc.repo.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if r.Uid != uid || r.Type != domain.PrivacyRequestTypeExport {
+		return nil, ErrPrivacyRequestNotFound
+	}
+	if r.Status != domain.PrivacyRequestStatusDone {
+		return nil, ErrExportNotReady
+	}
+	return svc.repo.GetFile(ctx, id, r.Archive)
+}
+
+func (svc *privacyService) ListDue(ctx context.Context, now time.Time, limit int) ([]domain.PrivacyRequest, error) {
+	return svc.repo.FindDue(ctx, now, limit)
+}
+
+func (svc *privacyService) Process(ctx context.Context, r domain.PrivacyRequest) error {
+	// 先把状态改成执行中，这之后就不能撤销了。改失败了说明刚刚被撤销了
+	if r.Status == domain.PrivacyRequestStatusPending {
+		r.Status = domain.PrivacyRequestStatusRunning
+		if err := svc.repo.SaveProgress(ctx, r); err != nil {
+			return err
+		}
+	}
+	for stage := r.NextStage(); stage != ""; stage = r.NextStage() {
+		var err error
+		switch r.Type {
+		case domain.PrivacyRequestTypeExport:
+			err = svc.export(ctx, &r, stage)
+		case domain.PrivacyRequestTypeDelete:
+			err = svc.delete(ctx, r.Uid, stage)
+		default:
+			return fmt.Errorf("未知的申请类型 %d", r.Type)
+		}
+		if err != nil {
+			return fmt.Errorf("执行 %s 失败: %w", stage, err)
+		}
+		r.Stage = stage
+		if r.NextStage() == "" {
+			r.Status = domain.PrivacyRequestStatusDone
+		}
+		if err = svc.repo.SaveProgress(ctx, r); err != nil {
+			return err
+		}
+	}
+	svc.l.Info("隐私申请执行完成", logger.Int64("id", r.Id), logger.Int64("uid", r.Uid))
+	return nil
+}
+
+func (svc *privacyService) delete(ctx context.Context, uid int64, stage string) error {
+	switch stage {
+	case domain.DeleteStageUser:
+		return svc.userRepo.Anonymize(ctx, uid)
+	case domain.DeleteStageSessions:
+		return svc.revoker.RevokeOtherSessions(ctx, uid, "")
+	case domain.DeleteStageArticles:
+		return svc.withdrawArticles(ctx, uid)
+	case domain.DeleteStageComments:
+		return svc.deleteComments(ctx, uid)
+	case domain.DeleteStageLikes:
+		return svc.cancelLikes(ctx, uid)
+	case domain.DeleteStageHistory:
+		return svc.historySvc.Clear(ctx, uid)
+	case domain.DeleteStageNotifications:
+		return svc.notificationSvc.DeleteAll(ctx, uid)
+	default:
+		return fmt.Errorf("未知的步骤 %s", stage)
+	}
+}
+
+// withdrawArticles 撤回已经发表的文章，取消定时发表的文章。
+// 处理过的文章下一批就查不到了，所以每次都从头查
+func (svc *privacyService) withdrawArticles(ctx context.Context, uid int64) error {
+	for {
+		arts, err := svc.artSvc.ListPubByAuthors(ctx, []int64{uid}, time.Now(), 0, privacyBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, art := range arts {
+			if err = svc.artSvc.Withdraw(ctx, art); err != nil {
+				return err
+			}
+		}
+		if len(arts) < privacyBatchSize {
+			break
+		}
+	}
+	for {
+		arts, err := svc.artSvc.ListScheduled(ctx, uid, 0, privacyBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, art := range arts {
+			if err = svc.artSvc.CancelSchedule(ctx, art.Id, uid); err != nil {
+				return err
+			}
+		}
+		if len(arts) < privacyBatchSize {
+			return nil
+		}
+	}
+}
+
+// deleteComments 评论服务每次删一批，删掉的下一批就查不到了
+func (svc *privacyService) deleteComments(ctx context.Context, uid int64) error {
+	for {
+		resp, err := svc.commentSvc.DeleteByUid(ctx, &commentv1.DeleteByUidRequest{Uid: uid, Limit: privacyBatchSize})
+		if err != nil {
+			return err
+		}
+		if resp.GetCnt() < privacyBatchSize {
+			return nil
+		}
+	}
+}
+
+// cancelLikes 一个一个取消点赞，点赞数和热榜都按照取消点赞处理。取消了的下一批就查不到了，所以每次都从头查
+func (svc *privacyService) cancelLikes(ctx context.Context, uid int64) error {
+	for {
+		resp, err := svc.interactSvc.ListLikes(ctx, &interactv1.ListLikesRequest{Uid: uid, Limit: privacyBatchSize})
+		if err != nil {
+			return err
+		}
+		for _, like := range resp.GetLikes() {
+			_, err = svc.interactSvc.CancelLike(ctx, &interactv1.CancelLikeRequest{
+				Biz: like.GetBiz(), BizId: like.GetBizId(), Uid: uid,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if len(resp.GetLikes()) < privacyBatchSize {
+			return nil
+		}
+	}
+}
+
+// 导出文件里面的数据，字段名要稳定，所以不直接用 domain 里面的结构体
+type exportProfile struct {
+	Id       int64  `json:"id"`
+	Email    string `json:"email"`
+	Phone    string `json:"phone"`
+	Nickname string `json:"nickname"`
+	AboutMe  string `json:"about_me"`
+	Birthday string `json:"birthday"`
+	Ctime    string `json:"ctime"`
+}
+
+type exportArticle struct {
+	Id      int64  `json:"id"`
+	Title   string `json:"title"`
+	Content string `json:"content"`
+	Status  uint8  `json:"status"`
+	Ctime   string `json:"ctime"`
+	Utime   string `json:"utime"`
+}
+
+type exportBizItem struct {
+	Biz   string `json:"biz"`
+	BizId int64  `json:"biz_id"`
+	Time  string `json:"time"`
+}
+
+type exportCollection struct {
+	Id    int64           `json:"id"`
+	Name  string          `json:"name"`
+	Items []exportBizItem `json:"items"`
+}
+
+func (svc *privacyService) export(ctx context.Context, r *domain.PrivacyRequest, stage string) error {
+	var (
+		data any
+		err  error
+	)
+	switch stage {
+	case domain.ExportStageProfile:
+		data, err = svc.exportProfile(ctx, r.Uid)
+	case domain.ExportStageArticles:
+		data, err = svc.exportArticles(ctx, r.Uid)
+	case domain.ExportStageLikes:
+		data, err = svc.exportLikes(ctx, r.Uid)
+	case domain.ExportStageCollections:
+		data, err = svc.exportCollections(ctx, r.Uid)
+	case domain.ExportStageHistory:
+		data, err = svc.exportHistory(ctx, r.Uid)
+	case domain.ExportStageArchive:
+		r.Archive = exportArchiveName
+		return svc.archive(ctx, r.Id)
+	default:
+		return fmt.Errorf("未知的步骤 %s", stage)
+	}
+	if err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	// 重复执行直接覆盖，换了一个实例执行也一样
+	return svc.repo.SaveFile(ctx, r.Id, stage+".json", content)
+}
+
+func (svc *privacyService) exportProfile(ctx context.Context, uid int64) (exportProfile, error) {
+	u, err := svc.userRepo.FindById(ctx, uid)
+	if err != nil {
+		return exportProfile{}, err
+	}
+	return exportProfile{
+		Id:       u.Id,
+		Email:    u.Email,
+		Phone:    u.Phone,
+		Nickname: u.Nickname,
+		AboutMe:  u.AboutMe,
+		Birthday: formatExportTime(u.Birthday),
+		Ctime:    formatExportTime(u.Ctime),
+	}, nil
+}
+
+func (svc *privacyService) exportArticles(ctx context.Context, uid int64) ([]exportArticle, error) {
+	res := make([]exportArticle, 0)
+	for offset := 0; ; offset += privacyBatchSize {
+		arts, err := svc.artSvc.List(ctx, uid, offset, privacyBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, art := range arts {
+			// 列表里面没有内容，要一篇一篇查
+			detail, err := svc.artSvc.GetById(ctx, art.Id)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, exportArticle{
+				Id:      detail.Id,
+				Title:   detail.Title,
+				Content: detail.Content,
+				Status:  detail.Status.ToUnit8(),
+				Ctime:   formatExportTime(detail.Ctime),
+				Utime:   formatExportTime(detail.Utime),
+			})
+		}
+		if len(arts) < privacyBatchSize {
+			return res, nil
+		}
+	}
+}
+
+func (svc *privacyService) exportLikes(ctx context.Context, uid int64) ([]exportBizItem, error) {
+	res := make([]exportBizItem, 0)
+	for offset := 0; ; offset += privacyBatchSize {
+		resp, err := svc.interactSvc.ListLikes(ctx, &interactv1.ListLikesRequest{
+			Uid: uid, Offset: int64(offset), Limit: privacyBatchSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, slice.Map(resp.GetLikes(), func(idx int, src *interactv1.UserLike) exportBizItem {
+			return exportBizItem{Biz: src.GetBiz(), BizId: src.GetBizId(), Time: formatExportTime(time.UnixMilli(src.GetCtime()))}
+		})...)
+		if len(resp.GetLikes()) < privacyBatchSize {
+			return res, nil
+		}
+	}
+}
+
+// exportCollections 没有放进收藏夹的收藏算在 id 为 0 的默认收藏夹里面
+func (svc *privacyService) exportCollections(ctx context.Context, uid int64) ([]exportCollection, error) {
+	res := []exportCollection{{Name: "默认收藏夹"}}
+	for offset := 0; ; offset += privacyBatchSize {
+		resp, err := svc.interactSvc.ListCollections(ctx, &interactv1.ListCollectionsRequest{
+			Uid: uid, Offset: int64(offset), Limit: privacyBatchSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range resp.GetCollections() {
+			res = append(res, exportCollection{Id: c.GetId(), Name: c.GetName()})
+		}
+		if len(resp.GetCollections()) < privacyBatchSize {
+			break
+		}
+	}
+	for i := range res {
+		items, err := svc.exportCollectionItems(ctx, uid, res[i].Id)
+		if err != nil {
+			return nil, err
+		}
+		res[i].Items = items
+	}
+	return res, nil
+}
+
+func (svc *privacyService) exportCollectionItems(ctx context.Context, uid int64, cid int64) ([]exportBizItem, error) {
+	res := make([]exportBizItem, 0)
+	for offset := 0; ; offset += privacyBatchSize {
+		resp, err := svc.interactSvc.ListCollectionItems(ctx, &interactv1.ListCollectionItemsRequest{
+			Cid: cid, Uid: uid, Offset: int64(offset), Limit: privacyBatchSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, slice.Map(resp.GetItems(), func(idx int, src *interactv1.CollectionItem) exportBizItem {
+			return exportBizItem{Biz: src.GetBiz(), BizId: src.GetBizId(), Time: formatExportTime(time.UnixMilli(src.GetCtime()))}
+		})...)
+		if len(resp.GetItems()) < privacyBatchSize {
+			return res, nil
+		}
+	}
+}
+
+func (svc *privacyService) exportHistory(ctx context.Context, uid int64) ([]exportBizItem, error) {
+	res := make([]exportBizItem, 0)
+	for offset := 0; ; offset += privacyBatchSize {
+		records, err := svc.historySvc.List(ctx, uid, offset, privacyBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, slice.Map(records, func(idx int, src domain.HistoryRecord) exportBizItem {
+			return exportBizItem{Biz: src.Biz, BizId: src.BizId, Time: formatExportTime(src.Utime)}
+		})...)
+		if len(records) < privacyBatchSize {
+			return res, nil
+		}
+	}
+}
+
+// archive 把前面几步导出的文件打包，然后删掉这些文件。
+// 崩溃之后重新执行的时候压缩包可能已经写好了，这时候只要接着删
+func (svc *privacyService) archive(ctx context.Context, id int64) error {
+	files, err := svc.repo.ListFiles(ctx, id)
+	if err != nil {
+		return err
+	}
+	_, archived := files[exportArchiveName]
+	delete(files, exportArchiveName)
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	if !archived {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		for _, name := range names {
+			w, er := zw.Create(name)
+			if er != nil {
+				return er
+			}
+			if _, er = w.Write(files[name]); er != nil {
+				return er
+			}
+		}
+		if err = zw.Close(); err != nil {
+			return err
+		}
+		if err = svc.repo.SaveFile(ctx, id, exportArchiveName, buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	return svc.repo.DeleteFiles(ctx, id, names)
+}
+
+func formatExportTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.DateTime)
+}
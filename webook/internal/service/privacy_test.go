@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	repomocks "github.com/liupch66/basic-go/webook/internal/repository/mocks"
+	svcmocks "github.com/liupch66/basic-go/webook/internal/service/mocks"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
+)
+
+func Test_privacyService_Process(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (*repomocks.MockPrivacyRequestRepository,
+			*repomocks.MockUserRepository, *svcmocks.MockSessionRevoker, *svcmocks.MockNotificationService)
+		req     domain.PrivacyRequest
+		wantErr error
+	}{
+		{
+			name: "从上次做完的那一步接着做",
+			mock: func(ctrl *gomock.Controller) (*repomocks.MockPrivacyRequestRepository,
+				*repomocks.MockUserRepository, *svcmocks.MockSessionRevoker, *svcmocks.MockNotificationService) {
+				repo := repomocks.NewMockPrivacyRequestRepository(ctrl)
+				notificationSvc := svcmocks.NewMockNotificationService(ctrl)
+				gomock.InOrder(
+					notificationSvc.EXPECT().DeleteAll(gomock.Any(), int64(123)).Return(nil),
+					repo.EXPECT().SaveProgress(gomock.Any(), domain.PrivacyRequest{Id: 1, Uid: 123,
+						Type: domain.PrivacyRequestTypeDelete, Status: domain.PrivacyRequestStatusDone,
+						Stage: domain.DeleteStageNotifications}).Return(nil),
+				)
+				return repo, repomocks.NewMockUserRepository(ctrl), svcmocks.NewMockSessionRevoker(ctrl), notificationSvc
+			},
+			req: domain.PrivacyRequest{Id: 1, Uid: 123, Type: domain.PrivacyRequestTypeDelete,
+				Status: domain.PrivacyRequestStatusRunning, Stage: domain.DeleteStageHistory},
+		},
+		{
+			name: "开始执行之前被撤销了",
+			mock: func(ctrl *gomock.Controller) (*repomocks.MockPrivacyRequestRepository,
+				*repomocks.MockUserRepository, *svcmocks.MockSessionRevoker, *svcmocks.MockNotificationService) {
+				repo := repomocks.NewMockPrivacyRequestRepository(ctrl)
+				repo.EXPECT().SaveProgress(gomock.Any(), domain.PrivacyRequest{Id: 1, Uid: 123,
+					Type: domain.PrivacyRequestTypeDelete, Status: domain.PrivacyRequestStatusRunning}).
+					Return(ErrPrivacyRequestNotFound)
+				return repo, repomocks.NewMockUserRepository(ctrl), svcmocks.NewMockSessionRevoker(ctrl),
+					svcmocks.NewMockNotificationService(ctrl)
+			},
+			req: domain.PrivacyRequest{Id: 1, Uid: 123, Type: domain.PrivacyRequestTypeDelete,
+				Status: domain.PrivacyRequestStatusPending},
+			wantErr: ErrPrivacyRequestNotFound,
+		},
+		{
+			name: "先抹掉个人信息再踢会话，踢会话失败了不保存进度",
+			mock: func(ctrl *gomock.Controller) (*repomocks.MockPrivacyRequestRepository,
+				*repomocks.MockUserRepository, *svcmocks.MockSessionRevoker, *svcmocks.MockNotificationService) {
+				repo := repomocks.NewMockPrivacyRequestRepository(ctrl)
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				revoker := svcmocks.NewMockSessionRevoker(ctrl)
+				gomock.InOrder(
+					userRepo.EXPECT().Anonymize(gomock.Any(), int64(123)).Return(nil),
+					repo.EXPECT().SaveProgress(gomock.Any(), domain.PrivacyRequest{Id: 1, Uid: 123,
+						Type: domain.PrivacyRequestTypeDelete, Status: domain.PrivacyRequestStatusRunning,
+						Stage: domain.DeleteStageUser}).Return(nil),
+					revoker.EXPECT().RevokeOtherSessions(gomock.Any(), int64(123), "").Return(context.DeadlineExceeded),
+				)
+				return repo, userRepo, revoker, svcmocks.NewMockNotificationService(ctrl)
+			},
+			req: domain.PrivacyRequest{Id: 1, Uid: 123, Type: domain.PrivacyRequestTypeDelete,
+				Status: domain.PrivacyRequestStatusRunning},
+			wantErr: context.DeadlineExceeded,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, userRepo, revoker, notificationSvc := tc.mock(ctrl)
+			svc := NewPrivacyService(repo, userRepo, nil, nil, notificationSvc, nil, nil, revoker,
+				time.Hour, logger.NewNopLogger())
+			err := svc.Process(context.Background(), tc.req)
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+func Test_privacyService_archive(t *testing.T) {
+	done := domain.PrivacyRequest{Id: 1, Uid: 123, Type: domain.PrivacyRequestTypeExport,
+		Status: domain.PrivacyRequestStatusDone, Stage: domain.ExportStageArchive, Archive: exportArchiveName}
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) *repomocks.MockPrivacyRequestRepository
+		wantErr error
+	}{
+		{
+			name: "打包之后删掉每一步的文件",
+			mock: func(ctrl *gomock.Controller) *repomocks.MockPrivacyRequestRepository {
+				repo := repomocks.NewMockPrivacyRequestRepository(ctrl)
+				gomock.InOrder(
+					repo.EXPECT().ListFiles(gomock.Any(), int64(1)).Return(map[string][]byte{
+						"profile.json": []byte("{}"),
+						"history.json": []byte("[]"),
+					}, nil),
+					repo.EXPECT().SaveFile(gomock.Any(), int64(1), exportArchiveName, gomock.Any()).Return(nil),
+					repo.EXPECT().DeleteFiles(gomock.Any(), int64(1), []string{"history.json", "profile.json"}).Return(nil),
+					repo.EXPECT().SaveProgress(gomock.Any(), done).Return(nil),
+				)
+				return repo
+			},
+		},
+		{
+			name: "上次已经打包好了，只删文件",
+			mock: func(ctrl *gomock.Controller) *repomocks.MockPrivacyRequestRepository {
+				repo := repomocks.NewMockPrivacyRequestRepository(ctrl)
+				gomock.InOrder(
+					repo.EXPECT().ListFiles(gomock.Any(), int64(1)).Return(map[string][]byte{
+						exportArchiveName: []byte("zip"),
+						"profile.json":    []byte("{}"),
+					}, nil),
+					repo.EXPECT().DeleteFiles(gomock.Any(), int64(1), []string{"profile.json"}).Return(nil),
+					repo.EXPECT().SaveProgress(gomock.Any(), done).Return(nil),
+				)
+				return repo
+			},
+		},
+		{
+			name: "打包失败了不保存进度",
+			mock: func(ctrl *gomock.Controller) *repomocks.MockPrivacyRequestRepository {
+				repo := repomocks.NewMockPrivacyRequestRepository(ctrl)
+				repo.EXPECT().ListFiles(gomock.Any(), int64(1)).Return(map[string][]byte{"profile.json": []byte("{}")}, nil)
+				repo.EXPECT().SaveFile(gomock.Any(), int64(1), exportArchiveName, gomock.Any()).
+					Return(context.DeadlineExceeded)
+				return repo
+			},
+			wantErr: context.DeadlineExceeded,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewPrivacyService(tc.mock(ctrl), nil, nil, nil, nil, nil, nil, nil, time.Hour, logger.NewNopLogger())
+			err := svc.Process(context.Background(), domain.PrivacyRequest{Id: 1, Uid: 123,
+				Type: domain.PrivacyRequestTypeExport, Status: domain.PrivacyRequestStatusRunning,
+				Stage: domain.ExportStageHistory})
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+func Test_privacyService_RequestDeletion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := repomocks.NewMockPrivacyRequestRepository(ctrl)
+	repo.EXPECT().FindActive(gomock.Any(), int64(123), domain.PrivacyRequestTypeDelete).
+		Return(domain.PrivacyRequest{Id: 1}, nil)
+	svc := NewPrivacyService(repo, nil, nil, nil, nil, nil, nil, nil, time.Hour, logger.NewNopLogger())
+	_, err := svc.RequestDeletion(context.Background(), 123)
+	assert.ErrorIs(t, err, ErrPrivacyRequestExists)
+}
@@ -3,7 +3,7 @@ package service
 import (
 	"context"
 	"errors"
-	"math"
+	"slices"
 	"time"
 
 	"github.com/ecodeclub/ekit/queue"
@@ -13,10 +13,31 @@ import (
 	"github.com/liupch66/basic-go/webook/internal/repository"
 )
 
+const (
+	// RankBoardOverall 综合榜，最近七天的文章
+	RankBoardOverall = "overall"
+	// RankBoardDaily 日榜，最近一天的文章
+	RankBoardDaily = "daily"
+)
+
+var ErrUnknownRankBoard = errors.New("未知的榜单")
+
 type RankService interface {
+	// TopN 计算并保存所有的榜单，定时任务调用
 	TopN(ctx context.Context) error
 	// TopN(ctx context.Context, n int64) error
 	// TopN(ctx context.Context, n int64) ([]domain.Article, error)
+
+	// GetTopN 查询 TopN 算好的榜单
+	GetTopN(ctx context.Context, board string) ([]domain.Article, error)
+	// GetAuthorTopN 作者榜，作者太多了没法提前算，查询的时候缓存未命中再算
+	GetAuthorTopN(ctx context.Context, authorId int64) ([]domain.Article, error)
+}
+
+type rankBoard struct {
+	name string
+	// window 只有这个时间窗口内更新过的文章才能上榜
+	window time.Duration
 }
 
 type BatchRankService struct {
@@ -25,52 +46,98 @@ type BatchRankService struct {
 	repo      repository.RankRepository
 	batchSize int
 	n         int
-	// 这里有个约束：不能返回负数
-	scoreFunc func(utime time.Time, likeCnt int64) float64
+	// 多个榜单共用一次扫描
+	boards   []rankBoard
+	strategy RankScoreStrategy
+	// authorScanLimit 算作者榜的时候只看作者最近的这么多篇文章
+	authorScanLimit int
 }
 
-func NewBatchRankService(artSvc ArticleService, interSvc interactv1.InteractServiceClient, repo repository.RankRepository) RankService {
+func NewBatchRankService(artSvc ArticleService, interSvc interactv1.InteractServiceClient,
+	repo repository.RankRepository, strategy RankScoreStrategy) *BatchRankService {
 	return &BatchRankService{
 		artSvc:    artSvc,
 		interSvc:  interSvc,
 		repo:      repo,
 		batchSize: 100,
 		n:         100,
-		scoreFunc: func(utime time.Time, likeCnt int64) float64 {
-			// 这个 factor 也可以做成参数
-			const factor = 1.5
-			return float64(likeCnt-1) / math.Pow(time.Since(utime).Hours()+2, factor)
+		boards: []rankBoard{
+			{name: RankBoardOverall, window: 7 * 24 * time.Hour},
+			{name: RankBoardDaily, window: 24 * time.Hour},
 		},
+		strategy:        strategy,
+		authorScanLimit: 200,
 	}
 }
 
 func (svc *BatchRankService) TopN(ctx context.Context) error {
-	arts, err := svc.topN(ctx)
+	boards, err := svc.topN(ctx)
 	if err != nil {
 		return err
 	}
 	// 这里存起来
-	return svc.repo.ReplaceTopN(ctx, arts)
+	for board, arts := range boards {
+		if err = svc.repo.ReplaceTopN(ctx, board, arts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (svc *BatchRankService) GetTopN(ctx context.Context, board string) ([]domain.Article, error) {
+	if !slices.ContainsFunc(svc.boards, func(b rankBoard) bool { return b.name == board }) {
+		return nil, ErrUnknownRankBoard
+	}
+	return svc.repo.GetTopN(ctx, board)
 }
 
-func (svc *BatchRankService) topN(ctx context.Context) ([]domain.Article, error) {
+func (svc *BatchRankService) GetAuthorTopN(ctx context.Context, authorId int64) ([]domain.Article, error) {
+	arts, err := svc.repo.GetAuthorTopN(ctx, authorId)
+	if err == nil {
+		return arts, nil
+	}
+
+	now := time.Now()
+	arts, err = svc.artSvc.ListPubByAuthors(ctx, []int64{authorId}, now, 0, svc.authorScanLimit)
+	if err != nil {
+		return nil, err
+	}
+	que := svc.newQueue()
+	if len(arts) > 0 {
+		ids := sliceMap[domain.Article, int64](arts, func(idx int, src domain.Article) int64 {
+			return src.Id
+		})
+		inters, er := svc.interSvc.GetByIds(ctx, &interactv1.GetByIdsRequest{Biz: "article", BizIds: ids})
+		if er != nil {
+			return nil, er
+		}
+		for _, art := range arts {
+			svc.enqueue(que, rankElement{art: art, score: svc.strategy.Score(art, inters.GetInteracts()[art.Id], now)})
+		}
+	}
+	res := svc.drain(que)
+	// 缓存失败不影响这一次的结果，下次再算就是了
+	_ = svc.repo.ReplaceAuthorTopN(ctx, authorId, res)
+	return res, nil
+}
+
+type rankElement struct {
+	art   domain.Article
+	score float64
+}
+
+func (svc *BatchRankService) topN(ctx context.Context) (map[string][]domain.Article, error) {
 	// 因为是分批次查询，要考虑耗时的影响，保证取的都是 now 之前的文章
 	now := time.Now()
 	offset := 0
 
-	type Element struct {
-		art   domain.Article
-		score float64
+	// 每个榜单一个队列，扫描到最长的窗口为止
+	ques := make([]*queue.PriorityQueue[rankElement], len(svc.boards))
+	var maxWindow time.Duration
+	for i, b := range svc.boards {
+		ques[i] = svc.newQueue()
+		maxWindow = max(maxWindow, b.window)
 	}
-	que := queue.NewPriorityQueue[Element](svc.n, func(src Element, dst Element) int {
-		if src.score > dst.score {
-			return 1
-		} else if src.score == dst.score {
-			return 0
-		} else {
-			return -1
-		}
-	})
 
 	for {
 		// 先拿一批文章
@@ -94,36 +161,27 @@ func (svc *BatchRankService) topN(ctx context.Context) ([]domain.Article, error)
 			ids = append(ids, art.Id)
 		}
 
-		// 根据文章 ids 再拿对应的点赞数据，这里拿到的结果是一个 map
+		// 根据文章 ids 再拿对应的互动数据，这里拿到的结果是一个 map
 		inters, err := svc.interSvc.GetByIds(ctx, &interactv1.GetByIdsRequest{Biz: "article", BizIds: ids})
 		if err != nil {
 			return nil, err
 		}
 
-		// 对取出来的一批排序
+		// 对取出来的一批排序，没有互动数据的 inter 是 nil，交给 strategy 处理
 		for _, art := range arts {
-			// inter, ok := inters[art.Id]
-			// if !ok {
-			// 	// 都没有点赞数据，肯定不是热度榜
-			// 	continue
-			// }
-			ele := Element{art: art, score: svc.scoreFunc(art.Utime, inters.Interacts[art.Id].LikeCnt)}
-			err = que.Enqueue(ele)
-			// topN 的 queue 已经满了
-			if errors.Is(err, queue.ErrOutOfCapacity) {
-				minEle, _ := que.Dequeue()
-				if ele.score > minEle.score {
-					_ = que.Enqueue(ele)
-				} else {
-					_ = que.Enqueue(minEle)
+			ele := rankElement{art: art, score: svc.strategy.Score(art, inters.GetInteracts()[art.Id], now)}
+			for i, b := range svc.boards {
+				if art.Utime.Before(now.Add(-b.window)) {
+					continue
 				}
+				svc.enqueue(ques[i], ele)
 			}
 		}
 
 		// 处理完这一批，要判断是否要进入下一批
 		// 我这一批次都没取够，我肯定没有下一批了
-		// 或者取到了七天前的数据，就不考虑放进热度榜了（取出来的 arts 排序是 utime DESC）
-		ddl := now.Add(-7 * 24 * time.Hour)
+		// 或者取到了最长窗口之前的数据，就不考虑放进热度榜了（取出来的 arts 排序是 utime DESC）
+		ddl := now.Add(-maxWindow)
 		if len(arts) < svc.batchSize || arts[len(arts)-1].Utime.Before(ddl) {
 			break
 		}
@@ -133,10 +191,42 @@ func (svc *BatchRankService) topN(ctx context.Context) ([]domain.Article, error)
 		offset += len(arts)
 	}
 
+	res := make(map[string][]domain.Article, len(svc.boards))
+	for i, b := range svc.boards {
+		res[b.name] = svc.drain(ques[i])
+	}
+	return res, nil
+}
+
+func (svc *BatchRankService) newQueue() *queue.PriorityQueue[rankElement] {
+	return queue.NewPriorityQueue[rankElement](svc.n, func(src rankElement, dst rankElement) int {
+		if src.score > dst.score {
+			return 1
+		} else if src.score == dst.score {
+			return 0
+		} else {
+			return -1
+		}
+	})
+}
+
+func (svc *BatchRankService) enqueue(que *queue.PriorityQueue[rankElement], ele rankElement) {
+	err := que.Enqueue(ele)
+	// topN 的 queue 已经满了
+	if errors.Is(err, queue.ErrOutOfCapacity) {
+		minEle, _ := que.Dequeue()
+		if ele.score > minEle.score {
+			_ = que.Enqueue(ele)
+		} else {
+			_ = que.Enqueue(minEle)
+		}
+	}
+}
+
+// drain 把热度榜 que 里面的 Article 取出来，注意热度榜结果由高到低排列
+func (svc *BatchRankService) drain(que *queue.PriorityQueue[rankElement]) []domain.Article {
 	ql := que.Len()
-	// 把热度榜 que 里面的 Article 取出来
 	res := make([]domain.Article, ql)
-	// 注意热度榜结果由高到低排列
 	for i := ql - 1; i >= 0; i-- {
 		ele, err := que.Dequeue()
 		// 取完了，不够 ql
@@ -145,7 +235,7 @@ func (svc *BatchRankService) topN(ctx context.Context) ([]domain.Article, error)
 		}
 		res[i] = ele.art
 	}
-	return res, nil
+	return res
 }
 
 func sliceMap[Src any, Dst any](src []Src, fn func(idx int, src Src) Dst) []Dst {
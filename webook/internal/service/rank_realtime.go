@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"math"
+	"time"
+
+	interactv1 "github.com/liupch66/basic-go/webook/api/proto/gen/interact/v1"
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/repository"
+)
+
+// RankBoardRealtime 实时榜，由互动事件增量计算
+const RankBoardRealtime = "realtime"
+
+// RankWeights 实时榜里面每一次互动加多少分，取消类的动作扣同样的分
+type RankWeights struct {
+	Read    float64
+	Like    float64
+	Collect float64
+}
+
+// RealtimeRankService 增量计算的实时热榜，不需要每次扫全部文章。
+// 互动事件进来的时候给文章加分，Refresh 再把分数最高的文章写到 RankRepository，
+// 读的时候和批量计算的榜单走同一条 本地缓存 → Redis → ForceGet 的链路。
+// 综合榜、日榜和作者榜还是交给 BatchRankService，它的定时任务顺带给实时榜对账
+type RealtimeRankService struct {
+	batch     *BatchRankService
+	artSvc    ArticleService
+	interSvc  interactv1.InteractServiceClient
+	scoreRepo repository.RankScoreRepository
+	repo      repository.RankRepository
+	weights   map[domain.InteractAction]float64
+	n         int
+}
+
+func NewRealtimeRankService(batch *BatchRankService, artSvc ArticleService, interSvc interactv1.InteractServiceClient,
+	scoreRepo repository.RankScoreRepository, repo repository.RankRepository, weights RankWeights) *RealtimeRankService {
+	return &RealtimeRankService{
+		batch:     batch,
+		artSvc:    artSvc,
+		interSvc:  interSvc,
+		scoreRepo: scoreRepo,
+		repo:      repo,
+		weights: map[domain.InteractAction]float64{
+			domain.InteractActionRead:          weights.Read,
+			domain.InteractActionLike:          weights.Like,
+			domain.InteractActionCancelLike:    -weights.Like,
+			domain.InteractActionCollect:       weights.Collect,
+			domain.InteractActionCancelCollect: -weights.Collect,
+		},
+		n: 100,
+	}
+}
+
+// Incr 一次互动给文章加分，t 是互动发生的时间，决定了分数落在哪个时间桶
+func (svc *RealtimeRankService) Incr(ctx context.Context, aid int64, action domain.InteractAction, t time.Time) error {
+	delta, ok := svc.weights[action]
+	// 不认识的动作，或者这个动作不计分
+	if !ok || delta == 0 {
+		return nil
+	}
+	return svc.scoreRepo.IncrScore(ctx, aid, delta, t)
+}
+
+// Refresh 把实时分数最高的文章写到实时榜，每个节点都可以跑，顺带刷新自己的本地缓存
+func (svc *RealtimeRankService) Refresh(ctx context.Context) error {
+	now := time.Now()
+	ids, err := svc.scoreRepo.TopIds(ctx, now, svc.n)
+	if err != nil {
+		return err
+	}
+	var arts []domain.Article
+	if len(ids) > 0 {
+		arts, err = svc.artSvc.ListPubByIds(ctx, ids)
+		if err != nil {
+			return err
+		}
+	}
+	artMap := make(map[int64]domain.Article, len(arts))
+	for _, art := range arts {
+		artMap[art.Id] = art
+	}
+
+	// 按照分数的顺序排好，查不到的说明已经撤回了，顺便从分数里面删掉
+	res := make([]domain.Article, 0, len(ids))
+	var gone []int64
+	for _, id := range ids {
+		art, ok := artMap[id]
+		if !ok {
+			gone = append(gone, id)
+			continue
+		}
+		res = append(res, art)
+	}
+	if len(gone) > 0 {
+		if err = svc.scoreRepo.RemoveScore(ctx, gone, now); err != nil {
+			return err
+		}
+	}
+	return svc.repo.ReplaceTopN(ctx, RankBoardRealtime, res)
+}
+
+// Reconcile 用互动服务里面的计数给实时分数对账。
+// 实时分数是互动事件一条一条加出来的：消息重复消费会多加分，点赞之后过了几个小时再取消，
+// 加的分已经衰减了，扣的分没有衰减，就会扣成负数，以后再怎么互动都上不了榜。
+// 窗口里面的分数不会超过按照总的阅读、点赞、收藏数算出来的分数，也不会是负数，超出范围的就重置
+func (svc *RealtimeRankService) Reconcile(ctx context.Context, now time.Time) error {
+	scores, err := svc.scoreRepo.Scores(ctx, now, svc.n)
+	if err != nil || len(scores) == 0 {
+		return err
+	}
+	ids := make([]int64, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+	resp, err := svc.interSvc.GetByIds(ctx, &interactv1.GetByIdsRequest{Biz: "article", BizIds: ids})
+	if err != nil {
+		return err
+	}
+	inters := resp.GetInteracts()
+	for id, score := range scores {
+		inter := inters[id]
+		upper := float64(inter.GetReadCnt())*svc.weights[domain.InteractActionRead] +
+			float64(inter.GetLikeCnt())*svc.weights[domain.InteractActionLike] +
+			float64(inter.GetCollectCnt())*svc.weights[domain.InteractActionCollect]
+		target := math.Max(math.Min(score, upper), 0)
+		if target == score {
+			continue
+		}
+		if err = svc.scoreRepo.ResetScore(ctx, id, target, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TopN 批量计算的定时任务还是跑全量，跑完之后把实时榜也对一下账
+func (svc *RealtimeRankService) TopN(ctx context.Context) error {
+	if err := svc.batch.TopN(ctx); err != nil {
+		return err
+	}
+	if err := svc.Reconcile(ctx, time.Now()); err != nil {
+		return err
+	}
+	return svc.Refresh(ctx)
+}
+
+func (svc *RealtimeRankService) GetTopN(ctx context.Context, board string) ([]domain.Article, error) {
+	if board == RankBoardRealtime {
+		return svc.repo.GetTopN(ctx, board)
+	}
+	return svc.batch.GetTopN(ctx, board)
+}
+
+func (svc *RealtimeRankService) GetAuthorTopN(ctx context.Context, authorId int64) ([]domain.Article, error) {
+	return svc.batch.GetAuthorTopN(ctx, authorId)
+}
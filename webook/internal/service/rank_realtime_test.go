@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	interactv1 "github.com/liupch66/basic-go/webook/api/proto/gen/interact/v1"
+	mockinteract "github.com/liupch66/basic-go/webook/api/proto/gen/interact/v1/mocks"
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/repository"
+	repomocks "github.com/liupch66/basic-go/webook/internal/repository/mocks"
+	svcmocks "github.com/liupch66/basic-go/webook/internal/service/mocks"
+)
+
+func TestRealtimeRankService_Incr(t *testing.T) {
+	now := time.Now()
+	testCases := []struct {
+		name   string
+		mock   func(ctrl *gomock.Controller) repository.RankScoreRepository
+		action domain.InteractAction
+
+		expectedErr error
+	}{
+		{
+			name: "点赞加分",
+			mock: func(ctrl *gomock.Controller) repository.RankScoreRepository {
+				repo := repomocks.NewMockRankScoreRepository(ctrl)
+				repo.EXPECT().IncrScore(gomock.Any(), int64(1), float64(1), now).Return(nil)
+				return repo
+			},
+			action: domain.InteractActionLike,
+		},
+		{
+			name: "取消收藏扣分",
+			mock: func(ctrl *gomock.Controller) repository.RankScoreRepository {
+				repo := repomocks.NewMockRankScoreRepository(ctrl)
+				repo.EXPECT().IncrScore(gomock.Any(), int64(1), float64(-2), now).Return(nil)
+				return repo
+			},
+			action: domain.InteractActionCancelCollect,
+		},
+		{
+			name: "不认识的动作",
+			mock: func(ctrl *gomock.Controller) repository.RankScoreRepository {
+				return repomocks.NewMockRankScoreRepository(ctrl)
+			},
+			action: "share",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewRealtimeRankService(nil, nil, nil, tc.mock(ctrl), nil,
+				RankWeights{Read: 0.1, Like: 1, Collect: 2})
+			err := svc.Incr(context.Background(), 1, tc.action, now)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestRealtimeRankService_Refresh(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (ArticleService, repository.RankScoreRepository, repository.RankRepository)
+
+		expectedErr error
+	}{
+		{
+			name: "按照分数排序，撤回的文章删掉分数",
+			mock: func(ctrl *gomock.Controller) (ArticleService, repository.RankScoreRepository, repository.RankRepository) {
+				scoreRepo := repomocks.NewMockRankScoreRepository(ctrl)
+				scoreRepo.EXPECT().TopIds(gomock.Any(), gomock.Any(), 100).Return([]int64{3, 1, 2}, nil)
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				// 2 已经撤回了
+				artSvc.EXPECT().ListPubByIds(gomock.Any(), []int64{3, 1, 2}).
+					Return([]domain.Article{{Id: 1}, {Id: 3}}, nil)
+				scoreRepo.EXPECT().RemoveScore(gomock.Any(), []int64{2}, gomock.Any()).Return(nil)
+				repo := repomocks.NewMockRankRepository(ctrl)
+				repo.EXPECT().ReplaceTopN(gomock.Any(), RankBoardRealtime, []domain.Article{{Id: 3}, {Id: 1}}).
+					Return(nil)
+				return artSvc, scoreRepo, repo
+			},
+		},
+		{
+			name: "还没有任何分数",
+			mock: func(ctrl *gomock.Controller) (ArticleService, repository.RankScoreRepository, repository.RankRepository) {
+				scoreRepo := repomocks.NewMockRankScoreRepository(ctrl)
+				scoreRepo.EXPECT().TopIds(gomock.Any(), gomock.Any(), 100).Return([]int64{}, nil)
+				repo := repomocks.NewMockRankRepository(ctrl)
+				repo.EXPECT().ReplaceTopN(gomock.Any(), RankBoardRealtime, []domain.Article{}).Return(nil)
+				return svcmocks.NewMockArticleService(ctrl), scoreRepo, repo
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			artSvc, scoreRepo, repo := tc.mock(ctrl)
+			svc := NewRealtimeRankService(nil, artSvc, nil, scoreRepo, repo, RankWeights{})
+			err := svc.Refresh(context.Background())
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestRealtimeRankService_Reconcile(t *testing.T) {
+	now := time.Now()
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (interactv1.InteractServiceClient, repository.RankScoreRepository)
+
+		expectedErr error
+	}{
+		{
+			name: "超过总分的和负分的重置，正常的不动",
+			mock: func(ctrl *gomock.Controller) (interactv1.InteractServiceClient, repository.RankScoreRepository) {
+				scoreRepo := repomocks.NewMockRankScoreRepository(ctrl)
+				scoreRepo.EXPECT().Scores(gomock.Any(), now, 100).
+					Return(map[int64]float64{1: 10, 2: 3, 3: -1}, nil)
+				interSvc := mockinteract.NewMockInteractServiceClient(ctrl)
+				interSvc.EXPECT().GetByIds(gomock.Any(), gomock.Any()).
+					Return(&interactv1.GetByIdsResponse{Interacts: map[int64]*interactv1.Interact{
+						// 1 重复消费了，总分只有 2*0.5+2*1+1*2=5
+						1: {ReadCnt: 2, LikeCnt: 2, CollectCnt: 1},
+						2: {LikeCnt: 3},
+						3: {LikeCnt: 1},
+					}}, nil)
+				scoreRepo.EXPECT().ResetScore(gomock.Any(), int64(1), float64(5), now).Return(nil)
+				scoreRepo.EXPECT().ResetScore(gomock.Any(), int64(3), float64(0), now).Return(nil)
+				return interSvc, scoreRepo
+			},
+		},
+		{
+			name: "没有分数",
+			mock: func(ctrl *gomock.Controller) (interactv1.InteractServiceClient, repository.RankScoreRepository) {
+				scoreRepo := repomocks.NewMockRankScoreRepository(ctrl)
+				scoreRepo.EXPECT().Scores(gomock.Any(), now, 100).Return(map[int64]float64{}, nil)
+				return mockinteract.NewMockInteractServiceClient(ctrl), scoreRepo
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			interSvc, scoreRepo := tc.mock(ctrl)
+			svc := NewRealtimeRankService(nil, nil, interSvc, scoreRepo, nil,
+				RankWeights{Read: 0.5, Like: 1, Collect: 2})
+			err := svc.Reconcile(context.Background(), now)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
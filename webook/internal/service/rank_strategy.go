@@ -0,0 +1,69 @@
+package service
+
+import (
+	"math"
+	"time"
+
+	interactv1 "github.com/liupch66/basic-go/webook/api/proto/gen/interact/v1"
+	"github.com/liupch66/basic-go/webook/internal/domain"
+)
+
+// RankScoreStrategy 热度计算策略，通过配置里面的名字选择
+type RankScoreStrategy interface {
+	Name() string
+	// Score 这里有个约束：不能返回负数。inter 可能是 nil，说明这篇文章还没有任何互动
+	Score(art domain.Article, inter *interactv1.Interact, now time.Time) float64
+}
+
+// LikeRankStrategy 只看点赞数，也就是最早的热度算法
+type LikeRankStrategy struct {
+	gravity float64
+}
+
+func NewLikeRankStrategy(gravity float64) *LikeRankStrategy {
+	return &LikeRankStrategy{gravity: gravity}
+}
+
+func (s *LikeRankStrategy) Name() string {
+	return "like"
+}
+
+func (s *LikeRankStrategy) Score(art domain.Article, inter *interactv1.Interact, now time.Time) float64 {
+	likeCnt := inter.GetLikeCnt()
+	if likeCnt <= 1 {
+		return 0
+	}
+	return float64(likeCnt-1) / math.Pow(now.Sub(art.Utime).Hours()+2, s.gravity)
+}
+
+// WeightedRankStrategy 阅读、点赞、收藏加权求和，再按照发表时长衰减
+type WeightedRankStrategy struct {
+	readWeight    float64
+	likeWeight    float64
+	collectWeight float64
+	// gravity 越大，老文章掉得越快
+	gravity float64
+}
+
+func NewWeightedRankStrategy(readWeight, likeWeight, collectWeight, gravity float64) *WeightedRankStrategy {
+	return &WeightedRankStrategy{
+		readWeight:    readWeight,
+		likeWeight:    likeWeight,
+		collectWeight: collectWeight,
+		gravity:       gravity,
+	}
+}
+
+func (s *WeightedRankStrategy) Name() string {
+	return "weighted"
+}
+
+func (s *WeightedRankStrategy) Score(art domain.Article, inter *interactv1.Interact, now time.Time) float64 {
+	total := float64(inter.GetReadCnt())*s.readWeight +
+		float64(inter.GetLikeCnt())*s.likeWeight +
+		float64(inter.GetCollectCnt())*s.collectWeight
+	if total <= 0 {
+		return 0
+	}
+	return total / math.Pow(now.Sub(art.Utime).Hours()+2, s.gravity)
+}
@@ -5,22 +5,26 @@ import (
 	"testing"
 	"time"
 
+	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 
 	interactv1 "github.com/liupch66/basic-go/webook/api/proto/gen/interact/v1"
 	mockinteract "github.com/liupch66/basic-go/webook/api/proto/gen/interact/v1/mocks"
 	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/repository"
+	repomocks "github.com/liupch66/basic-go/webook/internal/repository/mocks"
 	svcmocks "github.com/liupch66/basic-go/webook/internal/service/mocks"
 )
 
 func TestBatchRankService_TopN(t *testing.T) {
 	now := time.Now()
+	twoDaysAgo := now.Add(-48 * time.Hour)
 	testCases := []struct {
-		name         string
-		mock         func(ctrl *gomock.Controller) (ArticleService, interactv1.InteractServiceClient)
-		expectedErr  error
-		expectedArts []domain.Article
+		name           string
+		mock           func(ctrl *gomock.Controller) (ArticleService, interactv1.InteractServiceClient)
+		expectedErr    error
+		expectedBoards map[string][]domain.Article
 	}{
 		{
 			name: "计算成功",
@@ -28,11 +32,12 @@ func TestBatchRankService_TopN(t *testing.T) {
 				artSvc := svcmocks.NewMockArticleService(ctrl)
 				interSvc := mockinteract.NewMockInteractServiceClient(ctrl)
 				// 取第一批
-				artSvc.EXPECT().ListPub(gomock.Any(), gomock.Any(), 0, 3).
+				artSvc.EXPECT().ListPub(gomock.Any(), gomock.Any(), 0, 4).
 					Return([]domain.Article{
 						{Id: 1, Ctime: now, Utime: now},
 						{Id: 2, Ctime: now, Utime: now},
 						{Id: 3, Ctime: now, Utime: now},
+						{Id: 4, Ctime: twoDaysAgo, Utime: twoDaysAgo},
 					}, nil)
 				interSvc.EXPECT().GetByIds(gomock.Any(), gomock.Any()).
 					Return(&interactv1.GetByIdsResponse{
@@ -40,19 +45,29 @@ func TestBatchRankService_TopN(t *testing.T) {
 							1: {BizId: 1, LikeCnt: 1},
 							2: {BizId: 2, LikeCnt: 2},
 							3: {BizId: 3, LikeCnt: 3},
+							4: {BizId: 4, LikeCnt: 100},
 						},
 					}, nil)
 
 				// 取第二批，全被第一批取完了
-				artSvc.EXPECT().ListPub(gomock.Any(), gomock.Any(), 3, 3).
+				artSvc.EXPECT().ListPub(gomock.Any(), gomock.Any(), 4, 4).
 					Return([]domain.Article{}, nil)
 				return artSvc, interSvc
 			},
 			expectedErr: nil,
-			expectedArts: []domain.Article{
-				{Id: 3, Ctime: now, Utime: now},
-				{Id: 2, Ctime: now, Utime: now},
-				{Id: 1, Ctime: now, Utime: now},
+			expectedBoards: map[string][]domain.Article{
+				// 4 虽然点赞多，但是两天前的，分数衰减到了 2 和 3 之间
+				RankBoardOverall: {
+					{Id: 3, Ctime: now, Utime: now},
+					{Id: 2, Ctime: now, Utime: now},
+					{Id: 4, Ctime: twoDaysAgo, Utime: twoDaysAgo},
+				},
+				// 日榜上不了 4
+				RankBoardDaily: {
+					{Id: 3, Ctime: now, Utime: now},
+					{Id: 2, Ctime: now, Utime: now},
+					{Id: 1, Ctime: now, Utime: now},
+				},
 			},
 		},
 	}
@@ -67,14 +82,81 @@ func TestBatchRankService_TopN(t *testing.T) {
 				artSvc:    artSvc,
 				interSvc:  interSvc,
 				repo:      nil,
-				batchSize: 3,
+				batchSize: 4,
 				n:         3,
-				scoreFunc: func(utime time.Time, likeCnt int64) float64 {
-					return float64(likeCnt)
+				boards: []rankBoard{
+					{name: RankBoardOverall, window: 7 * 24 * time.Hour},
+					{name: RankBoardDaily, window: 24 * time.Hour},
 				},
+				strategy: NewLikeRankStrategy(1.5),
+			}
+
+			boards, err := svc.topN(context.Background())
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedBoards, boards)
+		})
+	}
+}
+
+func TestBatchRankService_GetAuthorTopN(t *testing.T) {
+	now := time.Now()
+	testCases := []struct {
+		name         string
+		mock         func(ctrl *gomock.Controller) (ArticleService, interactv1.InteractServiceClient, repository.RankRepository)
+		expectedErr  error
+		expectedArts []domain.Article
+	}{
+		{
+			name: "缓存命中",
+			mock: func(ctrl *gomock.Controller) (ArticleService, interactv1.InteractServiceClient, repository.RankRepository) {
+				repo := repomocks.NewMockRankRepository(ctrl)
+				repo.EXPECT().GetAuthorTopN(gomock.Any(), int64(123)).
+					Return([]domain.Article{{Id: 1}}, nil)
+				return svcmocks.NewMockArticleService(ctrl), mockinteract.NewMockInteractServiceClient(ctrl), repo
+			},
+			expectedArts: []domain.Article{{Id: 1}},
+		},
+		{
+			name: "缓存未命中，现算并回写",
+			mock: func(ctrl *gomock.Controller) (ArticleService, interactv1.InteractServiceClient, repository.RankRepository) {
+				repo := repomocks.NewMockRankRepository(ctrl)
+				repo.EXPECT().GetAuthorTopN(gomock.Any(), int64(123)).
+					Return(nil, redis.Nil)
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				artSvc.EXPECT().ListPubByAuthors(gomock.Any(), []int64{123}, gomock.Any(), int64(0), 10).
+					Return([]domain.Article{{Id: 1, Utime: now}, {Id: 2, Utime: now}}, nil)
+				interSvc := mockinteract.NewMockInteractServiceClient(ctrl)
+				interSvc.EXPECT().GetByIds(gomock.Any(), &interactv1.GetByIdsRequest{Biz: "article", BizIds: []int64{1, 2}}).
+					Return(&interactv1.GetByIdsResponse{
+						Interacts: map[int64]*interactv1.Interact{
+							1: {BizId: 1, ReadCnt: 10},
+							2: {BizId: 2, ReadCnt: 10, CollectCnt: 1},
+						},
+					}, nil)
+				repo.EXPECT().ReplaceAuthorTopN(gomock.Any(), int64(123),
+					[]domain.Article{{Id: 2, Utime: now}, {Id: 1, Utime: now}}).Return(nil)
+				return artSvc, interSvc, repo
+			},
+			expectedArts: []domain.Article{{Id: 2, Utime: now}, {Id: 1, Utime: now}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			artSvc, interSvc, repo := tc.mock(ctrl)
+			svc := &BatchRankService{
+				artSvc:          artSvc,
+				interSvc:        interSvc,
+				repo:            repo,
+				n:               3,
+				strategy:        NewWeightedRankStrategy(1, 2, 5, 1.5),
+				authorScanLimit: 10,
 			}
 
-			arts, err := svc.topN(context.Background())
+			arts, err := svc.GetAuthorTopN(context.Background(), 123)
 			assert.Equal(t, tc.expectedErr, err)
 			assert.Equal(t, tc.expectedArts, arts)
 		})
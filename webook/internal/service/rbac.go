@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/repository"
+)
+
+var (
+	ErrUserBanned = errors.New("用户被封禁了")
+	// ErrUserDeleted 账号已经注销了，老的 refresh_token 也不能再换 access_token
+	ErrUserDeleted = errors.New("用户已注销")
+)
+
+// RBACService 签发 access_token 的时候查用户的角色和权限，放到 UserClaims 里面。
+// 角色变了要等 access_token 刷新之后才生效
+type RBACService interface {
+	// Authorize 被封禁的用户返回 ErrUserBanned，注销了的返回 ErrUserDeleted
+	Authorize(ctx context.Context, uid int64) (roles []string, perms []string, err error)
+	// Roles 只查角色，不管用户的状态，配置的超级管理员也算上
+	Roles(ctx context.Context, uid int64) ([]string, error)
+}
+
+type rbacService struct {
+	repo repository.UserRepository
+	// superAdmins 配置文件里面的超级管理员，不用在数据库里面授予角色，
+	// 不然第一个管理员没法产生
+	superAdmins []int64
+}
+
+func NewRBACService(repo repository.UserRepository, superAdmins []int64) RBACService {
+	return &rbacService{repo: repo, superAdmins: superAdmins}
+}
+
+func (svc *rbacService) Authorize(ctx context.Context, uid int64) ([]string, []string, error) {
+	u, err := svc.repo.FindById(ctx, uid)
+	if err != nil {
+		return nil, nil, err
+	}
+	if u.Status == domain.UserStatusBanned {
+		return nil, nil, ErrUserBanned
+	}
+	if u.Status == domain.UserStatusDeleted {
+		return nil, nil, ErrUserDeleted
+	}
+	roles, err := svc.Roles(ctx, uid)
+	if err != nil {
+		return nil, nil, err
+	}
+	return roles, domain.PermissionsOf(roles), nil
+}
+
+func (svc *rbacService) Roles(ctx context.Context, uid int64) ([]string, error) {
+	roles, err := svc.repo.FindRoles(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(svc.superAdmins, uid) && !slices.Contains(roles, domain.RoleAdmin) {
+		roles = append(roles, domain.RoleAdmin)
+	}
+	return roles, nil
+}
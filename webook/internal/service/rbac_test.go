@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/repository"
+	repomocks "github.com/liupch66/basic-go/webook/internal/repository/mocks"
+)
+
+func Test_rbacService_Authorize(t *testing.T) {
+	testCases := []struct {
+		name        string
+		mock        func(ctrl *gomock.Controller) repository.UserRepository
+		superAdmins []int64
+		uid         int64
+		wantRoles   []string
+		wantPerms   []string
+		wantErr     error
+	}{
+		{
+			name: "普通用户",
+			mock: func(ctrl *gomock.Controller) repository.UserRepository {
+				repo := repomocks.NewMockUserRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(1)).Return(domain.User{Id: 1}, nil)
+				repo.EXPECT().FindRoles(gomock.Any(), int64(1)).Return([]string{}, nil)
+				return repo
+			},
+			uid:       1,
+			wantRoles: []string{},
+		},
+		{
+			name: "审核员",
+			mock: func(ctrl *gomock.Controller) repository.UserRepository {
+				repo := repomocks.NewMockUserRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(1)).Return(domain.User{Id: 1}, nil)
+				repo.EXPECT().FindRoles(gomock.Any(), int64(1)).Return([]string{domain.RoleModerator}, nil)
+				return repo
+			},
+			uid:       1,
+			wantRoles: []string{domain.RoleModerator},
+			wantPerms: []string{domain.PermUserBan, domain.PermArticleWithdraw, domain.PermAuditRead},
+		},
+		{
+			name: "配置的超级管理员",
+			mock: func(ctrl *gomock.Controller) repository.UserRepository {
+				repo := repomocks.NewMockUserRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(1)).Return(domain.User{Id: 1}, nil)
+				repo.EXPECT().FindRoles(gomock.Any(), int64(1)).Return([]string{domain.RoleModerator}, nil)
+				return repo
+			},
+			superAdmins: []int64{1},
+			uid:         1,
+			wantRoles:   []string{domain.RoleModerator, domain.RoleAdmin},
+			wantPerms: []string{domain.PermUserBan, domain.PermArticleWithdraw, domain.PermAuditRead,
+				domain.PermUserMerge, domain.PermUserRole, domain.PermMigrator, domain.PermSmsRead},
+		},
+		{
+			name: "被封禁了",
+			mock: func(ctrl *gomock.Controller) repository.UserRepository {
+				repo := repomocks.NewMockUserRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(1)).
+					Return(domain.User{Id: 1, Status: domain.UserStatusBanned}, nil)
+				return repo
+			},
+			superAdmins: []int64{1},
+			uid:         1,
+			wantErr:     ErrUserBanned,
+		},
+		{
+			name: "已经注销了",
+			mock: func(ctrl *gomock.Controller) repository.UserRepository {
+				repo := repomocks.NewMockUserRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(1)).
+					Return(domain.User{Id: 1, Status: domain.UserStatusDeleted}, nil)
+				return repo
+			},
+			uid:     1,
+			wantErr: ErrUserDeleted,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewRBACService(tc.mock(ctrl), tc.superAdmins)
+			roles, perms, err := svc.Authorize(context.Background(), tc.uid)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantRoles, roles)
+			assert.Equal(t, tc.wantPerms, perms)
+		})
+	}
+}
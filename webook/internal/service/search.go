@@ -0,0 +1,25 @@
+package service
+
+import (
+	"context"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/repository"
+)
+
+type SearchService interface {
+	// SearchArticle 只能搜到已经发表的文章，按相关度排序
+	SearchArticle(ctx context.Context, keyword string, offset int, limit int) (domain.ArticleSearchResult, error)
+}
+
+type searchService struct {
+	repo repository.ArticleSearchRepository
+}
+
+func NewSearchService(repo repository.ArticleSearchRepository) SearchService {
+	return &searchService{repo: repo}
+}
+
+func (svc *searchService) SearchArticle(ctx context.Context, keyword string, offset int, limit int) (domain.ArticleSearchResult, error) {
+	return svc.repo.SearchArticle(ctx, keyword, offset, limit)
+}
@@ -2,31 +2,116 @@ package async
 
 import (
 	"context"
+	"errors"
+	"math/rand"
+	"sync"
 	"time"
 
 	"github.com/liupch66/basic-go/webook/internal/domain"
 	"github.com/liupch66/basic-go/webook/internal/repository"
 	"github.com/liupch66/basic-go/webook/internal/service/sms"
+	"github.com/liupch66/basic-go/webook/internal/service/sms/circuitbreaker"
+	"github.com/liupch66/basic-go/webook/internal/service/sms/ratelimit"
 	"github.com/liupch66/basic-go/webook/pkg/logger"
+	"github.com/liupch66/basic-go/webook/pkg/windowx"
 )
 
+// 决定同步还是异步发送的原因，也是监控指标里面的 reason 标签
+const (
+	ReasonHealthy     = "healthy"
+	ReasonSlow        = "slow"
+	ReasonErrorRate   = "error_rate"
+	ReasonRateLimited = "rate_limited"
+	// ReasonRecovering 已经转了异步，还在恢复期
+	ReasonRecovering = "recovering"
+	// ReasonProbe 异步期间留一点流量同步发送，好知道服务商有没有恢复
+	ReasonProbe     = "probe"
+	ReasonRecovered = "recovered"
+)
+
+type Config struct {
+	// Window 统计窗口，分成 Buckets 个桶滑动
+	Window  time.Duration
+	Buckets int
+	// MinRequests 窗口内请求数太少的时候不判断
+	MinRequests int64
+	// Baseline 服务商正常的响应时间，平均响应时间超过 Baseline * SlowFactor 就转异步
+	Baseline   time.Duration
+	SlowFactor float64
+	// ErrorRate 错误率超过这个值就转异步
+	ErrorRate float64
+	// LimitedRate 被限流（或者熔断）的比例超过这个值就转异步
+	LimitedRate float64
+	// RecoveryPeriod 转异步之后至少等这么久，再根据这段时间的数据判断要不要切回同步
+	RecoveryPeriod time.Duration
+	// ProbeRatio 异步期间同步发送的比例
+	ProbeRatio float64
+
+	// RetryMax 异步发送最多发几次
+	RetryMax int
+	// 异步发送失败之后的重试间隔，第 n 次失败之后等 BackoffBase * 2^(n-1)，最多等 BackoffMax
+	BackoffBase time.Duration
+	BackoffMax  time.Duration
+	// Lease 抢占之后多久没有结果，别的节点可以重新抢占
+	Lease time.Duration
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Window:         30 * time.Second,
+		Buckets:        6,
+		MinRequests:    10,
+		Baseline:       200 * time.Millisecond,
+		SlowFactor:     3,
+		ErrorRate:      0.3,
+		LimitedRate:    0.1,
+		RecoveryPeriod: time.Minute,
+		ProbeRatio:     0.01,
+		RetryMax:       3,
+		BackoffBase:    10 * time.Second,
+		BackoffMax:     5 * time.Minute,
+		Lease:          time.Minute,
+	}
+}
+
+// Recorder 每一次的决定和同步异步之间的切换，给监控用
+type Recorder interface {
+	OnDecision(async bool, reason string)
+	OnSwitch(async bool, reason string)
+}
+
+// Service 服务商正常的时候同步发送，响应变慢、错误率变高或者被限流的时候转储到数据库，
+// 由 StartAsyncCycle 异步发送，过了恢复期再切回同步
 type Service struct {
 	svc sms.Service
 	// 转异步，存储发短信请求的 repository
-	repo repository.AsyncSmsRepository
-	l    logger.LoggerV1
+	repo     repository.AsyncSmsRepository
+	cfg      Config
+	recorder Recorder
+	l        logger.LoggerV1
+
+	mu    sync.Mutex
+	async bool
+	// switchedAt 上一次转异步（或者延长恢复期）的时间
+	switchedAt time.Time
+	signals    *windowx.Window[snapshot]
+	now        func() time.Time
+	rand       func() float64
 }
 
-func NewService(svc sms.Service, repo repository.AsyncSmsRepository, l logger.LoggerV1) sms.Service {
-	res := &Service{
-		svc:  svc,
-		repo: repo,
-		l:    l,
+// NewService recorder 可以为 nil。异步发送要调用方启动 StartAsyncCycle
+func NewService(svc sms.Service, repo repository.AsyncSmsRepository, cfg Config,
+	recorder Recorder, l logger.LoggerV1) *Service {
+	return &Service{
+		svc:      svc,
+		repo:     repo,
+		cfg:      cfg,
+		recorder: recorder,
+		l:        l,
+		signals:  windowx.New[snapshot](cfg.Window, cfg.Buckets),
+		now:      time.Now,
+		rand:     rand.Float64,
 	}
-	// go func() {
-	// 	res.StartAsyncCycle()
-	// }()
-	return res
 }
 
 // StartAsyncCycle 异步发送消息
@@ -42,27 +127,28 @@ func (s *Service) AsyncSend() {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	// 抢占一个异步发送的消息，确保在非常多个实例
 	// 比如 k8s 部署了三个 pod，一个请求，只有一个实例能拿到
-	as, err := s.repo.PreemptWaitingSMS(ctx)
+	as, err := s.repo.PreemptWaitingSMS(ctx, s.cfg.Lease)
 	cancel()
-	switch err {
-	case nil:
+	switch {
+	case err == nil:
 		// 执行发送
 		// 这个也可以做成配置的
 		ctx, cancel = context.WithTimeout(context.Background(), time.Second)
 		defer cancel()
-		err = s.svc.Send(ctx, as.TplId, as.Args, as.Numbers...)
+		// 异步发送的结果也算在统计数据里面，恢复期要靠它判断服务商有没有恢复
+		err = s.send(ctx, as.TplId, as.Args, as.Numbers...)
 		if err != nil {
-			// 啥也不需要干
-			s.l.Error("执行异步发送短信失败", logger.Error(err), logger.Int64("id", as.Id))
+			s.l.Error("执行异步发送短信失败", logger.Error(err), logger.Int64("id", as.Id),
+				logger.Int("retry_cnt", as.RetryCnt), logger.Int("retry_max", as.RetryMax))
 		}
 		res := err == nil
-		// 通知 repository 我这一次的执行结果
-		err = s.repo.ReportScheduleResult(ctx, as.Id, res)
+		// 通知 repository 我这一次的执行结果，失败了按照退避策略决定下一次什么时候发
+		err = s.repo.ReportScheduleResult(ctx, as.Id, res, s.now().Add(s.backoff(as.RetryCnt)))
 		if err != nil {
 			s.l.Error("执行异步发送短信成功，但是标记数据库失败", logger.Error(err),
 				logger.Bool("res", res), logger.Int64("id", as.Id))
 		}
-	case repository.ErrWaitingSMSNotFound:
+	case errors.Is(err, repository.ErrWaitingSMSNotFound):
 		// 睡一秒。可以自己决定
 		time.Sleep(time.Second)
 	default:
@@ -75,31 +161,121 @@ func (s *Service) AsyncSend() {
 	}
 }
 
+// backoff 第 retryCnt 次发送失败之后，隔多久再发
+func (s *Service) backoff(retryCnt int) time.Duration {
+	if retryCnt < 1 {
+		retryCnt = 1
+	}
+	d := s.cfg.BackoffBase
+	for i := 1; i < retryCnt && d < s.cfg.BackoffMax; i++ {
+		d *= 2
+	}
+	return min(d, s.cfg.BackoffMax)
+}
+
 func (s *Service) Send(ctx context.Context, tplId string, args []string, numbers ...string) error {
-	if s.needAsync() {
+	async, reason := s.needAsync()
+	if s.recorder != nil {
+		s.recorder.OnDecision(async, reason)
+	}
+	if async {
 		// 需要异步发送，直接转储到数据库
-		err := s.repo.Add(ctx, domain.AsyncSms{
-			TplId:   tplId,
-			Args:    args,
-			Numbers: numbers,
-			// 设置可以重试三次
-			RetryMax: 3,
+		return s.repo.Add(ctx, domain.AsyncSms{
+			TplId:    tplId,
+			Args:     args,
+			Numbers:  numbers,
+			RetryMax: s.cfg.RetryMax,
+			NextTime: s.now(),
 		})
+	}
+	return s.send(ctx, tplId, args, numbers...)
+}
+
+// send 真的调用服务商，顺便记录响应时间和结果
+func (s *Service) send(ctx context.Context, tplId string, args []string, numbers ...string) error {
+	start := s.now()
+	err := s.svc.Send(ctx, tplId, args, numbers...)
+	cost := s.now().Sub(start)
+	// 调用方自己取消的不算服务商的问题
+	if errors.Is(err, context.Canceled) {
 		return err
 	}
-	return s.svc.Send(ctx, tplId, args, numbers...)
+	limited := errors.Is(err, ratelimit.ErrLimited) || errors.Is(err, circuitbreaker.ErrOpen)
+	s.mu.Lock()
+	s.signals.Update(s.now(), func(snap *snapshot) {
+		snap.add(cost, err != nil, limited)
+	})
+	s.mu.Unlock()
+	return err
+}
+
+// needAsync 返回要不要异步发送和原因
+// 1. 同步的时候，窗口内平均响应时间、错误率、被限流的比例有一个超过阈值就转异步
+// 2. 异步的时候，过了恢复期之后用恢复期内的数据（探测流量和异步发送的结果）判断，
+// 恢复了就切回同步，没恢复就再等一个恢复期
+func (s *Service) needAsync() (bool, string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	var snap snapshot
+	s.signals.Range(now, func(val snapshot) {
+		snap.merge(val)
+	})
+	if !s.async {
+		if snap.total < s.cfg.MinRequests {
+			return false, ReasonHealthy
+		}
+		reason := s.unhealthy(snap)
+		if reason == "" {
+			return false, ReasonHealthy
+		}
+		s.switchTo(true, reason, now)
+		return true, reason
+	}
+	if now.Sub(s.switchedAt) >= s.cfg.RecoveryPeriod {
+		// 恢复期内一个请求都没有的话也切回同步，让同步的数据来判断
+		reason := s.unhealthy(snap)
+		if reason == "" {
+			s.switchTo(false, ReasonRecovered, now)
+			return false, ReasonRecovered
+		}
+		s.switchedAt = now
+		s.signals.Reset()
+	}
+	if s.rand() < s.cfg.ProbeRatio {
+		return false, ReasonProbe
+	}
+	return true, ReasonRecovering
 }
 
-// 开始思考系统容错问题,面试赢得竞争优势
-func (s *Service) needAsync() bool {
-	// 这边就是你要设计的，各种判定要不要触发异步的方案
-	// 1. 基于响应时间的，平均响应时间
-	// 1.1 使用绝对阈值，比如说直接发送的时候，（连续一段时间，或者连续N个请求）响应时间超过了 500ms，然后后续请求转异步
-	// 1.2 变化趋势，比如说当前一秒钟内的所有请求的响应时间比上一秒钟增长了 X%，就转异步
-	// 2. 基于错误率：一段时间内，收到 err 的请求比率大于 X%，转异步
-
-	// 什么时候退出异步
-	// 1. 进入异步 N 分钟后
-	// 2. 保留 1% 的流量（或者更少），继续同步发送，判定响应时间/错误率
-	return true
+// unhealthy 返回不健康的原因，健康的话返回空字符串
+func (s *Service) unhealthy(snap snapshot) string {
+	if snap.total == 0 {
+		return ""
+	}
+	if float64(snap.limited)/float64(snap.total) >= s.cfg.LimitedRate {
+		return ReasonRateLimited
+	}
+	if sent := snap.total - snap.limited; sent > 0 && float64(snap.fail)/float64(sent) >= s.cfg.ErrorRate {
+		return ReasonErrorRate
+	}
+	if float64(snap.avgCost()) > float64(s.cfg.Baseline)*s.cfg.SlowFactor {
+		return ReasonSlow
+	}
+	return ""
+}
+
+// switchTo 调用的时候要持有锁。切换之后清空统计数据，恢复期只看切换之后的数据
+func (s *Service) switchTo(async bool, reason string, now time.Time) {
+	s.async = async
+	s.switchedAt = now
+	s.signals.Reset()
+	if async {
+		s.l.Warn("短信转异步发送", logger.String("reason", reason))
+	} else {
+		s.l.Info("短信切回同步发送")
+	}
+	if s.recorder != nil {
+		s.recorder.OnSwitch(async, reason)
+	}
 }
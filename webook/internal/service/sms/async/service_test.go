@@ -0,0 +1,174 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	repomocks "github.com/liupch66/basic-go/webook/internal/repository/mocks"
+	"github.com/liupch66/basic-go/webook/internal/service/sms/circuitbreaker"
+	"github.com/liupch66/basic-go/webook/internal/service/sms/failover"
+	smsmocks "github.com/liupch66/basic-go/webook/internal/service/sms/mocks"
+	"github.com/liupch66/basic-go/webook/internal/service/sms/ratelimit"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
+)
+
+func testConfig() Config {
+	return Config{
+		Window:         10 * time.Second,
+		Buckets:        10,
+		MinRequests:    4,
+		Baseline:       100 * time.Millisecond,
+		SlowFactor:     3,
+		ErrorRate:      0.5,
+		LimitedRate:    0.5,
+		RecoveryPeriod: time.Minute,
+		ProbeRatio:     0.01,
+		RetryMax:       3,
+		BackoffBase:    10 * time.Second,
+		BackoffMax:     25 * time.Second,
+		Lease:          time.Minute,
+	}
+}
+
+// fakeClock 测试里面手动拨时间
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func newTestService(ctrl *gomock.Controller) (*Service, *smsmocks.MockService,
+	*repomocks.MockAsyncSmsRepository, *fakeClock) {
+	svc := smsmocks.NewMockService(ctrl)
+	repo := repomocks.NewMockAsyncSmsRepository(ctrl)
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)}
+	s := NewService(svc, repo, testConfig(), nil, logger.NewNopLogger())
+	s.now = clock.Now
+	// 默认不走探测流量
+	s.rand = func() float64 { return 1 }
+	return s, svc, repo, clock
+}
+
+func send(s *Service) error {
+	return s.Send(context.Background(), "tpl", []string{"123456"}, "13800000000")
+}
+
+func TestService_Switch(t *testing.T) {
+	testCases := []struct {
+		name       string
+		mock       func(svc *smsmocks.MockService, clock *fakeClock)
+		wantReason string
+	}{
+		{
+			name: "错误率太高",
+			mock: func(svc *smsmocks.MockService, clock *fakeClock) {
+				svc.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(errors.New("短信发送失败")).Times(4)
+			},
+			wantReason: ReasonErrorRate,
+		},
+		{
+			name: "被限流",
+			mock: func(svc *smsmocks.MockService, clock *fakeClock) {
+				svc.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(ratelimit.ErrLimited).Times(4)
+			},
+			wantReason: ReasonRateLimited,
+		},
+		{
+			name: "failover 后面的服务商都被限流或者熔断",
+			mock: func(svc *smsmocks.MockService, clock *fakeClock) {
+				svc.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(errors.Join(failover.ErrAllFailed, ratelimit.ErrLimited, circuitbreaker.ErrOpen)).Times(4)
+			},
+			wantReason: ReasonRateLimited,
+		},
+		{
+			name: "响应太慢",
+			mock: func(svc *smsmocks.MockService, clock *fakeClock) {
+				svc.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, tplId string, args []string, numbers ...string) error {
+						clock.now = clock.now.Add(500 * time.Millisecond)
+						return nil
+					}).Times(4)
+			},
+			wantReason: ReasonSlow,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			s, svc, repo, clock := newTestService(ctrl)
+			tc.mock(svc, clock)
+			for i := 0; i < 4; i++ {
+				_ = send(s)
+			}
+			repo.EXPECT().Add(gomock.Any(), domain.AsyncSms{TplId: "tpl", Args: []string{"123456"},
+				Numbers: []string{"13800000000"}, RetryMax: 3, NextTime: clock.now}).Return(nil)
+			assert.NoError(t, send(s))
+			assert.True(t, s.async)
+		})
+	}
+}
+
+func TestService_Recover(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	s, svc, repo, clock := newTestService(ctrl)
+	svc.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(errors.New("短信发送失败")).Times(4)
+	for i := 0; i < 4; i++ {
+		_ = send(s)
+	}
+	repo.EXPECT().Add(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	assert.NoError(t, send(s))
+
+	// 恢复期内的探测流量同步发送
+	s.rand = func() float64 { return 0 }
+	svc.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	assert.NoError(t, send(s))
+	s.rand = func() float64 { return 1 }
+	// 还在恢复期
+	clock.now = clock.now.Add(30 * time.Second)
+	assert.NoError(t, send(s))
+
+	// 过了恢复期，探测都成功了，切回同步
+	clock.now = clock.now.Add(30 * time.Second)
+	svc.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	assert.NoError(t, send(s))
+	assert.False(t, s.async)
+}
+
+func TestService_AsyncSendBackoff(t *testing.T) {
+	testCases := []struct {
+		name     string
+		retryCnt int
+		wantWait time.Duration
+	}{
+		{name: "第一次失败", retryCnt: 1, wantWait: 10 * time.Second},
+		{name: "第二次失败", retryCnt: 2, wantWait: 20 * time.Second},
+		{name: "超过最大间隔", retryCnt: 3, wantWait: 25 * time.Second},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			s, svc, repo, clock := newTestService(ctrl)
+			repo.EXPECT().PreemptWaitingSMS(gomock.Any(), time.Minute).
+				Return(domain.AsyncSms{Id: 1, TplId: "tpl", RetryMax: 3, RetryCnt: tc.retryCnt}, nil)
+			svc.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(errors.New("短信发送失败"))
+			repo.EXPECT().ReportScheduleResult(gomock.Any(), int64(1), false, clock.now.Add(tc.wantWait)).Return(nil)
+			s.AsyncSend()
+		})
+	}
+}
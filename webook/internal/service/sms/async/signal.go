@@ -0,0 +1,43 @@
+package async
+
+import (
+	"time"
+)
+
+// snapshot 一个桶或者整个窗口里面被装饰的 sms.Service 的表现：平均响应时间、错误率和被限流的比例。
+// cost 是成功和失败的请求的总耗时，被限流的请求没有真的发出去，不算
+type snapshot struct {
+	total   int64
+	fail    int64
+	limited int64
+	cost    time.Duration
+}
+
+func (s *snapshot) add(cost time.Duration, fail bool, limited bool) {
+	s.total++
+	switch {
+	case limited:
+		s.limited++
+	case fail:
+		s.fail++
+		s.cost += cost
+	default:
+		s.cost += cost
+	}
+}
+
+func (s *snapshot) merge(other snapshot) {
+	s.total += other.total
+	s.fail += other.fail
+	s.limited += other.limited
+	s.cost += other.cost
+}
+
+// avgCost 真的发出去了的请求的平均响应时间
+func (s snapshot) avgCost() time.Duration {
+	sent := s.total - s.limited
+	if sent <= 0 {
+		return 0
+	}
+	return s.cost / time.Duration(sent)
+}
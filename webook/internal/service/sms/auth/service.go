@@ -18,13 +18,14 @@ func NewService(svc sms.Service, key []byte) sms.Service {
 	return &Service{svc: svc, key: key}
 }
 
+// Claims 业务方申请的 token 里面带着可以使用的模板名字
 type Claims struct {
 	jwt.RegisteredClaims
-	tplId string
+	Tpl string `json:"tpl"`
 }
 
 // Send 使用 token 进行短信服务的权限控制,这里 tplToken 的语义发生了改变,之前就是 tplId, 这里是带 token 的
-// 这个 tplToken 是线下申请的代表业务方的 token
+// 这个 tplToken 是线下申请的代表业务方的 token，校验通过之后换成 token 里面的模板名字往下传
 func (s *Service) Send(ctx context.Context, tplToken string, params []string, numbers ...string) error {
 	var c Claims
 	token, err := jwt.ParseWithClaims(tplToken, &c, func(token *jwt.Token) (interface{}, error) {
@@ -36,5 +37,8 @@ func (s *Service) Send(ctx context.Context, tplToken string, params []string, nu
 	if !token.Valid {
 		return errors.New("短信服务 token 不合法")
 	}
-	return s.svc.Send(ctx, tplToken, params, numbers...)
+	if c.Tpl == "" {
+		return errors.New("短信服务 token 里面没有模板")
+	}
+	return s.svc.Send(ctx, c.Tpl, params, numbers...)
 }
@@ -0,0 +1,269 @@
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/liupch66/basic-go/webook/internal/service/sms"
+	"github.com/liupch66/basic-go/webook/pkg/windowx"
+)
+
+// ErrOpen 服务商熔断中，没有真的发送
+var ErrOpen = errors.New("短信服务商熔断中")
+
+type State int32
+
+const (
+	// StateClosed 正常发送，同时统计错误率和慢调用比例
+	StateClosed State = iota
+	// StateOpen 熔断，所有请求直接返回 ErrOpen
+	StateOpen
+	// StateHalfOpen 熔断时间到了，放少量探测请求过去，都成功了就恢复，有一个失败就继续熔断
+	StateHalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half_open"
+	default:
+		return "unknown"
+	}
+}
+
+type Config struct {
+	// Window 统计窗口，分成 Buckets 个桶滑动
+	Window  time.Duration
+	Buckets int
+	// MinRequests 窗口内请求数太少的时候不判断，避免一两个失败就熔断
+	MinRequests int64
+	// ErrorRate 错误率超过这个值就熔断
+	ErrorRate float64
+	// SlowThreshold 超过这个时间的调用算慢调用，SlowRate 是慢调用比例的阈值
+	SlowThreshold time.Duration
+	SlowRate      float64
+	// OpenTimeout 熔断多久之后进入半开状态
+	OpenTimeout time.Duration
+	// ProbeRequests 半开状态下放过去的探测请求数
+	ProbeRequests int
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Window:        time.Minute,
+		Buckets:       6,
+		MinRequests:   20,
+		ErrorRate:     0.5,
+		SlowThreshold: 3 * time.Second,
+		SlowRate:      0.8,
+		OpenTimeout:   30 * time.Second,
+		ProbeRequests: 3,
+	}
+}
+
+// Recorder 状态变化、每次调用的结果和被拒绝的请求，给监控用
+type Recorder interface {
+	OnStateChange(provider string, from, to State)
+	OnResult(provider string, fail bool, duration time.Duration)
+	OnRejected(provider string)
+}
+
+// counts 滑动窗口里面每个桶统计的调用次数、失败数和慢调用数
+type counts struct {
+	total int64
+	fail  int64
+	slow  int64
+}
+
+// Service 熔断器装饰器，每个服务商单独包一个。
+// 和 failover 组合使用的时候，熔断中的服务商会被直接跳过
+type Service struct {
+	provider string
+	svc      sms.Service
+	cfg      Config
+	recorder Recorder
+
+	mu       sync.Mutex
+	state    State
+	openedAt time.Time
+	// probing 半开状态下已经放过去、还没有返回的探测请求数，probeSucc 是成功了的探测请求数
+	probing   int
+	probeSucc int
+	// generation 每切换一次状态加一，上一轮的探测请求返回的时候就知道自己过时了
+	generation uint64
+	window     *windowx.Window[counts]
+	now        func() time.Time
+}
+
+// NewService recorder 可以为 nil
+func NewService(provider string, svc sms.Service, cfg Config, recorder Recorder) *Service {
+	s := &Service{
+		provider: provider,
+		svc:      svc,
+		cfg:      cfg,
+		recorder: recorder,
+		window:   windowx.New[counts](cfg.Window, cfg.Buckets),
+		now:      time.Now,
+	}
+	if recorder != nil {
+		recorder.OnStateChange(provider, StateClosed, StateClosed)
+	}
+	return s
+}
+
+func (s *Service) Send(ctx context.Context, tplId string, params []string, numbers ...string) error {
+	probe, gen, err := s.allow()
+	if err != nil {
+		return err
+	}
+	start := s.now()
+	err = s.svc.Send(ctx, tplId, params, numbers...)
+	s.record(probe, gen, err, s.now().Sub(start))
+	return err
+}
+
+// Available 现在发送会不会被拒绝，不占用探测的名额。failover 用这个跳过熔断中的服务商
+func (s *Service) Available() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch s.state {
+	case StateOpen:
+		return !s.now().Before(s.openedAt.Add(s.cfg.OpenTimeout))
+	case StateHalfOpen:
+		return s.probing+s.probeSucc < s.cfg.ProbeRequests
+	default:
+		return true
+	}
+}
+
+// Supports 被装饰的服务商能不能发这个模板，failover 要透过熔断器问到真正的服务商
+func (s *Service) Supports(tplId string) bool {
+	t, ok := s.svc.(interface{ Supports(tplId string) bool })
+	return !ok || t.Supports(tplId)
+}
+
+// DeliveryRate 被装饰的服务商最近的送达率，failover 用来把送达率低的服务商往后排
+func (s *Service) DeliveryRate() (float64, bool) {
+	d, ok := s.svc.(interface{ DeliveryRate() (float64, bool) })
+	if !ok {
+		return 0, false
+	}
+	return d.DeliveryRate()
+}
+
+func (s *Service) State() State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state
+}
+
+// allow 判断能不能发送，半开状态下放过去的请求是探测请求
+func (s *Service) allow() (bool, uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.state == StateOpen && !s.now().Before(s.openedAt.Add(s.cfg.OpenTimeout)) {
+		s.transit(StateHalfOpen)
+	}
+	switch s.state {
+	case StateOpen:
+		s.reject()
+		return false, s.generation, ErrOpen
+	case StateHalfOpen:
+		if s.probing+s.probeSucc >= s.cfg.ProbeRequests {
+			s.reject()
+			return false, s.generation, ErrOpen
+		}
+		s.probing++
+		return true, s.generation, nil
+	default:
+		return false, s.generation, nil
+	}
+}
+
+func (s *Service) record(probe bool, gen uint64, err error, duration time.Duration) {
+	// 调用方自己取消的不算服务商的问题，探测的名额还回去
+	if errors.Is(err, context.Canceled) {
+		if probe {
+			s.mu.Lock()
+			if s.generation == gen {
+				s.probing--
+			}
+			s.mu.Unlock()
+		}
+		return
+	}
+	fail := err != nil
+	slow := duration >= s.cfg.SlowThreshold
+	if s.recorder != nil {
+		s.recorder.OnResult(s.provider, fail, duration)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// 发送期间状态可能已经变了，比如另外一个探测请求失败了，过时的结果直接丢掉
+	if s.generation != gen {
+		return
+	}
+	if probe {
+		s.probing--
+		if fail || slow {
+			s.transit(StateOpen)
+			return
+		}
+		s.probeSucc++
+		if s.probeSucc >= s.cfg.ProbeRequests {
+			s.transit(StateClosed)
+		}
+		return
+	}
+	now := s.now()
+	s.window.Update(now, func(c *counts) {
+		c.total++
+		if fail {
+			c.fail++
+		}
+		if slow {
+			c.slow++
+		}
+	})
+	var total, failCnt, slowCnt int64
+	s.window.Range(now, func(c counts) {
+		total += c.total
+		failCnt += c.fail
+		slowCnt += c.slow
+	})
+	if total < s.cfg.MinRequests {
+		return
+	}
+	if float64(failCnt)/float64(total) >= s.cfg.ErrorRate || float64(slowCnt)/float64(total) >= s.cfg.SlowRate {
+		s.transit(StateOpen)
+	}
+}
+
+// transit 调用的时候要持有锁
+func (s *Service) transit(to State) {
+	from := s.state
+	s.state = to
+	s.probing, s.probeSucc = 0, 0
+	s.generation++
+	switch to {
+	case StateOpen:
+		s.openedAt = s.now()
+	case StateClosed:
+		s.window.Reset()
+	}
+	if s.recorder != nil {
+		s.recorder.OnStateChange(s.provider, from, to)
+	}
+}
+
+func (s *Service) reject() {
+	if s.recorder != nil {
+		s.recorder.OnRejected(s.provider)
+	}
+}
@@ -0,0 +1,164 @@
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/liupch66/basic-go/webook/internal/service/sms"
+	smsmocks "github.com/liupch66/basic-go/webook/internal/service/sms/mocks"
+)
+
+var errSend = errors.New("短信发送失败")
+
+func TestService_Send(t *testing.T) {
+	cfg := Config{
+		Window:        10 * time.Second,
+		Buckets:       10,
+		MinRequests:   4,
+		ErrorRate:     0.5,
+		SlowThreshold: time.Second,
+		SlowRate:      0.8,
+		OpenTimeout:   5 * time.Second,
+		ProbeRequests: 2,
+	}
+	// open 连续失败 4 次把熔断器打开，再等到熔断时间结束
+	open := func(s *Service, now *time.Time) {
+		for i := 0; i < 4; i++ {
+			_ = s.Send(context.Background(), "tpl", []string{"123456"}, "13800000000")
+		}
+		*now = now.Add(5 * time.Second)
+	}
+	testCases := []struct {
+		name string
+		// now 是测试里面的时钟，手动拨时间
+		mock   func(ctrl *gomock.Controller, now *time.Time) sms.Service
+		before func(s *Service, now *time.Time)
+
+		// wantErrs 依次发送，每次发送期望的错误
+		wantErrs      []error
+		wantState     State
+		wantAvailable bool
+	}{
+		{
+			name: "请求数没到 MinRequests，不熔断",
+			mock: func(ctrl *gomock.Controller, now *time.Time) sms.Service {
+				svc := smsmocks.NewMockService(ctrl)
+				svc.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+				svc.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errSend)
+				return svc
+			},
+			before:        func(s *Service, now *time.Time) {},
+			wantErrs:      []error{nil, nil, errSend},
+			wantState:     StateClosed,
+			wantAvailable: true,
+		},
+		{
+			name: "错误率到了阈值，熔断之后不再调用服务商",
+			mock: func(ctrl *gomock.Controller, now *time.Time) sms.Service {
+				svc := smsmocks.NewMockService(ctrl)
+				svc.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+				svc.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errSend).Times(2)
+				return svc
+			},
+			before:    func(s *Service, now *time.Time) {},
+			wantErrs:  []error{nil, nil, errSend, errSend, ErrOpen},
+			wantState: StateOpen,
+		},
+		{
+			name: "之前的失败滑出窗口了",
+			mock: func(ctrl *gomock.Controller, now *time.Time) sms.Service {
+				svc := smsmocks.NewMockService(ctrl)
+				svc.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errSend).Times(3)
+				svc.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+				return svc
+			},
+			before: func(s *Service, now *time.Time) {
+				for i := 0; i < 3; i++ {
+					_ = s.Send(context.Background(), "tpl", []string{"123456"}, "13800000000")
+				}
+				*now = now.Add(11 * time.Second)
+			},
+			wantErrs:      []error{nil, nil},
+			wantState:     StateClosed,
+			wantAvailable: true,
+		},
+		{
+			name: "慢调用太多，熔断",
+			mock: func(ctrl *gomock.Controller, now *time.Time) sms.Service {
+				svc := smsmocks.NewMockService(ctrl)
+				svc.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, tplId string, params []string, numbers ...string) error {
+						*now = now.Add(2 * time.Second)
+						return nil
+					}).Times(4)
+				return svc
+			},
+			before:    func(s *Service, now *time.Time) {},
+			wantErrs:  []error{nil, nil, nil, nil},
+			wantState: StateOpen,
+		},
+		{
+			name: "探测都成功了，恢复",
+			mock: func(ctrl *gomock.Controller, now *time.Time) sms.Service {
+				svc := smsmocks.NewMockService(ctrl)
+				svc.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errSend).Times(4)
+				svc.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+				return svc
+			},
+			before:        open,
+			wantErrs:      []error{nil, nil},
+			wantState:     StateClosed,
+			wantAvailable: true,
+		},
+		{
+			name: "探测失败，继续熔断",
+			mock: func(ctrl *gomock.Controller, now *time.Time) sms.Service {
+				svc := smsmocks.NewMockService(ctrl)
+				svc.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errSend).Times(5)
+				return svc
+			},
+			before:    open,
+			wantErrs:  []error{errSend, ErrOpen},
+			wantState: StateOpen,
+		},
+		{
+			name: "探测请求都还没返回，多出来的请求被拒绝",
+			mock: func(ctrl *gomock.Controller, now *time.Time) sms.Service {
+				svc := smsmocks.NewMockService(ctrl)
+				svc.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errSend).Times(4)
+				return svc
+			},
+			before: func(s *Service, now *time.Time) {
+				open(s, now)
+				// 占住两个探测的名额
+				_, _, _ = s.allow()
+				_, _, _ = s.allow()
+			},
+			wantErrs:  []error{ErrOpen},
+			wantState: StateHalfOpen,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+			s := NewService("test", tc.mock(ctrl, &now), cfg, nil)
+			s.now = func() time.Time {
+				return now
+			}
+			tc.before(s, &now)
+			for _, wantErr := range tc.wantErrs {
+				err := s.Send(context.Background(), "tpl", []string{"123456"}, "13800000000")
+				assert.ErrorIs(t, err, wantErr)
+			}
+			assert.Equal(t, tc.wantState, s.State())
+			assert.Equal(t, tc.wantAvailable, s.Available())
+		})
+	}
+}
@@ -7,8 +7,55 @@ import (
 	"sync/atomic"
 
 	"github.com/liupch66/basic-go/webook/internal/service/sms"
+	"github.com/liupch66/basic-go/webook/internal/service/sms/circuitbreaker"
 )
 
+// ErrAllFailed 所有服务商都失败了，返回的错误里面还 Join 了每个服务商的错误，
+// 上层用 errors.Is 可以知道是不是被限流、熔断了
+var ErrAllFailed = errors.New("所有短信服务商都发送失败")
+
+// availability 包了熔断器之类的服务商可以告诉 failover 现在能不能用，不能用的直接跳过
+type availability interface {
+	Available() bool
+}
+
+// templateSupport 服务商可以告诉 failover 有没有申请这个模板，没有的直接跳过
+type templateSupport interface {
+	Supports(tplId string) bool
+}
+
+// deliveryStats 服务商可以告诉 failover 最近的送达率，回执太少的时候 ok 是 false
+type deliveryStats interface {
+	DeliveryRate() (float64, bool)
+}
+
+// lowDeliveryRate 送达率低于这个值的服务商，接口调用成功了用户也大概率收不到，排到最后面
+const lowDeliveryRate = 0.8
+
+func available(svc sms.Service, tplId string) bool {
+	if a, ok := svc.(availability); ok && !a.Available() {
+		return false
+	}
+	t, ok := svc.(templateSupport)
+	return !ok || t.Supports(tplId)
+}
+
+// prioritize 送达率低的服务商挪到后面，别的保持原来的顺序
+func prioritize(svcs []sms.Service) []sms.Service {
+	res := make([]sms.Service, 0, len(svcs))
+	var low []sms.Service
+	for _, svc := range svcs {
+		if d, ok := svc.(deliveryStats); ok {
+			if rate, ok := d.DeliveryRate(); ok && rate < lowDeliveryRate {
+				low = append(low, svc)
+				continue
+			}
+		}
+		res = append(res, svc)
+	}
+	return append(res, low...)
+}
+
 type Service struct {
 	svcs []sms.Service
 }
@@ -18,15 +65,25 @@ func NewService(svcs []sms.Service) *Service {
 }
 
 // Send 每次都从头开始轮询，绝大多数请求会在 svcs[0] 就成功，负载不均衡。
+// 送达率低的服务商只在别的都失败了的时候才用
 func (s *Service) Send(ctx context.Context, tplId string, params []string, numbers ...string) error {
-	for _, svc := range s.svcs {
+	errs := []error{ErrAllFailed}
+	for _, svc := range prioritize(s.svcs) {
+		if !available(svc, tplId) {
+			// 熔断中跳过的服务商也要记下来，不然上层只看到发送失败，不知道是熔断了
+			if a, ok := svc.(availability); ok && !a.Available() {
+				errs = append(errs, circuitbreaker.ErrOpen)
+			}
+			continue
+		}
 		err := svc.Send(ctx, tplId, params, numbers...)
 		if err == nil {
 			return nil
 		}
 		log.Println(err)
+		errs = append(errs, err)
 	}
-	return errors.New("所有短信服务商都发送失败")
+	return errors.Join(errs...)
 }
 
 // =================================================================================================
@@ -42,6 +99,9 @@ func (s *ServiceV1) Send(ctx context.Context, tplId string, params []string, num
 		idx := atomic.AddUint64(&s.idx, 1)
 		// 注意取余
 		svc := s.svcs[idx%length]
+		if !available(svc, tplId) {
+			continue
+		}
 		err := svc.Send(ctx, tplId, params, numbers...)
 		switch {
 		case err == nil:
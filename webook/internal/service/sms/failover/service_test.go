@@ -9,7 +9,9 @@ import (
 	"go.uber.org/mock/gomock"
 
 	"github.com/liupch66/basic-go/webook/internal/service/sms"
+	"github.com/liupch66/basic-go/webook/internal/service/sms/circuitbreaker"
 	smsmocks "github.com/liupch66/basic-go/webook/internal/service/sms/mocks"
+	"github.com/liupch66/basic-go/webook/internal/service/sms/ratelimit"
 )
 
 func TestService_Send(t *testing.T) {
@@ -29,7 +31,26 @@ func TestService_Send(t *testing.T) {
 				svc1.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("短信发送失败"))
 				return []sms.Service{svc0, svc1}
 			},
-			expectedErr: errors.New("所有短信服务商都发送失败"),
+			expectedErr: ErrAllFailed,
+		},
+		{
+			name: "所有短信服务商都被限流",
+			mock: func(ctrl *gomock.Controller) []sms.Service {
+				svc0 := smsmocks.NewMockService(ctrl)
+				svc1 := smsmocks.NewMockService(ctrl)
+				svc0.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("短信发送失败"))
+				svc1.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(ratelimit.ErrLimited)
+				return []sms.Service{svc0, svc1}
+			},
+			// 异步发送靠这个判断服务商是不是被限流了
+			expectedErr: ratelimit.ErrLimited,
+		},
+		{
+			name: "所有短信服务商都熔断了",
+			mock: func(ctrl *gomock.Controller) []sms.Service {
+				return []sms.Service{unavailableService{smsmocks.NewMockService(ctrl)}}
+			},
+			expectedErr: circuitbreaker.ErrOpen,
 		},
 		{
 			name: "第一次发送,直接成功",
@@ -51,6 +72,37 @@ func TestService_Send(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		{
+			name: "熔断中的服务商直接跳过",
+			mock: func(ctrl *gomock.Controller) []sms.Service {
+				svc1 := smsmocks.NewMockService(ctrl)
+				svc1.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				// svc0 被调用的话 gomock 会报错
+				return []sms.Service{unavailableService{smsmocks.NewMockService(ctrl)}, svc1}
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "没有这个模板的服务商直接跳过",
+			mock: func(ctrl *gomock.Controller) []sms.Service {
+				svc1 := smsmocks.NewMockService(ctrl)
+				svc1.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				return []sms.Service{unsupportedService{smsmocks.NewMockService(ctrl)}, svc1}
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "送达率低的服务商排到最后",
+			mock: func(ctrl *gomock.Controller) []sms.Service {
+				svc0 := smsmocks.NewMockService(ctrl)
+				svc1 := smsmocks.NewMockService(ctrl)
+				svc1.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("短信发送失败"))
+				// svc1 失败了才轮到 svc0
+				svc0.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				return []sms.Service{lowDeliveryService{svc0}, svc1}
+			},
+			expectedErr: nil,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -58,7 +110,34 @@ func TestService_Send(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			s := NewService(tc.mock(ctrl))
 			err := s.Send(context.Background(), "test", []string{"test"}, "test")
-			assert.Equal(t, tc.expectedErr, err)
+			assert.ErrorIs(t, err, tc.expectedErr)
 		})
 	}
 }
+
+// unavailableService 模拟熔断中的服务商
+type unavailableService struct {
+	sms.Service
+}
+
+func (unavailableService) Available() bool {
+	return false
+}
+
+// unsupportedService 模拟没有申请模板的服务商
+type unsupportedService struct {
+	sms.Service
+}
+
+func (unsupportedService) Supports(tplId string) bool {
+	return false
+}
+
+// lowDeliveryService 模拟最近送达率很低的服务商
+type lowDeliveryService struct {
+	sms.Service
+}
+
+func (lowDeliveryService) DeliveryRate() (float64, bool) {
+	return 0.3, true
+}
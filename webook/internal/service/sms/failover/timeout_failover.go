@@ -31,6 +31,14 @@ func (s *ServiceForTimeout) Send(ctx context.Context, tplId string, params []str
 		idx := atomic.LoadInt32(&s.idx)
 		// 获取当前服务商
 		svc := s.svcs[idx%length]
+		// 熔断中的、没有这个模板的服务商直接切换到下一个
+		if !available(svc, tplId) {
+			if atomic.CompareAndSwapInt32(&s.idx, idx, (idx+1)%length) {
+				atomic.StoreInt32(&s.cnt, 0)
+			}
+			attempts++
+			continue
+		}
 		err := svc.Send(ctx, tplId, params, numbers...)
 		if err == nil {
 			newIdx := (idx + 1) % length
@@ -3,17 +3,66 @@ package memory
 import (
 	"context"
 	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/service/sms/receipt"
+	"github.com/liupch66/basic-go/webook/internal/service/sms/template"
 )
 
+// Vendor 本地开发用的服务商的名字
+const Vendor = "memory"
+
+// Service 本地开发用，不真的发短信，把渲染好的内容打印出来。
+// 发出去的短信下一次拉回执的时候都算送达了，方便在本地把回执的流程走通
 type Service struct {
+	registry *template.Registry
+	tracker  receipt.Tracker
+	seq      atomic.Int64
+
+	mu      sync.Mutex
+	pending []domain.SmsReceipt
 }
 
-func NewService() *Service {
-	return &Service{}
+func NewService(registry *template.Registry, tracker receipt.Tracker) *Service {
+	return &Service{registry: registry, tracker: tracker}
 }
 
 func (svc *Service) Send(ctx context.Context, tplId string, params []string, numbers ...string) error {
-	// 发送模板： {1}为您的登录验证码，请于{2}分钟内填写，如非本人操作，请忽略本短信。
-	fmt.Println(params[0])
+	content, err := svc.registry.Preview(tplId, params)
+	if err != nil {
+		return err
+	}
+	fmt.Println(numbers, content)
+	msgs := make([]domain.SmsMessage, 0, len(numbers))
+	receipts := make([]domain.SmsReceipt, 0, len(numbers))
+	for _, number := range numbers {
+		msgId := fmt.Sprintf("%d-%d", time.Now().UnixMilli(), svc.seq.Add(1))
+		msgs = append(msgs, domain.SmsMessage{Vendor: Vendor, MsgId: msgId, TplId: tplId, Number: number})
+		receipts = append(receipts, domain.SmsReceipt{Vendor: Vendor, MsgId: msgId, Number: number,
+			Status: domain.SmsStatusDelivered})
+	}
+	svc.tracker.Track(ctx, msgs...)
+	svc.mu.Lock()
+	svc.pending = append(svc.pending, receipts...)
+	svc.mu.Unlock()
 	return nil
 }
+
+func (svc *Service) DeliveryRate() (float64, bool) {
+	return svc.tracker.DeliveryRate(Vendor)
+}
+
+func (svc *Service) PullReceipts(ctx context.Context) ([]domain.SmsReceipt, error) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	res := svc.pending
+	svc.pending = nil
+	now := time.Now()
+	for i := range res {
+		res[i].DeliverTime = now
+	}
+	return res, nil
+}
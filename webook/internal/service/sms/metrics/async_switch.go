@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/liupch66/basic-go/webook/internal/service/sms/async"
+)
+
+var _ async.Recorder = (*AsyncSwitchRecorder)(nil)
+
+// AsyncSwitchRecorder 把同步还是异步发送的每一次决定、以及两者之间的切换暴露给 Prometheus
+type AsyncSwitchRecorder struct {
+	mode      prometheus.Gauge
+	decisions *prometheus.CounterVec
+	switches  *prometheus.CounterVec
+}
+
+func NewAsyncSwitchRecorder() *AsyncSwitchRecorder {
+	r := &AsyncSwitchRecorder{
+		mode: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "geektime",
+			Subsystem: "webook",
+			Name:      "sms_async_mode",
+			Help:      "短信现在的发送方式，0 同步，1 异步",
+		}),
+		decisions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "geektime",
+			Subsystem: "webook",
+			Name:      "sms_async_decisions_total",
+			Help:      "每一条短信是同步还是异步发送的，以及原因",
+		}, []string{"mode", "reason"}),
+		switches: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "geektime",
+			Subsystem: "webook",
+			Name:      "sms_async_switches_total",
+			Help:      "同步和异步之间的切换次数",
+		}, []string{"to", "reason"}),
+	}
+	prometheus.MustRegister(r.mode, r.decisions, r.switches)
+	return r
+}
+
+func (r *AsyncSwitchRecorder) OnDecision(async bool, reason string) {
+	r.decisions.WithLabelValues(asyncMode(async), reason).Inc()
+}
+
+func (r *AsyncSwitchRecorder) OnSwitch(async bool, reason string) {
+	if async {
+		r.mode.Set(1)
+	} else {
+		r.mode.Set(0)
+	}
+	r.switches.WithLabelValues(asyncMode(async), reason).Inc()
+}
+
+func asyncMode(async bool) string {
+	if async {
+		return "async"
+	}
+	return "sync"
+}
@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/liupch66/basic-go/webook/internal/service/sms/circuitbreaker"
+)
+
+var _ circuitbreaker.Recorder = (*CircuitBreakerRecorder)(nil)
+
+// CircuitBreakerRecorder 把每个服务商熔断器的状态、调用结果和耗时暴露给 Prometheus。
+// 所有服务商共用一个，用 provider 标签区分
+type CircuitBreakerRecorder struct {
+	state       *prometheus.GaugeVec
+	transitions *prometheus.CounterVec
+	results     *prometheus.CounterVec
+	latency     *prometheus.HistogramVec
+	rejected    *prometheus.CounterVec
+}
+
+func NewCircuitBreakerRecorder() *CircuitBreakerRecorder {
+	r := &CircuitBreakerRecorder{
+		state: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "geektime",
+			Subsystem: "webook",
+			Name:      "sms_breaker_state",
+			Help:      "短信服务商熔断器的状态，0 关闭，1 熔断，2 半开",
+		}, []string{"provider"}),
+		transitions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "geektime",
+			Subsystem: "webook",
+			Name:      "sms_breaker_transitions_total",
+			Help:      "短信服务商熔断器的状态切换次数",
+		}, []string{"provider", "from", "to"}),
+		results: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "geektime",
+			Subsystem: "webook",
+			Name:      "sms_breaker_requests_total",
+			Help:      "经过熔断器发送的短信，按成功失败区分",
+		}, []string{"provider", "result"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "geektime",
+			Subsystem: "webook",
+			Name:      "sms_breaker_latency_seconds",
+			Help:      "短信服务商的响应时间",
+			Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2, 3, 5, 10},
+		}, []string{"provider"}),
+		rejected: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "geektime",
+			Subsystem: "webook",
+			Name:      "sms_breaker_rejected_total",
+			Help:      "熔断中被直接拒绝的短信",
+		}, []string{"provider"}),
+	}
+	prometheus.MustRegister(r.state, r.transitions, r.results, r.latency, r.rejected)
+	return r
+}
+
+func (r *CircuitBreakerRecorder) OnStateChange(provider string, from, to circuitbreaker.State) {
+	r.state.WithLabelValues(provider).Set(float64(to))
+	if from != to {
+		r.transitions.WithLabelValues(provider, from.String(), to.String()).Inc()
+	}
+}
+
+func (r *CircuitBreakerRecorder) OnResult(provider string, fail bool, duration time.Duration) {
+	result := "success"
+	if fail {
+		result = "fail"
+	}
+	r.results.WithLabelValues(provider, result).Inc()
+	r.latency.WithLabelValues(provider).Observe(duration.Seconds())
+}
+
+func (r *CircuitBreakerRecorder) OnRejected(provider string) {
+	r.rejected.WithLabelValues(provider).Inc()
+}
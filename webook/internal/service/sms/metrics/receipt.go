@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/service/sms/receipt"
+)
+
+var _ receipt.Recorder = (*ReceiptRecorder)(nil)
+
+// ReceiptRecorder 每个服务商的短信状态和送达率
+type ReceiptRecorder struct {
+	status       *prometheus.CounterVec
+	deliveryRate *prometheus.GaugeVec
+}
+
+func NewReceiptRecorder() *ReceiptRecorder {
+	r := &ReceiptRecorder{
+		status: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "geektime",
+			Subsystem: "webook",
+			Name:      "sms_messages_total",
+			Help:      "短信的状态，accepted 是服务商受理了，delivered 和 failed 来自回执",
+		}, []string{"vendor", "status"}),
+		deliveryRate: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "geektime",
+			Subsystem: "webook",
+			Name:      "sms_delivery_rate",
+			Help:      "短信服务商最近的送达率",
+		}, []string{"vendor"}),
+	}
+	prometheus.MustRegister(r.status, r.deliveryRate)
+	return r
+}
+
+func (r *ReceiptRecorder) OnStatus(vendor string, status domain.SmsStatus) {
+	r.status.WithLabelValues(vendor, status.String()).Inc()
+}
+
+func (r *ReceiptRecorder) OnDeliveryRate(vendor string, rate float64) {
+	r.deliveryRate.WithLabelValues(vendor).Set(rate)
+}
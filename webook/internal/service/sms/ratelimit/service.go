@@ -11,7 +11,8 @@ import (
 
 const key = "sms_tencent"
 
-var errLimited = errors.New("触发了限流")
+// ErrLimited 被限流了，没有真的发送
+var ErrLimited = errors.New("触发了限流")
 
 // 装饰器模式的优点
 // 增强功能：装饰器模式可以在不改变原有代码的情况下，增加新的功能（如限流、缓存、日志等）。
@@ -37,8 +38,7 @@ func (s *Service) Send(ctx context.Context, tplId string, params []string, numbe
 		return fmt.Errorf("短信服务判断限流出现错误: %w", err)
 	}
 	if limited {
-		// 后面有需要的话,可以做成公开错误
-		return errLimited
+		return ErrLimited
 	}
 	// 可以在这里加新代码,实现新特性
 	// 没有侵入 svc.send 修改代码
@@ -63,7 +63,7 @@ func (s *ServiceV1) Send(ctx context.Context, tplId string, params []string, num
 		return fmt.Errorf("短信服务判断限流出现错误: %w", err)
 	}
 	if limited {
-		return errLimited
+		return ErrLimited
 	}
 	err = s.Service.Send(ctx, tplId, params, numbers...)
 	// err = s.Send(ctx, tplId, params, numbers...)
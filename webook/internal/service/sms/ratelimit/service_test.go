@@ -40,7 +40,7 @@ func TestService_Send(t *testing.T) {
 				limiter.EXPECT().Limit(gomock.Any(), key).Return(true, nil)
 				return nil, limiter
 			},
-			expectedErr: errLimited,
+			expectedErr: ErrLimited,
 		},
 		{
 			name: "限流器异常",
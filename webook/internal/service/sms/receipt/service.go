@@ -0,0 +1,262 @@
+package receipt
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/repository"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
+	"github.com/liupch66/basic-go/webook/pkg/windowx"
+)
+
+var (
+	ErrUnknownVendor   = errors.New("没有接入这个服务商的回执回调")
+	ErrInvalidCallback = errors.New("回执回调的格式不对")
+	// ErrUnauthorizedCallback 回调没有带上和服务商约定的密钥，可能是伪造的
+	ErrUnauthorizedCallback = errors.New("回执回调的密钥不对")
+)
+
+// Tracker 服务商受理了短信之后调用 Track 记下来，回执靠流水号对上。
+// DeliveryRate 是这个服务商最近的送达率，回执太少的时候 ok 是 false
+type Tracker interface {
+	Track(ctx context.Context, msgs ...domain.SmsMessage)
+	DeliveryRate(vendor string) (rate float64, ok bool)
+}
+
+// CallbackParser 服务商推过来的回执，每家的格式都不一样
+type CallbackParser interface {
+	ParseCallback(body []byte) ([]domain.SmsReceipt, error)
+}
+
+// Poller 主动去服务商那边拉回执，拉过的服务商不会再给
+type Poller interface {
+	PullReceipts(ctx context.Context) ([]domain.SmsReceipt, error)
+}
+
+// Recorder 每条短信的状态和每个服务商的送达率，给监控用
+type Recorder interface {
+	OnStatus(vendor string, status domain.SmsStatus)
+	OnDeliveryRate(vendor string, rate float64)
+}
+
+type Config struct {
+	// Window 统计送达率的窗口，分成 Buckets 个桶滑动
+	Window  time.Duration
+	Buckets int
+	// MinReceipts 窗口内的回执太少的时候送达率不可信，不拿去影响服务商的选择
+	MinReceipts int64
+	// PollInterval 多久拉一次回执
+	PollInterval time.Duration
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Window:       10 * time.Minute,
+		Buckets:      10,
+		MinReceipts:  50,
+		PollInterval: 10 * time.Second,
+	}
+}
+
+var _ Tracker = (*Service)(nil)
+
+// callback 接入的回执回调。回调地址是公开的，只有带上 secret 的才是服务商推过来的
+type callback struct {
+	secret string
+	parser CallbackParser
+}
+
+// counts 滑动窗口里面每个桶统计的回执数
+type counts struct {
+	delivered int64
+	failed    int64
+}
+
+// Service 记录每一条短信的状态，回执可以是服务商回调推过来的，也可以是我们定时拉的
+type Service struct {
+	repo     repository.SmsMessageRepository
+	cfg      Config
+	recorder Recorder
+	l        logger.LoggerV1
+
+	mu        sync.RWMutex
+	callbacks map[string]callback
+	pollers   map[string]Poller
+	rates     map[string]*windowx.Window[counts]
+	now       func() time.Time
+}
+
+// NewService recorder 可以为 nil。拉回执要调用方启动 StartPollCycle
+func NewService(repo repository.SmsMessageRepository, cfg Config, recorder Recorder, l logger.LoggerV1) *Service {
+	return &Service{
+		repo:      repo,
+		cfg:       cfg,
+		recorder:  recorder,
+		l:         l,
+		callbacks: make(map[string]callback),
+		pollers:   make(map[string]Poller),
+		rates:     make(map[string]*windowx.Window[counts]),
+		now:       time.Now,
+	}
+}
+
+// AddCallback 接入服务商的回执回调，回调地址是 /sms/receipts/{vendor}?token={secret}。
+// 在服务商的控制台配置回调地址的时候带上 secret，没有配置 secret 的话所有回调都会被拒绝
+func (s *Service) AddCallback(vendor string, secret string, p CallbackParser) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.callbacks[vendor] = callback{secret: secret, parser: p}
+}
+
+// AddPoller 没有回调或者回调不可靠的服务商，定时去拉
+func (s *Service) AddPoller(vendor string, p Poller) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pollers[vendor] = p
+}
+
+// Track 短信已经发出去了，记录失败不能让发送失败，打个日志就行
+func (s *Service) Track(ctx context.Context, msgs ...domain.SmsMessage) {
+	if len(msgs) == 0 {
+		return
+	}
+	for i := range msgs {
+		if msgs[i].Status == domain.SmsStatusUnknown {
+			msgs[i].Status = domain.SmsStatusAccepted
+		}
+		if s.recorder != nil {
+			s.recorder.OnStatus(msgs[i].Vendor, msgs[i].Status)
+		}
+	}
+	if err := s.repo.Create(ctx, msgs); err != nil {
+		s.l.Error("记录短信发送状态失败", logger.String("vendor", msgs[0].Vendor),
+			logger.Int("cnt", len(msgs)), logger.Error(err))
+	}
+}
+
+// HandleCallback 服务商回调推过来的回执，token 要和 AddCallback 的时候给的 secret 一样才处理，
+// 不然谁都可以伪造回执，改掉短信的状态，压低服务商的送达率
+func (s *Service) HandleCallback(ctx context.Context, vendor string, token string, body []byte) error {
+	s.mu.RLock()
+	cb, ok := s.callbacks[vendor]
+	s.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownVendor, vendor)
+	}
+	if cb.secret == "" || subtle.ConstantTimeCompare([]byte(token), []byte(cb.secret)) != 1 {
+		return fmt.Errorf("%w: %s", ErrUnauthorizedCallback, vendor)
+	}
+	receipts, err := cb.parser.ParseCallback(body)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidCallback, err)
+	}
+	return s.Ingest(ctx, receipts)
+}
+
+// Ingest 更新短信的状态。同一个回执推了多次或者回调和拉取都拿到了，只算一次
+func (s *Service) Ingest(ctx context.Context, receipts []domain.SmsReceipt) error {
+	var errs []error
+	for _, r := range receipts {
+		if r.Status != domain.SmsStatusDelivered && r.Status != domain.SmsStatusFailed {
+			errs = append(errs, fmt.Errorf("回执 %s/%s 的状态不对：%s", r.Vendor, r.MsgId, r.Status))
+			continue
+		}
+		updated, err := s.repo.UpdateByReceipt(ctx, r)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !updated {
+			// 回执比发送记录先到的话也会走到这里，一般是服务商回调很快而我们写数据库很慢，丢掉就行
+			s.l.Debug("重复的短信回执或者找不到短信", logger.String("vendor", r.Vendor), logger.String("msgId", r.MsgId))
+			continue
+		}
+		s.observe(r)
+	}
+	return errors.Join(errs...)
+}
+
+func (s *Service) observe(r domain.SmsReceipt) {
+	s.mu.Lock()
+	w, ok := s.rates[r.Vendor]
+	if !ok {
+		w = windowx.New[counts](s.cfg.Window, s.cfg.Buckets)
+		s.rates[r.Vendor] = w
+	}
+	now := s.now()
+	w.Update(now, func(c *counts) {
+		if r.Status == domain.SmsStatusDelivered {
+			c.delivered++
+		} else {
+			c.failed++
+		}
+	})
+	delivered, total := stat(w, now)
+	s.mu.Unlock()
+	if s.recorder != nil {
+		s.recorder.OnStatus(r.Vendor, r.Status)
+		s.recorder.OnDeliveryRate(r.Vendor, float64(delivered)/float64(total))
+	}
+}
+
+func (s *Service) DeliveryRate(vendor string) (float64, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	w, ok := s.rates[vendor]
+	if !ok {
+		return 0, false
+	}
+	delivered, total := stat(w, s.now())
+	if total == 0 || total < s.cfg.MinReceipts {
+		return 0, false
+	}
+	return float64(delivered) / float64(total), true
+}
+
+// stat 窗口内送达的回执数和总的回执数
+func stat(w *windowx.Window[counts], now time.Time) (delivered int64, total int64) {
+	w.Range(now, func(c counts) {
+		delivered += c.delivered
+		total += c.delivered + c.failed
+	})
+	return
+}
+
+func (s *Service) List(ctx context.Context, q domain.SmsMessageQuery) ([]domain.SmsMessage, error) {
+	return s.repo.List(ctx, q)
+}
+
+// StartPollCycle 定时拉回执。和 StartAsyncCycle 一样没有设计退出机制
+func (s *Service) StartPollCycle() {
+	ticker := time.NewTicker(s.cfg.PollInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		s.Poll()
+	}
+}
+
+// Poll 每个服务商拉一次，一个服务商出错了不影响别的
+func (s *Service) Poll() {
+	s.mu.RLock()
+	pollers := make(map[string]Poller, len(s.pollers))
+	for vendor, p := range s.pollers {
+		pollers[vendor] = p
+	}
+	s.mu.RUnlock()
+	for vendor, p := range pollers {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		receipts, err := p.PullReceipts(ctx)
+		if err == nil {
+			err = s.Ingest(ctx, receipts)
+		}
+		cancel()
+		if err != nil {
+			s.l.Error("拉取短信回执失败", logger.String("vendor", vendor), logger.Error(err))
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package receipt
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/repository"
+	repomocks "github.com/liupch66/basic-go/webook/internal/repository/mocks"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
+)
+
+type parserFunc func(body []byte) ([]domain.SmsReceipt, error)
+
+func (f parserFunc) ParseCallback(body []byte) ([]domain.SmsReceipt, error) {
+	return f(body)
+}
+
+func newTestService(repo repository.SmsMessageRepository) *Service {
+	cfg := DefaultConfig()
+	cfg.MinReceipts = 4
+	svc := NewService(repo, cfg, nil, logger.NewNopLogger())
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+func TestService_Track(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repomocks.NewMockSmsMessageRepository(ctrl)
+	repo.EXPECT().Create(gomock.Any(), []domain.SmsMessage{
+		{Vendor: "tencent", MsgId: "1", Number: "+8613800000000", Status: domain.SmsStatusAccepted},
+		{Vendor: "tencent", Number: "+8613800000001", Status: domain.SmsStatusFailed, Reason: "LimitExceeded"},
+	}).Return(errors.New("数据库错误"))
+	svc := newTestService(repo)
+	// 记录失败了也不影响发送
+	svc.Track(context.Background(),
+		domain.SmsMessage{Vendor: "tencent", MsgId: "1", Number: "+8613800000000"},
+		domain.SmsMessage{Vendor: "tencent", Number: "+8613800000001", Status: domain.SmsStatusFailed, Reason: "LimitExceeded"},
+	)
+}
+
+func TestService_Ingest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := repomocks.NewMockSmsMessageRepository(ctrl)
+	svc := newTestService(repo)
+
+	delivered := domain.SmsReceipt{Vendor: "tencent", MsgId: "1", Status: domain.SmsStatusDelivered}
+	failed := domain.SmsReceipt{Vendor: "tencent", MsgId: "2", Status: domain.SmsStatusFailed}
+	repo.EXPECT().UpdateByReceipt(gomock.Any(), delivered).Return(true, nil).Times(3)
+	repo.EXPECT().UpdateByReceipt(gomock.Any(), failed).Return(true, nil)
+	assert.NoError(t, svc.Ingest(context.Background(), []domain.SmsReceipt{delivered, delivered, delivered}))
+	// 回执太少，送达率不可信
+	_, ok := svc.DeliveryRate("tencent")
+	assert.False(t, ok)
+
+	assert.NoError(t, svc.Ingest(context.Background(), []domain.SmsReceipt{failed}))
+	// 重复的回执不算
+	repo.EXPECT().UpdateByReceipt(gomock.Any(), failed).Return(false, nil)
+	assert.NoError(t, svc.Ingest(context.Background(), []domain.SmsReceipt{failed}))
+	rate, ok := svc.DeliveryRate("tencent")
+	assert.True(t, ok)
+	assert.Equal(t, 0.75, rate)
+
+	// 窗口过去了之后重新统计
+	svc.now = func() time.Time { return time.Date(2024, 1, 1, 1, 0, 0, 0, time.Local) }
+	_, ok = svc.DeliveryRate("tencent")
+	assert.False(t, ok)
+
+	// 状态不对的回执不会更新
+	err := svc.Ingest(context.Background(), []domain.SmsReceipt{{Vendor: "tencent", MsgId: "3", Status: domain.SmsStatusAccepted}})
+	assert.Error(t, err)
+}
+
+func TestService_HandleCallback(t *testing.T) {
+	testCases := []struct {
+		name   string
+		mock   func(ctrl *gomock.Controller) repository.SmsMessageRepository
+		secret string
+		vendor string
+		token  string
+		body   string
+
+		wantErr error
+	}{
+		{
+			name: "没有接入的服务商",
+			mock: func(ctrl *gomock.Controller) repository.SmsMessageRepository {
+				return repomocks.NewMockSmsMessageRepository(ctrl)
+			},
+			secret:  "secret",
+			vendor:  "aliyun",
+			token:   "secret",
+			body:    "1",
+			wantErr: ErrUnknownVendor,
+		},
+		{
+			name: "密钥不对，不处理回执",
+			mock: func(ctrl *gomock.Controller) repository.SmsMessageRepository {
+				return repomocks.NewMockSmsMessageRepository(ctrl)
+			},
+			secret:  "secret",
+			vendor:  "tencent",
+			token:   "guess",
+			body:    "1",
+			wantErr: ErrUnauthorizedCallback,
+		},
+		{
+			name: "没有配置密钥，都拒绝",
+			mock: func(ctrl *gomock.Controller) repository.SmsMessageRepository {
+				return repomocks.NewMockSmsMessageRepository(ctrl)
+			},
+			vendor:  "tencent",
+			body:    "1",
+			wantErr: ErrUnauthorizedCallback,
+		},
+		{
+			name: "格式不对",
+			mock: func(ctrl *gomock.Controller) repository.SmsMessageRepository {
+				return repomocks.NewMockSmsMessageRepository(ctrl)
+			},
+			secret:  "secret",
+			vendor:  "tencent",
+			token:   "secret",
+			body:    "bad",
+			wantErr: ErrInvalidCallback,
+		},
+		{
+			name: "更新短信状态",
+			mock: func(ctrl *gomock.Controller) repository.SmsMessageRepository {
+				repo := repomocks.NewMockSmsMessageRepository(ctrl)
+				repo.EXPECT().UpdateByReceipt(gomock.Any(),
+					domain.SmsReceipt{Vendor: "tencent", MsgId: "1", Status: domain.SmsStatusDelivered}).Return(true, nil)
+				return repo
+			},
+			secret: "secret",
+			vendor: "tencent",
+			token:  "secret",
+			body:   "1",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := newTestService(tc.mock(ctrl))
+			svc.AddCallback("tencent", tc.secret, parserFunc(func(body []byte) ([]domain.SmsReceipt, error) {
+				if string(body) == "bad" {
+					return nil, errors.New("格式不对")
+				}
+				return []domain.SmsReceipt{{Vendor: "tencent", MsgId: string(body), Status: domain.SmsStatusDelivered}}, nil
+			}))
+			err := svc.HandleCallback(context.Background(), tc.vendor, tc.token, []byte(tc.body))
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/service/sms/ratelimit"
+	"github.com/liupch66/basic-go/webook/internal/service/sms/receipt"
+	"github.com/liupch66/basic-go/webook/internal/service/sms/template"
+)
+
+// Vendor 沙箱服务商的名字
+const Vendor = "sandbox"
+
+// maxPerNumber 每个手机号只留最近的这么多条
+const maxPerNumber = 20
+
+// Message 沙箱"发出去"的短信
+type Message struct {
+	MsgId  string
+	Number string
+	TplId  string
+	Params []string
+	// Content 渲染好的内容
+	Content string
+	Time    time.Time
+}
+
+// Fault 注入的故障，按照注入的顺序一次用一个
+type Fault struct {
+	// Latency 模拟服务商的响应时间，ctx 先到期的话返回 ctx.Err()
+	Latency time.Duration
+	// Timeout 服务商一直没有响应，直到 ctx 超时。ctx 没有超时时间的话直接返回 context.DeadlineExceeded
+	Timeout bool
+	// Limited 服务商限流了，返回 ratelimit.ErrLimited。套了 ratelimit 装饰器的话由 Limiter 返回限流
+	Limited bool
+	// Err 服务商返回的错误
+	Err error
+	// Times 连续生效几次，0 就是一直生效，直到 Reset
+	Times int
+}
+
+// Service 端到端测试用的服务商，不真的发短信，按手机号存下来给测试读。
+// 还可以注入延迟、错误、超时和限流，用来测试 failover、ratelimit 这些装饰器
+type Service struct {
+	registry *template.Registry
+	tracker  receipt.Tracker
+	seq      atomic.Int64
+
+	mu       sync.Mutex
+	messages map[string][]Message
+	faults   []Fault
+	pending  []domain.SmsReceipt
+	now      func() time.Time
+}
+
+// NewService tracker 可以为 nil，不为 nil 的话发出去的短信下一次拉回执的时候都算送达了
+func NewService(registry *template.Registry, tracker receipt.Tracker) *Service {
+	return &Service{
+		registry: registry,
+		tracker:  tracker,
+		messages: make(map[string][]Message),
+		now:      time.Now,
+	}
+}
+
+func (s *Service) Send(ctx context.Context, tplId string, params []string, numbers ...string) error {
+	f, ok := s.nextFault()
+	if ok {
+		if err := s.inject(ctx, f); err != nil {
+			return err
+		}
+	}
+	content, err := s.registry.Preview(tplId, params)
+	if err != nil {
+		return err
+	}
+	now := s.now()
+	msgs := make([]domain.SmsMessage, 0, len(numbers))
+	s.mu.Lock()
+	for _, number := range numbers {
+		msgId := fmt.Sprintf("%d-%d", now.UnixMilli(), s.seq.Add(1))
+		ms := append(s.messages[number], Message{
+			MsgId:   msgId,
+			Number:  number,
+			TplId:   tplId,
+			Params:  params,
+			Content: content,
+			Time:    now,
+		})
+		if len(ms) > maxPerNumber {
+			ms = ms[len(ms)-maxPerNumber:]
+		}
+		s.messages[number] = ms
+		msgs = append(msgs, domain.SmsMessage{Vendor: Vendor, MsgId: msgId, TplId: tplId, Number: number})
+		if s.tracker != nil {
+			s.pending = append(s.pending, domain.SmsReceipt{Vendor: Vendor, MsgId: msgId, Number: number,
+				Status: domain.SmsStatusDelivered})
+		}
+	}
+	s.mu.Unlock()
+	if s.tracker != nil {
+		s.tracker.Track(ctx, msgs...)
+	}
+	return nil
+}
+
+func (s *Service) inject(ctx context.Context, f Fault) error {
+	if f.Timeout {
+		if _, ok := ctx.Deadline(); !ok {
+			return context.DeadlineExceeded
+		}
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	if f.Latency > 0 {
+		select {
+		case <-time.After(f.Latency):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if f.Limited {
+		return ratelimit.ErrLimited
+	}
+	return f.Err
+}
+
+// Inject 追加故障，前面的故障用完了才会轮到后面的
+func (s *Service) Inject(faults ...Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, faults...)
+}
+
+func (s *Service) nextFault() (Fault, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.popFault(func(Fault) bool { return true })
+}
+
+// popFault 调用的时候要持有锁。第一个故障满足 match 的话用掉一次
+func (s *Service) popFault(match func(f Fault) bool) (Fault, bool) {
+	if len(s.faults) == 0 || !match(s.faults[0]) {
+		return Fault{}, false
+	}
+	f := s.faults[0]
+	if f.Times > 0 {
+		s.faults[0].Times--
+		if s.faults[0].Times == 0 {
+			s.faults = s.faults[1:]
+		}
+	}
+	return f, true
+}
+
+// Limiter 给 ratelimit 装饰器用，轮到的故障是 Limited 的时候判定为限流，并且用掉这个故障
+func (s *Service) Limiter() *Limiter {
+	return &Limiter{s: s}
+}
+
+// Last 这个手机号收到的最后一条短信
+func (s *Service) Last(number string) (Message, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ms := s.messages[number]
+	if len(ms) == 0 {
+		return Message{}, false
+	}
+	return ms[len(ms)-1], true
+}
+
+// Messages 这个手机号最近收到的短信，按时间顺序
+func (s *Service) Messages(number string) []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Message(nil), s.messages[number]...)
+}
+
+// Reset 清掉所有短信和还没用完的故障，每个测试开始之前调用
+func (s *Service) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = make(map[string][]Message)
+	s.faults = nil
+}
+
+func (s *Service) DeliveryRate() (float64, bool) {
+	if s.tracker == nil {
+		return 0, false
+	}
+	return s.tracker.DeliveryRate(Vendor)
+}
+
+func (s *Service) PullReceipts(ctx context.Context) ([]domain.SmsReceipt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res := s.pending
+	s.pending = nil
+	now := s.now()
+	for i := range res {
+		res[i].DeliverTime = now
+	}
+	return res, nil
+}
+
+// Limiter 实现了 pkg/ratelimit.Limiter
+type Limiter struct {
+	s *Service
+}
+
+func (l *Limiter) Limit(ctx context.Context, key string) (bool, error) {
+	l.s.mu.Lock()
+	defer l.s.mu.Unlock()
+	_, ok := l.s.popFault(func(f Fault) bool { return f.Limited })
+	return ok, nil
+}
@@ -0,0 +1,96 @@
+package sandbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liupch66/basic-go/webook/internal/service/sms"
+	"github.com/liupch66/basic-go/webook/internal/service/sms/failover"
+	"github.com/liupch66/basic-go/webook/internal/service/sms/ratelimit"
+	"github.com/liupch66/basic-go/webook/internal/service/sms/template"
+)
+
+const number = "13800000000"
+
+func newTestService(t *testing.T) *Service {
+	registry, err := template.NewRegistry(template.Template{
+		Name:    "verify_code",
+		Content: "{1}为您的登录验证码，请于{2}分钟内填写。",
+		Params:  []template.Param{{Name: "code"}, {Name: "minutes"}},
+		Vendors: map[string]template.VendorTemplate{Vendor: {}},
+	})
+	require.NoError(t, err)
+	return NewService(registry, nil)
+}
+
+func TestService_Send(t *testing.T) {
+	s := newTestService(t)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+	s.now = func() time.Time { return now }
+	_, ok := s.Last(number)
+	assert.False(t, ok)
+
+	require.NoError(t, s.Send(context.Background(), "verify_code", []string{"123456", "10"}, number))
+	require.NoError(t, s.Send(context.Background(), "verify_code", []string{"654321", "10"}, number))
+	msg, ok := s.Last(number)
+	require.True(t, ok)
+	assert.Equal(t, []string{"654321", "10"}, msg.Params)
+	assert.Equal(t, "654321为您的登录验证码，请于10分钟内填写。", msg.Content)
+	assert.Equal(t, now, msg.Time)
+	assert.Len(t, s.Messages(number), 2)
+
+	s.Reset()
+	assert.Empty(t, s.Messages(number))
+}
+
+func TestService_Inject(t *testing.T) {
+	s := newTestService(t)
+	vendorErr := errors.New("服务商错误")
+	s.Inject(Fault{Err: vendorErr, Times: 2}, Fault{Limited: true, Times: 1}, Fault{Latency: 50 * time.Millisecond, Times: 1})
+	send := func(ctx context.Context) error {
+		return s.Send(ctx, "verify_code", []string{"123456", "10"}, number)
+	}
+
+	assert.ErrorIs(t, send(context.Background()), vendorErr)
+	assert.ErrorIs(t, send(context.Background()), vendorErr)
+	assert.ErrorIs(t, send(context.Background()), ratelimit.ErrLimited)
+	// 比 ctx 的超时时间慢
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, send(ctx), context.DeadlineExceeded)
+	// 故障都用完了
+	assert.NoError(t, send(context.Background()))
+	assert.Len(t, s.Messages(number), 1)
+}
+
+func TestService_FailoverForTimeout(t *testing.T) {
+	slow, fast := newTestService(t), newTestService(t)
+	slow.Inject(Fault{Timeout: true})
+	svc := failover.NewServiceForTimeout([]sms.Service{slow, fast}, 2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	// 连续超时两次之后切换到下一个服务商
+	require.NoError(t, svc.Send(ctx, "verify_code", []string{"123456", "10"}, number))
+	_, ok := slow.Last(number)
+	assert.False(t, ok)
+	_, ok = fast.Last(number)
+	assert.True(t, ok)
+}
+
+func TestService_RateLimit(t *testing.T) {
+	s := newTestService(t)
+	s.Inject(Fault{Limited: true, Times: 1})
+	svc := ratelimit.NewService(s, s.Limiter())
+
+	err := svc.Send(context.Background(), "verify_code", []string{"123456", "10"}, number)
+	assert.ErrorIs(t, err, ratelimit.ErrLimited)
+	_, ok := s.Last(number)
+	assert.False(t, ok)
+	assert.NoError(t, svc.Send(context.Background(), "verify_code", []string{"123456", "10"}, number))
+}
@@ -0,0 +1,130 @@
+package template
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	ErrUnknownTemplate = errors.New("未知的短信模板")
+	ErrInvalidParams   = errors.New("短信模板参数不对")
+	// ErrVendorTemplateNotFound 这个服务商没有申请这个模板
+	ErrVendorTemplateNotFound = errors.New("服务商没有对应的短信模板")
+)
+
+// Template 业务方只认识 Name，每个服务商的模板 ID 和签名在 Vendors 里面
+type Template struct {
+	Name string `yaml:"name"`
+	// Content 模板内容，参数用 {1}、{2} 占位，和服务商那边申请的保持一致，预览用
+	Content string  `yaml:"content"`
+	Params  []Param `yaml:"params"`
+	// Vendors 服务商的名字到服务商那边的模板
+	Vendors map[string]VendorTemplate `yaml:"vendors"`
+}
+
+// Param 模板参数，顺序就是占位符的顺序
+type Param struct {
+	Name string `yaml:"name"`
+	// MaxLen 参数的最大长度（按字符算），0 就是不限制。服务商一般都有限制，比如验证码最多 6 位
+	MaxLen int `yaml:"maxLen"`
+}
+
+type VendorTemplate struct {
+	Id string `yaml:"id"`
+	// SignName 签名，为空的话用服务商默认的签名
+	SignName string `yaml:"signName"`
+}
+
+// Registry 短信模板注册中心，初始化之后只读，可以并发使用
+type Registry struct {
+	tpls map[string]Template
+}
+
+func NewRegistry(tpls ...Template) (*Registry, error) {
+	r := &Registry{tpls: make(map[string]Template, len(tpls))}
+	for _, tpl := range tpls {
+		if tpl.Name == "" {
+			return nil, errors.New("短信模板没有名字")
+		}
+		if _, ok := r.tpls[tpl.Name]; ok {
+			return nil, fmt.Errorf("短信模板 %s 重复了", tpl.Name)
+		}
+		// 占位符的数量要和参数对得上，不然预览出来的和真的发出去的不一样
+		for i := range tpl.Params {
+			if !strings.Contains(tpl.Content, placeholder(i)) {
+				return nil, fmt.Errorf("短信模板 %s 缺少占位符 %s", tpl.Name, placeholder(i))
+			}
+		}
+		if strings.Contains(tpl.Content, placeholder(len(tpl.Params))) {
+			return nil, fmt.Errorf("短信模板 %s 的占位符比参数多", tpl.Name)
+		}
+		r.tpls[tpl.Name] = tpl
+	}
+	return r, nil
+}
+
+func (r *Registry) Get(name string) (Template, error) {
+	tpl, ok := r.tpls[name]
+	if !ok {
+		return Template{}, fmt.Errorf("%w: %s", ErrUnknownTemplate, name)
+	}
+	return tpl, nil
+}
+
+// Validate 检查参数个数和长度
+func (r *Registry) Validate(name string, params []string) error {
+	tpl, err := r.Get(name)
+	if err != nil {
+		return err
+	}
+	if len(params) != len(tpl.Params) {
+		return fmt.Errorf("%w: %s 需要 %d 个参数，传了 %d 个", ErrInvalidParams, name, len(tpl.Params), len(params))
+	}
+	for i, p := range tpl.Params {
+		if p.MaxLen > 0 && utf8.RuneCountInString(params[i]) > p.MaxLen {
+			return fmt.Errorf("%w: %s 的参数 %s 超过了 %d 个字", ErrInvalidParams, name, p.Name, p.MaxLen)
+		}
+	}
+	return nil
+}
+
+// Resolve 找到服务商那边的模板 ID 和签名
+func (r *Registry) Resolve(name string, vendor string) (VendorTemplate, error) {
+	tpl, err := r.Get(name)
+	if err != nil {
+		return VendorTemplate{}, err
+	}
+	vt, ok := tpl.Vendors[vendor]
+	if !ok {
+		return VendorTemplate{}, fmt.Errorf("%w: %s 在 %s", ErrVendorTemplateNotFound, name, vendor)
+	}
+	return vt, nil
+}
+
+// Supports 服务商有没有这个模板，failover 用来跳过没有申请模板的服务商
+func (r *Registry) Supports(name string, vendor string) bool {
+	_, err := r.Resolve(name, vendor)
+	return err == nil
+}
+
+// Preview 渲染出短信内容，不带签名
+func (r *Registry) Preview(name string, params []string) (string, error) {
+	if err := r.Validate(name, params); err != nil {
+		return "", err
+	}
+	tpl, _ := r.Get(name)
+	// 一次替换完，参数里面本身带了 {2} 之类的也不会被后面的参数再替换一遍
+	oldnew := make([]string, 0, 2*len(params))
+	for i, p := range params {
+		oldnew = append(oldnew, placeholder(i), p)
+	}
+	return strings.NewReplacer(oldnew...).Replace(tpl.Content), nil
+}
+
+// placeholder 第 i 个参数的占位符，从 {1} 开始
+func placeholder(i int) string {
+	return "{" + strconv.Itoa(i+1) + "}"
+}
@@ -0,0 +1,87 @@
+package template
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	smsmocks "github.com/liupch66/basic-go/webook/internal/service/sms/mocks"
+)
+
+func verifyCodeTemplate() Template {
+	return Template{
+		Name:    "verify_code",
+		Content: "{1}为您的登录验证码，请于{2}分钟内填写。",
+		Params:  []Param{{Name: "code", MaxLen: 6}, {Name: "minutes"}},
+		Vendors: map[string]VendorTemplate{"tencent": {Id: "1977183", SignName: "webook"}},
+	}
+}
+
+func TestNewRegistry(t *testing.T) {
+	testCases := []struct {
+		name    string
+		tpl     Template
+		wantErr bool
+	}{
+		{name: "正常", tpl: verifyCodeTemplate()},
+		{
+			name: "缺少占位符",
+			tpl: Template{Name: "a", Content: "{1}为您的登录验证码",
+				Params: []Param{{Name: "code"}, {Name: "minutes"}}},
+			wantErr: true,
+		},
+		{
+			name:    "占位符比参数多",
+			tpl:     Template{Name: "a", Content: "{1}为您的登录验证码，请于{2}分钟内填写", Params: []Param{{Name: "code"}}},
+			wantErr: true,
+		},
+		{name: "没有名字", tpl: Template{Content: "你好"}, wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewRegistry(tc.tpl)
+			assert.Equal(t, tc.wantErr, err != nil)
+		})
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	r, err := NewRegistry(verifyCodeTemplate())
+	require.NoError(t, err)
+
+	vt, err := r.Resolve("verify_code", "tencent")
+	require.NoError(t, err)
+	assert.Equal(t, VendorTemplate{Id: "1977183", SignName: "webook"}, vt)
+	_, err = r.Resolve("verify_code", "aliyun")
+	assert.ErrorIs(t, err, ErrVendorTemplateNotFound)
+	_, err = r.Resolve("unknown", "tencent")
+	assert.ErrorIs(t, err, ErrUnknownTemplate)
+
+	content, err := r.Preview("verify_code", []string{"123456", "10"})
+	require.NoError(t, err)
+	assert.Equal(t, "123456为您的登录验证码，请于10分钟内填写。", content)
+	// 参数里面带了占位符，不会被后面的参数再替换
+	content, err = r.Preview("verify_code", []string{"{2}", "10"})
+	require.NoError(t, err)
+	assert.Equal(t, "{2}为您的登录验证码，请于10分钟内填写。", content)
+
+	assert.ErrorIs(t, r.Validate("verify_code", []string{"123456"}), ErrInvalidParams)
+	assert.ErrorIs(t, r.Validate("verify_code", []string{"1234567", "10"}), ErrInvalidParams)
+}
+
+func TestService_Send(t *testing.T) {
+	r, err := NewRegistry(verifyCodeTemplate())
+	require.NoError(t, err)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc := smsmocks.NewMockService(ctrl)
+	svc.EXPECT().Send(gomock.Any(), "verify_code", []string{"123456", "10"}, "13800000000").Return(nil)
+	s := NewService(svc, r)
+
+	assert.NoError(t, s.Send(context.Background(), "verify_code", []string{"123456", "10"}, "13800000000"))
+	// 参数不对的不会调用服务商
+	assert.ErrorIs(t, s.Send(context.Background(), "verify_code", []string{"123456"}, "13800000000"), ErrInvalidParams)
+}
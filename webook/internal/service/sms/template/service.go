@@ -0,0 +1,25 @@
+package template
+
+import (
+	"context"
+
+	"github.com/liupch66/basic-go/webook/internal/service/sms"
+)
+
+// Service 校验模板和参数的装饰器，放在最外面，参数不对的请求不会转异步，也不会调用任何服务商
+type Service struct {
+	svc      sms.Service
+	registry *Registry
+}
+
+func NewService(svc sms.Service, registry *Registry) *Service {
+	return &Service{svc: svc, registry: registry}
+}
+
+// Send tplId 是模板的名字，不是服务商的模板 ID
+func (s *Service) Send(ctx context.Context, tplId string, params []string, numbers ...string) error {
+	if err := s.registry.Validate(tplId, params); err != nil {
+		return err
+	}
+	return s.svc.Send(ctx, tplId, params, numbers...)
+}
@@ -2,27 +2,48 @@ package tencent
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"time"
 
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
 	sms "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/sms/v20210111"
 	"go.uber.org/zap"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/service/sms/receipt"
+	"github.com/liupch66/basic-go/webook/internal/service/sms/template"
 )
 
+// Vendor 模板注册中心里面腾讯云的名字
+const Vendor = "tencent"
+
 type Service struct {
 	client   *sms.Client
 	appId    *string
 	signName *string
+	registry *template.Registry
+	tracker  receipt.Tracker
 	// summaryVec *prometheus.SummaryVec // 接入记得初始化
 }
 
-func NewService(appId string, signName string, client *sms.Client) *Service {
+// NewService signName 是默认的签名，模板里面配置了签名的话用模板的
+func NewService(appId string, signName string, client *sms.Client, registry *template.Registry,
+	tracker receipt.Tracker) *Service {
 	return &Service{
 		appId:    &appId,
 		signName: &signName,
 		client:   client,
+		registry: registry,
+		tracker:  tracker,
 	}
 }
 
+// Supports 在腾讯云申请了的模板才能发
+func (s *Service) Supports(tplId string) bool {
+	return s.registry.Supports(tplId, Vendor)
+}
+
 func (s *Service) toStringPtrSlice(src []string) []*string {
 	res := make([]*string, 0, len(src))
 	for _, val := range src {
@@ -31,12 +52,20 @@ func (s *Service) toStringPtrSlice(src []string) []*string {
 	return res
 }
 
+// Send tplId 是模板的名字，这里换成腾讯云的模板 ID
 func (s *Service) Send(ctx context.Context, tplId string, params []string, numbers ...string) error {
+	vt, err := s.registry.Resolve(tplId, Vendor)
+	if err != nil {
+		return err
+	}
 	req := sms.NewSendSmsRequest()
 	req.SetContext(ctx)
 	req.SmsSdkAppId = s.appId
 	req.SignName = s.signName
-	req.TemplateId = &tplId
+	if vt.SignName != "" {
+		req.SignName = &vt.SignName
+	}
+	req.TemplateId = &vt.Id
 	req.TemplateParamSet = s.toStringPtrSlice(params)
 	req.PhoneNumberSet = s.toStringPtrSlice(numbers)
 	resp, err := s.client.SendSms(req)
@@ -44,17 +73,114 @@ func (s *Service) Send(ctx context.Context, tplId string, params []string, numbe
 	if err != nil {
 		return err
 	}
+	// 每个手机号都记下来，有一个失败了就返回错误
+	msgs := make([]domain.SmsMessage, 0, len(resp.Response.SendStatusSet))
+	var sendErr error
 	for _, status := range resp.Response.SendStatusSet {
 		// 这里想监控短信码只能侵入式监控了
 		// code, _ := strconv.Atoi(*status.Code)
 		// s.summaryVec.WithLabelValues().Observe(float64(code))
 		//  空指针解引用会 panic
 		if status == nil {
-			return fmt.Errorf("短信发送失败")
+			sendErr = fmt.Errorf("短信发送失败")
+			continue
+		}
+		msg := domain.SmsMessage{
+			Vendor: Vendor,
+			MsgId:  stringValue(status.SerialNo),
+			TplId:  tplId,
+			Number: stringValue(status.PhoneNumber),
 		}
-		if *status.Code != "Ok" {
-			return fmt.Errorf("短信发送失败，错误码：%s，原因：%s", *status.Code, *status.Message)
+		if code := stringValue(status.Code); code != "Ok" {
+			msg.Status = domain.SmsStatusFailed
+			msg.Reason = fmt.Sprintf("%s: %s", code, stringValue(status.Message))
+			sendErr = fmt.Errorf("短信发送失败，错误码：%s，原因：%s", code, stringValue(status.Message))
+		}
+		msgs = append(msgs, msg)
+	}
+	s.tracker.Track(ctx, msgs...)
+	return sendErr
+}
+
+func (s *Service) DeliveryRate() (float64, bool) {
+	return s.tracker.DeliveryRate(Vendor)
+}
+
+// callbackReceipt 腾讯云短信的状态回调，一次推过来的是一个数组
+type callbackReceipt struct {
+	UserReceiveTime string `json:"user_receive_time"`
+	NationCode      string `json:"nationcode"`
+	Mobile          string `json:"mobile"`
+	// ReportStatus SUCCESS 或者 FAIL
+	ReportStatus string `json:"report_status"`
+	ErrMsg       string `json:"errmsg"`
+	Description  string `json:"description"`
+	Sid          string `json:"sid"`
+}
+
+func (s *Service) ParseCallback(body []byte) ([]domain.SmsReceipt, error) {
+	var crs []callbackReceipt
+	if err := json.Unmarshal(body, &crs); err != nil {
+		return nil, err
+	}
+	res := make([]domain.SmsReceipt, 0, len(crs))
+	for _, cr := range crs {
+		r := domain.SmsReceipt{
+			Vendor: Vendor,
+			MsgId:  cr.Sid,
+			Number: "+" + cr.NationCode + cr.Mobile,
+			Status: domain.SmsStatusDelivered,
 		}
+		if cr.ReportStatus != "SUCCESS" {
+			r.Status = domain.SmsStatusFailed
+			r.Reason = fmt.Sprintf("%s: %s", cr.ErrMsg, cr.Description)
+		}
+		// 回调里面的时间是北京时间
+		if t, err := time.ParseInLocation(time.DateTime, cr.UserReceiveTime, time.Local); err == nil {
+			r.DeliverTime = t
+		}
+		res = append(res, r)
+	}
+	return res, nil
+}
+
+// PullReceipts 拉取的回执腾讯云那边不会再给了，一次最多拉 100 条
+func (s *Service) PullReceipts(ctx context.Context) ([]domain.SmsReceipt, error) {
+	req := sms.NewPullSmsSendStatusRequest()
+	req.SetContext(ctx)
+	req.SmsSdkAppId = s.appId
+	req.Limit = common.Uint64Ptr(100)
+	resp, err := s.client.PullSmsSendStatus(req)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.SmsReceipt, 0, len(resp.Response.PullSmsSendStatusSet))
+	for _, status := range resp.Response.PullSmsSendStatusSet {
+		if status == nil {
+			continue
+		}
+		r := domain.SmsReceipt{
+			Vendor: Vendor,
+			MsgId:  stringValue(status.SerialNo),
+			Number: stringValue(status.PhoneNumber),
+			Status: domain.SmsStatusDelivered,
+		}
+		if stringValue(status.ReportStatus) != "SUCCESS" {
+			r.Status = domain.SmsStatusFailed
+			r.Reason = stringValue(status.Description)
+		}
+		if status.UserReceiveTime != nil {
+			r.DeliverTime = time.Unix(int64(*status.UserReceiveTime), 0)
+		}
+		res = append(res, r)
+	}
+	return res, nil
+}
+
+// stringValue 腾讯云返回的字段都是指针，nil 的当成空字符串
+func stringValue(ptr *string) string {
+	if ptr == nil {
+		return ""
 	}
-	return nil
+	return *ptr
 }
@@ -4,11 +4,20 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
 	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/profile"
 	sms "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/sms/v20210111"
+	"go.uber.org/mock/gomock"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	repomocks "github.com/liupch66/basic-go/webook/internal/repository/mocks"
+	"github.com/liupch66/basic-go/webook/internal/service/sms/receipt"
+	"github.com/liupch66/basic-go/webook/internal/service/sms/template"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
 )
 
 func TestService_Send(t *testing.T) {
@@ -33,7 +42,19 @@ func TestService_Send(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	svc := NewService("1400859261", "webook公众号", client)
+	registry, err := template.NewRegistry(template.Template{
+		Name:    "verify_code",
+		Content: "{1}为您的登录验证码，请于{2}分钟内填写，如非本人操作，请忽略本短信。",
+		Params:  []template.Param{{Name: "code"}, {Name: "minutes"}},
+		Vendors: map[string]template.VendorTemplate{Vendor: {Id: "1977183"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := repomocks.NewMockSmsMessageRepository(gomock.NewController(t))
+	repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	tracker := receipt.NewService(repo, receipt.DefaultConfig(), nil, logger.NewNopLogger())
+	svc := NewService("1400859261", "webook公众号", client, registry, tracker)
 
 	testCases := []struct {
 		name    string
@@ -44,16 +65,15 @@ func TestService_Send(t *testing.T) {
 		expectedErr error
 	}{
 		{
-			name: "发送验证码成功",
-			// {1}为您的登录验证码，请于{2}分钟内填写，如非本人操作，请忽略本短信。
-			tplId:       "1977183",
+			name:        "发送验证码成功",
+			tplId:       "verify_code",
 			params:      []string{"888", "5"},
 			numbers:     []string{number},
 			expectedErr: nil,
 		},
 		{
 			name:        "发送验证码失败",
-			tplId:       "1977183",
+			tplId:       "verify_code",
 			params:      []string{"888", "5"},
 			numbers:     []string{"10086"},
 			expectedErr: nil,
@@ -64,3 +84,21 @@ func TestService_Send(t *testing.T) {
 		assert.Equal(t, tc.expectedErr, err)
 	}
 }
+
+func TestService_ParseCallback(t *testing.T) {
+	body := `[{"user_receive_time":"2024-01-01 08:03:04","nationcode":"86","mobile":"13800000000",
+"report_status":"SUCCESS","errmsg":"DELIVRD","description":"用户短信送达成功","sid":"2019:123"},
+{"user_receive_time":"","nationcode":"86","mobile":"13800000001",
+"report_status":"FAIL","errmsg":"MK:0001","description":"空号","sid":"2019:124"}]`
+	receipts, err := (&Service{}).ParseCallback([]byte(body))
+	require.NoError(t, err)
+	assert.Equal(t, []domain.SmsReceipt{
+		{Vendor: Vendor, MsgId: "2019:123", Number: "+8613800000000", Status: domain.SmsStatusDelivered,
+			DeliverTime: time.Date(2024, 1, 1, 8, 3, 4, 0, time.Local)},
+		{Vendor: Vendor, MsgId: "2019:124", Number: "+8613800000001", Status: domain.SmsStatusFailed,
+			Reason: "MK:0001: 空号"},
+	}, receipts)
+
+	_, err = (&Service{}).ParseCallback([]byte("{"))
+	assert.Error(t, err)
+}
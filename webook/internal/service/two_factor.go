@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/repository"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
+	"github.com/liupch66/basic-go/webook/pkg/totp"
+)
+
+var (
+	ErrTotpAlreadyEnabled      = errors.New("已经启用了两步验证")
+	ErrTotpNotEnrolled         = errors.New("还没有绑定验证器")
+	ErrInvalidTotpCode         = errors.New("两步验证的验证码错误")
+	ErrTwoFactorExpired        = repository.ErrTwoFactorExpired
+	ErrTwoFactorAttemptTooMany = repository.ErrTwoFactorAttemptTooMany
+)
+
+const (
+	totpIssuer        = "webook"
+	recoveryCodeCnt   = 10
+	recoveryCodeBytes = 10
+)
+
+//go:generate mockgen -package=svcmocks -source=two_factor.go -destination=mocks/two_factor_mock.go TwoFactorService
+type TwoFactorService interface {
+	// Enroll 生成新的密钥，account 展示在验证器里面。要 Activate 之后才生效
+	Enroll(ctx context.Context, uid int64, account string) (domain.TotpEnrollment, error)
+	// Activate 输入验证器上的验证码确认绑定，返回一次性的恢复码，只展示这一次
+	Activate(ctx context.Context, uid int64, code string) ([]string, error)
+	// Disable code 可以是验证器上的验证码，也可以是恢复码
+	Disable(ctx context.Context, uid int64, code string) error
+	Enabled(ctx context.Context, uid int64) (bool, error)
+	// BeginLogin 密码校验通过之后调用，返回一个短期有效的 token，凭它和验证码完成登录
+	BeginLogin(ctx context.Context, uid int64) (string, error)
+	// CompleteLogin 校验通过返回用户 id。尝试次数有限制
+	CompleteLogin(ctx context.Context, token, code string) (int64, error)
+}
+
+type twoFactorService struct {
+	repo repository.TwoFactorRepository
+	l    logger.LoggerV1
+}
+
+func NewTwoFactorService(repo repository.TwoFactorRepository, l logger.LoggerV1) TwoFactorService {
+	return &twoFactorService{repo: repo, l: l}
+}
+
+func (svc *twoFactorService) Enroll(ctx context.Context, uid int64, account string) (domain.TotpEnrollment, error) {
+	t, err := svc.repo.FindTotp(ctx, uid)
+	switch {
+	case err == nil && t.Enabled:
+		return domain.TotpEnrollment{}, ErrTotpAlreadyEnabled
+	case err != nil && !errors.Is(err, repository.ErrTotpNotFound):
+		return domain.TotpEnrollment{}, err
+	}
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return domain.TotpEnrollment{}, err
+	}
+	if err = svc.repo.SavePendingTotp(ctx, uid, secret); err != nil {
+		return domain.TotpEnrollment{}, err
+	}
+	return domain.TotpEnrollment{Secret: secret, URI: totp.URI(totpIssuer, account, secret)}, nil
+}
+
+func (svc *twoFactorService) Activate(ctx context.Context, uid int64, code string) ([]string, error) {
+	t, err := svc.repo.FindTotp(ctx, uid)
+	if errors.Is(err, repository.ErrTotpNotFound) {
+		return nil, ErrTotpNotEnrolled
+	}
+	if err != nil {
+		return nil, err
+	}
+	if t.Enabled {
+		return nil, ErrTotpAlreadyEnabled
+	}
+	ok, err := svc.verifyTotp(ctx, t, code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidTotpCode
+	}
+	codes := make([]string, 0, recoveryCodeCnt)
+	hashes := make([]string, 0, recoveryCodeCnt)
+	for i := 0; i < recoveryCodeCnt; i++ {
+		c, err := svc.newRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, c)
+		hashes = append(hashes, svc.hashRecoveryCode(c))
+	}
+	err = svc.repo.EnableTotp(ctx, uid, hashes)
+	if errors.Is(err, repository.ErrTotpNotPending) {
+		// 并发确认，另外一个请求已经启用了
+		return nil, ErrTotpAlreadyEnabled
+	}
+	if err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func (svc *twoFactorService) Disable(ctx context.Context, uid int64, code string) error {
+	t, err := svc.repo.FindTotp(ctx, uid)
+	if errors.Is(err, repository.ErrTotpNotFound) {
+		return ErrTotpNotEnrolled
+	}
+	if err != nil {
+		return err
+	}
+	if t.Enabled {
+		ok, err := svc.verify(ctx, t, code)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrInvalidTotpCode
+		}
+	}
+	return svc.repo.DeleteTotp(ctx, uid)
+}
+
+func (svc *twoFactorService) Enabled(ctx context.Context, uid int64) (bool, error) {
+	t, err := svc.repo.FindTotp(ctx, uid)
+	if errors.Is(err, repository.ErrTotpNotFound) {
+		return false, nil
+	}
+	return t.Enabled, err
+}
+
+func (svc *twoFactorService) BeginLogin(ctx context.Context, uid int64) (string, error) {
+	token := uuid.New().String()
+	return token, svc.repo.SetPending(ctx, token, uid)
+}
+
+func (svc *twoFactorService) CompleteLogin(ctx context.Context, token, code string) (int64, error) {
+	uid, err := svc.repo.Attempt(ctx, token)
+	if err != nil {
+		return 0, err
+	}
+	t, err := svc.repo.FindTotp(ctx, uid)
+	if err != nil {
+		return 0, err
+	}
+	ok, err := svc.verify(ctx, t, code)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, ErrInvalidTotpCode
+	}
+	if err = svc.repo.DelPending(ctx, token); err != nil {
+		// 不影响这次登录，剩下的尝试次数用完或者过期也就失效了
+		svc.l.Error("删除两步验证 token 失败", logger.Error(err), logger.Int64("uid", uid))
+	}
+	return uid, nil
+}
+
+// verify 验证器上的验证码或者恢复码都可以
+func (svc *twoFactorService) verify(ctx context.Context, t domain.Totp, code string) (bool, error) {
+	if len(code) == totp.Digits {
+		return svc.verifyTotp(ctx, t, code)
+	}
+	return svc.repo.UseRecoveryCode(ctx, t.Uid, svc.hashRecoveryCode(code))
+}
+
+func (svc *twoFactorService) verifyTotp(ctx context.Context, t domain.Totp, code string) (bool, error) {
+	step, ok := totp.Validate(t.Secret, code, time.Now(), 1)
+	if !ok {
+		return false, nil
+	}
+	// 防重放，同一个验证码只能用一次
+	return svc.repo.MarkTotpUsed(ctx, t.Uid, step)
+}
+
+// newRecoveryCode 形如 abcde-fghij
+func (svc *twoFactorService) newRecoveryCode() (string, error) {
+	buf := make([]byte, recoveryCodeBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	s := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(buf))[:10]
+	return s[:5] + "-" + s[5:], nil
+}
+
+// hashRecoveryCode 恢复码的熵足够高，不需要 bcrypt 这种慢哈希
+func (svc *twoFactorService) hashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/repository"
+	repomocks "github.com/liupch66/basic-go/webook/internal/repository/mocks"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
+	"github.com/liupch66/basic-go/webook/pkg/totp"
+)
+
+func Test_twoFactorService_CompleteLogin(t *testing.T) {
+	secret, err := totp.GenerateSecret()
+	assert.NoError(t, err)
+	code, err := totp.CodeAt(secret, totp.Step(time.Now()))
+	assert.NoError(t, err)
+	enabled := domain.Totp{Uid: 123, Secret: secret, Enabled: true}
+
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) repository.TwoFactorRepository
+		code string
+
+		expectedUid int64
+		expectedErr error
+	}{
+		{
+			name: "验证器验证码正确",
+			mock: func(ctrl *gomock.Controller) repository.TwoFactorRepository {
+				repo := repomocks.NewMockTwoFactorRepository(ctrl)
+				repo.EXPECT().Attempt(gomock.Any(), "token").Return(int64(123), nil)
+				repo.EXPECT().FindTotp(gomock.Any(), int64(123)).Return(enabled, nil)
+				repo.EXPECT().MarkTotpUsed(gomock.Any(), int64(123), gomock.Any()).Return(true, nil)
+				repo.EXPECT().DelPending(gomock.Any(), "token").Return(nil)
+				return repo
+			},
+			code:        code,
+			expectedUid: 123,
+		},
+		{
+			name: "验证码已经用过了",
+			mock: func(ctrl *gomock.Controller) repository.TwoFactorRepository {
+				repo := repomocks.NewMockTwoFactorRepository(ctrl)
+				repo.EXPECT().Attempt(gomock.Any(), "token").Return(int64(123), nil)
+				repo.EXPECT().FindTotp(gomock.Any(), int64(123)).Return(enabled, nil)
+				repo.EXPECT().MarkTotpUsed(gomock.Any(), int64(123), gomock.Any()).Return(false, nil)
+				return repo
+			},
+			code:        code,
+			expectedErr: ErrInvalidTotpCode,
+		},
+		{
+			name: "恢复码",
+			mock: func(ctrl *gomock.Controller) repository.TwoFactorRepository {
+				repo := repomocks.NewMockTwoFactorRepository(ctrl)
+				repo.EXPECT().Attempt(gomock.Any(), "token").Return(int64(123), nil)
+				repo.EXPECT().FindTotp(gomock.Any(), int64(123)).Return(enabled, nil)
+				// 大小写和横线不影响
+				repo.EXPECT().UseRecoveryCode(gomock.Any(), int64(123),
+					(&twoFactorService{}).hashRecoveryCode("abcdefghij")).Return(true, nil)
+				repo.EXPECT().DelPending(gomock.Any(), "token").Return(nil)
+				return repo
+			},
+			code:        "ABCDE-FGHIJ",
+			expectedUid: 123,
+		},
+		{
+			name: "尝试次数用完了",
+			mock: func(ctrl *gomock.Controller) repository.TwoFactorRepository {
+				repo := repomocks.NewMockTwoFactorRepository(ctrl)
+				repo.EXPECT().Attempt(gomock.Any(), "token").Return(int64(0), ErrTwoFactorAttemptTooMany)
+				return repo
+			},
+			code:        code,
+			expectedErr: ErrTwoFactorAttemptTooMany,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewTwoFactorService(tc.mock(ctrl), logger.NewNopLogger())
+			uid, err := svc.CompleteLogin(context.Background(), "token", tc.code)
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedUid, uid)
+		})
+	}
+}
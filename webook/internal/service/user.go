@@ -25,7 +25,13 @@ type UserService interface {
 	UpdateNonSensitiveInfo(ctx context.Context, user domain.User) error
 	Profile(ctx context.Context, id int64) (domain.User, error)
 	FindOrCreateByPhone(ctx context.Context, phone string) (domain.User, error)
-	FindOrCreateByWechat(ctx context.Context, wechatInfo domain.WechatInfo) (domain.User, error)
+	// FindOrCreateByIdentity 第三方账号登录，第一次登录的时候自动注册
+	FindOrCreateByIdentity(ctx context.Context, identity domain.Identity) (domain.User, error)
+	FindByEmail(ctx context.Context, email string) (domain.User, error)
+	// VerifyEmail 邮箱验证码校验通过之后调用
+	VerifyEmail(ctx context.Context, email string) error
+	// ResetPassword 忘记密码，邮箱验证码校验通过之后设置新密码
+	ResetPassword(ctx context.Context, email, password string) (domain.User, error)
 }
 
 type userService struct {
@@ -113,14 +119,43 @@ func (svc *userService) FindOrCreateByPhone(ctx context.Context, phone string) (
 	return svc.repo.FindByPhone(ctx, phone)
 }
 
-func (svc *userService) FindOrCreateByWechat(ctx context.Context, wechatInfo domain.WechatInfo) (domain.User, error) {
-	u, err := svc.repo.FindByWechat(ctx, wechatInfo.OpenId)
+func (svc *userService) FindOrCreateByIdentity(ctx context.Context, identity domain.Identity) (domain.User, error) {
+	u, err := svc.repo.FindByIdentity(ctx, identity.Provider, identity.Subject)
 	if !errors.Is(err, ErrUserNotFound) {
 		return u, err
 	}
-	err = svc.repo.Create(ctx, domain.User{WechatInfo: wechatInfo})
+	svc.l.Info("第三方账号未注册,注册新用户", logger.String("provider", identity.Provider))
+	err = svc.repo.CreateWithIdentity(ctx, domain.User{Nickname: identity.Name}, identity)
+	// 并发登录的时候另一个请求已经创建好了
 	if err != nil && !errors.Is(err, ErrUserDuplicate) {
 		return domain.User{}, err
 	}
-	return svc.repo.FindByWechat(ctx, wechatInfo.OpenId)
+	return svc.repo.FindByIdentity(ctx, identity.Provider, identity.Subject)
+}
+
+func (svc *userService) FindByEmail(ctx context.Context, email string) (domain.User, error) {
+	return svc.repo.FindByEmail(ctx, email)
+}
+
+func (svc *userService) VerifyEmail(ctx context.Context, email string) error {
+	u, err := svc.repo.FindByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+	if u.EmailVerified {
+		return nil
+	}
+	return svc.repo.SetEmailVerified(ctx, u.Id)
+}
+
+func (svc *userService) ResetPassword(ctx context.Context, email, password string) (domain.User, error) {
+	u, err := svc.repo.FindByEmail(ctx, email)
+	if err != nil {
+		return domain.User{}, err
+	}
+	encrypted, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return domain.User{}, err
+	}
+	return u, svc.repo.UpdatePassword(ctx, u.Id, string(encrypted))
 }
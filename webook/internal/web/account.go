@@ -0,0 +1,176 @@
+package web
+
+import (
+	"errors"
+
+	regexp "github.com/dlclark/regexp2"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/service"
+	ijwt "github.com/liupch66/basic-go/webook/internal/web/jwt"
+	"github.com/liupch66/basic-go/webook/pkg/ginx"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
+)
+
+var _ handler = (*AccountHandler)(nil)
+
+// bindPhoneBiz 绑定手机号的短信验证码，和登录的分开
+const bindPhoneBiz = "bind_phone"
+
+// AccountHandler 登录之后绑定、解绑手机号、邮箱和第三方账号。
+// 第三方账号的绑定要走授权流程，在 OAuth2Handler 里面
+type AccountHandler struct {
+	emailRegexp  *regexp.Regexp
+	phoneRegexp  *regexp.Regexp
+	svc          service.AccountService
+	userSvc      service.UserService
+	codeSvc      service.CodeService
+	emailCodeSvc service.EmailCodeService
+	l            logger.LoggerV1
+}
+
+func NewAccountHandler(svc service.AccountService, userSvc service.UserService, codeSvc service.CodeService,
+	emailCodeSvc service.EmailCodeService, l logger.LoggerV1) *AccountHandler {
+	return &AccountHandler{
+		emailRegexp:  regexp.MustCompile(emailRegexPattern, regexp.None),
+		phoneRegexp:  regexp.MustCompile(phoneRegexPattern, regexp.None),
+		svc:          svc,
+		userSvc:      userSvc,
+		codeSvc:      codeSvc,
+		emailCodeSvc: emailCodeSvc,
+		l:            l,
+	}
+}
+
+func (h *AccountHandler) RegisterRoutes(server *gin.Engine) {
+	ug := server.Group("/users")
+	{
+		ug.GET("/bindings", ginx.WrapClaims[ijwt.UserClaims](h.Bindings))
+		ug.POST("/bind/phone/code/send", ginx.WrapReqAndClaims[BindPhoneCodeReq, ijwt.UserClaims](h.SendPhoneCode))
+		ug.POST("/bind/phone", ginx.WrapReqAndClaims[BindPhoneReq, ijwt.UserClaims](h.BindPhone))
+		ug.POST("/unbind/phone", ginx.WrapClaims[ijwt.UserClaims](h.UnbindPhone))
+		ug.POST("/bind/email/code/send", ginx.WrapReqAndClaims[EmailCodeSendReq, ijwt.UserClaims](h.SendEmailCode))
+		ug.POST("/bind/email", ginx.WrapReqAndClaims[EmailVerifyReq, ijwt.UserClaims](h.BindEmail))
+		ug.POST("/unbind/email", ginx.WrapClaims[ijwt.UserClaims](h.UnbindEmail))
+		ug.POST("/unbind/identity", ginx.WrapReqAndClaims[UnbindIdentityReq, ijwt.UserClaims](h.UnbindIdentity))
+	}
+}
+
+func (h *AccountHandler) Bindings(ctx *gin.Context, uc ijwt.UserClaims) (Result, error) {
+	u, err := h.userSvc.Profile(ctx, uc.UserId)
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Data: BindingsVO{
+		Email: u.Email,
+		Phone: u.Phone,
+		Identities: slice.Map(u.Identities, func(idx int, src domain.Identity) IdentityVO {
+			return IdentityVO{Provider: src.Provider, Name: src.Name, Email: src.Email}
+		}),
+	}}, nil
+}
+
+func (h *AccountHandler) SendPhoneCode(ctx *gin.Context, req BindPhoneCodeReq, uc ijwt.UserClaims) (Result, error) {
+	ok, err := h.phoneRegexp.MatchString(req.Phone)
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	if !ok {
+		return Result{Code: 4, Msg: "请输入正确的手机号码"}, nil
+	}
+	err = h.codeSvc.Send(ctx, bindPhoneBiz, req.Phone, codeSendMeta(ctx, req.CaptchaToken))
+	if errors.Is(err, service.ErrCodeSendTooMany) {
+		return Result{Code: 4, Msg: "发送验证码太频繁"}, nil
+	}
+	if res, ok := codeDeniedResult(err); ok {
+		return res, nil
+	}
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Msg: "发送成功"}, nil
+}
+
+func (h *AccountHandler) BindPhone(ctx *gin.Context, req BindPhoneReq, uc ijwt.UserClaims) (Result, error) {
+	ok, err := h.codeSvc.Verify(ctx, bindPhoneBiz, req.Phone, req.Code)
+	if errors.Is(err, service.ErrCodeVerifyExpired) {
+		return Result{Code: 4, Msg: "验证码已过期"}, nil
+	}
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	if !ok {
+		return Result{Code: 4, Msg: "验证码错误"}, nil
+	}
+	return h.bindResult(h.svc.BindPhone(ctx, uc.UserId, req.Phone))
+}
+
+func (h *AccountHandler) UnbindPhone(ctx *gin.Context, uc ijwt.UserClaims) (Result, error) {
+	return h.unbindResult(h.svc.UnbindPhone(ctx, uc.UserId))
+}
+
+func (h *AccountHandler) SendEmailCode(ctx *gin.Context, req EmailCodeSendReq, uc ijwt.UserClaims) (Result, error) {
+	ok, err := h.emailRegexp.MatchString(req.Email)
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	if !ok {
+		return Result{Code: 4, Msg: "邮箱格式错误"}, nil
+	}
+	err = h.emailCodeSvc.Send(ctx, service.EmailBizBind, req.Email)
+	if errors.Is(err, service.ErrCodeSendTooMany) {
+		return Result{Code: 4, Msg: "发送太频繁，请稍后再试"}, nil
+	}
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Msg: "发送成功"}, nil
+}
+
+func (h *AccountHandler) BindEmail(ctx *gin.Context, req EmailVerifyReq, uc ijwt.UserClaims) (Result, error) {
+	ok, err := h.emailCodeSvc.Verify(ctx, service.EmailBizBind, req.Email, req.Code)
+	if errors.Is(err, service.ErrCodeVerifyExpired) {
+		return Result{Code: 4, Msg: "验证码已过期"}, nil
+	}
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	if !ok {
+		return Result{Code: 4, Msg: "验证码错误"}, nil
+	}
+	return h.bindResult(h.svc.BindEmail(ctx, uc.UserId, req.Email))
+}
+
+func (h *AccountHandler) UnbindEmail(ctx *gin.Context, uc ijwt.UserClaims) (Result, error) {
+	return h.unbindResult(h.svc.UnbindEmail(ctx, uc.UserId))
+}
+
+func (h *AccountHandler) UnbindIdentity(ctx *gin.Context, req UnbindIdentityReq, uc ijwt.UserClaims) (Result, error) {
+	return h.unbindResult(h.svc.UnbindIdentity(ctx, uc.UserId, req.Provider))
+}
+
+func (h *AccountHandler) bindResult(err error) (Result, error) {
+	switch {
+	case err == nil:
+		return Result{Msg: "绑定成功"}, nil
+	case errors.Is(err, service.ErrAlreadyBound):
+		return Result{Code: 4, Msg: "已经被其他账号绑定了"}, nil
+	default:
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+}
+
+func (h *AccountHandler) unbindResult(err error) (Result, error) {
+	switch {
+	case err == nil:
+		return Result{Msg: "解绑成功"}, nil
+	case errors.Is(err, service.ErrNotBound):
+		return Result{Code: 4, Msg: "没有绑定"}, nil
+	case errors.Is(err, service.ErrLastLoginMethod):
+		return Result{Code: 4, Msg: "这是最后一种登录方式，不能解绑"}, nil
+	default:
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+}
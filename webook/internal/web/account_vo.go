@@ -0,0 +1,29 @@
+package web
+
+type BindPhoneCodeReq struct {
+	Phone string `json:"phone"`
+	// CaptchaToken 被要求图形验证码之后才需要
+	CaptchaToken string `json:"captcha_token"`
+}
+
+type BindPhoneReq struct {
+	Phone string `json:"phone"`
+	Code  string `json:"code"`
+}
+
+type UnbindIdentityReq struct {
+	Provider string `json:"provider"`
+}
+
+type IdentityVO struct {
+	Provider string `json:"provider"`
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+}
+
+// BindingsVO 当前账号绑定的登录方式
+type BindingsVO struct {
+	Email      string       `json:"email"`
+	Phone      string       `json:"phone"`
+	Identities []IdentityVO `json:"identities"`
+}
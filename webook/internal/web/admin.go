@@ -0,0 +1,163 @@
+package web
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/repository"
+	"github.com/liupch66/basic-go/webook/internal/repository/article"
+	"github.com/liupch66/basic-go/webook/internal/service"
+	ijwt "github.com/liupch66/basic-go/webook/internal/web/jwt"
+	"github.com/liupch66/basic-go/webook/internal/web/middleware"
+	"github.com/liupch66/basic-go/webook/pkg/ginx"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
+)
+
+var _ handler = (*AdminHandler)(nil)
+
+// AdminHandler /admin 下面的接口，每个接口要的权限不一样，在路由上面单独检查
+type AdminHandler struct {
+	svc    service.AdminService
+	jwtHdl ijwt.Handler
+	l      logger.LoggerV1
+}
+
+func NewAdminHandler(svc service.AdminService, jwtHdl ijwt.Handler, l logger.LoggerV1) *AdminHandler {
+	return &AdminHandler{svc: svc, jwtHdl: jwtHdl, l: l}
+}
+
+func (h *AdminHandler) RegisterRoutes(server *gin.Engine) {
+	ag := server.Group("/admin")
+	{
+		ug := ag.Group("/users")
+		ug.POST("/ban", middleware.RequirePermission(domain.PermUserBan),
+			ginx.WrapReqAndClaims[BanUserReq, ijwt.UserClaims](h.Ban))
+		ug.POST("/unban", middleware.RequirePermission(domain.PermUserBan),
+			ginx.WrapReqAndClaims[UnbanUserReq, ijwt.UserClaims](h.Unban))
+		ug.POST("/merge", middleware.RequirePermission(domain.PermUserMerge),
+			ginx.WrapReqAndClaims[MergeUserReq, ijwt.UserClaims](h.Merge))
+		ug.POST("/roles/grant", middleware.RequirePermission(domain.PermUserRole),
+			ginx.WrapReqAndClaims[UserRoleReq, ijwt.UserClaims](h.GrantRole))
+		ug.POST("/roles/revoke", middleware.RequirePermission(domain.PermUserRole),
+			ginx.WrapReqAndClaims[UserRoleReq, ijwt.UserClaims](h.RevokeRole))
+
+		ag.POST("/articles/withdraw", middleware.RequirePermission(domain.PermArticleWithdraw),
+			ginx.WrapReqAndClaims[AdminWithdrawReq, ijwt.UserClaims](h.WithdrawArticle))
+		ag.POST("/audits", middleware.RequirePermission(domain.PermAuditRead),
+			ginx.WrapReq[AuditLogListReq](h.ListAuditLogs))
+	}
+}
+
+func (h *AdminHandler) Ban(ctx *gin.Context, req BanUserReq, uc ijwt.UserClaims) (Result, error) {
+	err := h.svc.BanUser(ctx, uc.UserId, req.Uid, req.Reason)
+	switch {
+	case errors.Is(err, service.ErrBanSelf):
+		return Result{Code: 4, Msg: "不能封禁自己"}, nil
+	case errors.Is(err, service.ErrBanPrivileged):
+		return Result{Code: 4, Msg: "不能封禁权限不低于自己的用户"}, nil
+	case errors.Is(err, repository.ErrUserNotFound):
+		return Result{Code: 4, Msg: "用户不存在"}, nil
+	case err != nil:
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	// 已经登录的会话全部踢掉，access_token 就马上失效了
+	if err = h.jwtHdl.RevokeOtherSessions(ctx, req.Uid, ""); err != nil {
+		h.l.Error("踢掉被封禁用户的会话失败", logger.Int64("uid", req.Uid), logger.Error(err))
+		return Result{Code: 5, Msg: "已封禁，但是踢掉登录会话失败，请重试"}, err
+	}
+	return Result{Msg: "封禁成功"}, nil
+}
+
+func (h *AdminHandler) Unban(ctx *gin.Context, req UnbanUserReq, uc ijwt.UserClaims) (Result, error) {
+	err := h.svc.UnbanUser(ctx, uc.UserId, req.Uid)
+	switch {
+	case errors.Is(err, repository.ErrUserNotFound):
+		return Result{Code: 4, Msg: "用户不存在"}, nil
+	case err != nil:
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Msg: "解封成功"}, nil
+}
+
+func (h *AdminHandler) Merge(ctx *gin.Context, req MergeUserReq, uc ijwt.UserClaims) (Result, error) {
+	err := h.svc.MergeUsers(ctx, uc.UserId, req.FromUid, req.ToUid)
+	switch {
+	case errors.Is(err, service.ErrInvalidMergeUser):
+		return Result{Code: 4, Msg: "参数错误"}, nil
+	case errors.Is(err, repository.ErrUserNotFound):
+		return Result{Code: 4, Msg: "用户不存在"}, nil
+	case err != nil:
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	// 被合并的账号已经删掉了，还登录着的会话踢掉
+	if err = h.jwtHdl.RevokeOtherSessions(ctx, req.FromUid, ""); err != nil {
+		h.l.Warn("踢掉被合并账号的会话失败", logger.Int64("uid", req.FromUid), logger.Error(err))
+	}
+	return Result{Msg: "合并成功"}, nil
+}
+
+func (h *AdminHandler) GrantRole(ctx *gin.Context, req UserRoleReq, uc ijwt.UserClaims) (Result, error) {
+	err := h.svc.GrantRole(ctx, uc.UserId, req.Uid, req.Role)
+	switch {
+	case errors.Is(err, service.ErrInvalidRole):
+		return Result{Code: 4, Msg: "角色不存在"}, nil
+	case errors.Is(err, repository.ErrUserNotFound):
+		return Result{Code: 4, Msg: "用户不存在"}, nil
+	case err != nil:
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Msg: "OK"}, nil
+}
+
+func (h *AdminHandler) RevokeRole(ctx *gin.Context, req UserRoleReq, uc ijwt.UserClaims) (Result, error) {
+	err := h.svc.RevokeRole(ctx, uc.UserId, req.Uid, req.Role)
+	switch {
+	case errors.Is(err, repository.ErrUserNotFound):
+		return Result{Code: 4, Msg: "用户没有这个角色"}, nil
+	case err != nil:
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Msg: "OK"}, nil
+}
+
+func (h *AdminHandler) WithdrawArticle(ctx *gin.Context, req AdminWithdrawReq, uc ijwt.UserClaims) (Result, error) {
+	err := h.svc.WithdrawArticle(ctx, uc.UserId, req.Id, req.Reason)
+	switch {
+	case errors.Is(err, article.ErrArticleNotFound):
+		return Result{Code: 4, Msg: "文章不存在"}, nil
+	case err != nil:
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Msg: "OK"}, nil
+}
+
+func (h *AdminHandler) ListAuditLogs(ctx *gin.Context, req AuditLogListReq) (Result, error) {
+	if req.Limit <= 0 || req.Limit > 100 {
+		return Result{Code: 4, Msg: "参数错误"}, nil
+	}
+	logs, err := h.svc.ListAuditLogs(ctx, domain.AuditLogQuery{
+		Operator:   req.Operator,
+		TargetType: req.TargetType,
+		TargetId:   req.TargetId,
+		Offset:     req.Offset,
+		Limit:      req.Limit,
+	})
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Data: slice.Map(logs, func(idx int, src domain.AuditLog) AuditLogVO {
+		return AuditLogVO{
+			Id:         src.Id,
+			Operator:   src.Operator,
+			Action:     src.Action,
+			TargetType: src.TargetType,
+			TargetId:   src.TargetId,
+			Detail:     src.Detail,
+			Ctime:      src.Ctime.Format(time.DateTime),
+		}
+	})}, nil
+}
@@ -0,0 +1,45 @@
+package web
+
+type BanUserReq struct {
+	Uid    int64  `json:"uid"`
+	Reason string `json:"reason"`
+}
+
+type UnbanUserReq struct {
+	Uid int64 `json:"uid"`
+}
+
+type MergeUserReq struct {
+	// FromUid 合并之后会被删掉
+	FromUid int64 `json:"from_uid"`
+	ToUid   int64 `json:"to_uid"`
+}
+
+type UserRoleReq struct {
+	Uid  int64  `json:"uid"`
+	Role string `json:"role"`
+}
+
+type AdminWithdrawReq struct {
+	Id     int64  `json:"id"`
+	Reason string `json:"reason"`
+}
+
+// AuditLogListReq 条件不传就是不过滤
+type AuditLogListReq struct {
+	Operator   int64  `json:"operator"`
+	TargetType string `json:"target_type"`
+	TargetId   int64  `json:"target_id"`
+	Offset     int    `json:"offset"`
+	Limit      int    `json:"limit"`
+}
+
+type AuditLogVO struct {
+	Id         int64  `json:"id"`
+	Operator   int64  `json:"operator"`
+	Action     string `json:"action"`
+	TargetType string `json:"target_type"`
+	TargetId   int64  `json:"target_id"`
+	Detail     string `json:"detail"`
+	Ctime      string `json:"ctime"`
+}
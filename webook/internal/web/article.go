@@ -1,6 +1,7 @@
 package web
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -10,6 +11,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"golang.org/x/sync/errgroup"
 
+	commentv1 "github.com/liupch66/basic-go/webook/api/proto/gen/comment/v1"
 	interactv1 "github.com/liupch66/basic-go/webook/api/proto/gen/interact/v1"
 	"github.com/liupch66/basic-go/webook/internal/domain"
 	"github.com/liupch66/basic-go/webook/internal/service"
@@ -21,14 +23,16 @@ import (
 var _ handler = (*ArticleHandler)(nil)
 
 type ArticleHandler struct {
-	svc      service.ArticleService
-	interSvc interactv1.InteractServiceClient
-	l        logger.LoggerV1
-	biz      string
+	svc        service.ArticleService
+	interSvc   interactv1.InteractServiceClient
+	commentSvc commentv1.CommentServiceClient
+	l          logger.LoggerV1
+	biz        string
 }
 
-func NewArticleHandler(svc service.ArticleService, interSvc interactv1.InteractServiceClient, l logger.LoggerV1) *ArticleHandler {
-	return &ArticleHandler{svc: svc, interSvc: interSvc, l: l, biz: "article"}
+func NewArticleHandler(svc service.ArticleService, interSvc interactv1.InteractServiceClient,
+	commentSvc commentv1.CommentServiceClient, l logger.LoggerV1) *ArticleHandler {
+	return &ArticleHandler{svc: svc, interSvc: interSvc, commentSvc: commentSvc, l: l, biz: "article"}
 }
 
 func (h *ArticleHandler) RegisterRoutes(server *gin.Engine) {
@@ -41,12 +45,31 @@ func (h *ArticleHandler) RegisterRoutes(server *gin.Engine) {
 		ag.POST("/list", ginx.WrapReqAndClaims[ListReq, jwt.UserClaims](h.List))
 		ag.GET("/detail/:id", ginx.WrapClaims[jwt.UserClaims](h.Detail))
 
+		// 定时发表
+		sg := ag.Group("/scheduled")
+		{
+			sg.POST("/publish", ginx.WrapReqAndClaims[ScheduleReq, jwt.UserClaims](h.Schedule))
+			sg.POST("/list", ginx.WrapReqAndClaims[ListReq, jwt.UserClaims](h.ListScheduled))
+			sg.POST("/reschedule", ginx.WrapReqAndClaims[RescheduleReq, jwt.UserClaims](h.Reschedule))
+			sg.POST("/cancel", ginx.WrapReqAndClaims[CancelScheduleReq, jwt.UserClaims](h.CancelSchedule))
+		}
+
+		// 历史版本，都是创作者自己的接口
+		rg := ag.Group("/revisions")
+		{
+			rg.POST("/list", ginx.WrapReqAndClaims[RevisionListReq, jwt.UserClaims](h.ListRevisions))
+			rg.GET("/detail/:id/:version", ginx.WrapClaims[jwt.UserClaims](h.RevisionDetail))
+			rg.POST("/diff", ginx.WrapReqAndClaims[RevisionDiffReq, jwt.UserClaims](h.DiffRevisions))
+			rg.POST("/rollback", ginx.WrapReqAndClaims[RollbackReq, jwt.UserClaims](h.Rollback))
+		}
+
 		pg := ag.Group("/pub")
 		{
 			pg.GET("/:id", ginx.WrapClaims[jwt.UserClaims](h.PubDetail))
 			// 点赞和取消点赞都是这个
 			pg.POST("/like", ginx.WrapReqAndClaims[LikeReq](h.Like))
 			pg.POST("/collect", ginx.WrapReqAndClaims[CollectReq](h.Collect))
+			pg.POST("/cancel_collect", ginx.WrapReqAndClaims[CancelCollectReq](h.CancelCollect))
 		}
 	}
 }
@@ -200,9 +223,10 @@ func (h *ArticleHandler) PubDetail(ctx *gin.Context, uc jwt.UserClaims) (Result,
 	// 接下来查询文章详情和阅读点赞收藏详情可以并行，也就是开两个 goroutine，但是最终的 article_vo 要等这两个执行完
 	// 所以可以用 WaitGroup，但是要各自处理错误，可以改进成 ErrGroup
 	var (
-		eg      errgroup.Group
-		art     domain.Article
-		getResp *interactv1.GetResponse
+		eg         errgroup.Group
+		art        domain.Article
+		getResp    *interactv1.GetResponse
+		commentCnt int64
 	)
 
 	// goroutine 里面最好不要复用外面的 error，防止不清楚最后的 error 到底是哪个
@@ -222,6 +246,20 @@ func (h *ArticleHandler) PubDetail(ctx *gin.Context, uc jwt.UserClaims) (Result,
 		return er
 	})
 
+	eg.Go(func() error {
+		resp, er := h.commentSvc.GetCount(ctx, &commentv1.GetCountRequest{
+			Biz:   h.biz,
+			BizId: id,
+		})
+		if er != nil {
+			// 评论数拿不到不影响看文章
+			h.l.Error("获取评论数失败", logger.Error(er), logger.Int64("article_id", id))
+			return nil
+		}
+		commentCnt = resp.GetCnt()
+		return nil
+	})
+
 	err = eg.Wait()
 	if err != nil {
 		return Result{Code: 5, Msg: "系统错误"}, fmt.Errorf("获取文章信息失败 %w", err)
@@ -251,6 +289,7 @@ func (h *ArticleHandler) PubDetail(ctx *gin.Context, uc jwt.UserClaims) (Result,
 		ReadCnt:    inter.ReadCnt,
 		LikeCnt:    inter.LikeCnt,
 		CollectCnt: inter.CollectCnt,
+		CommentCnt: commentCnt,
 		Ctime:      art.Ctime.Format(time.DateTime),
 		Utime:      art.Utime.Format(time.DateTime),
 	}}, nil
@@ -293,3 +332,154 @@ func (h *ArticleHandler) Collect(ctx *gin.Context, req CollectReq, uc jwt.UserCl
 	}
 	return Result{Msg: "OK"}, nil
 }
+
+func (h *ArticleHandler) CancelCollect(ctx *gin.Context, req CancelCollectReq, uc jwt.UserClaims) (Result, error) {
+	_, err := h.interSvc.CancelCollect(ctx, &interactv1.CancelCollectRequest{
+		Biz:   h.biz,
+		BizId: req.Id,
+		Uid:   uc.UserId,
+	})
+	if err != nil {
+		h.l.Error("读者取消收藏失败", logger.Int64("article_id", req.Id),
+			logger.Int64("user_id", uc.UserId), logger.Error(err))
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Msg: "OK"}, nil
+}
+
+func (h *ArticleHandler) Schedule(ctx *gin.Context, req ScheduleReq, uc jwt.UserClaims) (Result, error) {
+	id, err := h.svc.Schedule(ctx, req.ArticleReq.toDomain(uc.UserId), time.UnixMilli(req.PublishAt))
+	if errors.Is(err, service.ErrInvalidPublishTime) {
+		return Result{Code: 4, Msg: "发表时间必须晚于当前时间"}, nil
+	}
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Msg: "OK", Data: id}, nil
+}
+
+func (h *ArticleHandler) ListScheduled(ctx *gin.Context, req ListReq, uc jwt.UserClaims) (Result, error) {
+	res, err := h.svc.ListScheduled(ctx, uc.UserId, req.Offset, req.Limit)
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{
+		Data: slice.Map[domain.Article, ArticleVO](res, func(idx int, src domain.Article) ArticleVO {
+			return ArticleVO{
+				Id:        src.Id,
+				Title:     src.Title,
+				Abstract:  src.Abstract(),
+				Status:    src.Status.ToUnit8(),
+				PublishAt: src.PublishAt.Format(time.DateTime),
+				Ctime:     src.Ctime.Format(time.DateTime),
+				Utime:     src.Utime.Format(time.DateTime),
+			}
+		}),
+	}, nil
+}
+
+func (h *ArticleHandler) Reschedule(ctx *gin.Context, req RescheduleReq, uc jwt.UserClaims) (Result, error) {
+	err := h.svc.Reschedule(ctx, req.Id, uc.UserId, time.UnixMilli(req.PublishAt))
+	if errors.Is(err, service.ErrInvalidPublishTime) {
+		return Result{Code: 4, Msg: "发表时间必须晚于当前时间"}, nil
+	}
+	if errors.Is(err, service.ErrArticleNotScheduled) {
+		// 已经发表了、取消了或者不是自己的文章
+		return Result{Code: 4, Msg: "文章不是定时发表状态"}, nil
+	}
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Msg: "OK"}, nil
+}
+
+func (h *ArticleHandler) CancelSchedule(ctx *gin.Context, req CancelScheduleReq, uc jwt.UserClaims) (Result, error) {
+	err := h.svc.CancelSchedule(ctx, req.Id, uc.UserId)
+	if errors.Is(err, service.ErrArticleNotScheduled) {
+		return Result{Code: 4, Msg: "文章不是定时发表状态"}, nil
+	}
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Msg: "OK"}, nil
+}
+
+func (h *ArticleHandler) ListRevisions(ctx *gin.Context, req RevisionListReq, uc jwt.UserClaims) (Result, error) {
+	res, err := h.svc.ListRevisions(ctx, req.Id, uc.UserId, req.Offset, req.Limit)
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{
+		Data: slice.Map[domain.ArticleRevision, ArticleRevisionVO](res, func(idx int, src domain.ArticleRevision) ArticleRevisionVO {
+			return ArticleRevisionVO{
+				Version: src.Version,
+				Title:   src.Title,
+				Status:  src.Status.ToUnit8(),
+				Ctime:   src.Ctime.Format(time.DateTime),
+			}
+		}),
+	}, nil
+}
+
+func (h *ArticleHandler) RevisionDetail(ctx *gin.Context, uc jwt.UserClaims) (Result, error) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return Result{Code: 4, Msg: "参数错误"}, err
+	}
+	version, err := strconv.ParseInt(ctx.Param("version"), 10, 64)
+	if err != nil {
+		return Result{Code: 4, Msg: "参数错误"}, err
+	}
+	rev, err := h.svc.GetRevision(ctx, id, version, uc.UserId)
+	switch {
+	case errors.Is(err, service.ErrRevisionNotFound):
+		// 查询条件带上了 author_id，别人的文章也是走到这里
+		return Result{Code: 4, Msg: "版本不存在"}, nil
+	case err != nil:
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{
+		Data: ArticleRevisionVO{
+			Version: rev.Version,
+			Title:   rev.Title,
+			Content: rev.Content,
+			Status:  rev.Status.ToUnit8(),
+			Ctime:   rev.Ctime.Format(time.DateTime),
+		},
+	}, nil
+}
+
+func (h *ArticleHandler) DiffRevisions(ctx *gin.Context, req RevisionDiffReq, uc jwt.UserClaims) (Result, error) {
+	diff, err := h.svc.DiffRevisions(ctx, req.Id, req.From, req.To, uc.UserId)
+	switch {
+	case errors.Is(err, service.ErrRevisionNotFound):
+		return Result{Code: 4, Msg: "版本不存在"}, nil
+	case errors.Is(err, service.ErrDiffTooLarge):
+		return Result{Code: 4, Msg: "两个版本差异太大，无法对比"}, nil
+	case err != nil:
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{
+		Data: ArticleDiffVO{
+			From:         diff.From,
+			To:           diff.To,
+			TitleChanged: diff.TitleChanged,
+			Lines: slice.Map[domain.DiffLine, DiffLineVO](diff.Lines, func(idx int, src domain.DiffLine) DiffLineVO {
+				return DiffLineVO{Op: src.Op.String(), Text: src.Text}
+			}),
+		},
+	}, nil
+}
+
+func (h *ArticleHandler) Rollback(ctx *gin.Context, req RollbackReq, uc jwt.UserClaims) (Result, error) {
+	id, err := h.svc.Rollback(ctx, req.Id, req.Version, uc.UserId, req.Published)
+	if errors.Is(err, service.ErrRevisionNotFound) {
+		return Result{Code: 4, Msg: "版本不存在"}, nil
+	}
+	if err != nil {
+		h.l.Error("回滚文章失败", logger.Error(err), logger.Int64("article_id", req.Id),
+			logger.Int64("version", req.Version), logger.Int64("user_id", uc.UserId))
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Msg: "OK", Data: id}, nil
+}
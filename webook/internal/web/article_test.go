@@ -87,7 +87,8 @@ func TestArticleHandler_Publish(t *testing.T) {
 			server.Use(func(ctx *gin.Context) {
 				ctx.Set("user_claims", jwt.UserClaims{UserId: 4})
 			})
-			articleHdl := NewArticleHandler(tc.mock(ctrl))
+			svc, l := tc.mock(ctrl)
+			articleHdl := NewArticleHandler(svc, nil, nil, l)
 			articleHdl.RegisterRoutes(server)
 			server.ServeHTTP(resp, req)
 			assert.Equal(t, tc.expectedCode, resp.Code)
@@ -20,8 +20,11 @@ type ArticleVO struct {
 	ReadCnt    int64  `json:"read_cnt"`
 	LikeCnt    int64  `json:"like_cnt"`
 	CollectCnt int64  `json:"collect_cnt"`
-	Ctime      string `json:"ctime"`
-	Utime      string `json:"utime"`
+	CommentCnt int64  `json:"comment_cnt"`
+	// PublishAt 定时发表的时间，只有定时发表的列表里面有
+	PublishAt string `json:"publish_at,omitempty"`
+	Ctime     string `json:"ctime"`
+	Utime     string `json:"utime"`
 }
 
 type ArticleReq struct {
@@ -30,6 +33,21 @@ type ArticleReq struct {
 	Content string `json:"content"`
 }
 
+type ScheduleReq struct {
+	ArticleReq
+	// PublishAt 毫秒时间戳
+	PublishAt int64 `json:"publish_at"`
+}
+
+type RescheduleReq struct {
+	Id        int64 `json:"id"`
+	PublishAt int64 `json:"publish_at"`
+}
+
+type CancelScheduleReq struct {
+	Id int64 `json:"id"`
+}
+
 type ListReq struct {
 	Offset int `json:"offset"`
 	Limit  int `json:"limit"`
@@ -45,6 +63,10 @@ type CollectReq struct {
 	Cid int64 `json:"cid"`
 }
 
+type CancelCollectReq struct {
+	Id int64 `json:"id"`
+}
+
 func (req ArticleReq) toDomain(uid int64) domain.Article {
 	return domain.Article{
 		Id:      req.Id,
@@ -53,3 +75,43 @@ func (req ArticleReq) toDomain(uid int64) domain.Article {
 		Author:  domain.Author{Id: uid},
 	}
 }
+
+type ArticleRevisionVO struct {
+	Version int64  `json:"version"`
+	Title   string `json:"title"`
+	Content string `json:"content"`
+	Status  uint8  `json:"status"`
+	Ctime   string `json:"ctime"`
+}
+
+type DiffLineVO struct {
+	// equal, insert, delete
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+type ArticleDiffVO struct {
+	From         int64        `json:"from"`
+	To           int64        `json:"to"`
+	TitleChanged bool         `json:"title_changed"`
+	Lines        []DiffLineVO `json:"lines"`
+}
+
+type RevisionListReq struct {
+	Id     int64 `json:"id"`
+	Offset int   `json:"offset"`
+	Limit  int   `json:"limit"`
+}
+
+type RevisionDiffReq struct {
+	Id   int64 `json:"id"`
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+}
+
+type RollbackReq struct {
+	Id      int64 `json:"id"`
+	Version int64 `json:"version"`
+	// Published 为 true 表示连同线上的文章一起回滚
+	Published bool `json:"published"`
+}
@@ -45,6 +45,38 @@ func (i *InteractGrayscaleRelease) GetByIds(ctx context.Context, in *interactv1.
 	return i.selectClient().GetByIds(ctx, in, opts...)
 }
 
+func (i *InteractGrayscaleRelease) CancelCollect(ctx context.Context, in *interactv1.CancelCollectRequest, opts ...grpc.CallOption) (*interactv1.CancelCollectResponse, error) {
+	return i.selectClient().CancelCollect(ctx, in, opts...)
+}
+
+func (i *InteractGrayscaleRelease) CreateCollection(ctx context.Context, in *interactv1.CreateCollectionRequest, opts ...grpc.CallOption) (*interactv1.CreateCollectionResponse, error) {
+	return i.selectClient().CreateCollection(ctx, in, opts...)
+}
+
+func (i *InteractGrayscaleRelease) RenameCollection(ctx context.Context, in *interactv1.RenameCollectionRequest, opts ...grpc.CallOption) (*interactv1.RenameCollectionResponse, error) {
+	return i.selectClient().RenameCollection(ctx, in, opts...)
+}
+
+func (i *InteractGrayscaleRelease) DeleteCollection(ctx context.Context, in *interactv1.DeleteCollectionRequest, opts ...grpc.CallOption) (*interactv1.DeleteCollectionResponse, error) {
+	return i.selectClient().DeleteCollection(ctx, in, opts...)
+}
+
+func (i *InteractGrayscaleRelease) ListCollections(ctx context.Context, in *interactv1.ListCollectionsRequest, opts ...grpc.CallOption) (*interactv1.ListCollectionsResponse, error) {
+	return i.selectClient().ListCollections(ctx, in, opts...)
+}
+
+func (i *InteractGrayscaleRelease) ListCollectionItems(ctx context.Context, in *interactv1.ListCollectionItemsRequest, opts ...grpc.CallOption) (*interactv1.ListCollectionItemsResponse, error) {
+	return i.selectClient().ListCollectionItems(ctx, in, opts...)
+}
+
+func (i *InteractGrayscaleRelease) MoveCollectionItem(ctx context.Context, in *interactv1.MoveCollectionItemRequest, opts ...grpc.CallOption) (*interactv1.MoveCollectionItemResponse, error) {
+	return i.selectClient().MoveCollectionItem(ctx, in, opts...)
+}
+
+func (i *InteractGrayscaleRelease) MergeUser(ctx context.Context, in *interactv1.MergeUserRequest, opts ...grpc.CallOption) (*interactv1.MergeUserResponse, error) {
+	return i.selectClient().MergeUser(ctx, in, opts...)
+}
+
 func (i *InteractGrayscaleRelease) UpdateThreshold(newThreshold int32) {
 	i.threshold.Store(newThreshold)
 }
@@ -57,3 +89,7 @@ func (i *InteractGrayscaleRelease) selectClient() interactv1.InteractServiceClie
 	}
 	return i.local
 }
+
+func (i *InteractGrayscaleRelease) ListLikes(ctx context.Context, in *interactv1.ListLikesRequest, opts ...grpc.CallOption) (*interactv1.ListLikesResponse, error) {
+	return i.selectClient().ListLikes(ctx, in, opts...)
+}
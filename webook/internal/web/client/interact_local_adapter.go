@@ -2,8 +2,12 @@ package client
 
 import (
 	"context"
+	"errors"
 
+	"github.com/ecodeclub/ekit/slice"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	interactv1 "github.com/liupch66/basic-go/webook/api/proto/gen/interact/v1"
 	"github.com/liupch66/basic-go/webook/interact/domain"
@@ -60,6 +64,114 @@ func (i *InteractLocalAdapter) GetByIds(ctx context.Context, in *interactv1.GetB
 	return &interactv1.GetByIdsResponse{Interacts: res}, nil
 }
 
+func (i *InteractLocalAdapter) CancelCollect(ctx context.Context, in *interactv1.CancelCollectRequest, opts ...grpc.CallOption) (*interactv1.CancelCollectResponse, error) {
+	err := i.svc.CancelCollect(ctx, in.GetBiz(), in.GetBizId(), in.GetUid())
+	return &interactv1.CancelCollectResponse{}, err
+}
+
+func (i *InteractLocalAdapter) CreateCollection(ctx context.Context, in *interactv1.CreateCollectionRequest, opts ...grpc.CallOption) (*interactv1.CreateCollectionResponse, error) {
+	cid, err := i.svc.CreateCollection(ctx, in.GetUid(), in.GetName())
+	if err != nil {
+		return nil, i.toStatus(err)
+	}
+	return &interactv1.CreateCollectionResponse{Cid: cid}, nil
+}
+
+func (i *InteractLocalAdapter) RenameCollection(ctx context.Context, in *interactv1.RenameCollectionRequest, opts ...grpc.CallOption) (*interactv1.RenameCollectionResponse, error) {
+	err := i.svc.RenameCollection(ctx, in.GetCid(), in.GetUid(), in.GetName())
+	if err != nil {
+		return nil, i.toStatus(err)
+	}
+	return &interactv1.RenameCollectionResponse{}, nil
+}
+
+func (i *InteractLocalAdapter) DeleteCollection(ctx context.Context, in *interactv1.DeleteCollectionRequest, opts ...grpc.CallOption) (*interactv1.DeleteCollectionResponse, error) {
+	err := i.svc.DeleteCollection(ctx, in.GetCid(), in.GetUid())
+	if err != nil {
+		return nil, i.toStatus(err)
+	}
+	return &interactv1.DeleteCollectionResponse{}, nil
+}
+
+func (i *InteractLocalAdapter) ListCollections(ctx context.Context, in *interactv1.ListCollectionsRequest, opts ...grpc.CallOption) (*interactv1.ListCollectionsResponse, error) {
+	cs, err := i.svc.ListCollections(ctx, in.GetUid(), int(in.GetOffset()), int(in.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	return &interactv1.ListCollectionsResponse{
+		Collections: slice.Map(cs, func(idx int, src domain.Collection) *interactv1.Collection {
+			return &interactv1.Collection{
+				Id:    src.Id,
+				Name:  src.Name,
+				Uid:   src.Uid,
+				Ctime: src.Ctime.UnixMilli(),
+				Utime: src.Utime.UnixMilli(),
+			}
+		}),
+	}, nil
+}
+
+func (i *InteractLocalAdapter) ListCollectionItems(ctx context.Context, in *interactv1.ListCollectionItemsRequest, opts ...grpc.CallOption) (*interactv1.ListCollectionItemsResponse, error) {
+	items, err := i.svc.ListCollectionItems(ctx, in.GetCid(), in.GetUid(), int(in.GetOffset()), int(in.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	return &interactv1.ListCollectionItemsResponse{
+		Items: slice.Map(items, func(idx int, src domain.CollectionItem) *interactv1.CollectionItem {
+			return &interactv1.CollectionItem{
+				Cid:   src.Cid,
+				Biz:   src.Biz,
+				BizId: src.BizId,
+				Uid:   src.Uid,
+				Ctime: src.Ctime.UnixMilli(),
+			}
+		}),
+	}, nil
+}
+
+func (i *InteractLocalAdapter) MoveCollectionItem(ctx context.Context, in *interactv1.MoveCollectionItemRequest, opts ...grpc.CallOption) (*interactv1.MoveCollectionItemResponse, error) {
+	err := i.svc.MoveCollectItem(ctx, in.GetBiz(), in.GetBizId(), in.GetUid(), in.GetCid())
+	if err != nil {
+		return nil, i.toStatus(err)
+	}
+	return &interactv1.MoveCollectionItemResponse{}, nil
+}
+
+func (i *InteractLocalAdapter) MergeUser(ctx context.Context, in *interactv1.MergeUserRequest, opts ...grpc.CallOption) (*interactv1.MergeUserResponse, error) {
+	err := i.svc.MergeUser(ctx, in.GetFromUid(), in.GetToUid())
+	if err != nil {
+		return nil, i.toStatus(err)
+	}
+	return &interactv1.MergeUserResponse{}, nil
+}
+func (i *InteractLocalAdapter) ListLikes(ctx context.Context, in *interactv1.ListLikesRequest, opts ...grpc.CallOption) (*interactv1.ListLikesResponse, error) {
+	likes, err := i.svc.ListLikes(ctx, in.GetUid(), int(in.GetOffset()), int(in.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	return &interactv1.ListLikesResponse{
+		Likes: slice.Map(likes, func(idx int, src domain.UserLike) *interactv1.UserLike {
+			return &interactv1.UserLike{
+				Biz:   src.Biz,
+				BizId: src.BizId,
+				Ctime: src.Ctime.UnixMilli(),
+			}
+		}),
+	}, nil
+}
+
+// toStatus 和 gRPC 服务端保持一致，调用方只需要看错误码
+func (i *InteractLocalAdapter) toStatus(err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidCollectionName), errors.Is(err, service.ErrInvalidMergeUid):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrCollectionNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		return err
+	}
+}
+
 // DTO: Data Transfer Object
 func (i *InteractLocalAdapter) toDTO(inter domain.Interact) *interactv1.Interact {
 	return &interactv1.Interact{
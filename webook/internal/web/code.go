@@ -0,0 +1,49 @@
+package web
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/service"
+)
+
+// deviceHeader 前端算出来的设备指纹放在这个 header 里面，发验证码的频控用
+const deviceHeader = "X-Device-Id"
+
+func codeSendMeta(ctx *gin.Context, captchaToken string) domain.CodeSendMeta {
+	return domain.CodeSendMeta{
+		IP:           ctx.ClientIP(),
+		Device:       ctx.GetHeader(deviceHeader),
+		CaptchaToken: captchaToken,
+	}
+}
+
+// codeDeniedResult 发验证码被频控拦下来的话，Data 里面带上原因，前端根据原因弹图形验证码或者提示
+func codeDeniedResult(err error) (Result, bool) {
+	var de *service.CodeDeniedError
+	if !errors.As(err, &de) {
+		return Result{}, false
+	}
+	res := Result{Code: 4, Data: CodeDeniedVO{Reason: de.Reason}}
+	switch {
+	case de.Reason == service.CodeDenyCaptchaRequired:
+		res.Msg = "请先完成图形验证码"
+	case de.Reason == service.CodeDenyCaptchaInvalid:
+		res.Msg = "图形验证码不正确"
+	case strings.HasPrefix(de.Reason, domain.CodeDimBudget):
+		res.Msg = "今天的验证码已经发完了，请明天再试"
+	case strings.HasPrefix(de.Reason, domain.CodeDimPhone):
+		res.Msg = "这个手机号发送验证码次数太多，请稍后再试"
+	default:
+		res.Msg = "发送验证码次数太多，请稍后再试"
+	}
+	return res, true
+}
+
+// CodeDeniedVO Reason 是 captcha_required、captcha_invalid，或者超过了哪个配额，比如 phone_hour、ip_day、budget_day
+type CodeDeniedVO struct {
+	Reason string `json:"reason"`
+}
@@ -0,0 +1,156 @@
+package web
+
+import (
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	interactv1 "github.com/liupch66/basic-go/webook/api/proto/gen/interact/v1"
+	"github.com/liupch66/basic-go/webook/internal/web/jwt"
+	"github.com/liupch66/basic-go/webook/pkg/ginx"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
+)
+
+var _ handler = (*CollectionHandler)(nil)
+
+// CollectionHandler 收藏夹管理，收藏和取消收藏本身还是在 ArticleHandler 里面
+type CollectionHandler struct {
+	svc interactv1.InteractServiceClient
+	l   logger.LoggerV1
+	biz string
+}
+
+func NewCollectionHandler(svc interactv1.InteractServiceClient, l logger.LoggerV1) *CollectionHandler {
+	return &CollectionHandler{svc: svc, l: l, biz: "article"}
+}
+
+func (h *CollectionHandler) RegisterRoutes(server *gin.Engine) {
+	cg := server.Group("/collections")
+	{
+		cg.POST("/create", ginx.WrapReqAndClaims[CreateCollectionReq, jwt.UserClaims](h.Create))
+		cg.POST("/rename", ginx.WrapReqAndClaims[RenameCollectionReq, jwt.UserClaims](h.Rename))
+		cg.POST("/delete", ginx.WrapReqAndClaims[DeleteCollectionReq, jwt.UserClaims](h.Delete))
+		cg.GET("/list", ginx.WrapReqAndClaims[CollectionListReq, jwt.UserClaims](h.List))
+		cg.GET("/items", ginx.WrapReqAndClaims[CollectionItemsReq, jwt.UserClaims](h.Items))
+		// 把收藏的文章挪到另一个收藏夹
+		cg.POST("/move", ginx.WrapReqAndClaims[MoveCollectionItemReq, jwt.UserClaims](h.Move))
+	}
+}
+
+func (h *CollectionHandler) Create(ctx *gin.Context, req CreateCollectionReq, uc jwt.UserClaims) (Result, error) {
+	resp, err := h.svc.CreateCollection(ctx, &interactv1.CreateCollectionRequest{
+		Uid:  uc.UserId,
+		Name: req.Name,
+	})
+	if status.Code(err) == codes.InvalidArgument {
+		return Result{Code: 4, Msg: "收藏夹名字不合法"}, nil
+	}
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Data: resp.GetCid()}, nil
+}
+
+func (h *CollectionHandler) Rename(ctx *gin.Context, req RenameCollectionReq, uc jwt.UserClaims) (Result, error) {
+	_, err := h.svc.RenameCollection(ctx, &interactv1.RenameCollectionRequest{
+		Cid:  req.Cid,
+		Uid:  uc.UserId,
+		Name: req.Name,
+	})
+	switch status.Code(err) {
+	case codes.OK:
+		return Result{Msg: "OK"}, nil
+	case codes.InvalidArgument:
+		return Result{Code: 4, Msg: "收藏夹名字不合法"}, nil
+	case codes.NotFound:
+		return Result{Code: 4, Msg: "收藏夹不存在"}, nil
+	default:
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+}
+
+func (h *CollectionHandler) Delete(ctx *gin.Context, req DeleteCollectionReq, uc jwt.UserClaims) (Result, error) {
+	_, err := h.svc.DeleteCollection(ctx, &interactv1.DeleteCollectionRequest{
+		Cid: req.Cid,
+		Uid: uc.UserId,
+	})
+	if status.Code(err) == codes.NotFound {
+		return Result{Code: 4, Msg: "收藏夹不存在"}, nil
+	}
+	if err != nil {
+		h.l.Error("删除收藏夹失败", logger.Int64("cid", req.Cid),
+			logger.Int64("user_id", uc.UserId), logger.Error(err))
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Msg: "OK"}, nil
+}
+
+func (h *CollectionHandler) List(ctx *gin.Context, req CollectionListReq, uc jwt.UserClaims) (Result, error) {
+	if req.Limit <= 0 || req.Limit > 100 {
+		req.Limit = 20
+	}
+	resp, err := h.svc.ListCollections(ctx, &interactv1.ListCollectionsRequest{
+		Uid:    uc.UserId,
+		Offset: req.Offset,
+		Limit:  req.Limit,
+	})
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{
+		Data: slice.Map[*interactv1.Collection, CollectionVO](resp.GetCollections(),
+			func(idx int, src *interactv1.Collection) CollectionVO {
+				return CollectionVO{
+					Id:    src.GetId(),
+					Name:  src.GetName(),
+					Ctime: time.UnixMilli(src.GetCtime()).Format(time.DateTime),
+					Utime: time.UnixMilli(src.GetUtime()).Format(time.DateTime),
+				}
+			}),
+	}, nil
+}
+
+func (h *CollectionHandler) Items(ctx *gin.Context, req CollectionItemsReq, uc jwt.UserClaims) (Result, error) {
+	if req.Limit <= 0 || req.Limit > 100 {
+		req.Limit = 20
+	}
+	resp, err := h.svc.ListCollectionItems(ctx, &interactv1.ListCollectionItemsRequest{
+		Cid:    req.Cid,
+		Uid:    uc.UserId,
+		Offset: req.Offset,
+		Limit:  req.Limit,
+	})
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{
+		Data: slice.Map[*interactv1.CollectionItem, CollectionItemVO](resp.GetItems(),
+			func(idx int, src *interactv1.CollectionItem) CollectionItemVO {
+				return CollectionItemVO{
+					Cid:   src.GetCid(),
+					Biz:   src.GetBiz(),
+					BizId: src.GetBizId(),
+					Ctime: time.UnixMilli(src.GetCtime()).Format(time.DateTime),
+				}
+			}),
+	}, nil
+}
+
+func (h *CollectionHandler) Move(ctx *gin.Context, req MoveCollectionItemReq, uc jwt.UserClaims) (Result, error) {
+	_, err := h.svc.MoveCollectionItem(ctx, &interactv1.MoveCollectionItemRequest{
+		Biz:   h.biz,
+		BizId: req.Id,
+		Uid:   uc.UserId,
+		Cid:   req.Cid,
+	})
+	if status.Code(err) == codes.NotFound {
+		return Result{Code: 4, Msg: "收藏夹不存在或者没有收藏该文章"}, nil
+	}
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Msg: "OK"}, nil
+}
@@ -0,0 +1,46 @@
+package web
+
+type CreateCollectionReq struct {
+	Name string `json:"name"`
+}
+
+type RenameCollectionReq struct {
+	Cid  int64  `json:"cid"`
+	Name string `json:"name"`
+}
+
+type DeleteCollectionReq struct {
+	Cid int64 `json:"cid"`
+}
+
+type CollectionListReq struct {
+	Offset int64 `form:"offset"`
+	Limit  int64 `form:"limit"`
+}
+
+type CollectionItemsReq struct {
+	Cid    int64 `form:"cid"`
+	Offset int64 `form:"offset"`
+	Limit  int64 `form:"limit"`
+}
+
+type MoveCollectionItemReq struct {
+	// Id 文章 id
+	Id int64 `json:"id"`
+	// Cid 目标收藏夹
+	Cid int64 `json:"cid"`
+}
+
+type CollectionVO struct {
+	Id    int64  `json:"id"`
+	Name  string `json:"name"`
+	Ctime string `json:"ctime"`
+	Utime string `json:"utime"`
+}
+
+type CollectionItemVO struct {
+	Cid   int64  `json:"cid"`
+	Biz   string `json:"biz"`
+	BizId int64  `json:"biz_id"`
+	Ctime string `json:"ctime"`
+}
@@ -0,0 +1,145 @@
+package web
+
+import (
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	commentv1 "github.com/liupch66/basic-go/webook/api/proto/gen/comment/v1"
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/service"
+	"github.com/liupch66/basic-go/webook/internal/web/jwt"
+	"github.com/liupch66/basic-go/webook/pkg/ginx"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
+)
+
+var _ handler = (*CommentHandler)(nil)
+
+// CommentHandler 目前只有文章的评论
+type CommentHandler struct {
+	svc    commentv1.CommentServiceClient
+	artSvc service.ArticleService
+	l      logger.LoggerV1
+	biz    string
+}
+
+func NewCommentHandler(svc commentv1.CommentServiceClient, artSvc service.ArticleService, l logger.LoggerV1) *CommentHandler {
+	return &CommentHandler{svc: svc, artSvc: artSvc, l: l, biz: "article"}
+}
+
+func (h *CommentHandler) RegisterRoutes(server *gin.Engine) {
+	cg := server.Group("/comments")
+	{
+		cg.POST("/create", ginx.WrapReqAndClaims[CommentReq, jwt.UserClaims](h.Create))
+		cg.POST("/delete", ginx.WrapReqAndClaims[DeleteCommentReq, jwt.UserClaims](h.Delete))
+		cg.GET("/list", ginx.WrapReq[CommentListReq](h.List))
+		// 懒加载某条根评论下面的回复
+		cg.GET("/replies", ginx.WrapReq[RepliesReq](h.Replies))
+	}
+}
+
+func (h *CommentHandler) Create(ctx *gin.Context, req CommentReq, uc jwt.UserClaims) (Result, error) {
+	if req.Content == "" {
+		return Result{Code: 4, Msg: "评论内容不能为空"}, nil
+	}
+	// 只能评论已经发表的文章，草稿、仅自己可见和撤回的都不行
+	_, ok, err := h.publishedArticle(ctx, req.BizId)
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	if !ok {
+		return Result{Code: 4, Msg: "文章不存在"}, nil
+	}
+	resp, err := h.svc.CreateComment(ctx, &commentv1.CreateCommentRequest{
+		Comment: &commentv1.Comment{
+			Uid:      uc.UserId,
+			Biz:      h.biz,
+			BizId:    req.BizId,
+			Content:  req.Content,
+			ParentId: req.ParentId,
+		},
+	})
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Msg: "OK", Data: resp.GetId()}, nil
+}
+
+func (h *CommentHandler) Delete(ctx *gin.Context, req DeleteCommentReq, uc jwt.UserClaims) (Result, error) {
+	// 文章作者可以删除自己文章下面的评论。文章撤回了就只有评论者自己能删
+	art, _, err := h.publishedArticle(ctx, req.BizId)
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	_, err = h.svc.DeleteComment(ctx, &commentv1.DeleteCommentRequest{
+		Id:          req.Id,
+		Biz:         h.biz,
+		BizId:       req.BizId,
+		Uid:         uc.UserId,
+		BizOwnerUid: art.Author.Id,
+	})
+	if status.Code(err) == codes.PermissionDenied {
+		return Result{Code: 4, Msg: "没有权限删除该评论"}, nil
+	}
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Msg: "OK"}, nil
+}
+
+// publishedArticle 查询线上库，文章没有发表的时候 ok 是 false。
+// 不用 GetPublishedById，那个会发阅读事件，评论不算阅读
+func (h *CommentHandler) publishedArticle(ctx *gin.Context, id int64) (domain.Article, bool, error) {
+	arts, err := h.artSvc.ListPubByIds(ctx, []int64{id})
+	if err != nil || len(arts) == 0 {
+		return domain.Article{}, false, err
+	}
+	return arts[0], true, nil
+}
+
+func (h *CommentHandler) List(ctx *gin.Context, req CommentListReq) (Result, error) {
+	if req.Limit <= 0 || req.Limit > 100 {
+		req.Limit = 20
+	}
+	resp, err := h.svc.GetCommentList(ctx, &commentv1.GetCommentListRequest{
+		Biz:   h.biz,
+		BizId: req.BizId,
+		MinId: req.MinId,
+		Limit: req.Limit,
+	})
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Data: h.toVOs(resp.GetComments())}, nil
+}
+
+func (h *CommentHandler) Replies(ctx *gin.Context, req RepliesReq) (Result, error) {
+	if req.Limit <= 0 || req.Limit > 100 {
+		req.Limit = 20
+	}
+	resp, err := h.svc.GetMoreReplies(ctx, &commentv1.GetMoreRepliesRequest{
+		RootId: req.RootId,
+		MaxId:  req.MaxId,
+		Limit:  req.Limit,
+	})
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Data: h.toVOs(resp.GetReplies())}, nil
+}
+
+func (h *CommentHandler) toVOs(cs []*commentv1.Comment) []CommentVO {
+	return slice.Map(cs, func(idx int, src *commentv1.Comment) CommentVO {
+		return CommentVO{
+			Id:       src.GetId(),
+			Uid:      src.GetUid(),
+			Content:  src.GetContent(),
+			RootId:   src.GetRootId(),
+			ParentId: src.GetParentId(),
+			Ctime:    time.UnixMilli(src.GetCtime()).Format(time.DateTime),
+		}
+	})
+}
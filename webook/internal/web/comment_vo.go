@@ -0,0 +1,37 @@
+package web
+
+type CommentReq struct {
+	// BizId 评论的文章
+	BizId   int64  `json:"biz_id"`
+	Content string `json:"content"`
+	// ParentId 回复的评论，评论文章的时候不传
+	ParentId int64 `json:"parent_id"`
+}
+
+type DeleteCommentReq struct {
+	Id    int64 `json:"id"`
+	BizId int64 `json:"biz_id"`
+}
+
+type CommentListReq struct {
+	BizId int64 `form:"biz_id"`
+	// MinId 上一页最后一条评论的 id，第一页不传
+	MinId int64 `form:"min_id"`
+	Limit int64 `form:"limit"`
+}
+
+type RepliesReq struct {
+	RootId int64 `form:"root_id"`
+	// MaxId 上一页最后一条回复的 id，第一页不传
+	MaxId int64 `form:"max_id"`
+	Limit int64 `form:"limit"`
+}
+
+type CommentVO struct {
+	Id       int64  `json:"id"`
+	Uid      int64  `json:"uid"`
+	Content  string `json:"content"`
+	RootId   int64  `json:"root_id"`
+	ParentId int64  `json:"parent_id"`
+	Ctime    string `json:"ctime"`
+}
@@ -0,0 +1,177 @@
+package web
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	followv1 "github.com/liupch66/basic-go/webook/api/proto/gen/follow/v1"
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/service"
+	"github.com/liupch66/basic-go/webook/internal/web/jwt"
+	"github.com/liupch66/basic-go/webook/pkg/ginx"
+)
+
+var _ handler = (*FollowHandler)(nil)
+
+type FollowHandler struct {
+	svc     followv1.FollowServiceClient
+	feedSvc service.FeedService
+	userSvc service.UserService
+}
+
+func NewFollowHandler(svc followv1.FollowServiceClient, feedSvc service.FeedService, userSvc service.UserService) *FollowHandler {
+	return &FollowHandler{svc: svc, feedSvc: feedSvc, userSvc: userSvc}
+}
+
+func (h *FollowHandler) RegisterRoutes(server *gin.Engine) {
+	fg := server.Group("/follow")
+	{
+		fg.POST("/follow", ginx.WrapReqAndClaims[FollowReq, jwt.UserClaims](h.Follow))
+		fg.POST("/cancel", ginx.WrapReqAndClaims[FollowReq, jwt.UserClaims](h.CancelFollow))
+		fg.GET("/followees", ginx.WrapReqAndClaims[FollowListReq, jwt.UserClaims](h.Followees))
+		fg.GET("/followers", ginx.WrapReqAndClaims[FollowListReq, jwt.UserClaims](h.Followers))
+		fg.GET("/static", ginx.WrapReqAndClaims[FollowStaticReq, jwt.UserClaims](h.Static))
+		// 批量查询自己有没有关注这些人，比如文章列表页展示"已关注"
+		fg.POST("/check", ginx.WrapReqAndClaims[CheckFollowReq, jwt.UserClaims](h.Check))
+		// 关注的人发表的文章
+		fg.GET("/feed", ginx.WrapReqAndClaims[FeedReq, jwt.UserClaims](h.Feed))
+	}
+}
+
+func (h *FollowHandler) Follow(ctx *gin.Context, req FollowReq, uc jwt.UserClaims) (Result, error) {
+	// 关注服务里面没有用户数据，在这里确认被关注的人存在
+	_, err := h.userSvc.Profile(ctx, req.Followee)
+	if errors.Is(err, service.ErrUserNotFound) {
+		return Result{Code: 4, Msg: "用户不存在"}, nil
+	}
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	_, err = h.svc.Follow(ctx, &followv1.FollowRequest{
+		Follower: uc.UserId,
+		Followee: req.Followee,
+	})
+	if status.Code(err) == codes.InvalidArgument {
+		return Result{Code: 4, Msg: "不能关注该用户"}, nil
+	}
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Msg: "OK"}, nil
+}
+
+func (h *FollowHandler) CancelFollow(ctx *gin.Context, req FollowReq, uc jwt.UserClaims) (Result, error) {
+	_, err := h.svc.CancelFollow(ctx, &followv1.CancelFollowRequest{
+		Follower: uc.UserId,
+		Followee: req.Followee,
+	})
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Msg: "OK"}, nil
+}
+
+func (h *FollowHandler) Followees(ctx *gin.Context, req FollowListReq, uc jwt.UserClaims) (Result, error) {
+	if req.Uid <= 0 {
+		req.Uid = uc.UserId
+	}
+	if req.Limit <= 0 || req.Limit > 100 {
+		req.Limit = 20
+	}
+	resp, err := h.svc.GetFollowee(ctx, &followv1.GetFolloweeRequest{
+		Follower: req.Uid,
+		MinId:    req.MinId,
+		Limit:    req.Limit,
+	})
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Data: h.toVOs(resp.GetFollowRelations())}, nil
+}
+
+func (h *FollowHandler) Followers(ctx *gin.Context, req FollowListReq, uc jwt.UserClaims) (Result, error) {
+	if req.Uid <= 0 {
+		req.Uid = uc.UserId
+	}
+	if req.Limit <= 0 || req.Limit > 100 {
+		req.Limit = 20
+	}
+	resp, err := h.svc.GetFollower(ctx, &followv1.GetFollowerRequest{
+		Followee: req.Uid,
+		MinId:    req.MinId,
+		Limit:    req.Limit,
+	})
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Data: h.toVOs(resp.GetFollowRelations())}, nil
+}
+
+func (h *FollowHandler) Static(ctx *gin.Context, req FollowStaticReq, uc jwt.UserClaims) (Result, error) {
+	if req.Uid <= 0 {
+		req.Uid = uc.UserId
+	}
+	resp, err := h.svc.GetFollowStatic(ctx, &followv1.GetFollowStaticRequest{Uid: req.Uid})
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Data: FollowStaticVO{
+		Followers: resp.GetFollowStatic().GetFollowers(),
+		Followees: resp.GetFollowStatic().GetFollowees(),
+	}}, nil
+}
+
+func (h *FollowHandler) Check(ctx *gin.Context, req CheckFollowReq, uc jwt.UserClaims) (Result, error) {
+	if len(req.Followees) > 100 {
+		return Result{Code: 4, Msg: "一次最多查询 100 个用户"}, nil
+	}
+	resp, err := h.svc.BatchCheckFollow(ctx, &followv1.BatchCheckFollowRequest{
+		Follower:  uc.UserId,
+		Followees: req.Followees,
+	})
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Data: resp.GetFollowed()}, nil
+}
+
+func (h *FollowHandler) Feed(ctx *gin.Context, req FeedReq, uc jwt.UserClaims) (Result, error) {
+	before := time.Now()
+	if req.Before > 0 {
+		before = time.UnixMilli(req.Before)
+	}
+	if req.Limit <= 0 || req.Limit > 100 {
+		req.Limit = 20
+	}
+	arts, err := h.feedSvc.GetFeed(ctx, uc.UserId, before, req.BeforeId, req.Limit)
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{
+		Data: slice.Map[domain.Article, FeedArticleVO](arts, func(idx int, src domain.Article) FeedArticleVO {
+			return FeedArticleVO{
+				Id:       src.Id,
+				Title:    src.Title,
+				Abstract: src.Abstract(),
+				AuthorId: src.Author.Id,
+				Utime:    src.Utime.UnixMilli(),
+			}
+		}),
+	}, nil
+}
+
+func (h *FollowHandler) toVOs(rs []*followv1.FollowRelation) []FollowRelationVO {
+	return slice.Map(rs, func(idx int, src *followv1.FollowRelation) FollowRelationVO {
+		return FollowRelationVO{
+			Id:       src.GetId(),
+			Follower: src.GetFollower(),
+			Followee: src.GetFollowee(),
+			Ctime:    time.UnixMilli(src.GetCtime()).Format(time.DateTime),
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package web
+
+type FollowReq struct {
+	Followee int64 `json:"followee"`
+}
+
+type FollowListReq struct {
+	// Uid 要查看谁的关注列表或者粉丝列表，不传就是自己
+	Uid int64 `form:"uid"`
+	// MinId 上一页最后一条的 id，第一页不传
+	MinId int64 `form:"min_id"`
+	Limit int64 `form:"limit"`
+}
+
+type FollowStaticReq struct {
+	Uid int64 `form:"uid"`
+}
+
+type CheckFollowReq struct {
+	Followees []int64 `json:"followees"`
+}
+
+type FeedReq struct {
+	// Before 毫秒时间戳，翻页的时候传上一页最后一篇文章的更新时间，第一页不传
+	Before int64 `form:"before"`
+	// BeforeId 翻页的时候传上一页最后一篇文章的 id，和 Before 一起用
+	BeforeId int64 `form:"before_id"`
+	Limit    int   `form:"limit"`
+}
+
+type FollowRelationVO struct {
+	Id       int64  `json:"id"`
+	Follower int64  `json:"follower"`
+	Followee int64  `json:"followee"`
+	Ctime    string `json:"ctime"`
+}
+
+type FollowStaticVO struct {
+	Followers int64 `json:"followers"`
+	Followees int64 `json:"followees"`
+}
+
+type FeedArticleVO struct {
+	Id       int64  `json:"id"`
+	Title    string `json:"title"`
+	Abstract string `json:"abstract"`
+	AuthorId int64  `json:"author_id"`
+	// Utime 毫秒时间戳，下一页的 before 和 before_id 就用最后一篇的 utime 和 id
+	Utime int64 `json:"utime"`
+}
@@ -0,0 +1,69 @@
+package web
+
+import (
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/service"
+	"github.com/liupch66/basic-go/webook/internal/web/jwt"
+	"github.com/liupch66/basic-go/webook/pkg/ginx"
+)
+
+var _ handler = (*HistoryHandler)(nil)
+
+type HistoryHandler struct {
+	svc service.HistoryRecordService
+	// 目前只记录了文章的阅读历史
+	biz string
+}
+
+func NewHistoryHandler(svc service.HistoryRecordService) *HistoryHandler {
+	return &HistoryHandler{svc: svc, biz: "article"}
+}
+
+func (h *HistoryHandler) RegisterRoutes(server *gin.Engine) {
+	hg := server.Group("/history")
+	{
+		hg.GET("", ginx.WrapReqAndClaims[HistoryListReq, jwt.UserClaims](h.List))
+		hg.POST("/delete", ginx.WrapReqAndClaims[DeleteHistoryReq, jwt.UserClaims](h.Delete))
+		hg.POST("/clear", ginx.WrapClaims[jwt.UserClaims](h.Clear))
+	}
+}
+
+func (h *HistoryHandler) List(ctx *gin.Context, req HistoryListReq, uc jwt.UserClaims) (Result, error) {
+	if req.Limit <= 0 || req.Limit > 100 {
+		req.Limit = 20
+	}
+	rs, err := h.svc.List(ctx, uc.UserId, req.Offset, req.Limit)
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{
+		Data: slice.Map[domain.HistoryRecord, HistoryRecordVO](rs, func(idx int, src domain.HistoryRecord) HistoryRecordVO {
+			return HistoryRecordVO{
+				Biz:   src.Biz,
+				BizId: src.BizId,
+				Utime: src.Utime.Format(time.DateTime),
+			}
+		}),
+	}, nil
+}
+
+func (h *HistoryHandler) Delete(ctx *gin.Context, req DeleteHistoryReq, uc jwt.UserClaims) (Result, error) {
+	err := h.svc.Delete(ctx, uc.UserId, h.biz, req.BizId)
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Msg: "OK"}, nil
+}
+
+func (h *HistoryHandler) Clear(ctx *gin.Context, uc jwt.UserClaims) (Result, error) {
+	err := h.svc.Clear(ctx, uc.UserId)
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Msg: "OK"}, nil
+}
@@ -0,0 +1,18 @@
+package web
+
+type HistoryListReq struct {
+	Offset int `form:"offset"`
+	Limit  int `form:"limit"`
+}
+
+type DeleteHistoryReq struct {
+	// BizId 文章 id
+	BizId int64 `json:"biz_id"`
+}
+
+type HistoryRecordVO struct {
+	Biz   string `json:"biz"`
+	BizId int64  `json:"biz_id"`
+	// Utime 最近一次阅读的时间
+	Utime string `json:"utime"`
+}
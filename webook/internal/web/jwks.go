@@ -0,0 +1,30 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	ijwt "github.com/liupch66/basic-go/webook/internal/web/jwt"
+)
+
+var _ handler = (*JWKSHandler)(nil)
+
+// JWKSHandler 公开 access_token 的验签公钥，其他服务可以自己校验 token，不需要持有签名密钥。
+// 只有 RS256 和 EdDSA 的密钥会出现在这里
+type JWKSHandler struct {
+	keys *ijwt.Keyring
+}
+
+func NewJWKSHandler(keys *ijwt.Keyrings) *JWKSHandler {
+	return &JWKSHandler{keys: keys.Access}
+}
+
+func (h *JWKSHandler) RegisterRoutes(server *gin.Engine) {
+	server.GET("/.well-known/jwks.json", h.JWKS)
+}
+
+// JWKS 按照 RFC 7517 的格式返回，不套 Result
+func (h *JWKSHandler) JWKS(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, gin.H{"keys": h.keys.JWKS()})
+}
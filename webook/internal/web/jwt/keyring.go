@@ -0,0 +1,238 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrUnknownKid      = errors.New("未知的密钥 kid")
+	ErrNoSigningKey    = errors.New("当前密钥不能用来签名")
+	ErrUnsupportedAlg  = errors.New("不支持的签名算法")
+	ErrAlgMismatch     = errors.New("token 的签名算法和密钥不一致")
+	ErrInvalidKeyInput = errors.New("密钥配置错误")
+)
+
+// KeyConfig 一把密钥的配置。
+// HS256/HS384/HS512 用 Secret；RS256 和 EdDSA 用 PEM 格式的 PrivateKey/PublicKey，
+// 只配置 PublicKey 的密钥只能验签，不能作为当前签名密钥
+type KeyConfig struct {
+	Kid        string `yaml:"kid"`
+	Alg        string `yaml:"alg"`
+	Secret     string `yaml:"secret"`
+	PrivateKey string `yaml:"privateKey"`
+	PublicKey  string `yaml:"publicKey"`
+	// Retired 已经下线的密钥，签出来的 token 一律不认
+	Retired bool `yaml:"retired"`
+}
+
+// KeyringConfig Current 是签发新 token 用的 kid，Keys 里面没有 Retired 的密钥都可以用来验签
+type KeyringConfig struct {
+	Current string      `yaml:"current"`
+	Keys    []KeyConfig `yaml:"keys"`
+}
+
+// Key 解析好的密钥
+type Key struct {
+	Kid    string
+	Method jwt.SigningMethod
+	// SignKey 对称算法是 []byte，非对称算法是私钥，可以为 nil
+	SignKey any
+	// VerifyKey 对称算法是 []byte，非对称算法是公钥
+	VerifyKey any
+}
+
+func NewKey(cfg KeyConfig) (Key, error) {
+	if cfg.Kid == "" {
+		return Key{}, fmt.Errorf("%w: kid 不能为空", ErrInvalidKeyInput)
+	}
+	key := Key{Kid: cfg.Kid}
+	switch cfg.Alg {
+	case "HS256", "HS384", "HS512":
+		if cfg.Secret == "" {
+			return Key{}, fmt.Errorf("%w: %s 缺少 secret", ErrInvalidKeyInput, cfg.Kid)
+		}
+		key.Method = jwt.GetSigningMethod(cfg.Alg)
+		key.SignKey = []byte(cfg.Secret)
+		key.VerifyKey = []byte(cfg.Secret)
+	case "RS256":
+		key.Method = jwt.SigningMethodRS256
+		if cfg.PrivateKey != "" {
+			priv, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(cfg.PrivateKey))
+			if err != nil {
+				return Key{}, fmt.Errorf("%w: %s: %w", ErrInvalidKeyInput, cfg.Kid, err)
+			}
+			key.SignKey = priv
+			key.VerifyKey = &priv.PublicKey
+		}
+		if cfg.PublicKey != "" {
+			pub, err := jwt.ParseRSAPublicKeyFromPEM([]byte(cfg.PublicKey))
+			if err != nil {
+				return Key{}, fmt.Errorf("%w: %s: %w", ErrInvalidKeyInput, cfg.Kid, err)
+			}
+			key.VerifyKey = pub
+		}
+	case "EdDSA":
+		key.Method = jwt.SigningMethodEdDSA
+		if cfg.PrivateKey != "" {
+			priv, err := jwt.ParseEdPrivateKeyFromPEM([]byte(cfg.PrivateKey))
+			if err != nil {
+				return Key{}, fmt.Errorf("%w: %s: %w", ErrInvalidKeyInput, cfg.Kid, err)
+			}
+			key.SignKey = priv
+			key.VerifyKey = priv.(ed25519.PrivateKey).Public()
+		}
+		if cfg.PublicKey != "" {
+			pub, err := jwt.ParseEdPublicKeyFromPEM([]byte(cfg.PublicKey))
+			if err != nil {
+				return Key{}, fmt.Errorf("%w: %s: %w", ErrInvalidKeyInput, cfg.Kid, err)
+			}
+			key.VerifyKey = pub
+		}
+	default:
+		return Key{}, fmt.Errorf("%w: %s", ErrUnsupportedAlg, cfg.Alg)
+	}
+	if key.VerifyKey == nil {
+		return Key{}, fmt.Errorf("%w: %s 缺少 privateKey 或者 publicKey", ErrInvalidKeyInput, cfg.Kid)
+	}
+	return key, nil
+}
+
+// Keyring 一组轮换中的密钥。
+// 新 token 用当前密钥签名，并且在 header 里面带上 kid；验签的时候按 kid 找密钥。
+// 轮换的步骤：先把新密钥加进来（还不是 current），等所有实例都加载了再切 current，
+// 老密钥签的 token 都过期之后再把它标记为 retired
+type Keyring struct {
+	mu      sync.RWMutex
+	current Key
+	keys    map[string]Key
+}
+
+func NewKeyring(cfg KeyringConfig) (*Keyring, error) {
+	k := &Keyring{}
+	if err := k.Update(cfg); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// Update 整体替换密钥，配置有问题的时候保留原来的密钥
+func (k *Keyring) Update(cfg KeyringConfig) error {
+	keys := make(map[string]Key, len(cfg.Keys))
+	for _, kc := range cfg.Keys {
+		if kc.Retired {
+			continue
+		}
+		key, err := NewKey(kc)
+		if err != nil {
+			return err
+		}
+		keys[key.Kid] = key
+	}
+	current, ok := keys[cfg.Current]
+	if !ok {
+		return fmt.Errorf("%w: 当前密钥 %s 不存在或者已经下线", ErrInvalidKeyInput, cfg.Current)
+	}
+	if current.SignKey == nil {
+		return fmt.Errorf("%w: %s", ErrNoSigningKey, cfg.Current)
+	}
+	k.mu.Lock()
+	k.current = current
+	k.keys = keys
+	k.mu.Unlock()
+	return nil
+}
+
+// Sign 用当前密钥签名
+func (k *Keyring) Sign(claims jwt.Claims) (string, error) {
+	k.mu.RLock()
+	key := k.current
+	k.mu.RUnlock()
+	token := jwt.NewWithClaims(key.Method, claims)
+	token.Header["kid"] = key.Kid
+	return token.SignedString(key.SignKey)
+}
+
+// Parse 解析并且校验 token，claims 必须是指针
+func (k *Keyring) Parse(tokenStr string, claims jwt.Claims) (*jwt.Token, error) {
+	return jwt.ParseWithClaims(tokenStr, claims, k.keyFunc)
+}
+
+func (k *Keyring) keyFunc(token *jwt.Token) (any, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	var key Key
+	kid, ok := token.Header["kid"].(string)
+	if !ok {
+		// 引入 kid 之前签发的 token 没有 kid，只能拿当前密钥试一下，轮换之后就失效了
+		key = k.current
+	} else if key, ok = k.keys[kid]; !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKid, kid)
+	}
+	// 防止拿 HMAC 伪造 RS256 之类的算法混淆攻击
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, ErrAlgMismatch
+	}
+	return key.VerifyKey, nil
+}
+
+// JWK RFC 7517 格式的公钥
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Ed25519
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKS 导出所有非对称密钥的公钥，其他服务拿到之后就能自己验签，不需要持有签名的密钥。
+// 对称密钥不会出现在这里
+func (k *Keyring) JWKS() []JWK {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	res := make([]JWK, 0, len(k.keys))
+	for _, key := range k.keys {
+		jwk, ok := toJWK(key)
+		if ok {
+			res = append(res, jwk)
+		}
+	}
+	return res
+}
+
+func toJWK(key Key) (JWK, bool) {
+	enc := base64.RawURLEncoding
+	jwk := JWK{Kid: key.Kid, Alg: key.Method.Alg(), Use: "sig"}
+	switch pub := key.VerifyKey.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = enc.EncodeToString(pub.N.Bytes())
+		jwk.E = enc.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = enc.EncodeToString(pub)
+	default:
+		return JWK{}, false
+	}
+	return jwk, true
+}
+
+// Keyrings access_token、refresh_token 和微信扫码的 state 各用一组密钥，互相之间不能混用
+type Keyrings struct {
+	Access  *Keyring
+	Refresh *Keyring
+	State   *Keyring
+}
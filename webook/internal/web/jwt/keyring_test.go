@@ -0,0 +1,117 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyring_Rotate(t *testing.T) {
+	k1 := KeyConfig{Kid: "k1", Alg: "HS512", Secret: "secret-1"}
+	k2 := KeyConfig{Kid: "k2", Alg: "HS256", Secret: "secret-2"}
+	ring, err := NewKeyring(KeyringConfig{Current: "k1", Keys: []KeyConfig{k1}})
+	require.NoError(t, err)
+
+	oldToken, err := ring.Sign(testClaims(123))
+	require.NoError(t, err)
+
+	// 切换到新密钥，老 token 还能用
+	require.NoError(t, ring.Update(KeyringConfig{Current: "k2", Keys: []KeyConfig{k1, k2}}))
+	newToken, err := ring.Sign(testClaims(456))
+	require.NoError(t, err)
+	var uc UserClaims
+	_, err = ring.Parse(oldToken, &uc)
+	require.NoError(t, err)
+	assert.Equal(t, int64(123), uc.UserId)
+	_, err = ring.Parse(newToken, &uc)
+	require.NoError(t, err)
+	assert.Equal(t, int64(456), uc.UserId)
+
+	// 老密钥下线之后，老 token 就不认了
+	k1.Retired = true
+	require.NoError(t, ring.Update(KeyringConfig{Current: "k2", Keys: []KeyConfig{k1, k2}}))
+	_, err = ring.Parse(oldToken, &uc)
+	assert.ErrorIs(t, err, ErrUnknownKid)
+
+	// current 指向下线的密钥，更新失败，保留原来的密钥
+	err = ring.Update(KeyringConfig{Current: "k1", Keys: []KeyConfig{k1, k2}})
+	assert.ErrorIs(t, err, ErrInvalidKeyInput)
+	_, err = ring.Parse(newToken, &uc)
+	assert.NoError(t, err)
+}
+
+func TestKeyring_Asymmetric(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	edDer, err := x509.MarshalPKCS8PrivateKey(edKey)
+	require.NoError(t, err)
+	rsaPub, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	require.NoError(t, err)
+
+	keys := []KeyConfig{
+		{Kid: "rs", Alg: "RS256", PrivateKey: toPEM("RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey))},
+		{Kid: "ed", Alg: "EdDSA", PrivateKey: toPEM("PRIVATE KEY", edDer)},
+		{Kid: "hs", Alg: "HS512", Secret: "secret"},
+	}
+	for _, cur := range []string{"rs", "ed"} {
+		ring, err := NewKeyring(KeyringConfig{Current: cur, Keys: keys})
+		require.NoError(t, err)
+		tokenStr, err := ring.Sign(testClaims(123))
+		require.NoError(t, err)
+
+		// 其他服务只有公钥也能验签
+		verifier, err := NewKeyring(KeyringConfig{Current: "hs", Keys: []KeyConfig{
+			{Kid: "rs", Alg: "RS256", PublicKey: toPEM("PUBLIC KEY", rsaPub)},
+			{Kid: "ed", Alg: "EdDSA", PublicKey: toPEM("PUBLIC KEY", mustPKIX(t, edKey.Public()))},
+			{Kid: "hs", Alg: "HS512", Secret: "secret"},
+		}})
+		require.NoError(t, err)
+		var uc UserClaims
+		_, err = verifier.Parse(tokenStr, &uc)
+		require.NoError(t, err)
+		assert.Equal(t, int64(123), uc.UserId)
+
+		jwks := ring.JWKS()
+		assert.Len(t, jwks, 2)
+	}
+}
+
+func TestKeyring_AlgMismatch(t *testing.T) {
+	ring, err := NewKeyring(KeyringConfig{Current: "k1", Keys: []KeyConfig{{Kid: "k1", Alg: "HS512", Secret: "secret"}}})
+	require.NoError(t, err)
+	// kid 对得上，但是算法不一致
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, testClaims(123))
+	token.Header["kid"] = "k1"
+	tokenStr, err := token.SignedString([]byte("secret"))
+	require.NoError(t, err)
+	var uc UserClaims
+	_, err = ring.Parse(tokenStr, &uc)
+	assert.ErrorIs(t, err, ErrAlgMismatch)
+}
+
+func testClaims(uid int64) UserClaims {
+	return UserClaims{
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute))},
+		UserId:           uid,
+	}
+}
+
+func toPEM(typ string, der []byte) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}))
+}
+
+func mustPKIX(t *testing.T, pub any) []byte {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err)
+	return der
+}
@@ -0,0 +1,26 @@
+-- KEYS[1] 会话记录，KEYS[2] 用户的会话索引
+-- ARGV[1] 这次拿来刷新的 jti，ARGV[2] 新的 jti，ARGV[3] 当前时间（毫秒）
+-- ARGV[4] 宽限期（毫秒），ARGV[5] 会话的过期时间（毫秒）
+-- 返回 {状态, jti}：0 正常轮换，新 token 用 ARGV[2]；1 宽限期内的并发刷新，新 token 沿用返回的 jti；
+-- 2 重复使用；-1 会话不存在
+if redis.call("EXISTS", KEYS[1]) == 0 then
+    return {-1, ""}
+end
+local cur = redis.call("HGET", KEYS[1], "rt_jti")
+if cur == false then
+    -- 引入轮换之前登录的会话没有 jti
+    cur = ""
+end
+if cur == ARGV[1] then
+    redis.call("HSET", KEYS[1], "rt_jti", ARGV[2], "rt_prev", ARGV[1], "rt_rotated_at", ARGV[3])
+    redis.call("PEXPIRE", KEYS[1], ARGV[5])
+    redis.call("PEXPIRE", KEYS[2], ARGV[5])
+    return {0, ARGV[2]}
+end
+local prev = redis.call("HGET", KEYS[1], "rt_prev")
+local at = redis.call("HGET", KEYS[1], "rt_rotated_at")
+-- 同一个客户端并发刷新，第二个请求拿的还是上一个 jti
+if prev == ARGV[1] and at ~= false and tonumber(ARGV[3]) - tonumber(at) <= tonumber(ARGV[4]) then
+    return {1, cur}
+end
+return {2, ""}
@@ -0,0 +1,10 @@
+-- 会话记录已经过期或者被踢掉了，就不要再写回去
+local last = redis.call("HGET", KEYS[1], "last_seen")
+if last == false then
+    return 0
+end
+-- 每个请求都写一次 Redis 太浪费了，间隔够久才更新最近活跃时间
+if tonumber(ARGV[1]) - tonumber(last) >= tonumber(ARGV[2]) then
+    redis.call("HSET", KEYS[1], "last_seen", ARGV[1])
+end
+return 1
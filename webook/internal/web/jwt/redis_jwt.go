@@ -1,8 +1,11 @@
 package jwt
 
 import (
+	"context"
+	_ "embed"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -13,30 +16,54 @@ import (
 )
 
 var (
-	// AtKey access_token key
-	AtKey = []byte("C%B|]SiozBE,S)X>ru,3Uu0+rl1Lj.@O")
-	// RtKey refresh_token key
-	RtKey = []byte("C%B|]SiozBE,S)X>ru,3Uu0+rl1Lj.@1")
+	//go:embed lua/touch_session.lua
+	luaTouchSession string
+	//go:embed lua/rotate_refresh_token.lua
+	luaRotateRefreshToken string
+
+	ErrSessionNotFound = errors.New("会话不存在")
+	ErrInvalidToken    = errors.New("token 无效")
+	// ErrRefreshTokenReused 用过的 refresh_token 又被拿来刷新，可能是被盗用了
+	ErrRefreshTokenReused = errors.New("refresh_token 被重复使用")
+)
+
+const (
+	// sessionExpiration 和 refresh_token 的有效期保持一致
+	sessionExpiration = time.Hour * 24 * 7
+	// touchInterval 最近活跃时间的更新间隔
+	touchInterval = time.Minute
+	// refreshGracePeriod 客户端并发刷新的时候，刚被换掉的 refresh_token 在这段时间里面还可以用
+	refreshGracePeriod = time.Second * 10
 )
 
 type RedisJwtHandler struct {
-	cmd redis.Cmdable
+	cmd        redis.Cmdable
+	keys       *Keyrings
+	authorizer Authorizer
 }
 
-func NewRedisJwtHandler(cmd redis.Cmdable) Handler {
-	return &RedisJwtHandler{cmd: cmd}
+// NewRedisJwtHandler authorizer 为 nil 的话只能校验 token，不能签发
+func NewRedisJwtHandler(cmd redis.Cmdable, keys *Keyrings, authorizer Authorizer) Handler {
+	return &RedisJwtHandler{cmd: cmd, keys: keys, authorizer: authorizer}
 }
 
+// SetJwtToken 登录和刷新都会走到这里，每次都重新查一遍角色和权限
 func (h *RedisJwtHandler) SetJwtToken(ctx *gin.Context, userId int64, ssid string) error {
+	roles, perms, err := h.authorizer.Authorize(ctx, userId)
+	if err != nil {
+		return err
+	}
 	uc := UserClaims{
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute * 30)),
 		},
+		Ssid:      ssid,
 		UserId:    userId,
 		UserAgent: ctx.Request.UserAgent(),
+		Roles:     roles,
+		Perms:     perms,
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS512, uc)
-	tokenStr, err := token.SignedString(AtKey)
+	tokenStr, err := h.keys.Access.Sign(uc)
 	if err != nil {
 		return err
 	}
@@ -44,16 +71,28 @@ func (h *RedisJwtHandler) SetJwtToken(ctx *gin.Context, userId int64, ssid strin
 	return nil
 }
 
+// SetRefreshToken 重新签发一个 refresh_token，之前签发的就作废了
 func (h *RedisJwtHandler) SetRefreshToken(ctx *gin.Context, userId int64, ssid string) error {
+	jti := uuid.New().String()
+	pipe := h.cmd.TxPipeline()
+	pipe.HSet(ctx, h.sessionKey(ssid), "rt_jti", jti)
+	pipe.Expire(ctx, h.sessionKey(ssid), sessionExpiration)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+	return h.setRefreshToken(ctx, userId, ssid, jti)
+}
+
+func (h *RedisJwtHandler) setRefreshToken(ctx *gin.Context, userId int64, ssid, jti string) error {
 	rc := RefreshClaims{
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour * 24 * 7)),
+			ID:        jti,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(sessionExpiration)),
 		},
 		Ssid: ssid,
 		Uid:  userId,
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS512, rc)
-	tokenStr, err := token.SignedString(RtKey)
+	tokenStr, err := h.keys.Refresh.Sign(rc)
 	if err != nil {
 		return err
 	}
@@ -61,19 +100,117 @@ func (h *RedisJwtHandler) SetRefreshToken(ctx *gin.Context, userId int64, ssid s
 	return nil
 }
 
-func (h *RedisJwtHandler) SetLoginToken(ctx *gin.Context, uid int64) error {
+// RotateRefreshToken 每个 refresh_token 只能用一次，同一个会话里面只有最新签发的那个（按 jti 区分）是有效的。
+// 已经用过的 refresh_token 又出现了，说明很可能被盗用了，整个会话直接踢掉
+func (h *RedisJwtHandler) RotateRefreshToken(ctx *gin.Context, rc RefreshClaims) error {
+	newJti := uuid.New().String()
+	res, err := h.cmd.Eval(ctx, luaRotateRefreshToken,
+		[]string{h.sessionKey(rc.Ssid), h.userSessionsKey(rc.Uid)},
+		rc.ID, newJti, time.Now().UnixMilli(),
+		refreshGracePeriod.Milliseconds(), sessionExpiration.Milliseconds()).Slice()
+	if err != nil {
+		return err
+	}
+	code, _ := res[0].(int64)
+	jti, _ := res[1].(string)
+	switch code {
+	case 0, 1:
+		if err = h.SetJwtToken(ctx, rc.Uid, rc.Ssid); err != nil {
+			return err
+		}
+		return h.setRefreshToken(ctx, rc.Uid, rc.Ssid, jti)
+	case 2:
+		if err = h.revoke(ctx, rc.Uid, rc.Ssid); err != nil {
+			return fmt.Errorf("%w, 踢掉会话失败: %w", ErrRefreshTokenReused, err)
+		}
+		return ErrRefreshTokenReused
+	default:
+		// 引入会话记录之前登录的，refresh_token 里面没有 jti，redis 里面也没有会话记录
+		if rc.ID == "" {
+			return h.restoreLegacySession(ctx, rc)
+		}
+		return ErrSessionNotFound
+	}
+}
+
+// restoreLegacySession 老会话没有被拉黑的话补一条会话记录，之后就和新会话一样轮换了
+func (h *RedisJwtHandler) restoreLegacySession(ctx *gin.Context, rc RefreshClaims) error {
+	exists, err := h.cmd.Exists(ctx, fmt.Sprintf("users:ssid:%s", rc.Ssid)).Result()
+	if err != nil {
+		return err
+	}
+	if exists == 1 {
+		return ErrSessionNotFound
+	}
+	if err = h.SetJwtToken(ctx, rc.Uid, rc.Ssid); err != nil {
+		return err
+	}
+	jti := uuid.New().String()
+	if err = h.setRefreshToken(ctx, rc.Uid, rc.Ssid, jti); err != nil {
+		return err
+	}
+	return h.saveSession(ctx, rc.Uid, rc.Ssid, LoginMethodLegacy, jti)
+}
+
+func (h *RedisJwtHandler) ParseAccessToken(tokenStr string) (UserClaims, error) {
+	var uc UserClaims
+	token, err := h.keys.Access.Parse(tokenStr, &uc)
+	if err != nil {
+		return UserClaims{}, err
+	}
+	if !token.Valid {
+		return UserClaims{}, ErrInvalidToken
+	}
+	return uc, nil
+}
+
+func (h *RedisJwtHandler) ParseRefreshToken(tokenStr string) (RefreshClaims, error) {
+	var rc RefreshClaims
+	token, err := h.keys.Refresh.Parse(tokenStr, &rc)
+	if err != nil {
+		return RefreshClaims{}, err
+	}
+	if !token.Valid {
+		return RefreshClaims{}, ErrInvalidToken
+	}
+	return rc, nil
+}
+
+func (h *RedisJwtHandler) SetLoginToken(ctx *gin.Context, uid int64, method string) error {
 	ssid := uuid.New().String()
 	if err := h.SetJwtToken(ctx, uid, ssid); err != nil {
 		return err
 	}
-	return h.SetRefreshToken(ctx, uid, ssid)
+	jti := uuid.New().String()
+	if err := h.setRefreshToken(ctx, uid, ssid, jti); err != nil {
+		return err
+	}
+	return h.saveSession(ctx, uid, ssid, method, jti)
+}
+
+func (h *RedisJwtHandler) saveSession(ctx *gin.Context, uid int64, ssid, method, jti string) error {
+	now := time.Now().UnixMilli()
+	pipe := h.cmd.TxPipeline()
+	pipe.HSet(ctx, h.sessionKey(ssid),
+		"uid", uid,
+		"user_agent", ctx.Request.UserAgent(),
+		"ip", ctx.ClientIP(),
+		"method", method,
+		"ctime", now,
+		"last_seen", now,
+		"rt_jti", jti)
+	pipe.Expire(ctx, h.sessionKey(ssid), sessionExpiration)
+	pipe.ZAdd(ctx, h.userSessionsKey(uid), redis.Z{Score: float64(now), Member: ssid})
+	pipe.Expire(ctx, h.userSessionsKey(uid), sessionExpiration)
+	_, err := pipe.Exec(ctx)
+	return err
 }
 
 func (h *RedisJwtHandler) ClearToken(ctx *gin.Context) error {
 	ctx.Header("x-jwt-token", "")
 	ctx.Header("x-refresh-token", "")
 	uc := ctx.MustGet("user_claims").(UserClaims)
-	return h.cmd.Set(ctx, fmt.Sprintf("users:ssid:%s", uc.Ssid), "", time.Hour*24*7).Err()
+	return h.revoke(ctx, uc.UserId, uc.Ssid)
 }
 
 func (h *RedisJwtHandler) CheckSession(ctx *gin.Context, ssid string) error {
@@ -92,6 +229,9 @@ func (h *RedisJwtHandler) CheckSession(ctx *gin.Context, ssid string) error {
 	if exists == 1 {
 		return errors.New("用户已经退出登录")
 	}
+	// 顺便刷新最近活跃时间，失败了也不影响这次请求
+	_ = h.cmd.Eval(ctx, luaTouchSession, []string{h.sessionKey(ssid)},
+		time.Now().UnixMilli(), touchInterval.Milliseconds()).Err()
 	return nil
 }
 
@@ -103,3 +243,97 @@ func (h *RedisJwtHandler) ExtractToken(ctx *gin.Context) string {
 	}
 	return segments[1]
 }
+
+func (h *RedisJwtHandler) ListSessions(ctx context.Context, uid int64) ([]Session, error) {
+	ssids, err := h.cmd.ZRevRange(ctx, h.userSessionsKey(uid), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(ssids) == 0 {
+		return []Session{}, nil
+	}
+	pipe := h.cmd.Pipeline()
+	cmds := make([]*redis.MapStringStringCmd, 0, len(ssids))
+	for _, ssid := range ssids {
+		cmds = append(cmds, pipe.HGetAll(ctx, h.sessionKey(ssid)))
+	}
+	if _, err = pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+	res := make([]Session, 0, len(ssids))
+	var expired []any
+	for i, cmd := range cmds {
+		vals := cmd.Val()
+		// 会话记录过期了，索引里面的也顺手清掉
+		if len(vals) == 0 {
+			expired = append(expired, ssids[i])
+			continue
+		}
+		res = append(res, h.toSession(ssids[i], vals))
+	}
+	if len(expired) > 0 {
+		_ = h.cmd.ZRem(ctx, h.userSessionsKey(uid), expired...).Err()
+	}
+	return res, nil
+}
+
+func (h *RedisJwtHandler) RevokeSession(ctx context.Context, uid int64, ssid string) error {
+	// 只能踢掉自己的会话
+	err := h.cmd.ZScore(ctx, h.userSessionsKey(uid), ssid).Err()
+	if errors.Is(err, redis.Nil) {
+		return ErrSessionNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return h.revoke(ctx, uid, ssid)
+}
+
+func (h *RedisJwtHandler) RevokeOtherSessions(ctx context.Context, uid int64, curSsid string) error {
+	ssids, err := h.cmd.ZRange(ctx, h.userSessionsKey(uid), 0, -1).Result()
+	if err != nil {
+		return err
+	}
+	for _, ssid := range ssids {
+		if ssid == curSsid {
+			continue
+		}
+		if err = h.revoke(ctx, uid, ssid); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// revoke 把 ssid 拉黑，CheckSession 就会拒绝这个会话的 access_token 和 refresh_token
+func (h *RedisJwtHandler) revoke(ctx context.Context, uid int64, ssid string) error {
+	pipe := h.cmd.TxPipeline()
+	pipe.Set(ctx, fmt.Sprintf("users:ssid:%s", ssid), "", sessionExpiration)
+	pipe.Del(ctx, h.sessionKey(ssid))
+	pipe.ZRem(ctx, h.userSessionsKey(uid), ssid)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (h *RedisJwtHandler) toSession(ssid string, vals map[string]string) Session {
+	uid, _ := strconv.ParseInt(vals["uid"], 10, 64)
+	ctime, _ := strconv.ParseInt(vals["ctime"], 10, 64)
+	lastSeen, _ := strconv.ParseInt(vals["last_seen"], 10, 64)
+	return Session{
+		Ssid:      ssid,
+		Uid:       uid,
+		UserAgent: vals["user_agent"],
+		Ip:        vals["ip"],
+		Method:    vals["method"],
+		Ctime:     time.UnixMilli(ctime),
+		LastSeen:  time.UnixMilli(lastSeen),
+	}
+}
+
+func (h *RedisJwtHandler) sessionKey(ssid string) string {
+	return fmt.Sprintf("users:session:%s", ssid)
+}
+
+func (h *RedisJwtHandler) userSessionsKey(uid int64) string {
+	return fmt.Sprintf("users:sessions:%d", uid)
+}
@@ -0,0 +1,297 @@
+package jwt
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisJwtHandler_RevokeSession(t *testing.T) {
+	testCases := []struct {
+		name   string
+		before func(t *testing.T, mr *miniredis.Miniredis)
+		after  func(t *testing.T, mr *miniredis.Miniredis)
+		ssid   string
+
+		wantErr error
+	}{
+		{
+			name: "踢掉自己的会话",
+			before: func(t *testing.T, mr *miniredis.Miniredis) {
+				seedSession(t, mr, 1, "s1")
+				seedSession(t, mr, 1, "s2")
+			},
+			after: func(t *testing.T, mr *miniredis.Miniredis) {
+				assertRevoked(t, mr, 1, "s1")
+				assertActive(t, mr, 1, "s2")
+			},
+			ssid: "s1",
+		},
+		{
+			name: "别人的会话",
+			before: func(t *testing.T, mr *miniredis.Miniredis) {
+				seedSession(t, mr, 2, "s3")
+			},
+			after: func(t *testing.T, mr *miniredis.Miniredis) {
+				assertActive(t, mr, 2, "s3")
+			},
+			ssid:    "s3",
+			wantErr: ErrSessionNotFound,
+		},
+		{
+			name:    "会话不存在",
+			before:  func(t *testing.T, mr *miniredis.Miniredis) {},
+			after:   func(t *testing.T, mr *miniredis.Miniredis) {},
+			ssid:    "s4",
+			wantErr: ErrSessionNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mr := miniredis.RunT(t)
+			h := newRedisJwtHandler(t, mr)
+			tc.before(t, mr)
+			err := h.RevokeSession(context.Background(), 1, tc.ssid)
+			assert.Equal(t, tc.wantErr, err)
+			tc.after(t, mr)
+		})
+	}
+}
+
+func TestRedisJwtHandler_RevokeOtherSessions(t *testing.T) {
+	mr := miniredis.RunT(t)
+	h := newRedisJwtHandler(t, mr)
+	seedSession(t, mr, 1, "s1")
+	seedSession(t, mr, 1, "s2")
+	seedSession(t, mr, 1, "s3")
+	seedSession(t, mr, 2, "s4")
+
+	require.NoError(t, h.RevokeOtherSessions(context.Background(), 1, "s1"))
+	assertActive(t, mr, 1, "s1")
+	assertRevoked(t, mr, 1, "s2")
+	assertRevoked(t, mr, 1, "s3")
+	// 别人的会话不受影响
+	assertActive(t, mr, 2, "s4")
+}
+
+func TestRedisJwtHandler_CheckSession(t *testing.T) {
+	testCases := []struct {
+		name   string
+		before func(t *testing.T, h Handler, mr *miniredis.Miniredis)
+		after  func(t *testing.T, mr *miniredis.Miniredis)
+
+		wantErr bool
+	}{
+		{
+			name: "正常的会话，顺便刷新最近活跃时间",
+			before: func(t *testing.T, h Handler, mr *miniredis.Miniredis) {
+				seedSession(t, mr, 1, "s1")
+			},
+			after: func(t *testing.T, mr *miniredis.Miniredis) {
+				lastSeen, err := strconv.ParseInt(mr.HGet("users:session:s1", "last_seen"), 10, 64)
+				require.NoError(t, err)
+				assert.True(t, time.Since(time.UnixMilli(lastSeen)) < time.Minute)
+			},
+		},
+		{
+			name: "被踢掉的会话",
+			before: func(t *testing.T, h Handler, mr *miniredis.Miniredis) {
+				seedSession(t, mr, 1, "s1")
+				require.NoError(t, h.RevokeSession(context.Background(), 1, "s1"))
+			},
+			after: func(t *testing.T, mr *miniredis.Miniredis) {
+				// 被踢掉的会话不能被最近活跃时间写回去
+				assert.False(t, mr.Exists("users:session:s1"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mr := miniredis.RunT(t)
+			h := newRedisJwtHandler(t, mr)
+			tc.before(t, h, mr)
+			ctx, _ := newGinContext()
+			err := h.CheckSession(ctx, "s1")
+			assert.Equal(t, tc.wantErr, err != nil)
+			tc.after(t, mr)
+		})
+	}
+}
+
+func TestRedisJwtHandler_RotateRefreshToken(t *testing.T) {
+	now := time.Now()
+	testCases := []struct {
+		name   string
+		before func(t *testing.T, mr *miniredis.Miniredis)
+		// after 拿到的是响应里面新 refresh_token 的 jti，没有签发的话是空字符串
+		after func(t *testing.T, mr *miniredis.Miniredis, jti string)
+		jti   string
+
+		wantErr error
+	}{
+		{
+			name: "正常轮换",
+			before: func(t *testing.T, mr *miniredis.Miniredis) {
+				seedSession(t, mr, 1, "s1", "rt_jti", "j1")
+			},
+			after: func(t *testing.T, mr *miniredis.Miniredis, jti string) {
+				assert.NotEmpty(t, jti)
+				assert.NotEqual(t, "j1", jti)
+				assert.Equal(t, jti, mr.HGet("users:session:s1", "rt_jti"))
+				assert.Equal(t, "j1", mr.HGet("users:session:s1", "rt_prev"))
+			},
+			jti: "j1",
+		},
+		{
+			name: "宽限期内的并发刷新，沿用当前的 jti",
+			before: func(t *testing.T, mr *miniredis.Miniredis) {
+				seedSession(t, mr, 1, "s1", "rt_jti", "j2", "rt_prev", "j1",
+					"rt_rotated_at", strconv.FormatInt(now.Add(-time.Second).UnixMilli(), 10))
+			},
+			after: func(t *testing.T, mr *miniredis.Miniredis, jti string) {
+				assert.Equal(t, "j2", jti)
+				assert.Equal(t, "j2", mr.HGet("users:session:s1", "rt_jti"))
+				assertActive(t, mr, 1, "s1")
+			},
+			jti: "j1",
+		},
+		{
+			name: "过了宽限期重复使用，踢掉会话",
+			before: func(t *testing.T, mr *miniredis.Miniredis) {
+				seedSession(t, mr, 1, "s1", "rt_jti", "j2", "rt_prev", "j1",
+					"rt_rotated_at", strconv.FormatInt(now.Add(-refreshGracePeriod-time.Second).UnixMilli(), 10))
+			},
+			after: func(t *testing.T, mr *miniredis.Miniredis, jti string) {
+				assert.Empty(t, jti)
+				assertRevoked(t, mr, 1, "s1")
+			},
+			jti:     "j1",
+			wantErr: ErrRefreshTokenReused,
+		},
+		{
+			name: "引入轮换之前登录的会话没有 jti",
+			before: func(t *testing.T, mr *miniredis.Miniredis) {
+				seedSession(t, mr, 1, "s1")
+			},
+			after: func(t *testing.T, mr *miniredis.Miniredis, jti string) {
+				assert.NotEmpty(t, jti)
+				assert.Equal(t, jti, mr.HGet("users:session:s1", "rt_jti"))
+			},
+		},
+		{
+			name:   "引入会话记录之前登录的，补一条会话记录",
+			before: func(t *testing.T, mr *miniredis.Miniredis) {},
+			after: func(t *testing.T, mr *miniredis.Miniredis, jti string) {
+				assert.NotEmpty(t, jti)
+				assert.Equal(t, jti, mr.HGet("users:session:s1", "rt_jti"))
+				assert.Equal(t, LoginMethodLegacy, mr.HGet("users:session:s1", "method"))
+				assertActive(t, mr, 1, "s1")
+			},
+		},
+		{
+			name: "引入会话记录之前登录的，已经退出登录了",
+			before: func(t *testing.T, mr *miniredis.Miniredis) {
+				require.NoError(t, mr.Set("users:ssid:s1", ""))
+			},
+			after: func(t *testing.T, mr *miniredis.Miniredis, jti string) {
+				assert.Empty(t, jti)
+				assert.False(t, mr.Exists("users:session:s1"))
+			},
+			wantErr: ErrSessionNotFound,
+		},
+		{
+			name:   "会话不存在",
+			before: func(t *testing.T, mr *miniredis.Miniredis) {},
+			after: func(t *testing.T, mr *miniredis.Miniredis, jti string) {
+				assert.Empty(t, jti)
+				assert.False(t, mr.Exists("users:session:s1"))
+			},
+			jti:     "j1",
+			wantErr: ErrSessionNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mr := miniredis.RunT(t)
+			h := newRedisJwtHandler(t, mr)
+			tc.before(t, mr)
+			ctx, resp := newGinContext()
+			rc := RefreshClaims{RegisteredClaims: jwt.RegisteredClaims{ID: tc.jti}, Ssid: "s1", Uid: 1}
+			err := h.RotateRefreshToken(ctx, rc)
+			assert.ErrorIs(t, err, tc.wantErr)
+			var jti string
+			if tokenStr := resp.Header().Get("x-refresh-token"); tokenStr != "" {
+				assert.NotEmpty(t, resp.Header().Get("x-jwt-token"))
+				var newRc RefreshClaims
+				_, err = h.(*RedisJwtHandler).keys.Refresh.Parse(tokenStr, &newRc)
+				require.NoError(t, err)
+				jti = newRc.ID
+			}
+			tc.after(t, mr, jti)
+		})
+	}
+}
+
+type authorizerFunc func(ctx context.Context, uid int64) ([]string, []string, error)
+
+func (f authorizerFunc) Authorize(ctx context.Context, uid int64) ([]string, []string, error) {
+	return f(ctx, uid)
+}
+
+func newRedisJwtHandler(t *testing.T, mr *miniredis.Miniredis) Handler {
+	newKeyring := func(kid string) *Keyring {
+		ring, err := NewKeyring(KeyringConfig{Current: kid, Keys: []KeyConfig{{Kid: kid, Alg: "HS512", Secret: kid + "-secret"}}})
+		require.NoError(t, err)
+		return ring
+	}
+	keys := &Keyrings{Access: newKeyring("at"), Refresh: newKeyring("rt"), State: newKeyring("st")}
+	authorizer := authorizerFunc(func(ctx context.Context, uid int64) ([]string, []string, error) {
+		return nil, nil, nil
+	})
+	return NewRedisJwtHandler(redis.NewClient(&redis.Options{Addr: mr.Addr()}), keys, authorizer)
+}
+
+func newGinContext() (*gin.Context, *httptest.ResponseRecorder) {
+	resp := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(resp)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/users/refresh_token", nil)
+	return ctx, resp
+}
+
+// seedSession 和 SetLoginToken 写进去的一样，last_seen 是一个小时之前
+func seedSession(t *testing.T, mr *miniredis.Miniredis, uid int64, ssid string, fields ...string) {
+	before := strconv.FormatInt(time.Now().Add(-time.Hour).UnixMilli(), 10)
+	mr.HSet("users:session:"+ssid, append([]string{
+		"uid", strconv.FormatInt(uid, 10),
+		"method", LoginMethodPassword,
+		"ctime", before,
+		"last_seen", before,
+	}, fields...)...)
+	_, err := mr.ZAdd("users:sessions:"+strconv.FormatInt(uid, 10), float64(time.Now().UnixMilli()), ssid)
+	require.NoError(t, err)
+}
+
+func assertActive(t *testing.T, mr *miniredis.Miniredis, uid int64, ssid string) {
+	assert.True(t, mr.Exists("users:session:"+ssid))
+	assert.False(t, mr.Exists("users:ssid:"+ssid))
+	members, _ := mr.ZMembers("users:sessions:" + strconv.FormatInt(uid, 10))
+	assert.Contains(t, members, ssid)
+}
+
+func assertRevoked(t *testing.T, mr *miniredis.Miniredis, uid int64, ssid string) {
+	assert.False(t, mr.Exists("users:session:"+ssid))
+	assert.True(t, mr.Exists("users:ssid:"+ssid))
+	members, _ := mr.ZMembers("users:sessions:" + strconv.FormatInt(uid, 10))
+	assert.NotContains(t, members, ssid)
+}
@@ -1,6 +1,10 @@
 package jwt
 
 import (
+	"context"
+	"slices"
+	"time"
+
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 )
@@ -8,10 +12,45 @@ import (
 type Handler interface {
 	SetJwtToken(ctx *gin.Context, userId int64, ssid string) error
 	SetRefreshToken(ctx *gin.Context, userId int64, ssid string) error
-	SetLoginToken(ctx *gin.Context, uid int64) error
+	// SetLoginToken 登录成功之后调用，method 是登录方式，会一起记在会话记录里面
+	SetLoginToken(ctx *gin.Context, uid int64, method string) error
 	ClearToken(ctx *gin.Context) error
 	CheckSession(ctx *gin.Context, ssid string) error
 	ExtractToken(ctx *gin.Context) string
+	// ParseAccessToken 校验签名和有效期，按 token header 里面的 kid 找验签的密钥
+	ParseAccessToken(tokenStr string) (UserClaims, error)
+	ParseRefreshToken(tokenStr string) (RefreshClaims, error)
+	// RotateRefreshToken 用 refresh_token 换一对新的 access_token 和 refresh_token，老的 refresh_token 作废。
+	// 重复使用会踢掉整个会话并且返回 ErrRefreshTokenReused
+	RotateRefreshToken(ctx *gin.Context, rc RefreshClaims) error
+
+	// ListSessions 列出用户所有还在有效期内的登录会话，按登录时间倒序
+	ListSessions(ctx context.Context, uid int64) ([]Session, error)
+	// RevokeSession 踢掉用户的某一个会话，会话不属于该用户返回 ErrSessionNotFound
+	RevokeSession(ctx context.Context, uid int64, ssid string) error
+	// RevokeOtherSessions 踢掉除了 curSsid 之外的所有会话
+	RevokeOtherSessions(ctx context.Context, uid int64, curSsid string) error
+}
+
+// 第三方登录的 method 直接用平台的名字，比如 wechat、github
+const (
+	LoginMethodPassword = "password"
+	LoginMethodSMS      = "sms"
+	// LoginMethodPasswordTotp 密码加上 TOTP 两步验证
+	LoginMethodPasswordTotp = "password_totp"
+	// LoginMethodLegacy 引入会话记录之前登录的，不知道是怎么登录的
+	LoginMethodLegacy = "legacy"
+)
+
+// Session 一次登录对应一个会话，用 ssid 标识
+type Session struct {
+	Ssid      string
+	Uid       int64
+	UserAgent string
+	Ip        string
+	Method    string
+	Ctime     time.Time
+	LastSeen  time.Time
 }
 
 type UserClaims struct {
@@ -20,6 +59,19 @@ type UserClaims struct {
 	UserId int64
 	// 利用 UserAgent 增强登录安全性
 	UserAgent string
+	Roles     []string `json:",omitempty"`
+	// Perms 角色对应的权限，签发的时候就算好，校验的时候不用再查
+	Perms []string `json:",omitempty"`
+}
+
+// HasPermission 权限检查的中间件用
+func (uc UserClaims) HasPermission(perm string) bool {
+	return slices.Contains(uc.Perms, perm)
+}
+
+// Authorizer 签发 access_token 的时候查用户的角色和权限，返回 error 就不签发
+type Authorizer interface {
+	Authorize(ctx context.Context, uid int64) (roles []string, perms []string, err error)
 }
 
 type RefreshClaims struct {
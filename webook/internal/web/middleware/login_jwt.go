@@ -2,15 +2,16 @@ package middleware
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
 
 	ijwt "github.com/liupch66/basic-go/webook/internal/web/jwt"
 )
 
 type LoginJWTMiddlewareBuilder struct {
-	paths []string
+	paths    []string
+	prefixes []string
 	ijwt.Handler
 }
 
@@ -23,6 +24,12 @@ func (l *LoginJWTMiddlewareBuilder) IgnorePaths(paths ...string) *LoginJWTMiddle
 	return l
 }
 
+// IgnorePathPrefixes 路径里面带参数的，比如 /oauth2/:provider/callback，只能按前缀忽略
+func (l *LoginJWTMiddlewareBuilder) IgnorePathPrefixes(prefixes ...string) *LoginJWTMiddlewareBuilder {
+	l.prefixes = append(l.prefixes, prefixes...)
+	return l
+}
+
 func (l *LoginJWTMiddlewareBuilder) Build() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		// if ctx.Request.URL.Path == "/users/signup" || ctx.Request.URL.Path == "/users/login" {
@@ -33,12 +40,14 @@ func (l *LoginJWTMiddlewareBuilder) Build() gin.HandlerFunc {
 				return
 			}
 		}
+		for _, prefix := range l.prefixes {
+			if strings.HasPrefix(ctx.Request.URL.Path, prefix) {
+				return
+			}
+		}
 		tokenStr := l.ExtractToken(ctx)
-		var uc ijwt.UserClaims
-		token, err := jwt.ParseWithClaims(tokenStr, &uc, func(*jwt.Token) (interface{}, error) {
-			return []byte("C%B|]SiozBE,S)X>ru,3Uu0+rl1Lj.@O"), nil
-		})
-		if err != nil || !token.Valid || uc.UserId == 0 {
+		uc, err := l.ParseAccessToken(tokenStr)
+		if err != nil || uc.UserId == 0 {
 			ctx.AbortWithStatus(http.StatusUnauthorized)
 			return
 		}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	ijwt "github.com/liupch66/basic-go/webook/internal/web/jwt"
+)
+
+// RequirePermission 检查当前用户有没有 perm 这个权限，要放在登录校验的后面，
+// 可以加在路由分组上，也可以加在单个路由上
+func RequirePermission(perm string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		val, ok := ctx.Get("user_claims")
+		if !ok {
+			ctx.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+		uc, ok := val.(ijwt.UserClaims)
+		if !ok || !uc.HasPermission(perm) {
+			ctx.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package web
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/service"
+	"github.com/liupch66/basic-go/webook/internal/web/jwt"
+	"github.com/liupch66/basic-go/webook/pkg/ginx"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
+)
+
+var _ handler = (*NotificationHandler)(nil)
+
+type NotificationHandler struct {
+	svc     service.NotificationService
+	userSvc service.UserService
+	l       logger.LoggerV1
+}
+
+func NewNotificationHandler(svc service.NotificationService, userSvc service.UserService, l logger.LoggerV1) *NotificationHandler {
+	return &NotificationHandler{svc: svc, userSvc: userSvc, l: l}
+}
+
+func (h *NotificationHandler) RegisterRoutes(server *gin.Engine) {
+	ng := server.Group("/notifications")
+	{
+		ng.GET("", ginx.WrapReqAndClaims[NotificationListReq, jwt.UserClaims](h.List))
+		ng.GET("/unread_cnt", ginx.WrapClaims[jwt.UserClaims](h.UnreadCnt))
+		ng.POST("/read", ginx.WrapReqAndClaims[MarkReadReq, jwt.UserClaims](h.MarkRead))
+		ng.POST("/read_all", ginx.WrapClaims[jwt.UserClaims](h.MarkAllRead))
+	}
+}
+
+func (h *NotificationHandler) List(ctx *gin.Context, req NotificationListReq, uc jwt.UserClaims) (Result, error) {
+	if req.Limit <= 0 || req.Limit > 100 {
+		req.Limit = 20
+	}
+	ns, err := h.svc.List(ctx, uc.UserId, req.Offset, req.Limit)
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	cnt, err := h.svc.UnreadCnt(ctx, uc.UserId)
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	// 同一页里面同一个人只查一次
+	names := make(map[int64]string, len(ns))
+	vos := make([]NotificationVO, 0, len(ns))
+	for _, n := range ns {
+		vo := NotificationVO{
+			Id:          n.Id,
+			Type:        uint8(n.Type),
+			Biz:         n.Biz,
+			BizId:       n.BizId,
+			LastActorId: n.LastActorId,
+			ActorCnt:    n.ActorCnt,
+			Content:     n.Content,
+			Read:        n.Read,
+			Utime:       n.Utime.Format(time.DateTime),
+		}
+		if n.Type != domain.NotificationTypeSystem {
+			name, ok := names[n.LastActorId]
+			if !ok {
+				name = h.nickname(ctx, n.LastActorId)
+				names[n.LastActorId] = name
+			}
+			vo.LastActor = name
+			vo.Content = h.summary(name, n)
+		}
+		vos = append(vos, vo)
+	}
+	return Result{Data: NotificationListVO{Notifications: vos, UnreadCnt: cnt}}, nil
+}
+
+func (h *NotificationHandler) UnreadCnt(ctx *gin.Context, uc jwt.UserClaims) (Result, error) {
+	cnt, err := h.svc.UnreadCnt(ctx, uc.UserId)
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Data: cnt}, nil
+}
+
+func (h *NotificationHandler) MarkRead(ctx *gin.Context, req MarkReadReq, uc jwt.UserClaims) (Result, error) {
+	err := h.svc.MarkRead(ctx, uc.UserId, req.Ids)
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Msg: "OK"}, nil
+}
+
+func (h *NotificationHandler) MarkAllRead(ctx *gin.Context, uc jwt.UserClaims) (Result, error) {
+	err := h.svc.MarkAllRead(ctx, uc.UserId)
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Msg: "OK"}, nil
+}
+
+// nickname 查不到昵称不影响通知列表，显示成匿名用户
+func (h *NotificationHandler) nickname(ctx *gin.Context, uid int64) string {
+	u, err := h.userSvc.Profile(ctx, uid)
+	if err != nil {
+		h.l.Warn("查询通知里面的用户失败", logger.Int64("uid", uid), logger.Error(err))
+		return "匿名用户"
+	}
+	if u.Nickname == "" {
+		return fmt.Sprintf("用户%d", uid)
+	}
+	return u.Nickname
+}
+
+func (h *NotificationHandler) summary(name string, n domain.Notification) string {
+	var action string
+	switch n.Type {
+	case domain.NotificationTypeLike:
+		action = "赞了你的文章"
+	case domain.NotificationTypeCollect:
+		action = "收藏了你的文章"
+	case domain.NotificationTypeComment:
+		action = "评论了你的文章"
+	case domain.NotificationTypeReply:
+		action = "回复了你的评论"
+	}
+	if n.ActorCnt > 1 {
+		return fmt.Sprintf("%s 和其他 %d 人%s", name, n.ActorCnt-1, action)
+	}
+	return fmt.Sprintf("%s %s", name, action)
+}
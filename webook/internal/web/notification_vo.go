@@ -0,0 +1,30 @@
+package web
+
+type NotificationListReq struct {
+	Offset int `form:"offset"`
+	Limit  int `form:"limit"`
+}
+
+type MarkReadReq struct {
+	Ids []int64 `json:"ids"`
+}
+
+type NotificationVO struct {
+	Id    int64  `json:"id"`
+	Type  uint8  `json:"type"`
+	Biz   string `json:"biz"`
+	BizId int64  `json:"biz_id"`
+	// ActorCnt 折叠了多少人的互动，配合 LastActor 展示成 "张三和其他 12 人赞了你的文章"
+	LastActorId int64  `json:"last_actor_id"`
+	LastActor   string `json:"last_actor"`
+	ActorCnt    int64  `json:"actor_cnt"`
+	// Content 系统消息的内容，互动通知是拼好的文案
+	Content string `json:"content"`
+	Read    bool   `json:"read"`
+	Utime   string `json:"utime"`
+}
+
+type NotificationListVO struct {
+	Notifications []NotificationVO `json:"notifications"`
+	UnreadCnt     int64            `json:"unread_cnt"`
+}
@@ -0,0 +1,202 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/service"
+	"github.com/liupch66/basic-go/webook/internal/service/oauth2"
+	ijwt "github.com/liupch66/basic-go/webook/internal/web/jwt"
+	"github.com/liupch66/basic-go/webook/pkg/ginx"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
+)
+
+var _ handler = (*OAuth2Handler)(nil)
+
+// OAuth2Handler 所有第三方登录共用，:provider 是平台的名字。
+// 已经登录的用户绑定第三方账号也走同一个回调，区别在于 state 里面带了 uid
+type OAuth2Handler struct {
+	registry   *oauth2.Registry
+	userSvc    service.UserService
+	accountSvc service.AccountService
+	stateKeys  *ijwt.Keyring
+	cfg        OAuth2HandlerConfig
+	l          logger.LoggerV1
+	ijwt.Handler
+}
+
+// OAuth2HandlerConfig 生产环境 Secure 设置为 true
+type OAuth2HandlerConfig struct {
+	Secure bool
+}
+
+func NewOAuth2Handler(registry *oauth2.Registry, userSvc service.UserService, accountSvc service.AccountService,
+	cfg OAuth2HandlerConfig, jwtHdl ijwt.Handler, keys *ijwt.Keyrings, l logger.LoggerV1) *OAuth2Handler {
+	return &OAuth2Handler{
+		registry:   registry,
+		userSvc:    userSvc,
+		accountSvc: accountSvc,
+		stateKeys:  keys.State,
+		cfg:        cfg,
+		l:          l,
+		Handler:    jwtHdl,
+	}
+}
+
+func (h *OAuth2Handler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/oauth2")
+	{
+		g.GET("/:provider/authurl", h.AuthURL)
+		g.Any("/:provider/callback", h.Callback)
+	}
+	// 要登录才能绑定
+	server.GET("/users/bind/oauth2/:provider/authurl", ginx.WrapClaims[ijwt.UserClaims](h.BindAuthURL))
+}
+
+// StateClaim state、PKCE 的 verifier 和 nonce 都放在 cookie 里面，回调的时候再拿出来
+type StateClaim struct {
+	jwt.RegisteredClaims
+	Provider string
+	State    string
+	Verifier string
+	Nonce    string
+	// Uid 不为 0 说明是绑定
+	Uid int64
+}
+
+func (h *OAuth2Handler) AuthURL(ctx *gin.Context) {
+	provider, err := h.registry.Get(ctx.Param("provider"))
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "不支持的登录方式"})
+		return
+	}
+	req := oauth2.NewAuthRequest()
+	url, err := provider.AuthURL(ctx, req)
+	if err != nil {
+		h.l.Error("构造第三方登录 URL 失败", logger.String("provider", provider.Name()), logger.Error(err))
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "构造登录 URL 失败"})
+		return
+	}
+	if err = h.setStateCookie(ctx, provider.Name(), req, 0); err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{Data: url})
+}
+
+func (h *OAuth2Handler) BindAuthURL(ctx *gin.Context, uc ijwt.UserClaims) (Result, error) {
+	provider, err := h.registry.Get(ctx.Param("provider"))
+	if err != nil {
+		return Result{Code: 4, Msg: "不支持的绑定方式"}, nil
+	}
+	req := oauth2.NewAuthRequest()
+	url, err := provider.AuthURL(ctx, req)
+	if err != nil {
+		return Result{Code: 5, Msg: "构造绑定 URL 失败"}, err
+	}
+	if err = h.setStateCookie(ctx, provider.Name(), req, uc.UserId); err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Data: url}, nil
+}
+
+func (h *OAuth2Handler) setStateCookie(ctx *gin.Context, name string, req oauth2.AuthRequest, uid int64) error {
+	sc := StateClaim{
+		Provider:         name,
+		State:            req.State,
+		Verifier:         req.Verifier,
+		Nonce:            req.Nonce,
+		Uid:              uid,
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(10 * time.Minute))},
+	}
+	tokenStr, err := h.stateKeys.Sign(sc)
+	if err != nil {
+		return err
+	}
+	ctx.SetCookie("jwt-state", tokenStr, 600, h.callbackPath(name), "", h.cfg.Secure, true)
+	return nil
+}
+
+func (h *OAuth2Handler) callbackPath(name string) string {
+	return fmt.Sprintf("/oauth2/%s/callback", name)
+}
+
+func (h *OAuth2Handler) Callback(ctx *gin.Context) {
+	provider, err := h.registry.Get(ctx.Param("provider"))
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "不支持的登录方式"})
+		return
+	}
+	sc, err := h.verifyState(ctx, provider.Name())
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "第三方登录失败"})
+		return
+	}
+	// state 只能用一次
+	ctx.SetCookie("jwt-state", "", -1, h.callbackPath(provider.Name()), "", h.cfg.Secure, true)
+
+	identity, err := provider.VerifyCode(ctx, ctx.Query("code"),
+		oauth2.AuthRequest{State: sc.State, Verifier: sc.Verifier, Nonce: sc.Nonce})
+	if err != nil {
+		h.l.Warn("第三方登录校验 code 失败", logger.String("provider", provider.Name()), logger.Error(err))
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		return
+	}
+	if sc.Uid > 0 {
+		h.bind(ctx, sc.Uid, identity)
+		return
+	}
+	u, err := h.userSvc.FindOrCreateByIdentity(ctx, identity)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		return
+	}
+	err = h.SetLoginToken(ctx, u.Id, provider.Name())
+	if errors.Is(err, service.ErrUserBanned) {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "账号已被封禁"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{Msg: "OK"})
+}
+
+func (h *OAuth2Handler) bind(ctx *gin.Context, uid int64, identity domain.Identity) {
+	err := h.accountSvc.BindIdentity(ctx, uid, identity)
+	switch {
+	case err == nil:
+		ctx.JSON(http.StatusOK, Result{Msg: "绑定成功"})
+	case errors.Is(err, service.ErrAlreadyBound):
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "已经被其他账号绑定了"})
+	default:
+		h.l.Error("绑定第三方账号失败", logger.Int64("uid", uid),
+			logger.String("provider", identity.Provider), logger.Error(err))
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+	}
+}
+
+func (h *OAuth2Handler) verifyState(ctx *gin.Context, name string) (StateClaim, error) {
+	state := ctx.Query("state")
+	// 检验 state
+	tokenStr, err := ctx.Cookie("jwt-state")
+	if err != nil {
+		return StateClaim{}, fmt.Errorf("拿不到 state 的 cookie, %w", err)
+	}
+	var sc StateClaim
+	token, err := h.stateKeys.Parse(tokenStr, &sc)
+	if err != nil || !token.Valid {
+		return StateClaim{}, fmt.Errorf("cookie 不是合法 jwt token, %w", err)
+	}
+	if sc.State != state || sc.Provider != name {
+		return StateClaim{}, errors.New("state 被篡改了")
+	}
+	return sc, nil
+}
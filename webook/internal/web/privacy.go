@@ -0,0 +1,128 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/service"
+	ijwt "github.com/liupch66/basic-go/webook/internal/web/jwt"
+	"github.com/liupch66/basic-go/webook/pkg/ginx"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
+)
+
+var _ handler = (*PrivacyHandler)(nil)
+
+// PrivacyHandler 导出个人数据和注销账号，都是异步执行的，通过申请列表查看进度
+type PrivacyHandler struct {
+	svc service.PrivacyService
+	l   logger.LoggerV1
+}
+
+func NewPrivacyHandler(svc service.PrivacyService, l logger.LoggerV1) *PrivacyHandler {
+	return &PrivacyHandler{svc: svc, l: l}
+}
+
+func (h *PrivacyHandler) RegisterRoutes(server *gin.Engine) {
+	pg := server.Group("/users/privacy")
+	{
+		pg.POST("/export", ginx.WrapClaims[ijwt.UserClaims](h.RequestExport))
+		pg.POST("/delete", ginx.WrapClaims[ijwt.UserClaims](h.RequestDeletion))
+		pg.POST("/delete/cancel", ginx.WrapClaims[ijwt.UserClaims](h.CancelDeletion))
+		pg.GET("/requests", ginx.WrapReqAndClaims[PrivacyListReq, ijwt.UserClaims](h.List))
+		// 下载的是文件，不能用 ginx 包装
+		pg.GET("/exports/:id/download", h.Download)
+	}
+}
+
+func (h *PrivacyHandler) RequestExport(ctx *gin.Context, uc ijwt.UserClaims) (Result, error) {
+	r, err := h.svc.RequestExport(ctx, uc.UserId)
+	if errors.Is(err, service.ErrPrivacyRequestExists) {
+		return Result{Code: 4, Msg: "已经有正在导出的申请了"}, nil
+	}
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Msg: "申请成功", Data: h.toVO(r)}, nil
+}
+
+func (h *PrivacyHandler) RequestDeletion(ctx *gin.Context, uc ijwt.UserClaims) (Result, error) {
+	r, err := h.svc.RequestDeletion(ctx, uc.UserId)
+	if errors.Is(err, service.ErrPrivacyRequestExists) {
+		return Result{Code: 4, Msg: "已经申请过注销了"}, nil
+	}
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Msg: "申请成功，冷静期内可以撤销", Data: h.toVO(r)}, nil
+}
+
+func (h *PrivacyHandler) CancelDeletion(ctx *gin.Context, uc ijwt.UserClaims) (Result, error) {
+	err := h.svc.CancelDeletion(ctx, uc.UserId)
+	if errors.Is(err, service.ErrPrivacyRequestNotFound) {
+		return Result{Code: 4, Msg: "没有可以撤销的注销申请"}, nil
+	}
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Msg: "撤销成功"}, nil
+}
+
+func (h *PrivacyHandler) List(ctx *gin.Context, req PrivacyListReq, uc ijwt.UserClaims) (Result, error) {
+	if req.Limit <= 0 || req.Limit > 100 {
+		req.Limit = 20
+	}
+	rs, err := h.svc.List(ctx, uc.UserId, req.Offset, req.Limit)
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Data: slice.Map(rs, func(idx int, src domain.PrivacyRequest) PrivacyRequestVO {
+		return h.toVO(src)
+	})}, nil
+}
+
+func (h *PrivacyHandler) Download(ctx *gin.Context) {
+	uc, ok := ctx.MustGet("user_claims").(ijwt.UserClaims)
+	if !ok {
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "参数错误"})
+		return
+	}
+	data, err := h.svc.ExportArchive(ctx, uc.UserId, id)
+	switch {
+	case errors.Is(err, service.ErrPrivacyRequestNotFound):
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "申请不存在"})
+	case errors.Is(err, service.ErrExportNotReady):
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "还没有导出完，请稍后再试"})
+	case err != nil:
+		h.l.Error("查询导出文件失败", logger.Error(err), logger.Int64("id", id), logger.Int64("uid", uc.UserId))
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+	default:
+		ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="webook-export-%d.zip"`, id))
+		ctx.Data(http.StatusOK, "application/zip", data)
+	}
+}
+
+func (h *PrivacyHandler) toVO(r domain.PrivacyRequest) PrivacyRequestVO {
+	done, total := r.Progress()
+	return PrivacyRequestVO{
+		Id:        r.Id,
+		Type:      r.Type.ToUint8(),
+		Status:    r.Status.ToUint8(),
+		Stage:     r.Stage,
+		Progress:  fmt.Sprintf("%d/%d", done, total),
+		ExecuteAt: r.ExecuteAt.Format(time.DateTime),
+		Ctime:     r.Ctime.Format(time.DateTime),
+		Utime:     r.Utime.Format(time.DateTime),
+	}
+}
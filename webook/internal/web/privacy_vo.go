@@ -0,0 +1,18 @@
+package web
+
+type PrivacyListReq struct {
+	Offset int `form:"offset"`
+	Limit  int `form:"limit"`
+}
+
+// PrivacyRequestVO 数据导出或者注销账号的申请，Progress 是 "做完几步/一共几步"
+type PrivacyRequestVO struct {
+	Id        int64  `json:"id"`
+	Type      uint8  `json:"type"`
+	Status    uint8  `json:"status"`
+	Stage     string `json:"stage"`
+	Progress  string `json:"progress"`
+	ExecuteAt string `json:"execute_at"`
+	Ctime     string `json:"ctime"`
+	Utime     string `json:"utime"`
+}
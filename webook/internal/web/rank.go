@@ -0,0 +1,61 @@
+package web
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/service"
+	"github.com/liupch66/basic-go/webook/pkg/ginx"
+)
+
+var _ handler = (*RankHandler)(nil)
+
+// RankHandler 热榜，榜单由定时任务算好，这里只读
+type RankHandler struct {
+	svc service.RankService
+}
+
+func NewRankHandler(svc service.RankService) *RankHandler {
+	return &RankHandler{svc: svc}
+}
+
+func (h *RankHandler) RegisterRoutes(server *gin.Engine) {
+	server.GET("/articles/hot", ginx.WrapReq[HotListReq](h.HotList))
+}
+
+func (h *RankHandler) HotList(ctx *gin.Context, req HotListReq) (Result, error) {
+	var (
+		arts []domain.Article
+		err  error
+	)
+	if req.AuthorId > 0 {
+		arts, err = h.svc.GetAuthorTopN(ctx, req.AuthorId)
+	} else {
+		if req.Board == "" {
+			req.Board = service.RankBoardOverall
+		}
+		arts, err = h.svc.GetTopN(ctx, req.Board)
+	}
+	if errors.Is(err, service.ErrUnknownRankBoard) {
+		return Result{Code: 4, Msg: "榜单不存在"}, nil
+	}
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{
+		Data: slice.Map[domain.Article, ArticleVO](arts, func(idx int, src domain.Article) ArticleVO {
+			return ArticleVO{
+				Id:       src.Id,
+				Title:    src.Title,
+				Abstract: src.Abstract(),
+				Author:   src.Author.Name,
+				Ctime:    src.Ctime.Format(time.DateTime),
+				Utime:    src.Utime.Format(time.DateTime),
+			}
+		}),
+	}, nil
+}
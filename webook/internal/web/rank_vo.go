@@ -0,0 +1,8 @@
+package web
+
+type HotListReq struct {
+	// Board overall 综合榜（默认），daily 日榜，realtime 实时榜
+	Board string `form:"board"`
+	// AuthorId 不为 0 的时候查这个作者的热榜，忽略 Board
+	AuthorId int64 `form:"author_id"`
+}
@@ -0,0 +1,61 @@
+package web
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/service"
+	"github.com/liupch66/basic-go/webook/pkg/ginx"
+)
+
+var _ handler = (*SearchHandler)(nil)
+
+type SearchHandler struct {
+	svc service.SearchService
+}
+
+func NewSearchHandler(svc service.SearchService) *SearchHandler {
+	return &SearchHandler{svc: svc}
+}
+
+func (h *SearchHandler) RegisterRoutes(server *gin.Engine) {
+	sg := server.Group("/search")
+	{
+		sg.GET("/articles", ginx.WrapReq[SearchReq](h.SearchArticles))
+	}
+}
+
+func (h *SearchHandler) SearchArticles(ctx *gin.Context, req SearchReq) (Result, error) {
+	keyword := strings.TrimSpace(req.Keyword)
+	if keyword == "" {
+		return Result{Code: 4, Msg: "搜索关键字不能为空"}, nil
+	}
+	// 防止一次查太多
+	if req.Limit <= 0 || req.Limit > 100 {
+		req.Limit = 10
+	}
+	res, err := h.svc.SearchArticle(ctx, keyword, req.Offset, req.Limit)
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{
+		Data: SearchArticleResultVO{
+			Total: res.Total,
+			Articles: slice.Map[domain.ArticleSearchHit, SearchArticleVO](res.Articles,
+				func(idx int, src domain.ArticleSearchHit) SearchArticleVO {
+					return SearchArticleVO{
+						Id:         src.Article.Id,
+						Title:      src.Article.Title,
+						Author:     src.Article.Author.Name,
+						Score:      src.Score,
+						Highlights: src.Highlights,
+						Utime:      src.Article.Utime.Format(time.DateTime),
+					}
+				}),
+		},
+	}, nil
+}
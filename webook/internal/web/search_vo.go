@@ -0,0 +1,22 @@
+package web
+
+type SearchReq struct {
+	Keyword string `form:"q"`
+	Offset  int    `form:"offset"`
+	Limit   int    `form:"limit"`
+}
+
+type SearchArticleVO struct {
+	Id     int64   `json:"id"`
+	Title  string  `json:"title"`
+	Author string  `json:"author"`
+	Score  float64 `json:"score"`
+	// Highlights 命中的片段，key 是 title 或者 content，命中的词用 <mark></mark> 包起来
+	Highlights map[string][]string `json:"highlights"`
+	Utime      string              `json:"utime"`
+}
+
+type SearchArticleResultVO struct {
+	Total    int64             `json:"total"`
+	Articles []SearchArticleVO `json:"articles"`
+}
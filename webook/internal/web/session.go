@@ -0,0 +1,76 @@
+package web
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/liupch66/basic-go/webook/internal/web/jwt"
+	"github.com/liupch66/basic-go/webook/pkg/ginx"
+)
+
+var _ handler = (*SessionHandler)(nil)
+
+// SessionHandler 登录设备管理，用户可以看到自己在哪些地方登录了，也可以踢掉可疑的会话
+type SessionHandler struct {
+	jwtHdl jwt.Handler
+}
+
+func NewSessionHandler(jwtHdl jwt.Handler) *SessionHandler {
+	return &SessionHandler{jwtHdl: jwtHdl}
+}
+
+func (h *SessionHandler) RegisterRoutes(server *gin.Engine) {
+	sg := server.Group("/users/sessions")
+	{
+		sg.GET("", ginx.WrapClaims[jwt.UserClaims](h.List))
+		sg.POST("/revoke", ginx.WrapReqAndClaims[RevokeSessionReq, jwt.UserClaims](h.Revoke))
+		sg.POST("/revoke_others", ginx.WrapClaims[jwt.UserClaims](h.RevokeOthers))
+	}
+}
+
+func (h *SessionHandler) List(ctx *gin.Context, uc jwt.UserClaims) (Result, error) {
+	sessions, err := h.jwtHdl.ListSessions(ctx, uc.UserId)
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	vos := make([]SessionVO, 0, len(sessions))
+	for _, sess := range sessions {
+		vos = append(vos, SessionVO{
+			Ssid:      sess.Ssid,
+			UserAgent: sess.UserAgent,
+			Ip:        sess.Ip,
+			Method:    sess.Method,
+			Ctime:     sess.Ctime.Format(time.DateTime),
+			LastSeen:  sess.LastSeen.Format(time.DateTime),
+			Current:   sess.Ssid == uc.Ssid,
+		})
+	}
+	return Result{Data: vos}, nil
+}
+
+func (h *SessionHandler) Revoke(ctx *gin.Context, req RevokeSessionReq, uc jwt.UserClaims) (Result, error) {
+	if req.Ssid == "" {
+		return Result{Code: 4, Msg: "参数错误"}, nil
+	}
+	// 踢自己就是退出登录，走 /users/logout_jwt
+	if req.Ssid == uc.Ssid {
+		return Result{Code: 4, Msg: "不能踢掉当前会话"}, nil
+	}
+	err := h.jwtHdl.RevokeSession(ctx, uc.UserId, req.Ssid)
+	if errors.Is(err, jwt.ErrSessionNotFound) {
+		return Result{Code: 4, Msg: "会话不存在"}, nil
+	}
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Msg: "OK"}, nil
+}
+
+func (h *SessionHandler) RevokeOthers(ctx *gin.Context, uc jwt.UserClaims) (Result, error) {
+	if err := h.jwtHdl.RevokeOtherSessions(ctx, uc.UserId, uc.Ssid); err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Msg: "OK"}, nil
+}
@@ -0,0 +1,17 @@
+package web
+
+type RevokeSessionReq struct {
+	Ssid string `json:"ssid"`
+}
+
+type SessionVO struct {
+	Ssid      string `json:"ssid"`
+	UserAgent string `json:"user_agent"`
+	Ip        string `json:"ip"`
+	// Method 登录方式：password, sms, password_totp 或者第三方平台的名字
+	Method   string `json:"method"`
+	Ctime    string `json:"ctime"`
+	LastSeen string `json:"last_seen"`
+	// Current 是不是发起这次请求的会话
+	Current bool `json:"current"`
+}
@@ -0,0 +1,98 @@
+package web
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/service/sms/receipt"
+	"github.com/liupch66/basic-go/webook/internal/web/middleware"
+	"github.com/liupch66/basic-go/webook/pkg/ginx"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
+)
+
+var _ handler = (*SmsHandler)(nil)
+
+// SmsHandler 服务商的回执回调，和客服查询短信的状态
+type SmsHandler struct {
+	svc *receipt.Service
+	l   logger.LoggerV1
+}
+
+func NewSmsHandler(svc *receipt.Service, l logger.LoggerV1) *SmsHandler {
+	return &SmsHandler{svc: svc, l: l}
+}
+
+func (h *SmsHandler) RegisterRoutes(server *gin.Engine) {
+	// 服务商调用的，不需要登录，格式也是服务商定的，不能用 ginx 包装
+	server.POST("/sms/receipts/:vendor", h.Callback)
+	server.POST("/admin/sms/messages", middleware.RequirePermission(domain.PermSmsRead),
+		ginx.WrapReq[SmsMessageListReq](h.List))
+}
+
+// Callback 返回的格式是腾讯云要求的，别的服务商只看 HTTP 状态码。
+// token 是在服务商控制台配置回调地址的时候带上的密钥，校验通过了才处理回执
+func (h *SmsHandler) Callback(ctx *gin.Context) {
+	vendor := ctx.Param("vendor")
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"result": 1, "errmsg": "读取回执失败"})
+		return
+	}
+	err = h.svc.HandleCallback(ctx, vendor, ctx.Query("token"), body)
+	switch {
+	case errors.Is(err, receipt.ErrUnknownVendor):
+		ctx.JSON(http.StatusNotFound, gin.H{"result": 1, "errmsg": "unknown vendor"})
+	case errors.Is(err, receipt.ErrUnauthorizedCallback):
+		h.l.Warn("短信回执的密钥不对", logger.String("vendor", vendor), logger.String("ip", ctx.ClientIP()))
+		ctx.JSON(http.StatusUnauthorized, gin.H{"result": 1, "errmsg": "unauthorized"})
+	case errors.Is(err, receipt.ErrInvalidCallback):
+		h.l.Warn("短信回执的格式不对", logger.String("vendor", vendor), logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"result": 1, "errmsg": "invalid body"})
+	case err != nil:
+		// 返回错误服务商会重新推，重复的回执只算一次
+		h.l.Error("处理短信回执失败", logger.String("vendor", vendor), logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"result": 1, "errmsg": "internal error"})
+	default:
+		ctx.JSON(http.StatusOK, gin.H{"result": 0, "errmsg": "OK"})
+	}
+}
+
+func (h *SmsHandler) List(ctx *gin.Context, req SmsMessageListReq) (Result, error) {
+	// 不带条件的话会扫全表，客服查的时候至少给个手机号或者流水号
+	if req.Limit <= 0 || req.Limit > 100 || (req.Number == "" && req.MsgId == "") {
+		return Result{Code: 4, Msg: "参数错误"}, nil
+	}
+	msgs, err := h.svc.List(ctx, domain.SmsMessageQuery{
+		Number: req.Number,
+		Vendor: req.Vendor,
+		MsgId:  req.MsgId,
+		Offset: req.Offset,
+		Limit:  req.Limit,
+	})
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Data: slice.Map(msgs, func(idx int, src domain.SmsMessage) SmsMessageVO {
+		vo := SmsMessageVO{
+			Id:     src.Id,
+			Vendor: src.Vendor,
+			MsgId:  src.MsgId,
+			TplId:  src.TplId,
+			Number: src.Number,
+			Status: src.Status.String(),
+			Reason: src.Reason,
+			Ctime:  src.Ctime.Format(time.DateTime),
+			Utime:  src.Utime.Format(time.DateTime),
+		}
+		if !src.DeliverTime.IsZero() {
+			vo.DeliverTime = src.DeliverTime.Format(time.DateTime)
+		}
+		return vo
+	})}, nil
+}
@@ -0,0 +1,84 @@
+package web
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+
+	"github.com/liupch66/basic-go/webook/internal/service/sms/sandbox"
+	"github.com/liupch66/basic-go/webook/pkg/ginx"
+)
+
+var _ handler = (*SmsSandboxHandler)(nil)
+
+// SmsSandboxHandler 端到端测试读取沙箱里面的短信、注入故障。
+// 只有开了 sms.sandbox 才会注册路由，这些接口不需要登录，线上一定不能开
+type SmsSandboxHandler struct {
+	svc *sandbox.Service
+}
+
+// NewSmsSandboxHandler svc 为 nil 的话不注册路由
+func NewSmsSandboxHandler(svc *sandbox.Service) *SmsSandboxHandler {
+	return &SmsSandboxHandler{svc: svc}
+}
+
+func (h *SmsSandboxHandler) RegisterRoutes(server *gin.Engine) {
+	if h.svc == nil {
+		return
+	}
+	sg := server.Group("/sandbox/sms")
+	{
+		sg.GET("/messages/:number", ginx.Wrap(h.Messages))
+		sg.GET("/messages/:number/last", ginx.Wrap(h.Last))
+		sg.POST("/faults", ginx.WrapReq[SmsSandboxFaultsReq](h.Inject))
+		sg.POST("/reset", ginx.Wrap(h.Reset))
+	}
+}
+
+func (h *SmsSandboxHandler) Last(ctx *gin.Context) (Result, error) {
+	msg, ok := h.svc.Last(ctx.Param("number"))
+	if !ok {
+		return Result{Code: 4, Msg: "没有短信"}, nil
+	}
+	return Result{Data: h.toVO(msg)}, nil
+}
+
+func (h *SmsSandboxHandler) Messages(ctx *gin.Context) (Result, error) {
+	return Result{Data: slice.Map(h.svc.Messages(ctx.Param("number")), func(idx int, src sandbox.Message) SmsSandboxMessageVO {
+		return h.toVO(src)
+	})}, nil
+}
+
+func (h *SmsSandboxHandler) Inject(ctx *gin.Context, req SmsSandboxFaultsReq) (Result, error) {
+	h.svc.Inject(slice.Map(req.Faults, func(idx int, src SmsSandboxFault) sandbox.Fault {
+		f := sandbox.Fault{
+			Latency: time.Duration(src.LatencyMs) * time.Millisecond,
+			Timeout: src.Timeout,
+			Limited: src.Limited,
+			Times:   src.Times,
+		}
+		if src.Error != "" {
+			f.Err = errors.New(src.Error)
+		}
+		return f
+	})...)
+	return Result{Msg: "OK"}, nil
+}
+
+func (h *SmsSandboxHandler) Reset(ctx *gin.Context) (Result, error) {
+	h.svc.Reset()
+	return Result{Msg: "OK"}, nil
+}
+
+func (h *SmsSandboxHandler) toVO(msg sandbox.Message) SmsSandboxMessageVO {
+	return SmsSandboxMessageVO{
+		MsgId:   msg.MsgId,
+		Number:  msg.Number,
+		TplId:   msg.TplId,
+		Params:  msg.Params,
+		Content: msg.Content,
+		Time:    msg.Time.Format(time.DateTime),
+	}
+}
@@ -0,0 +1,25 @@
+package web
+
+type SmsSandboxMessageVO struct {
+	MsgId  string   `json:"msg_id"`
+	Number string   `json:"number"`
+	TplId  string   `json:"tpl_id"`
+	Params []string `json:"params"`
+	// Content 渲染好的短信内容
+	Content string `json:"content"`
+	Time    string `json:"time"`
+}
+
+// SmsSandboxFaultsReq 按顺序追加到沙箱的故障里面
+type SmsSandboxFaultsReq struct {
+	Faults []SmsSandboxFault `json:"faults"`
+}
+
+type SmsSandboxFault struct {
+	LatencyMs int64  `json:"latency_ms"`
+	Timeout   bool   `json:"timeout"`
+	Limited   bool   `json:"limited"`
+	Error     string `json:"error"`
+	// Times 连续生效几次，0 就是一直生效，直到 reset
+	Times int `json:"times"`
+}
@@ -0,0 +1,24 @@
+package web
+
+// SmsMessageListReq Number 和 MsgId 至少要给一个
+type SmsMessageListReq struct {
+	Number string `json:"number"`
+	Vendor string `json:"vendor"`
+	MsgId  string `json:"msg_id"`
+	Offset int    `json:"offset"`
+	Limit  int    `json:"limit"`
+}
+
+type SmsMessageVO struct {
+	Id     int64  `json:"id"`
+	Vendor string `json:"vendor"`
+	MsgId  string `json:"msg_id"`
+	TplId  string `json:"tpl_id"`
+	Number string `json:"number"`
+	// Status accepted、delivered 或者 failed
+	Status      string `json:"status"`
+	Reason      string `json:"reason"`
+	DeliverTime string `json:"deliver_time"`
+	Ctime       string `json:"ctime"`
+	Utime       string `json:"utime"`
+}
@@ -0,0 +1,109 @@
+package web
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/liupch66/basic-go/webook/internal/service"
+	ijwt "github.com/liupch66/basic-go/webook/internal/web/jwt"
+	"github.com/liupch66/basic-go/webook/pkg/ginx"
+)
+
+var _ handler = (*TwoFactorHandler)(nil)
+
+// TwoFactorHandler TOTP 两步验证：绑定验证器、解绑，以及输完密码之后的第二步登录
+type TwoFactorHandler struct {
+	svc     service.TwoFactorService
+	userSvc service.UserService
+	ijwt.Handler
+}
+
+func NewTwoFactorHandler(svc service.TwoFactorService, userSvc service.UserService, jwtHdl ijwt.Handler) *TwoFactorHandler {
+	return &TwoFactorHandler{svc: svc, userSvc: userSvc, Handler: jwtHdl}
+}
+
+func (h *TwoFactorHandler) RegisterRoutes(server *gin.Engine) {
+	server.POST("/users/login_2fa", ginx.WrapReq[TwoFactorLoginReq](h.Login))
+	tg := server.Group("/users/2fa/totp")
+	{
+		tg.POST("/enroll", ginx.WrapClaims[ijwt.UserClaims](h.Enroll))
+		tg.POST("/activate", ginx.WrapReqAndClaims[TotpCodeReq, ijwt.UserClaims](h.Activate))
+		tg.POST("/disable", ginx.WrapReqAndClaims[TotpCodeReq, ijwt.UserClaims](h.Disable))
+	}
+}
+
+func (h *TwoFactorHandler) Enroll(ctx *gin.Context, uc ijwt.UserClaims) (Result, error) {
+	u, err := h.userSvc.Profile(ctx, uc.UserId)
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	// 验证器里面展示的账号名
+	account := u.Email
+	if account == "" {
+		account = u.Phone
+	}
+	if account == "" {
+		account = strconv.FormatInt(u.Id, 10)
+	}
+	enroll, err := h.svc.Enroll(ctx, uc.UserId, account)
+	if errors.Is(err, service.ErrTotpAlreadyEnabled) {
+		return Result{Code: 4, Msg: "已经启用了两步验证"}, nil
+	}
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Data: TotpEnrollVO{Secret: enroll.Secret, URI: enroll.URI}}, nil
+}
+
+func (h *TwoFactorHandler) Activate(ctx *gin.Context, req TotpCodeReq, uc ijwt.UserClaims) (Result, error) {
+	codes, err := h.svc.Activate(ctx, uc.UserId, req.Code)
+	switch {
+	case errors.Is(err, service.ErrTotpNotEnrolled):
+		return Result{Code: 4, Msg: "请先绑定验证器"}, nil
+	case errors.Is(err, service.ErrTotpAlreadyEnabled):
+		return Result{Code: 4, Msg: "已经启用了两步验证"}, nil
+	case errors.Is(err, service.ErrInvalidTotpCode):
+		return Result{Code: 4, Msg: "验证码错误"}, nil
+	case err != nil:
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Data: RecoveryCodesVO{RecoveryCodes: codes}}, nil
+}
+
+func (h *TwoFactorHandler) Disable(ctx *gin.Context, req TotpCodeReq, uc ijwt.UserClaims) (Result, error) {
+	err := h.svc.Disable(ctx, uc.UserId, req.Code)
+	switch {
+	case errors.Is(err, service.ErrTotpNotEnrolled):
+		return Result{Code: 4, Msg: "没有启用两步验证"}, nil
+	case errors.Is(err, service.ErrInvalidTotpCode):
+		return Result{Code: 4, Msg: "验证码错误"}, nil
+	case err != nil:
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Msg: "OK"}, nil
+}
+
+// Login 邮箱密码登录返回了 x-2fa-token 之后，用它加上验证码（或者恢复码）完成登录
+func (h *TwoFactorHandler) Login(ctx *gin.Context, req TwoFactorLoginReq) (Result, error) {
+	uid, err := h.svc.CompleteLogin(ctx, req.Token, req.Code)
+	switch {
+	case errors.Is(err, service.ErrInvalidTotpCode):
+		return Result{Code: 4, Msg: "验证码错误"}, nil
+	case errors.Is(err, service.ErrTwoFactorExpired):
+		return Result{Code: 4, Msg: "登录已过期，请重新输入密码"}, nil
+	case errors.Is(err, service.ErrTwoFactorAttemptTooMany):
+		return Result{Code: 4, Msg: "尝试次数太多，请重新输入密码"}, nil
+	case err != nil:
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	err = h.SetLoginToken(ctx, uid, ijwt.LoginMethodPasswordTotp)
+	if errors.Is(err, service.ErrUserBanned) {
+		return Result{Code: 4, Msg: "账号已被封禁"}, nil
+	}
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Msg: "登录成功"}, nil
+}
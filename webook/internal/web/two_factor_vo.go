@@ -0,0 +1,21 @@
+package web
+
+type TotpCodeReq struct {
+	// Code 验证器上的 6 位数字，解绑的时候也可以是恢复码
+	Code string `json:"code"`
+}
+
+type TwoFactorLoginReq struct {
+	Token string `json:"token"`
+	Code  string `json:"code"`
+}
+
+type TotpEnrollVO struct {
+	Secret string `json:"secret"`
+	// URI otpauth:// 格式，前端生成二维码
+	URI string `json:"uri"`
+}
+
+type RecoveryCodesVO struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
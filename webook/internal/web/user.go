@@ -9,7 +9,6 @@ import (
 	regexp "github.com/dlclark/regexp2"
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/redis/go-redis/v9"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
@@ -38,17 +37,20 @@ type UserHandler struct {
 	phoneRegex     *regexp.Regexp
 	userSvc        service.UserService
 	codeSvc        service.CodeService
+	twoFactorSvc   service.TwoFactorService
 	ijwt.Handler
 	cmd redis.Cmdable
 }
 
-func NewUserHandler(userSvc service.UserService, codeSvc service.CodeService, jwtHdl ijwt.Handler) *UserHandler {
+func NewUserHandler(userSvc service.UserService, codeSvc service.CodeService, jwtHdl ijwt.Handler,
+	twoFactorSvc service.TwoFactorService) *UserHandler {
 	return &UserHandler{
 		emailRegexp:    regexp.MustCompile(emailRegexPattern, regexp.None),
 		passwordRegexp: regexp.MustCompile(passwordRegexPattern, regexp.None),
 		phoneRegex:     regexp.MustCompile(phoneRegexPattern, regexp.None),
 		userSvc:        userSvc,
 		codeSvc:        codeSvc,
+		twoFactorSvc:   twoFactorSvc,
 		Handler:        jwtHdl,
 	}
 }
@@ -191,8 +193,29 @@ func (u *UserHandler) LoginJWT(ctx *gin.Context) {
 		ctx.String(http.StatusOK, "系统错误")
 		return
 	}
+	// 启用了两步验证的话，先不给真正的 token，拿着 x-2fa-token 去 /users/login_2fa 输验证码
+	enabled, err := u.twoFactorSvc.Enabled(ctx, user.Id)
+	if err != nil {
+		ctx.String(http.StatusOK, "系统错误")
+		return
+	}
+	if enabled {
+		token, err := u.twoFactorSvc.BeginLogin(ctx, user.Id)
+		if err != nil {
+			ctx.String(http.StatusOK, "系统错误")
+			return
+		}
+		ctx.Header("x-2fa-token", token)
+		ctx.String(http.StatusOK, "请输入两步验证的验证码")
+		return
+	}
 	// 设置登录态
-	if err = u.SetLoginToken(ctx, user.Id); err != nil {
+	err = u.SetLoginToken(ctx, user.Id, ijwt.LoginMethodPassword)
+	if errors.Is(err, service.ErrUserBanned) {
+		ctx.String(http.StatusOK, "账号已被封禁")
+		return
+	}
+	if err != nil {
 		ctx.String(http.StatusOK, "系统错误")
 		return
 	}
@@ -289,6 +312,8 @@ func (u *UserHandler) ProfileJWT(ctx *gin.Context) {
 func (u *UserHandler) SendSmsLoginCode(ctx *gin.Context) {
 	type Req struct {
 		Phone string `json:"phone"`
+		// CaptchaToken 被要求图形验证码之后才需要
+		CaptchaToken string `json:"captcha_token"`
 	}
 	var req Req
 	if err := ctx.Bind(&req); err != nil {
@@ -305,7 +330,11 @@ func (u *UserHandler) SendSmsLoginCode(ctx *gin.Context) {
 		return
 	}
 	// 发送验证码
-	err = u.codeSvc.Send(ctx, biz, req.Phone)
+	err = u.codeSvc.Send(ctx, biz, req.Phone, codeSendMeta(ctx, req.CaptchaToken))
+	if res, denied := codeDeniedResult(err); denied {
+		ctx.JSON(http.StatusOK, res)
+		return
+	}
 	switch {
 	case err == nil:
 		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "发送成功"})
@@ -371,8 +400,16 @@ type LoginSmsReq struct {
 }
 
 func (u *UserHandler) LoginSms(ctx *gin.Context, req LoginSmsReq) (Result, error) {
+	// 验证手机号码格式是否正确
+	ok, err := u.phoneRegex.MatchString(req.Phone)
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	if !ok {
+		return Result{Code: 4, Msg: "请输入正确的手机号码"}, nil
+	}
 	// 校验验证码
-	ok, err := u.codeSvc.Verify(ctx, biz, req.Phone, req.Code)
+	ok, err = u.codeSvc.Verify(ctx, biz, req.Phone, req.Code)
 	if errors.Is(err, service.ErrCodeVerifyExpired) {
 		// 可以直接返回 error(debug 比较强的话),也可以考虑像下面这样包住(信息更详细)
 		return Result{Code: 4, Msg: "验证码已过期"}, fmt.Errorf("验证码已过期: %w", err)
@@ -389,7 +426,11 @@ func (u *UserHandler) LoginSms(ctx *gin.Context, req LoginSmsReq) (Result, error
 		return Result{Code: 5, Msg: "系统错误"}, fmt.Errorf("登录或注册用户失败: %w", err)
 	}
 
-	if err = u.SetLoginToken(ctx, user.Id); err != nil {
+	err = u.SetLoginToken(ctx, user.Id, ijwt.LoginMethodSMS)
+	if errors.Is(err, service.ErrUserBanned) {
+		return Result{Code: 4, Msg: "账号已被封禁"}, nil
+	}
+	if err != nil {
 		return Result{Code: 5, Msg: "系统错误"}, err
 	}
 	return Result{Code: 4, Msg: "验证码验证成功"}, nil
@@ -398,11 +439,8 @@ func (u *UserHandler) LoginSms(ctx *gin.Context, req LoginSmsReq) (Result, error
 func (u *UserHandler) RefreshToken(ctx *gin.Context) {
 	// 只有这里拿出来的是 refresh_token, 其他地方都是 access_token
 	refreshStr := u.ExtractToken(ctx)
-	var rc ijwt.RefreshClaims
-	token, err := jwt.ParseWithClaims(refreshStr, &rc, func(token *jwt.Token) (interface{}, error) {
-		return ijwt.RtKey, nil
-	})
-	if err != nil || !token.Valid {
+	rc, err := u.ParseRefreshToken(refreshStr)
+	if err != nil {
 		ctx.AbortWithStatus(http.StatusUnauthorized)
 		return
 	}
@@ -412,8 +450,16 @@ func (u *UserHandler) RefreshToken(ctx *gin.Context) {
 		return
 	}
 
-	// 刷新 access_token
-	if err = u.SetJwtToken(ctx, rc.Uid, rc.Ssid); err != nil {
+	// 刷新 access_token，同时换一个新的 refresh_token
+	err = u.RotateRefreshToken(ctx, rc)
+	if errors.Is(err, ijwt.ErrRefreshTokenReused) {
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+		// 整个会话已经踢掉了，要监控
+		zap.L().Warn("refresh_token 被重复使用，可能被盗用", zap.Error(err),
+			zap.Int64("uid", rc.Uid), zap.String("ssid", rc.Ssid), zap.String("ip", ctx.ClientIP()))
+		return
+	}
+	if err != nil {
 		ctx.AbortWithStatus(http.StatusUnauthorized)
 		// 可以打乱码方便检索,或者补充信息
 		zap.L().Error("asdfds 刷新 access_token 异常", zap.Error(err), zap.String("method", "UserHandler_RefreshToken"))
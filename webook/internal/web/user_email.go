@@ -0,0 +1,137 @@
+package web
+
+import (
+	"errors"
+
+	regexp "github.com/dlclark/regexp2"
+	"github.com/gin-gonic/gin"
+
+	"github.com/liupch66/basic-go/webook/internal/service"
+	ijwt "github.com/liupch66/basic-go/webook/internal/web/jwt"
+	"github.com/liupch66/basic-go/webook/pkg/ginx"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
+)
+
+var _ handler = (*UserEmailHandler)(nil)
+
+// UserEmailHandler 邮箱验证码相关：注册之后验证邮箱、忘记密码
+type UserEmailHandler struct {
+	emailRegexp    *regexp.Regexp
+	passwordRegexp *regexp.Regexp
+	userSvc        service.UserService
+	codeSvc        service.EmailCodeService
+	jwtHdl         ijwt.Handler
+	l              logger.LoggerV1
+}
+
+func NewUserEmailHandler(userSvc service.UserService, codeSvc service.EmailCodeService, jwtHdl ijwt.Handler,
+	l logger.LoggerV1) *UserEmailHandler {
+	return &UserEmailHandler{
+		emailRegexp:    regexp.MustCompile(emailRegexPattern, regexp.None),
+		passwordRegexp: regexp.MustCompile(passwordRegexPattern, regexp.None),
+		userSvc:        userSvc,
+		codeSvc:        codeSvc,
+		jwtHdl:         jwtHdl,
+		l:              l,
+	}
+}
+
+func (h *UserEmailHandler) RegisterRoutes(server *gin.Engine) {
+	ug := server.Group("/users")
+	{
+		ug.POST("/signup/email/code/send", ginx.WrapReq[EmailCodeSendReq](h.SendVerifyCode))
+		ug.POST("/signup/email/verify", ginx.WrapReq[EmailVerifyReq](h.VerifyEmail))
+		ug.POST("/password/reset/code/send", ginx.WrapReq[EmailCodeSendReq](h.SendResetCode))
+		ug.POST("/password/reset", ginx.WrapReq[ResetPasswordReq](h.ResetPassword))
+	}
+}
+
+// SendVerifyCode 注册之后给邮箱发验证码。不管邮箱有没有注册都返回成功，免得被拿来探测哪些邮箱注册了
+func (h *UserEmailHandler) SendVerifyCode(ctx *gin.Context, req EmailCodeSendReq) (Result, error) {
+	return h.send(ctx, service.EmailBizSignup, req.Email, func(verified bool) bool {
+		// 已经验证过的就不用再发了
+		return !verified
+	})
+}
+
+func (h *UserEmailHandler) VerifyEmail(ctx *gin.Context, req EmailVerifyReq) (Result, error) {
+	ok, err := h.codeSvc.Verify(ctx, service.EmailBizSignup, req.Email, req.Code)
+	if errors.Is(err, service.ErrCodeVerifyExpired) {
+		return Result{Code: 4, Msg: "验证码已过期"}, nil
+	}
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	if !ok {
+		return Result{Code: 4, Msg: "验证码错误"}, nil
+	}
+	if err = h.userSvc.VerifyEmail(ctx, req.Email); err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	return Result{Msg: "邮箱验证成功"}, nil
+}
+
+// SendResetCode 忘记密码，同样不暴露邮箱有没有注册
+func (h *UserEmailHandler) SendResetCode(ctx *gin.Context, req EmailCodeSendReq) (Result, error) {
+	return h.send(ctx, service.EmailBizResetPassword, req.Email, func(bool) bool {
+		return true
+	})
+}
+
+func (h *UserEmailHandler) ResetPassword(ctx *gin.Context, req ResetPasswordReq) (Result, error) {
+	isPassword, err := h.passwordRegexp.MatchString(req.Password)
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	if !isPassword {
+		return Result{Code: 4, Msg: "密码必须大于 8 位，并且包含数字，字母和特殊符号"}, nil
+	}
+	if req.Password != req.ConfirmPassword {
+		return Result{Code: 4, Msg: "两次输入的密码不一致"}, nil
+	}
+	ok, err := h.codeSvc.Verify(ctx, service.EmailBizResetPassword, req.Email, req.Code)
+	if errors.Is(err, service.ErrCodeVerifyExpired) {
+		return Result{Code: 4, Msg: "验证码已过期"}, nil
+	}
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	if !ok {
+		return Result{Code: 4, Msg: "验证码错误"}, nil
+	}
+	u, err := h.userSvc.ResetPassword(ctx, req.Email, req.Password)
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	// 密码可能已经泄露了，之前的登录全部踢掉
+	if err = h.jwtHdl.RevokeOtherSessions(ctx, u.Id, ""); err != nil {
+		h.l.Error("重置密码之后踢掉会话失败", logger.Error(err), logger.Int64("uid", u.Id))
+	}
+	return Result{Msg: "密码重置成功"}, nil
+}
+
+// send shouldSend 根据邮箱是否已经验证决定要不要真的发
+func (h *UserEmailHandler) send(ctx *gin.Context, biz, addr string, shouldSend func(verified bool) bool) (Result, error) {
+	isEmail, err := h.emailRegexp.MatchString(addr)
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	if !isEmail {
+		return Result{Code: 4, Msg: "邮箱格式错误"}, nil
+	}
+	u, err := h.userSvc.FindByEmail(ctx, addr)
+	if errors.Is(err, service.ErrUserNotFound) {
+		return Result{Msg: "发送成功"}, nil
+	}
+	if err != nil {
+		return Result{Code: 5, Msg: "系统错误"}, err
+	}
+	if !shouldSend(u.EmailVerified) {
+		return Result{Msg: "发送成功"}, nil
+	}
+	// 没注册的邮箱总是返回发送成功，这里发送太频繁或者发送失败也只能返回一样的结果，不然照样能探测出来
+	if err = h.codeSvc.Send(ctx, biz, addr); err != nil {
+		h.l.Warn("发送邮箱验证码失败", logger.String("biz", biz), logger.Error(err))
+	}
+	return Result{Msg: "发送成功"}, nil
+}
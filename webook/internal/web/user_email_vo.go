@@ -0,0 +1,17 @@
+package web
+
+type EmailCodeSendReq struct {
+	Email string `json:"email"`
+}
+
+type EmailVerifyReq struct {
+	Email string `json:"email"`
+	Code  string `json:"code"`
+}
+
+type ResetPasswordReq struct {
+	Email           string `json:"email"`
+	Code            string `json:"code"`
+	Password        string `json:"password"`
+	ConfirmPassword string `json:"confirmPassword"`
+}
@@ -121,7 +121,7 @@ func TestUserHandler_Signup(t *testing.T) {
 			require.NoError(t, err)
 			resp := httptest.NewRecorder()
 			// 这里用不上 codeSvc, 可以偷懒,不 mock 出来
-			userHdl := NewUserHandler(tc.mock(ctrl), nil, nil)
+			userHdl := NewUserHandler(tc.mock(ctrl), nil, nil, nil)
 			userHdl.RegisterRoutes(server)
 			server.ServeHTTP(resp, req)
 
@@ -139,6 +139,7 @@ func TestUserHandler_LoginSms(t *testing.T) {
 		mock    func(ctrl *gomock.Controller) (service.UserService, service.CodeService, jwt.Handler)
 		reqBody string
 
+		expectedCode   int
 		expectedResult Result
 	}{
 		{
@@ -157,7 +158,7 @@ func TestUserHandler_LoginSms(t *testing.T) {
 						Phone:    "15512345678",
 						Ctime:    now,
 					}, nil)
-				jwtHdl.EXPECT().SetLoginToken(gomock.Any(), int64(3)).Return(nil)
+				jwtHdl.EXPECT().SetLoginToken(gomock.Any(), int64(3), jwt.LoginMethodSMS).Return(nil)
 				return userSvc, codeSvc, jwtHdl
 			},
 			reqBody: `{"phone": "15512345678", "code": "123456"}`,
@@ -171,11 +172,8 @@ func TestUserHandler_LoginSms(t *testing.T) {
 			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService, jwt.Handler) {
 				return nil, nil, nil
 			},
-			reqBody: `{"phone": "15512345678", "code": "123456"`,
-			expectedResult: Result{
-				Code: 5,
-				Msg:  "系统错误",
-			},
+			reqBody:      `{"phone": "15512345678", "code": "123456"`,
+			expectedCode: http.StatusBadRequest,
 		},
 		{
 			name: "手机格式不对",
@@ -258,9 +256,15 @@ func TestUserHandler_LoginSms(t *testing.T) {
 			req.Header.Set("Content-Type", "application/json")
 			require.NoError(t, err)
 			resp := httptest.NewRecorder()
-			userHdl := NewUserHandler(tc.mock(ctrl))
+			userSvc, codeSvc, jwtHdl := tc.mock(ctrl)
+			userHdl := NewUserHandler(userSvc, codeSvc, jwtHdl, nil)
 			userHdl.RegisterRoutes(server)
 			server.ServeHTTP(resp, req)
+			if tc.expectedCode != 0 {
+				// bind 失败直接返回 400，没有响应体
+				assert.Equal(t, tc.expectedCode, resp.Code)
+				return
+			}
 
 			var res Result
 			err = json.Unmarshal(resp.Body.Bytes(), &res)
@@ -269,3 +273,105 @@ func TestUserHandler_LoginSms(t *testing.T) {
 		})
 	}
 }
+
+func TestUserHandler_LoginJWT(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		mock    func(ctrl *gomock.Controller) (service.UserService, service.TwoFactorService, jwt.Handler)
+		reqBody string
+
+		expectedBody     string
+		expected2FAToken string
+	}{
+		{
+			name: "启用了两步验证，只给 x-2fa-token",
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.TwoFactorService, jwt.Handler) {
+				userSvc := svcmocks.NewMockUserService(ctrl)
+				twoFactorSvc := svcmocks.NewMockTwoFactorService(ctrl)
+				userSvc.EXPECT().Login(gomock.Any(), "123@qq.com", "hello#world123").
+					Return(domain.User{Id: 3}, nil)
+				twoFactorSvc.EXPECT().Enabled(gomock.Any(), int64(3)).Return(true, nil)
+				twoFactorSvc.EXPECT().BeginLogin(gomock.Any(), int64(3)).Return("2fa-token", nil)
+				// 不能设置登录态
+				return userSvc, twoFactorSvc, jwtHdlmocks.NewMockHandler(ctrl)
+			},
+			reqBody:          `{"email": "123@qq.com", "password": "hello#world123"}`,
+			expectedBody:     "请输入两步验证的验证码",
+			expected2FAToken: "2fa-token",
+		},
+		{
+			name: "没有启用两步验证，直接登录",
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.TwoFactorService, jwt.Handler) {
+				userSvc := svcmocks.NewMockUserService(ctrl)
+				twoFactorSvc := svcmocks.NewMockTwoFactorService(ctrl)
+				jwtHdl := jwtHdlmocks.NewMockHandler(ctrl)
+				userSvc.EXPECT().Login(gomock.Any(), "123@qq.com", "hello#world123").
+					Return(domain.User{Id: 3}, nil)
+				twoFactorSvc.EXPECT().Enabled(gomock.Any(), int64(3)).Return(false, nil)
+				jwtHdl.EXPECT().SetLoginToken(gomock.Any(), int64(3), jwt.LoginMethodPassword).Return(nil)
+				return userSvc, twoFactorSvc, jwtHdl
+			},
+			reqBody:      `{"email": "123@qq.com", "password": "hello#world123"}`,
+			expectedBody: "登录成功",
+		},
+		{
+			name: "邮箱或密码不对",
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.TwoFactorService, jwt.Handler) {
+				userSvc := svcmocks.NewMockUserService(ctrl)
+				userSvc.EXPECT().Login(gomock.Any(), "123@qq.com", "hello#world123").
+					Return(domain.User{}, service.ErrInvalidEmailOrPassword)
+				return userSvc, nil, nil
+			},
+			reqBody:      `{"email": "123@qq.com", "password": "hello#world123"}`,
+			expectedBody: "邮箱或密码不对",
+		},
+		{
+			name: "查询两步验证状态出错",
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.TwoFactorService, jwt.Handler) {
+				userSvc := svcmocks.NewMockUserService(ctrl)
+				twoFactorSvc := svcmocks.NewMockTwoFactorService(ctrl)
+				userSvc.EXPECT().Login(gomock.Any(), "123@qq.com", "hello#world123").
+					Return(domain.User{Id: 3}, nil)
+				twoFactorSvc.EXPECT().Enabled(gomock.Any(), int64(3)).Return(false, errors.New("mock db error"))
+				return userSvc, twoFactorSvc, jwtHdlmocks.NewMockHandler(ctrl)
+			},
+			reqBody:      `{"email": "123@qq.com", "password": "hello#world123"}`,
+			expectedBody: "系统错误",
+		},
+		{
+			name: "生成 x-2fa-token 出错",
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.TwoFactorService, jwt.Handler) {
+				userSvc := svcmocks.NewMockUserService(ctrl)
+				twoFactorSvc := svcmocks.NewMockTwoFactorService(ctrl)
+				userSvc.EXPECT().Login(gomock.Any(), "123@qq.com", "hello#world123").
+					Return(domain.User{Id: 3}, nil)
+				twoFactorSvc.EXPECT().Enabled(gomock.Any(), int64(3)).Return(true, nil)
+				twoFactorSvc.EXPECT().BeginLogin(gomock.Any(), int64(3)).Return("", errors.New("mock redis error"))
+				return userSvc, twoFactorSvc, jwtHdlmocks.NewMockHandler(ctrl)
+			},
+			reqBody:      `{"email": "123@qq.com", "password": "hello#world123"}`,
+			expectedBody: "系统错误",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			server := gin.Default()
+			req, err := http.NewRequest(http.MethodPost, "/users/login", bytes.NewBuffer([]byte(tc.reqBody)))
+			req.Header.Set("Content-Type", "application/json")
+			require.NoError(t, err)
+			resp := httptest.NewRecorder()
+			userSvc, twoFactorSvc, jwtHdl := tc.mock(ctrl)
+			userHdl := NewUserHandler(userSvc, nil, jwtHdl, twoFactorSvc)
+			userHdl.RegisterRoutes(server)
+			server.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			assert.Equal(t, tc.expectedBody, resp.Body.String())
+			assert.Equal(t, tc.expected2FAToken, resp.Header().Get("x-2fa-token"))
+		})
+	}
+}
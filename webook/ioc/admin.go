@@ -0,0 +1,20 @@
+package ioc
+
+import (
+	"github.com/spf13/viper"
+
+	"github.com/liupch66/basic-go/webook/internal/repository"
+	"github.com/liupch66/basic-go/webook/internal/service"
+)
+
+// InitRBACService admin.uids 里面的用户不管数据库里面有没有授予角色，都是管理员
+func InitRBACService(repo repository.UserRepository) service.RBACService {
+	type Config struct {
+		Uids []int64 `yaml:"uids"`
+	}
+	var cfg Config
+	if err := viper.UnmarshalKey("admin", &cfg); err != nil {
+		panic(err)
+	}
+	return service.NewRBACService(repo, cfg.Uids)
+}
@@ -0,0 +1,33 @@
+package ioc
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+
+	"github.com/liupch66/basic-go/webook/internal/service/captcha"
+	"github.com/liupch66/basic-go/webook/internal/service/captcha/siteverify"
+	"github.com/liupch66/basic-go/webook/internal/service/captcha/static"
+)
+
+// InitCaptchaService provider 必须显式配置：siteverify 调用图形验证码服务商，static 只给本地开发用。
+// 没有配置或者配错了直接 panic，不能悄悄退化成 static
+func InitCaptchaService() captcha.Service {
+	type Config struct {
+		Provider   string            `yaml:"provider"`
+		Token      string            `yaml:"token"`
+		SiteVerify siteverify.Config `yaml:"siteVerify"`
+	}
+	var cfg Config
+	if err := viper.UnmarshalKey("captcha", &cfg); err != nil {
+		panic(err)
+	}
+	switch cfg.Provider {
+	case "siteverify":
+		return siteverify.NewService(cfg.SiteVerify)
+	case "static":
+		return static.NewService(cfg.Token)
+	default:
+		panic(fmt.Errorf("未知的图形验证码服务商 %q", cfg.Provider))
+	}
+}
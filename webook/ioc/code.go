@@ -0,0 +1,26 @@
+package ioc
+
+import (
+	"github.com/spf13/viper"
+
+	"github.com/liupch66/basic-go/webook/internal/domain"
+	"github.com/liupch66/basic-go/webook/internal/repository"
+	"github.com/liupch66/basic-go/webook/internal/service"
+	"github.com/liupch66/basic-go/webook/internal/service/captcha"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
+)
+
+// InitCodeThrottleService code.throttle.policies 按 biz 配置，没有配置的 biz 用 default
+func InitCodeThrottleService(repo repository.CodeThrottleRepository, captchaSvc captcha.Service,
+	l logger.LoggerV1) service.CodeThrottleService {
+	type Config struct {
+		// DailyBudget 所有业务加起来每天最多发多少条验证码
+		DailyBudget int64                                `yaml:"dailyBudget"`
+		Policies    map[string]domain.CodeThrottlePolicy `yaml:"policies"`
+	}
+	var cfg Config
+	if err := viper.UnmarshalKey("code.throttle", &cfg); err != nil {
+		panic(err)
+	}
+	return service.NewCodeThrottleService(repo, captchaSvc, cfg.Policies, cfg.DailyBudget, l)
+}
@@ -0,0 +1,39 @@
+package ioc
+
+import (
+	"github.com/spf13/viper"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/naming/resolver"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	commentv1 "github.com/liupch66/basic-go/webook/api/proto/gen/comment/v1"
+)
+
+// InitCommentGRPCClient 评论服务只有 gRPC 版本，同样用 ETCD 做服务注册发现
+func InitCommentGRPCClient(cli *clientv3.Client) commentv1.CommentServiceClient {
+	type Config struct {
+		Name   string `yaml:"name"`
+		Secure bool   `yaml:"secure"`
+	}
+	var cfg Config
+	if err := viper.UnmarshalKey("grpc.client.comment", &cfg); err != nil {
+		panic(err)
+	}
+
+	bd, err := resolver.NewBuilder(cli)
+	if err != nil {
+		panic(err)
+	}
+	opts := []grpc.DialOption{grpc.WithResolvers(bd)}
+	if cfg.Secure {
+		// 这里要加载证书什么的，启用 HTTPS
+	} else {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	cc, err := grpc.NewClient("etcd:///service/"+cfg.Name, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return commentv1.NewCommentServiceClient(cc)
+}
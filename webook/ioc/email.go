@@ -0,0 +1,37 @@
+package ioc
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+
+	"github.com/liupch66/basic-go/webook/internal/service/email"
+	"github.com/liupch66/basic-go/webook/internal/service/email/file"
+	"github.com/liupch66/basic-go/webook/internal/service/email/memory"
+	"github.com/liupch66/basic-go/webook/internal/service/email/smtp"
+)
+
+// InitEmailService provider 选 smtp 才会真的发邮件，本地开发用 file 或者 memory
+func InitEmailService() email.Service {
+	type Config struct {
+		Provider string      `yaml:"provider"`
+		Dir      string      `yaml:"dir"`
+		SMTP     smtp.Config `yaml:"smtp"`
+	}
+	cfg := Config{Provider: "memory", Dir: "webook/data/mail"}
+	if err := viper.UnmarshalKey("email", &cfg); err != nil {
+		panic(err)
+	}
+	tpls := email.NewDefaultTemplateEngine()
+	switch cfg.Provider {
+	case "smtp":
+		return smtp.NewService(cfg.SMTP, tpls)
+	case "file":
+		return file.NewService(cfg.Dir, cfg.SMTP.From, tpls)
+	case "memory":
+		return memory.NewService(tpls)
+	default:
+		// 写错了 provider 不能悄悄退化成不发邮件
+		panic(fmt.Errorf("未知的邮件服务 %s", cfg.Provider))
+	}
+}
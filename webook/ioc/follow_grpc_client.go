@@ -0,0 +1,39 @@
+package ioc
+
+import (
+	"github.com/spf13/viper"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/naming/resolver"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	followv1 "github.com/liupch66/basic-go/webook/api/proto/gen/follow/v1"
+)
+
+// InitFollowGRPCClient 关注服务也是用 ETCD 做服务注册发现
+func InitFollowGRPCClient(cli *clientv3.Client) followv1.FollowServiceClient {
+	type Config struct {
+		Name   string `yaml:"name"`
+		Secure bool   `yaml:"secure"`
+	}
+	var cfg Config
+	if err := viper.UnmarshalKey("grpc.client.follow", &cfg); err != nil {
+		panic(err)
+	}
+
+	bd, err := resolver.NewBuilder(cli)
+	if err != nil {
+		panic(err)
+	}
+	opts := []grpc.DialOption{grpc.WithResolvers(bd)}
+	if cfg.Secure {
+		// 这里要加载证书什么的，启用 HTTPS
+	} else {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	cc, err := grpc.NewClient("etcd:///service/"+cfg.Name, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return followv1.NewFollowServiceClient(cc)
+}
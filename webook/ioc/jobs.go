@@ -15,7 +15,11 @@ func InitRankJob(svc service.RankService, lockClient *rlock.Client, l logger.Log
 	return job.NewRankJob(svc, 30*time.Second, lockClient, l)
 }
 
-func InitJobs(l logger.LoggerV1, rankJob *job.RankJob) *cron.Cron {
+func InitRealtimeRankJob(svc *service.RealtimeRankService) *job.RealtimeRankJob {
+	return job.NewRealtimeRankJob(svc, 5*time.Second)
+}
+
+func InitJobs(l logger.LoggerV1, rankJob *job.RankJob, realtimeRankJob *job.RealtimeRankJob) *cron.Cron {
 	res := cron.New(cron.WithSeconds())
 	b := job.NewCronJobBuilder(l)
 	// 秒 分 时 日 月 星期 （年）
@@ -23,5 +27,10 @@ func InitJobs(l logger.LoggerV1, rankJob *job.RankJob) *cron.Cron {
 	if err != nil {
 		panic(err)
 	}
+	// 实时榜只是读一下 Redis 的 ZSET，可以跑得很频繁
+	_, err = res.AddJob("*/10 * * * * ?", b.Build(realtimeRankJob))
+	if err != nil {
+		panic(err)
+	}
 	return res
 }
@@ -0,0 +1,55 @@
+package ioc
+
+import (
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+
+	ijwt "github.com/liupch66/basic-go/webook/internal/web/jwt"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
+)
+
+// InitJwtKeyrings 加载 access_token、refresh_token 和微信 state 的签名密钥。
+// 配置变更的时候热加载，新配置有问题就继续用老的密钥
+func InitJwtKeyrings(l logger.LoggerV1) *ijwt.Keyrings {
+	type Config struct {
+		Access  ijwt.KeyringConfig `yaml:"access"`
+		Refresh ijwt.KeyringConfig `yaml:"refresh"`
+		State   ijwt.KeyringConfig `yaml:"state"`
+	}
+	var cfg Config
+	if err := viper.UnmarshalKey("jwt", &cfg); err != nil {
+		panic(err)
+	}
+	access, err := ijwt.NewKeyring(cfg.Access)
+	if err != nil {
+		panic(err)
+	}
+	refresh, err := ijwt.NewKeyring(cfg.Refresh)
+	if err != nil {
+		panic(err)
+	}
+	state, err := ijwt.NewKeyring(cfg.State)
+	if err != nil {
+		panic(err)
+	}
+	res := &ijwt.Keyrings{Access: access, Refresh: refresh, State: state}
+
+	viper.WatchConfig()
+	viper.OnConfigChange(func(in fsnotify.Event) {
+		var newCfg Config
+		if err := viper.UnmarshalKey("jwt", &newCfg); err != nil {
+			l.Error("解析 jwt 密钥配置失败", logger.Error(err))
+			return
+		}
+		if err := res.Access.Update(newCfg.Access); err != nil {
+			l.Error("更新 access_token 密钥失败", logger.Error(err))
+		}
+		if err := res.Refresh.Update(newCfg.Refresh); err != nil {
+			l.Error("更新 refresh_token 密钥失败", logger.Error(err))
+		}
+		if err := res.State.Update(newCfg.State); err != nil {
+			l.Error("更新微信 state 密钥失败", logger.Error(err))
+		}
+	})
+	return res
+}
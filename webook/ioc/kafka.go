@@ -5,6 +5,9 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/liupch66/basic-go/webook/internal/events"
+	"github.com/liupch66/basic-go/webook/internal/events/article"
+	"github.com/liupch66/basic-go/webook/internal/events/comment"
+	"github.com/liupch66/basic-go/webook/internal/events/interact"
 )
 
 func InitKafka() sarama.Client {
@@ -33,6 +36,8 @@ func InitSyncProducer(client sarama.Client) sarama.SyncProducer {
 	return producer
 }
 
-func NewConsumers() []events.Consumer {
-	return []events.Consumer{}
+func NewConsumers(search *article.SearchConsumer, history *article.HistoryRecordConsumer,
+	rank *interact.RankConsumer, notification *interact.NotificationConsumer,
+	commentNotification *comment.NotificationConsumer) []events.Consumer {
+	return []events.Consumer{search, history, rank, notification, commentNotification}
 }
@@ -19,9 +19,22 @@ func InitLocalFuncExecutor(svc service.RankService) *job.LocalFuncExecutor {
 	return executor
 }
 
-func InitScheduler(svc service.CronJobService, l logger.LoggerV1, executor *job.LocalFuncExecutor) *job.Scheduler {
+func InitScheduledPublishExecutor(svc service.ArticleService, l logger.LoggerV1) *job.ScheduledPublishExecutor {
+	return job.NewScheduledPublishExecutor(svc, l)
+}
+
+func InitPrivacyRequestExecutor(svc service.PrivacyService, l logger.LoggerV1) *job.PrivacyRequestExecutor {
+	return job.NewPrivacyRequestExecutor(svc, l)
+}
+
+func InitScheduler(svc service.CronJobService, l logger.LoggerV1, executor *job.LocalFuncExecutor,
+	pubExecutor *job.ScheduledPublishExecutor, privacyExecutor *job.PrivacyRequestExecutor) *job.Scheduler {
 	s := job.NewScheduler(svc, l)
 	// 要在数据库里面插入一条 rank job 的记录，通过管理任务接口来插入
 	s.RegisterExecutor(executor)
+	// 定时发表文章，同样要在数据库里面插入一条记录
+	s.RegisterExecutor(pubExecutor)
+	// 导出数据和注销账号
+	s.RegisterExecutor(privacyExecutor)
 	return s
 }
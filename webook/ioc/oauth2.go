@@ -0,0 +1,100 @@
+package ioc
+
+import (
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/liupch66/basic-go/webook/internal/service/oauth2"
+	"github.com/liupch66/basic-go/webook/internal/service/oauth2/generic"
+	"github.com/liupch66/basic-go/webook/internal/service/oauth2/oidc"
+	"github.com/liupch66/basic-go/webook/internal/service/oauth2/wechat"
+	"github.com/liupch66/basic-go/webook/internal/web"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
+)
+
+type oauth2ProviderConfig struct {
+	Name         string   `yaml:"name"`
+	Type         string   `yaml:"type"`
+	ClientId     string   `yaml:"clientId"`
+	ClientSecret string   `yaml:"clientSecret"`
+	RedirectURL  string   `yaml:"redirectURL"`
+	Scopes       []string `yaml:"scopes"`
+	PKCE         bool     `yaml:"pkce"`
+	// oidc 用
+	Issuer string `yaml:"issuer"`
+	// oauth2 用
+	AuthURL      string `yaml:"authURL"`
+	TokenURL     string `yaml:"tokenURL"`
+	UserInfoURL  string `yaml:"userInfoURL"`
+	SubjectField string `yaml:"subjectField"`
+	EmailField   string `yaml:"emailField"`
+	NameField    string `yaml:"nameField"`
+}
+
+// InitOAuth2Registry 没有配置 clientId 的平台不启用
+func InitOAuth2Registry(l logger.LoggerV1) *oauth2.Registry {
+	type Config struct {
+		Providers []oauth2ProviderConfig `yaml:"providers"`
+	}
+	var cfg Config
+	if err := viper.UnmarshalKey("oauth2", &cfg); err != nil {
+		panic(err)
+	}
+	client := &http.Client{Timeout: 10 * time.Second}
+	providers := make([]oauth2.Provider, 0, len(cfg.Providers))
+	for _, pc := range cfg.Providers {
+		if pc.ClientId == "" {
+			l.Warn("第三方登录没有配置 clientId，不启用", logger.String("provider", pc.Name))
+			continue
+		}
+		if pc.ClientSecret == "" {
+			pc.ClientSecret = os.Getenv(strings.ToUpper(pc.Name) + "_CLIENT_SECRET")
+		}
+		switch pc.Type {
+		case "wechat":
+			providers = append(providers, wechat.NewWechatService(pc.ClientId, pc.ClientSecret, pc.RedirectURL, l))
+		case "github":
+			providers = append(providers, generic.NewGitHubProvider(pc.ClientId, pc.ClientSecret, pc.RedirectURL, client))
+		case "oidc":
+			providers = append(providers, oidc.NewProvider(oidc.Config{
+				Name:         pc.Name,
+				Issuer:       pc.Issuer,
+				ClientId:     pc.ClientId,
+				ClientSecret: pc.ClientSecret,
+				RedirectURL:  pc.RedirectURL,
+				Scopes:       pc.Scopes,
+				PKCE:         pc.PKCE,
+			}, client))
+		case "oauth2":
+			providers = append(providers, generic.NewProvider(generic.Config{
+				Name:         pc.Name,
+				ClientId:     pc.ClientId,
+				ClientSecret: pc.ClientSecret,
+				AuthURL:      pc.AuthURL,
+				TokenURL:     pc.TokenURL,
+				UserInfoURL:  pc.UserInfoURL,
+				RedirectURL:  pc.RedirectURL,
+				Scopes:       pc.Scopes,
+				PKCE:         pc.PKCE,
+				SubjectField: pc.SubjectField,
+				EmailField:   pc.EmailField,
+				NameField:    pc.NameField,
+			}, client))
+		default:
+			panic("不支持的第三方登录类型 " + pc.Type)
+		}
+	}
+	return oauth2.NewRegistry(providers...)
+}
+
+func InitOAuth2HandlerConfig() web.OAuth2HandlerConfig {
+	cfg := web.OAuth2HandlerConfig{Secure: false}
+	if err := viper.UnmarshalKey("oauth2", &cfg); err != nil {
+		panic(err)
+	}
+	return cfg
+}
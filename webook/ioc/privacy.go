@@ -0,0 +1,30 @@
+package ioc
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+
+	commentv1 "github.com/liupch66/basic-go/webook/api/proto/gen/comment/v1"
+	interactv1 "github.com/liupch66/basic-go/webook/api/proto/gen/interact/v1"
+	"github.com/liupch66/basic-go/webook/internal/repository"
+	"github.com/liupch66/basic-go/webook/internal/service"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
+)
+
+// InitPrivacyService 冷静期默认 7 天
+func InitPrivacyService(repo repository.PrivacyRequestRepository, userRepo repository.UserRepository,
+	artSvc service.ArticleService, historySvc service.HistoryRecordService,
+	notificationSvc service.NotificationService, interactSvc interactv1.InteractServiceClient,
+	commentSvc commentv1.CommentServiceClient, revoker service.SessionRevoker,
+	l logger.LoggerV1) service.PrivacyService {
+	type Config struct {
+		CoolingOff time.Duration `yaml:"coolingOff"`
+	}
+	cfg := Config{CoolingOff: 7 * 24 * time.Hour}
+	if err := viper.UnmarshalKey("privacy", &cfg); err != nil {
+		panic(err)
+	}
+	return service.NewPrivacyService(repo, userRepo, artSvc, historySvc, notificationSvc, interactSvc, commentSvc,
+		revoker, cfg.CoolingOff, l)
+}
@@ -0,0 +1,47 @@
+package ioc
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+
+	"github.com/liupch66/basic-go/webook/internal/service"
+)
+
+// InitRankWeights 实时榜每次互动加的分，和 weighted 热度算法共用一套权重
+func InitRankWeights() service.RankWeights {
+	type Config struct {
+		ReadWeight    float64 `yaml:"readWeight"`
+		LikeWeight    float64 `yaml:"likeWeight"`
+		CollectWeight float64 `yaml:"collectWeight"`
+	}
+	cfg := Config{ReadWeight: 0.1, LikeWeight: 1, CollectWeight: 2}
+	if err := viper.UnmarshalKey("rank", &cfg); err != nil {
+		panic(err)
+	}
+	return service.RankWeights{Read: cfg.ReadWeight, Like: cfg.LikeWeight, Collect: cfg.CollectWeight}
+}
+
+// InitRankScoreStrategy 按照配置里面的名字选热度算法
+func InitRankScoreStrategy() service.RankScoreStrategy {
+	type Config struct {
+		Strategy      string  `yaml:"strategy"`
+		Gravity       float64 `yaml:"gravity"`
+		ReadWeight    float64 `yaml:"readWeight"`
+		LikeWeight    float64 `yaml:"likeWeight"`
+		CollectWeight float64 `yaml:"collectWeight"`
+	}
+	// 没有配置的话就是原本的只看点赞
+	cfg := Config{Strategy: "like", Gravity: 1.5}
+	if err := viper.UnmarshalKey("rank", &cfg); err != nil {
+		panic(err)
+	}
+	switch cfg.Strategy {
+	case "like":
+		return service.NewLikeRankStrategy(cfg.Gravity)
+	case "weighted":
+		return service.NewWeightedRankStrategy(cfg.ReadWeight, cfg.LikeWeight, cfg.CollectWeight, cfg.Gravity)
+	default:
+		panic(fmt.Errorf("未知的热度算法 %s", cfg.Strategy))
+	}
+}
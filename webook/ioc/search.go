@@ -0,0 +1,30 @@
+package ioc
+
+import (
+	"errors"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/spf13/viper"
+
+	"github.com/liupch66/basic-go/webook/internal/repository/dao/search"
+)
+
+func InitSearchIndex() bleve.Index {
+	type Config struct {
+		// Path 索引在本地磁盘上的目录
+		Path string `yaml:"path"`
+	}
+	var cfg Config
+	if err := viper.UnmarshalKey("search", &cfg); err != nil {
+		panic(err)
+	}
+	index, err := bleve.Open(cfg.Path)
+	if errors.Is(err, bleve.ErrorIndexPathDoesNotExist) {
+		// 第一次启动，还没有索引
+		index, err = bleve.New(cfg.Path, search.NewArticleIndexMapping())
+	}
+	if err != nil {
+		panic(err)
+	}
+	return index
+}
@@ -2,27 +2,96 @@ package ioc
 
 import (
 	"os"
+	"sync"
 
 	"github.com/redis/go-redis/v9"
+	"github.com/spf13/viper"
 	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
 	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/profile"
 	tencentSMS "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/sms/v20210111"
 
+	"github.com/liupch66/basic-go/webook/internal/repository"
 	"github.com/liupch66/basic-go/webook/internal/service/sms"
+	"github.com/liupch66/basic-go/webook/internal/service/sms/async"
+	"github.com/liupch66/basic-go/webook/internal/service/sms/circuitbreaker"
+	"github.com/liupch66/basic-go/webook/internal/service/sms/failover"
 	"github.com/liupch66/basic-go/webook/internal/service/sms/memory"
+	"github.com/liupch66/basic-go/webook/internal/service/sms/metrics"
+	"github.com/liupch66/basic-go/webook/internal/service/sms/receipt"
+	"github.com/liupch66/basic-go/webook/internal/service/sms/sandbox"
+	"github.com/liupch66/basic-go/webook/internal/service/sms/template"
 	"github.com/liupch66/basic-go/webook/internal/service/sms/tencent"
+	"github.com/liupch66/basic-go/webook/pkg/logger"
 )
 
-func InitSmsService(cmd redis.Cmdable) sms.Service {
+// Prometheus 的指标只能注册一次，测试里面会多次初始化短信服务
+var (
+	smsBreakerRecorder = sync.OnceValue(metrics.NewCircuitBreakerRecorder)
+	smsAsyncRecorder   = sync.OnceValue(metrics.NewAsyncSwitchRecorder)
+	smsReceiptRecorder = sync.OnceValue(metrics.NewReceiptRecorder)
+)
+
+// InitSmsReceiptService 记录每一条短信的状态，服务商在 InitSmsService 里面接入回执
+func InitSmsReceiptService(repo repository.SmsMessageRepository, l logger.LoggerV1) *receipt.Service {
+	svc := receipt.NewService(repo, receipt.DefaultConfig(), smsReceiptRecorder(), l)
+	go svc.StartPollCycle()
+	return svc
+}
+
+// InitSmsTemplateRegistry 短信模板在 sms.templates 里面配置，业务方只用模板的名字
+func InitSmsTemplateRegistry() *template.Registry {
+	var tpls []template.Template
+	if err := viper.UnmarshalKey("sms.templates", &tpls); err != nil {
+		panic(err)
+	}
+	registry, err := template.NewRegistry(tpls...)
+	if err != nil {
+		panic(err)
+	}
+	return registry
+}
+
+// InitSmsSandbox 没有开 sms.sandbox 的时候返回 nil
+func InitSmsSandbox(registry *template.Registry, receiptSvc *receipt.Service) *sandbox.Service {
+	if !viper.GetBool("sms.sandbox") {
+		return nil
+	}
+	svc := sandbox.NewService(registry, receiptSvc)
+	receiptSvc.AddPoller(sandbox.Vendor, svc)
+	return svc
+}
+
+func InitSmsService(cmd redis.Cmdable, repo repository.AsyncSmsRepository, receiptSvc *receipt.Service,
+	sandboxSvc *sandbox.Service, registry *template.Registry, l logger.LoggerV1) sms.Service {
 	// 装饰器模式,可以一直套
 	// svc := ratelimit.NewService(memory.NewService(), limiter.NewRedisSlideWindowLimiter(cmd, 100, time.Second))
 	// return retryable.NewService(svc, 3)
 	// 接入监控
 	// return metrics.NewPrometheusDecorator(memory.NewService())
-	return memory.NewService()
+	// 每个服务商单独包一个熔断器，failover 会跳过熔断中的服务商，送达率低的排到后面。接入腾讯云之后加到后面
+	// 开了沙箱就用沙箱代替 memory，端到端测试要读到发出去的验证码
+	var provider sms.Service
+	vendor := sandbox.Vendor
+	if sandboxSvc != nil {
+		provider = sandboxSvc
+	} else {
+		memorySvc := memory.NewService(registry, receiptSvc)
+		receiptSvc.AddPoller(memory.Vendor, memorySvc)
+		provider, vendor = memorySvc, memory.Vendor
+	}
+	svc := failover.NewService([]sms.Service{
+		circuitbreaker.NewService(vendor, provider, circuitbreaker.DefaultConfig(), smsBreakerRecorder()),
+	})
+	// 根据服务商的表现决定同步还是异步发送
+	asyncSvc := async.NewService(svc, repo, async.DefaultConfig(), smsAsyncRecorder(), l)
+	go asyncSvc.StartAsyncCycle()
+	// 最外面校验模板和参数，参数不对的不会存到异步发送的表里面
+	return template.NewService(asyncSvc, registry)
 }
 
-func initSmsTencentService() sms.Service {
+// initSmsTencentService 腾讯云的回执优先走回调，拉取是兜底，两边拿到同一个回执只算一次。
+// 腾讯云的回调不带签名，控制台上配置的回调地址要带上 ?token=${SMS_CALLBACK_TOKEN}
+func initSmsTencentService(registry *template.Registry, receiptSvc *receipt.Service) sms.Service {
 	secretId, ok := os.LookupEnv("SMS_SECRET_ID")
 	if !ok {
 		panic("没有找到环境变量 SMS_SECRET_ID")
@@ -31,9 +100,16 @@ func initSmsTencentService() sms.Service {
 	if !ok {
 		panic("没有找到环境变量 SMS_SECRET_KEY")
 	}
+	callbackToken, ok := os.LookupEnv("SMS_CALLBACK_TOKEN")
+	if !ok {
+		panic("没有找到环境变量 SMS_CALLBACK_TOKEN")
+	}
 	client, err := tencentSMS.NewClient(common.NewCredential(secretId, secretKey), "ap-nanjing", profile.NewClientProfile())
 	if err != nil {
 		panic(err)
 	}
-	return tencent.NewService("1400859261", "webook公众号", client)
+	svc := tencent.NewService("1400859261", "webook公众号", client, registry, receiptSvc)
+	receiptSvc.AddCallback(tencent.Vendor, callbackToken, svc)
+	receiptSvc.AddPoller(tencent.Vendor, svc)
+	return svc
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/redis/go-redis/v9"
+	"github.com/spf13/viper"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 
 	"github.com/liupch66/basic-go/webook/internal/web"
@@ -20,14 +21,41 @@ import (
 )
 
 func InitWebServer(middlewares []gin.HandlerFunc, userHdl *web.UserHandler,
-	oauth2WechatHal *web.OAuth2WechatHandler, articleHdl *web.ArticleHandler) *gin.Engine {
+	oauth2Hdl *web.OAuth2Handler, articleHdl *web.ArticleHandler, searchHdl *web.SearchHandler,
+	commentHdl *web.CommentHandler, followHdl *web.FollowHandler, historyHdl *web.HistoryHandler,
+	collectionHdl *web.CollectionHandler, rankHdl *web.RankHandler,
+	notificationHdl *web.NotificationHandler, sessionHdl *web.SessionHandler,
+	jwksHdl *web.JWKSHandler, twoFactorHdl *web.TwoFactorHandler, userEmailHdl *web.UserEmailHandler,
+	accountHdl *web.AccountHandler, adminHdl *web.AdminHandler, privacyHdl *web.PrivacyHandler,
+	smsHdl *web.SmsHandler, smsSandboxHdl *web.SmsSandboxHandler) *gin.Engine {
 	server := gin.Default()
+	// gin 默认信任所有代理，客户端自己带个 X-Forwarded-For 就能伪造 ClientIP，绕过按 IP 的限流和验证码频控。
+	// 只信任配置了的反向代理，没有配置的话 ClientIP 就是 RemoteIP
+	if err := server.SetTrustedProxies(viper.GetStringSlice("web.trustedProxies")); err != nil {
+		panic(err)
+	}
 	// 打开这个才能链路追踪
 	// server.ContextWithFallback = true
 	server.Use(middlewares...)
 	userHdl.RegisterRoutes(server)
-	oauth2WechatHal.RegisterRoutes(server)
+	oauth2Hdl.RegisterRoutes(server)
 	articleHdl.RegisterRoutes(server)
+	searchHdl.RegisterRoutes(server)
+	commentHdl.RegisterRoutes(server)
+	followHdl.RegisterRoutes(server)
+	historyHdl.RegisterRoutes(server)
+	collectionHdl.RegisterRoutes(server)
+	rankHdl.RegisterRoutes(server)
+	notificationHdl.RegisterRoutes(server)
+	sessionHdl.RegisterRoutes(server)
+	jwksHdl.RegisterRoutes(server)
+	twoFactorHdl.RegisterRoutes(server)
+	userEmailHdl.RegisterRoutes(server)
+	accountHdl.RegisterRoutes(server)
+	adminHdl.RegisterRoutes(server)
+	privacyHdl.RegisterRoutes(server)
+	smsHdl.RegisterRoutes(server)
+	smsSandboxHdl.RegisterRoutes(server)
 	web.NewObservabilityHandler().RegisterRoutes(server)
 	return server
 }
@@ -52,8 +80,8 @@ func InitMiddlewares(l logger.LoggerV1, redisCli redis.Cmdable, jwtHdl ijwt.Hand
 		// acBuilder.Build(),
 		// cors 跨域资源共享
 		cors.New(cors.Config{
-			AllowHeaders:     []string{"Authorization", "Content-Type"},
-			ExposeHeaders:    []string{"x-jwt-token", "x-refresh-token"},
+			AllowHeaders:     []string{"Authorization", "Content-Type", "X-Device-Id"},
+			ExposeHeaders:    []string{"x-jwt-token", "x-refresh-token", "x-2fa-token"},
 			AllowCredentials: true,
 			AllowOriginFunc: func(origin string) bool {
 				if strings.HasPrefix(origin, "http://localhost") || strings.HasPrefix(origin, "http://127.0.0.1") {
@@ -72,12 +100,24 @@ func InitMiddlewares(l logger.LoggerV1, redisCli redis.Cmdable, jwtHdl ijwt.Hand
 			"/users/login",
 			"/users/login_sms/code/send",
 			"/users/login_sms",
-			"/oauth2/wechat/authurl",
-			"/oauth2/wechat/callback",
-			"/wechat/callback.do",
+			"/users/login_2fa",
+			"/users/signup/email/code/send",
+			"/users/signup/email/verify",
+			"/users/password/reset/code/send",
+			"/users/password/reset",
 			// access_token 过期了要通过 refresh_token 刷新
 			"/users/refresh_token",
+			"/.well-known/jwks.json",
 			"/test/metrics",
+			// 热榜不需要登录
+			"/articles/hot",
+		).IgnorePathPrefixes(
+			// 所有第三方登录的 authurl 和 callback
+			"/oauth2/",
+			// 短信服务商的回执回调
+			"/sms/receipts/",
+			// 端到端测试读沙箱里面的短信，只有开了 sms.sandbox 才有这些路由
+			"/sandbox/sms/",
 		).Build(),
 		(&metrics.PrometheusBuilder{
 			Namespace:  "geektime",
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"time"
 
@@ -32,6 +33,14 @@ func main() {
 	cron := app.cron
 	cron.Start()
 
+	schedulerCtx, schedulerCancel := context.WithCancel(context.Background())
+	defer schedulerCancel()
+	go func() {
+		if err := app.scheduler.Start(schedulerCtx); err != nil && !errors.Is(err, context.Canceled) {
+			zap.L().Error("任务调度退出", zap.Error(err))
+		}
+	}()
+
 	server := app.web
 	server.GET("/hello", func(ctx *gin.Context) {
 		ctx.String(http.StatusOK, "Hello, world!")
@@ -0,0 +1,106 @@
+package diffx
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrTooLarge 两边的文本差异太大，不做对比
+var ErrTooLarge = errors.New("diffx: 文本太大，无法对比")
+
+// MaxCells 去掉首尾相同的行之后，LCS 表最多这么多格子，大约 8MB 内存
+// 不限制的话，两个几万行的版本就能把内存打满
+const MaxCells = 1 << 20
+
+type Op uint8
+
+const (
+	OpEqual Op = iota
+	OpInsert
+	OpDelete
+)
+
+type Edit struct {
+	Op   Op
+	Text string
+}
+
+// Lines 按行比较 a 和 b，返回把 a 变成 b 的编辑序列
+// 用的是最朴素的 LCS 动态规划，时间和空间都是 O(m*n)，所以先去掉首尾相同的行，
+// 剩下的部分超过 MaxCells 就返回 ErrTooLarge
+func Lines(a, b string) ([]Edit, error) {
+	src, dst := split(a), split(b)
+	// 一般只改了中间几行，首尾相同的部分直接算 OpEqual
+	prefix := 0
+	for prefix < len(src) && prefix < len(dst) && src[prefix] == dst[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(src)-prefix && suffix < len(dst)-prefix &&
+		src[len(src)-1-suffix] == dst[len(dst)-1-suffix] {
+		suffix++
+	}
+	midSrc, midDst := src[prefix:len(src)-suffix], dst[prefix:len(dst)-suffix]
+	m, n := len(midSrc), len(midDst)
+	if m > 0 && n > 0 && m > MaxCells/n {
+		return nil, ErrTooLarge
+	}
+
+	res := make([]Edit, 0, max(len(src), len(dst)))
+	for _, line := range src[:prefix] {
+		res = append(res, Edit{Op: OpEqual, Text: line})
+	}
+	res = append(res, lcs(midSrc, midDst)...)
+	for _, line := range src[len(src)-suffix:] {
+		res = append(res, Edit{Op: OpEqual, Text: line})
+	}
+	return res, nil
+}
+
+func lcs(src, dst []string) []Edit {
+	m, n := len(src), len(dst)
+	// table[i][j] 表示 src[i:] 和 dst[j:] 的最长公共子序列长度
+	table := make([][]int, m+1)
+	for i := range table {
+		table[i] = make([]int, n+1)
+	}
+	for i := m - 1; i >= 0; i-- {
+		for j := n - 1; j >= 0; j-- {
+			if src[i] == dst[j] {
+				table[i][j] = table[i+1][j+1] + 1
+			} else {
+				table[i][j] = max(table[i+1][j], table[i][j+1])
+			}
+		}
+	}
+	res := make([]Edit, 0, max(m, n))
+	i, j := 0, 0
+	for i < m && j < n {
+		switch {
+		case src[i] == dst[j]:
+			res = append(res, Edit{Op: OpEqual, Text: src[i]})
+			i++
+			j++
+		case table[i+1][j] >= table[i][j+1]:
+			res = append(res, Edit{Op: OpDelete, Text: src[i]})
+			i++
+		default:
+			res = append(res, Edit{Op: OpInsert, Text: dst[j]})
+			j++
+		}
+	}
+	for ; i < m; i++ {
+		res = append(res, Edit{Op: OpDelete, Text: src[i]})
+	}
+	for ; j < n; j++ {
+		res = append(res, Edit{Op: OpInsert, Text: dst[j]})
+	}
+	return res
+}
+
+func split(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+}
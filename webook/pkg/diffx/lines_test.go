@@ -0,0 +1,95 @@
+package diffx
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLines(t *testing.T) {
+	testCases := []struct {
+		name string
+		a    string
+		b    string
+
+		want    []Edit
+		wantErr error
+	}{
+		{
+			name: "完全相同",
+			a:    "第一行\n第二行",
+			b:    "第一行\n第二行",
+			want: []Edit{{Op: OpEqual, Text: "第一行"}, {Op: OpEqual, Text: "第二行"}},
+		},
+		{
+			name: "从空到有",
+			a:    "",
+			b:    "新增",
+			want: []Edit{{Op: OpInsert, Text: "新增"}},
+		},
+		{
+			name: "删除一行",
+			a:    "a\nb\nc",
+			b:    "a\nc",
+			want: []Edit{{Op: OpEqual, Text: "a"}, {Op: OpDelete, Text: "b"}, {Op: OpEqual, Text: "c"}},
+		},
+		{
+			name: "修改一行",
+			a:    "a\nb\nc",
+			b:    "a\nB\nc",
+			want: []Edit{
+				{Op: OpEqual, Text: "a"},
+				{Op: OpDelete, Text: "b"},
+				{Op: OpInsert, Text: "B"},
+				{Op: OpEqual, Text: "c"},
+			},
+		},
+		{
+			name: "兼容 CRLF",
+			a:    "a\r\nb",
+			b:    "a\nb\nc",
+			want: []Edit{{Op: OpEqual, Text: "a"}, {Op: OpEqual, Text: "b"}, {Op: OpInsert, Text: "c"}},
+		},
+		{
+			name: "首尾相同只比较中间",
+			a:    manyLines("a", 2000) + "\nold\n" + manyLines("z", 2000),
+			b:    manyLines("a", 2000) + "\nnew\n" + manyLines("z", 2000),
+			want: func() []Edit {
+				res := equalEdits("a", 2000)
+				res = append(res, Edit{Op: OpDelete, Text: "old"}, Edit{Op: OpInsert, Text: "new"})
+				return append(res, equalEdits("z", 2000)...)
+			}(),
+		},
+		{
+			name:    "差异太大",
+			a:       manyLines("a", 2000),
+			b:       manyLines("b", 2000),
+			wantErr: ErrTooLarge,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			edits, err := Lines(tc.a, tc.b)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, edits)
+		})
+	}
+}
+
+func manyLines(prefix string, n int) string {
+	lines := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		lines = append(lines, prefix+strconv.Itoa(i))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func equalEdits(prefix string, n int) []Edit {
+	res := make([]Edit, 0, n)
+	for i := 0; i < n; i++ {
+		res = append(res, Edit{Op: OpEqual, Text: prefix + strconv.Itoa(i)})
+	}
+	return res
+}
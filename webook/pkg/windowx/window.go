@@ -0,0 +1,61 @@
+package windowx
+
+import (
+	"time"
+)
+
+type bucket[T any] struct {
+	// start 这个桶对应的时间段的开始时间，过期了的桶要先清空再用
+	start time.Time
+	val   T
+}
+
+// Window 滑动窗口，把窗口分成多个桶，每个桶统计一小段时间的数据，T 是桶里面的统计数据。
+// 过期的桶在用到的时候清空，不需要额外的 goroutine。不是并发安全的，调用方自己加锁
+type Window[T any] struct {
+	buckets []bucket[T]
+	size    time.Duration
+}
+
+// New window 是整个窗口的长度，分成 cnt 个桶。
+// cnt 没有配置（小于 1）的时候按一个桶算，不然取模和算桶的长度都会 panic
+func New[T any](window time.Duration, cnt int) *Window[T] {
+	if cnt < 1 {
+		cnt = 1
+	}
+	size := window / time.Duration(cnt)
+	if size <= 0 {
+		size = time.Nanosecond
+	}
+	return &Window[T]{
+		buckets: make([]bucket[T], cnt),
+		size:    size,
+	}
+}
+
+// Update 用 fn 更新 now 所在的桶
+func (w *Window[T]) Update(now time.Time, fn func(val *T)) {
+	start := now.Truncate(w.size)
+	b := &w.buckets[int(start.UnixNano()/int64(w.size))%len(w.buckets)]
+	if !b.start.Equal(start) {
+		*b = bucket[T]{start: start}
+	}
+	fn(&b.val)
+}
+
+// Range 遍历窗口里面还没有过期的桶
+func (w *Window[T]) Range(now time.Time, fn func(val T)) {
+	earliest := now.Truncate(w.size).Add(-w.size * time.Duration(len(w.buckets)-1))
+	for _, b := range w.buckets {
+		if b.start.Before(earliest) {
+			continue
+		}
+		fn(b.val)
+	}
+}
+
+func (w *Window[T]) Reset() {
+	for i := range w.buckets {
+		w.buckets[i] = bucket[T]{}
+	}
+}
@@ -0,0 +1,93 @@
+package windowx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWindow(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+	testCases := []struct {
+		name    string
+		window  time.Duration
+		buckets int
+		// adds 每个元素是一次 Update 的时间
+		adds []time.Duration
+		at   time.Duration
+
+		want int
+	}{
+		{
+			name:    "都在窗口里面",
+			window:  10 * time.Second,
+			buckets: 10,
+			adds:    []time.Duration{0, time.Second, 5 * time.Second, 9 * time.Second},
+			at:      9 * time.Second,
+			want:    4,
+		},
+		{
+			name:    "过期的桶不算",
+			window:  10 * time.Second,
+			buckets: 10,
+			adds:    []time.Duration{0, time.Second, 5 * time.Second, 11 * time.Second},
+			at:      11 * time.Second,
+			want:    2,
+		},
+		{
+			name:    "复用的桶先清空",
+			window:  10 * time.Second,
+			buckets: 10,
+			// 0s 和 10s 落在同一个桶
+			adds: []time.Duration{0, 0, 10 * time.Second},
+			at:   10 * time.Second,
+			want: 1,
+		},
+		{
+			name:    "没有配置桶的个数",
+			window:  10 * time.Second,
+			buckets: 0,
+			// 整个窗口只有一个桶，11s 的时候前面两个都过期了
+			adds: []time.Duration{0, 5 * time.Second, 11 * time.Second},
+			at:   11 * time.Second,
+			want: 1,
+		},
+		{
+			name:    "没有配置窗口",
+			buckets: 10,
+			adds:    []time.Duration{0, time.Second},
+			at:      time.Second,
+			want:    1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := New[int](tc.window, tc.buckets)
+			for _, d := range tc.adds {
+				w.Update(start.Add(d), func(val *int) {
+					*val++
+				})
+			}
+			var got int
+			w.Range(start.Add(tc.at), func(val int) {
+				got += val
+			})
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestWindow_Reset(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+	w := New[int](10*time.Second, 10)
+	w.Update(now, func(val *int) {
+		*val++
+	})
+	w.Reset()
+	var got int
+	w.Range(now, func(val int) {
+		got += val
+	})
+	assert.Equal(t, 0, got)
+}
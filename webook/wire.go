@@ -6,11 +6,14 @@ import (
 	"github.com/google/wire"
 
 	article2 "github.com/liupch66/basic-go/webook/internal/events/article"
+	"github.com/liupch66/basic-go/webook/internal/events/comment"
+	"github.com/liupch66/basic-go/webook/internal/events/interact"
 	"github.com/liupch66/basic-go/webook/internal/repository"
 	"github.com/liupch66/basic-go/webook/internal/repository/article"
 	"github.com/liupch66/basic-go/webook/internal/repository/cache"
 	"github.com/liupch66/basic-go/webook/internal/repository/dao"
 	article3 "github.com/liupch66/basic-go/webook/internal/repository/dao/article"
+	"github.com/liupch66/basic-go/webook/internal/repository/dao/search"
 	"github.com/liupch66/basic-go/webook/internal/service"
 	"github.com/liupch66/basic-go/webook/internal/web"
 	ijwt "github.com/liupch66/basic-go/webook/internal/web/jwt"
@@ -22,12 +25,68 @@ var rankServiceSet = wire.NewSet(
 	cache.NewRankLocalCache,
 	repository.NewCachedRankRepository,
 	service.NewBatchRankService,
+	ioc.InitRankScoreStrategy,
+	// 实时榜包了一层批量计算的榜单，对外都用它
+	cache.NewRedisRankScoreCache,
+	repository.NewCachedRankScoreRepository,
+	ioc.InitRankWeights,
+	service.NewRealtimeRankService,
+	wire.Bind(new(service.RankService), new(*service.RealtimeRankService)),
+)
+
+var historyServiceSet = wire.NewSet(
+	dao.NewGORMHistoryRecordDAO,
+	cache.NewRedisHistoryRecordCache,
+	repository.NewCachedHistoryRecordRepository,
+	service.NewHistoryRecordService,
+)
+
+var notificationServiceSet = wire.NewSet(
+	dao.NewGORMNotificationDAO,
+	cache.NewRedisNotificationCache,
+	repository.NewCachedNotificationRepository,
+	service.NewNotificationService,
+)
+
+var twoFactorServiceSet = wire.NewSet(
+	dao.NewGORMTwoFactorDAO,
+	cache.NewRedisTwoFactorCache,
+	repository.NewCachedTwoFactorRepository,
+	service.NewTwoFactorService,
+)
+
+var privacyServiceSet = wire.NewSet(
+	dao.NewGORMPrivacyRequestDAO,
+	repository.NewPrivacyRequestRepository,
+	ioc.InitPrivacyService,
+)
+
+var schedulerSet = wire.NewSet(
+	dao.NewGORMCronJobDAO,
+	repository.NewPreemptCronJobRepository,
+	service.NewCronJobService,
+	ioc.InitLocalFuncExecutor,
+	ioc.InitScheduledPublishExecutor,
+	ioc.InitPrivacyRequestExecutor,
+	ioc.InitScheduler,
+)
+
+var searchServiceSet = wire.NewSet(
+	ioc.InitSearchIndex,
+	search.NewBleveArticleDAO,
+	repository.NewArticleSearchRepository,
+	service.NewSearchService,
 )
 
 func InitApp() *App {
 	wire.Build(
 		ioc.InitDB, ioc.InitRedis, ioc.InitRLockClient, ioc.InitLogger,
 		ioc.InitKafka, ioc.InitSyncProducer, article2.NewSaramaSyncProducer,
+		article2.NewSearchConsumer,
+		article2.NewHistoryRecordConsumer,
+		interact.NewRankConsumer,
+		interact.NewNotificationConsumer,
+		comment.NewNotificationConsumer,
 		ioc.NewConsumers,
 
 		dao.NewUserDAO, article3.NewGORMArticleDAO,
@@ -35,19 +94,43 @@ func InitApp() *App {
 
 		repository.NewUserRepository, repository.NewCodeRepository, article.NewCachedArticleRepository,
 
-		service.NewUserService, service.NewCodeService, ioc.InitSmsService, ioc.InitWechatService,
+		service.NewUserService, service.NewCodeService, ioc.InitSmsService, ioc.InitSmsTemplateRegistry,
+		cache.NewRedisCodeThrottleCache, repository.NewCodeThrottleRepository, ioc.InitCodeThrottleService,
+		ioc.InitCaptchaService,
+		dao.NewGORMAsyncSmsDAO, repository.NewAsyncSMSRepository,
+		dao.NewGORMSmsMessageDAO, repository.NewSmsMessageRepository, ioc.InitSmsReceiptService,
+		ioc.InitSmsSandbox,
+		service.NewEmailCodeService, ioc.InitEmailService, ioc.InitOAuth2Registry,
+		service.NewAccountService, service.NewAdminService, ioc.InitRBACService,
+		dao.NewGORMAuditLogDAO, repository.NewAuditLogRepository,
+		wire.Bind(new(ijwt.Authorizer), new(service.RBACService)),
+		// 注销账号的时候要踢掉所有会话
+		wire.Bind(new(service.SessionRevoker), new(ijwt.Handler)),
 		service.NewArticleService,
 		// 流量控制的 client
 		// service2.NewInteractService, ioc.InitInteractGRPCClient,
 		// etcd 服务注册发现的 client
 		ioc.InitEtcdClient, ioc.InitInteractGRPCClientV1,
+		ioc.InitCommentGRPCClient,
+		ioc.InitFollowGRPCClient, service.NewFollowFeedService,
 
 		rankServiceSet,
-		ioc.InitRankJob,
+		searchServiceSet,
+		historyServiceSet,
+		notificationServiceSet,
+		twoFactorServiceSet,
+		privacyServiceSet,
+		schedulerSet,
+		ioc.InitRankJob, ioc.InitRealtimeRankJob,
 		ioc.InitJobs,
 
-		web.NewUserHandler, ioc.InitWechatHandlerConfig, web.NewOAuth2WechatHandler, ijwt.NewRedisJwtHandler,
-		web.NewArticleHandler,
+		web.NewUserHandler, ioc.InitOAuth2HandlerConfig, web.NewOAuth2Handler, ijwt.NewRedisJwtHandler,
+		ioc.InitJwtKeyrings, web.NewJWKSHandler, web.NewTwoFactorHandler, web.NewUserEmailHandler,
+		web.NewArticleHandler, web.NewSearchHandler, web.NewCommentHandler,
+		web.NewFollowHandler, web.NewHistoryHandler, web.NewCollectionHandler,
+		web.NewRankHandler, web.NewNotificationHandler, web.NewSessionHandler,
+		web.NewAccountHandler, web.NewAdminHandler, web.NewPrivacyHandler,
+		web.NewSmsHandler, web.NewSmsSandboxHandler,
 
 		ioc.InitMiddlewares,
 
@@ -9,11 +9,14 @@ package main
 import (
 	"github.com/google/wire"
 	article3 "github.com/liupch66/basic-go/webook/internal/events/article"
+	"github.com/liupch66/basic-go/webook/internal/events/comment"
+	"github.com/liupch66/basic-go/webook/internal/events/interact"
 	"github.com/liupch66/basic-go/webook/internal/repository"
 	article2 "github.com/liupch66/basic-go/webook/internal/repository/article"
 	"github.com/liupch66/basic-go/webook/internal/repository/cache"
 	"github.com/liupch66/basic-go/webook/internal/repository/dao"
 	"github.com/liupch66/basic-go/webook/internal/repository/dao/article"
+	"github.com/liupch66/basic-go/webook/internal/repository/dao/search"
 	"github.com/liupch66/basic-go/webook/internal/service"
 	"github.com/liupch66/basic-go/webook/internal/web"
 	"github.com/liupch66/basic-go/webook/internal/web/jwt"
@@ -29,48 +32,136 @@ import (
 func InitApp() *App {
 	loggerV1 := ioc.InitLogger()
 	cmdable := ioc.InitRedis()
-	handler := jwt.NewRedisJwtHandler(cmdable)
-	v := ioc.InitMiddlewares(loggerV1, cmdable, handler)
+	keyrings := ioc.InitJwtKeyrings(loggerV1)
 	db := ioc.InitDB(loggerV1)
 	userDAO := dao.NewUserDAO(db)
 	userCache := cache.NewUserCache(cmdable)
 	userRepository := repository.NewUserRepository(userDAO, userCache)
+	rbacService := ioc.InitRBACService(userRepository)
+	handler := jwt.NewRedisJwtHandler(cmdable, keyrings, rbacService)
+	v := ioc.InitMiddlewares(loggerV1, cmdable, handler)
 	userService := service.NewUserService(userRepository, loggerV1)
 	codeCache := cache.NewCodeCache(cmdable)
 	codeRepository := repository.NewCodeRepository(codeCache)
-	smsService := ioc.InitSmsService(cmdable)
-	codeService := service.NewCodeService(codeRepository, smsService)
-	userHandler := web.NewUserHandler(userService, codeService, handler)
-	wechatService := ioc.InitWechatService(loggerV1)
-	wechatHandlerConfig := ioc.InitWechatHandlerConfig()
-	oAuth2WechatHandler := web.NewOAuth2WechatHandler(wechatService, userService, wechatHandlerConfig, handler)
+	asyncSmsDAO := dao.NewGORMAsyncSmsDAO(db)
+	asyncSmsRepository := repository.NewAsyncSMSRepository(asyncSmsDAO)
+	smsMessageDAO := dao.NewGORMSmsMessageDAO(db)
+	smsMessageRepository := repository.NewSmsMessageRepository(smsMessageDAO)
+	receiptService := ioc.InitSmsReceiptService(smsMessageRepository, loggerV1)
+	registry := ioc.InitSmsTemplateRegistry()
+	sandboxService := ioc.InitSmsSandbox(registry, receiptService)
+	smsService := ioc.InitSmsService(cmdable, asyncSmsRepository, receiptService, sandboxService, registry, loggerV1)
+	codeThrottleCache := cache.NewRedisCodeThrottleCache(cmdable)
+	codeThrottleRepository := repository.NewCodeThrottleRepository(codeThrottleCache)
+	captchaService := ioc.InitCaptchaService()
+	codeThrottleService := ioc.InitCodeThrottleService(codeThrottleRepository, captchaService, loggerV1)
+	codeService := service.NewCodeService(codeRepository, smsService, codeThrottleService)
+	twoFactorDAO := dao.NewGORMTwoFactorDAO(db)
+	twoFactorCache := cache.NewRedisTwoFactorCache(cmdable)
+	twoFactorRepository := repository.NewCachedTwoFactorRepository(twoFactorDAO, twoFactorCache)
+	twoFactorService := service.NewTwoFactorService(twoFactorRepository, loggerV1)
+	userHandler := web.NewUserHandler(userService, codeService, handler, twoFactorService)
+	oauth2Registry := ioc.InitOAuth2Registry(loggerV1)
 	articleDAO := article.NewGORMArticleDAO(db)
 	articleCache := cache.NewRedisArticleCache(cmdable)
 	articleRepository := article2.NewCachedArticleRepository(userRepository, articleDAO, articleCache, loggerV1)
-	client := ioc.InitKafka()
-	syncProducer := ioc.InitSyncProducer(client)
+	client := ioc.InitEtcdClient()
+	interactServiceClient := ioc.InitInteractGRPCClientV1(client)
+	accountService := service.NewAccountService(userRepository, articleRepository, interactServiceClient, loggerV1)
+	oAuth2HandlerConfig := ioc.InitOAuth2HandlerConfig()
+	oAuth2Handler := web.NewOAuth2Handler(oauth2Registry, userService, accountService, oAuth2HandlerConfig, handler, keyrings, loggerV1)
+	saramaClient := ioc.InitKafka()
+	syncProducer := ioc.InitSyncProducer(saramaClient)
 	producer := article3.NewSaramaSyncProducer(syncProducer)
 	articleService := service.NewArticleService(articleRepository, loggerV1, producer)
-	clientv3Client := ioc.InitEtcdClient()
-	interactServiceClient := ioc.InitInteractGRPCClientV1(clientv3Client)
-	articleHandler := web.NewArticleHandler(articleService, interactServiceClient, loggerV1)
-	engine := ioc.InitWebServer(v, userHandler, oAuth2WechatHandler, articleHandler)
-	v2 := ioc.NewConsumers()
+	commentServiceClient := ioc.InitCommentGRPCClient(client)
+	articleHandler := web.NewArticleHandler(articleService, interactServiceClient, commentServiceClient, loggerV1)
+	index := ioc.InitSearchIndex()
+	articleSearchDAO := search.NewBleveArticleDAO(index)
+	articleSearchRepository := repository.NewArticleSearchRepository(articleSearchDAO)
+	searchService := service.NewSearchService(articleSearchRepository)
+	searchHandler := web.NewSearchHandler(searchService)
+	commentHandler := web.NewCommentHandler(commentServiceClient, articleService, loggerV1)
+	followServiceClient := ioc.InitFollowGRPCClient(client)
+	feedService := service.NewFollowFeedService(articleService, followServiceClient)
+	followHandler := web.NewFollowHandler(followServiceClient, feedService, userService)
+	historyRecordDAO := dao.NewGORMHistoryRecordDAO(db)
+	historyRecordCache := cache.NewRedisHistoryRecordCache(cmdable)
+	historyRecordRepository := repository.NewCachedHistoryRecordRepository(historyRecordDAO, historyRecordCache, loggerV1)
+	historyRecordService := service.NewHistoryRecordService(historyRecordRepository)
+	historyHandler := web.NewHistoryHandler(historyRecordService)
+	collectionHandler := web.NewCollectionHandler(interactServiceClient, loggerV1)
 	rankLocalCache := cache.NewRankLocalCache()
 	redisRankCache := cache.NewRedisRankCache(cmdable)
 	rankRepository := repository.NewCachedRankRepository(rankLocalCache, redisRankCache)
-	rankService := service.NewBatchRankService(articleService, interactServiceClient, rankRepository)
+	rankScoreStrategy := ioc.InitRankScoreStrategy()
+	batchRankService := service.NewBatchRankService(articleService, interactServiceClient, rankRepository, rankScoreStrategy)
+	redisRankScoreCache := cache.NewRedisRankScoreCache(cmdable)
+	rankScoreRepository := repository.NewCachedRankScoreRepository(redisRankScoreCache)
+	rankWeights := ioc.InitRankWeights()
+	realtimeRankService := service.NewRealtimeRankService(batchRankService, articleService, interactServiceClient, rankScoreRepository, rankRepository, rankWeights)
+	rankHandler := web.NewRankHandler(realtimeRankService)
+	notificationDAO := dao.NewGORMNotificationDAO(db)
+	notificationCache := cache.NewRedisNotificationCache(cmdable)
+	notificationRepository := repository.NewCachedNotificationRepository(notificationDAO, notificationCache, loggerV1)
+	notificationService := service.NewNotificationService(notificationRepository, articleService)
+	notificationHandler := web.NewNotificationHandler(notificationService, userService, loggerV1)
+	sessionHandler := web.NewSessionHandler(handler)
+	jwksHandler := web.NewJWKSHandler(keyrings)
+	twoFactorHandler := web.NewTwoFactorHandler(twoFactorService, userService, handler)
+	emailService := ioc.InitEmailService()
+	emailCodeService := service.NewEmailCodeService(codeRepository, emailService)
+	userEmailHandler := web.NewUserEmailHandler(userService, emailCodeService, handler, loggerV1)
+	accountHandler := web.NewAccountHandler(accountService, userService, codeService, emailCodeService, loggerV1)
+	auditLogDAO := dao.NewGORMAuditLogDAO(db)
+	auditLogRepository := repository.NewAuditLogRepository(auditLogDAO)
+	adminService := service.NewAdminService(userRepository, auditLogRepository, rbacService, accountService, articleService, notificationService, loggerV1)
+	adminHandler := web.NewAdminHandler(adminService, handler, loggerV1)
+	privacyRequestDAO := dao.NewGORMPrivacyRequestDAO(db)
+	privacyRequestRepository := repository.NewPrivacyRequestRepository(privacyRequestDAO)
+	privacyService := ioc.InitPrivacyService(privacyRequestRepository, userRepository, articleService, historyRecordService, notificationService, interactServiceClient, commentServiceClient, handler, loggerV1)
+	privacyHandler := web.NewPrivacyHandler(privacyService, loggerV1)
+	smsHandler := web.NewSmsHandler(receiptService, loggerV1)
+	smsSandboxHandler := web.NewSmsSandboxHandler(sandboxService)
+	engine := ioc.InitWebServer(v, userHandler, oAuth2Handler, articleHandler, searchHandler, commentHandler, followHandler, historyHandler, collectionHandler, rankHandler, notificationHandler, sessionHandler, jwksHandler, twoFactorHandler, userEmailHandler, accountHandler, adminHandler, privacyHandler, smsHandler, smsSandboxHandler)
+	searchConsumer := article3.NewSearchConsumer(saramaClient, articleSearchRepository, articleRepository, userRepository, loggerV1)
+	historyRecordConsumer := article3.NewHistoryRecordConsumer(saramaClient, historyRecordRepository, loggerV1)
+	rankConsumer := interact.NewRankConsumer(saramaClient, realtimeRankService, loggerV1)
+	notificationConsumer := interact.NewNotificationConsumer(saramaClient, notificationService, loggerV1)
+	commentNotificationConsumer := comment.NewNotificationConsumer(saramaClient, notificationService, loggerV1)
+	v2 := ioc.NewConsumers(searchConsumer, historyRecordConsumer, rankConsumer, notificationConsumer, commentNotificationConsumer)
 	rlockClient := ioc.InitRLockClient(cmdable)
-	rankJob := ioc.InitRankJob(rankService, rlockClient, loggerV1)
-	cron := ioc.InitJobs(loggerV1, rankJob)
+	rankJob := ioc.InitRankJob(realtimeRankService, rlockClient, loggerV1)
+	realtimeRankJob := ioc.InitRealtimeRankJob(realtimeRankService)
+	cron := ioc.InitJobs(loggerV1, rankJob, realtimeRankJob)
+	cronJobDAO := dao.NewGORMCronJobDAO(db)
+	cronJobRepository := repository.NewPreemptCronJobRepository(cronJobDAO)
+	cronJobService := service.NewCronJobService(cronJobRepository, loggerV1)
+	localFuncExecutor := ioc.InitLocalFuncExecutor(realtimeRankService)
+	scheduledPublishExecutor := ioc.InitScheduledPublishExecutor(articleService, loggerV1)
+	privacyRequestExecutor := ioc.InitPrivacyRequestExecutor(privacyService, loggerV1)
+	scheduler := ioc.InitScheduler(cronJobService, loggerV1, localFuncExecutor, scheduledPublishExecutor, privacyRequestExecutor)
 	app := &App{
 		web:       engine,
 		consumers: v2,
 		cron:      cron,
+		scheduler: scheduler,
 	}
 	return app
 }
 
 // wire.go:
 
-var rankServiceSet = wire.NewSet(cache.NewRedisRankCache, cache.NewRankLocalCache, repository.NewCachedRankRepository, service.NewBatchRankService)
+var rankServiceSet = wire.NewSet(cache.NewRedisRankCache, cache.NewRankLocalCache, repository.NewCachedRankRepository, service.NewBatchRankService, ioc.InitRankScoreStrategy, cache.NewRedisRankScoreCache, repository.NewCachedRankScoreRepository, ioc.InitRankWeights, service.NewRealtimeRankService, wire.Bind(new(service.RankService), new(*service.RealtimeRankService)))
+
+var historyServiceSet = wire.NewSet(dao.NewGORMHistoryRecordDAO, cache.NewRedisHistoryRecordCache, repository.NewCachedHistoryRecordRepository, service.NewHistoryRecordService)
+
+var notificationServiceSet = wire.NewSet(dao.NewGORMNotificationDAO, cache.NewRedisNotificationCache, repository.NewCachedNotificationRepository, service.NewNotificationService)
+
+var twoFactorServiceSet = wire.NewSet(dao.NewGORMTwoFactorDAO, cache.NewRedisTwoFactorCache, repository.NewCachedTwoFactorRepository, service.NewTwoFactorService)
+
+var privacyServiceSet = wire.NewSet(dao.NewGORMPrivacyRequestDAO, repository.NewPrivacyRequestRepository, ioc.InitPrivacyService)
+
+var schedulerSet = wire.NewSet(dao.NewGORMCronJobDAO, repository.NewPreemptCronJobRepository, service.NewCronJobService, ioc.InitLocalFuncExecutor, ioc.InitScheduledPublishExecutor, ioc.InitPrivacyRequestExecutor, ioc.InitScheduler)
+
+var searchServiceSet = wire.NewSet(ioc.InitSearchIndex, search.NewBleveArticleDAO, repository.NewArticleSearchRepository, service.NewSearchService)